@@ -10,6 +10,8 @@ const ResetPassword = lazy(() => import('./pages/rest-password'));
 const Login = lazy(() => import('./pages/login'));
 const Dashboard = lazy(() => import('./pages/dashboard'));
 const SignUp = lazy(() => import('./pages/signup'));
+const Device = lazy(() => import('./pages/device'));
+const Consent = lazy(() => import('./pages/consent'));
 
 const Wrapper = styled.div`
 	font-family: ${(props) => props.theme.fonts.fontStack};
@@ -87,6 +89,12 @@ export default function Root({
 					<Route path="/app" exact>
 						<Dashboard />
 					</Route>
+					<Route path="/app/device" exact>
+						<Device />
+					</Route>
+					<Route path="/app/consent" exact>
+						<Consent />
+					</Route>
 				</Switch>
 			</Suspense>
 		);
@@ -103,6 +111,12 @@ export default function Root({
 						<Route path="/app/signup" exact>
 							<SignUp urlProps={urlProps} />
 						</Route>
+						<Route path="/app/device" exact>
+							<Login urlProps={urlProps} />
+						</Route>
+						<Route path="/app/consent" exact>
+							<Login urlProps={urlProps} />
+						</Route>
 						<Route path="/app/reset-password">
 							<ResetPassword />
 						</Route>
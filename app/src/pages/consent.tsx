@@ -0,0 +1,121 @@
+import React, { useEffect } from 'react';
+import { useAuthorizer } from '@authorizerdev/authorizer-react';
+import { hasWindow } from '../utils/common';
+
+const consentRequestQuery = `
+	query consentRequest($params: ConsentRequestInput!) {
+		consent_request(params: $params) {
+			client_id
+			client_name
+			scope
+		}
+	}
+`;
+
+const consentMutation = `
+	mutation consent($params: ConsentInput!) {
+		consent(params: $params) {
+			redirect_uri
+		}
+	}
+`;
+
+export default function Consent() {
+	const searchParams = new URLSearchParams(
+		hasWindow() ? window.location.search : ``
+	);
+	const consentChallenge = searchParams.get('consent_challenge') || '';
+	const [consentRequest, setConsentRequest] = React.useState<{
+		client_id: string;
+		client_name: string;
+		scope: string[];
+	} | null>(null);
+	const [loading, setLoading] = React.useState(true);
+	const [error, setError] = React.useState('');
+	const { token, authorizerRef } = useAuthorizer();
+
+	useEffect(() => {
+		const getConsentRequest = async () => {
+			try {
+				const res = await authorizerRef.graphqlQuery({
+					query: consentRequestQuery,
+					variables: {
+						params: {
+							consent_challenge: consentChallenge,
+						},
+					},
+					headers: {
+						Authorization: `Bearer ${token?.access_token}`,
+					},
+				});
+				setConsentRequest(res.consent_request);
+			} catch (err: any) {
+				setError(err.message || String(err));
+			}
+			setLoading(false);
+		};
+		getConsentRequest();
+	}, []);
+
+	const onConsent = async (approve: boolean) => {
+		setLoading(true);
+		setError('');
+		try {
+			const res = await authorizerRef.graphqlQuery({
+				query: consentMutation,
+				variables: {
+					params: {
+						consent_challenge: consentChallenge,
+						approve,
+					},
+				},
+				headers: {
+					Authorization: `Bearer ${token?.access_token}`,
+				},
+			});
+			window.location.replace(res.consent.redirect_uri);
+		} catch (err: any) {
+			setError(err.message || String(err));
+			setLoading(false);
+		}
+	};
+
+	return (
+		<div>
+			<h1>Authorize application</h1>
+			{consentRequest && (
+				<div>
+					<p>
+						<b>{consentRequest.client_name || consentRequest.client_id}</b>{' '}
+						is requesting access to your account with the following
+						permissions:
+					</p>
+					<ul>
+						{consentRequest.scope.map((scope) => (
+							<li key={scope}>{scope}</li>
+						))}
+					</ul>
+				</div>
+			)}
+			{error && <p style={{ color: '#EF4444' }}>{error}</p>}
+			{loading ? (
+				<h3>Processing....</h3>
+			) : (
+				consentRequest && (
+					<div>
+						<button
+							type="button"
+							onClick={() => onConsent(true)}
+							style={{ marginRight: '8px' }}
+						>
+							Allow
+						</button>
+						<button type="button" onClick={() => onConsent(false)}>
+							Deny
+						</button>
+					</div>
+				)
+			)}
+		</div>
+	);
+}
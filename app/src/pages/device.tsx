@@ -0,0 +1,89 @@
+import React from 'react';
+import { useAuthorizer } from '@authorizerdev/authorizer-react';
+import { hasWindow } from '../utils/common';
+
+const verifyDeviceCodeMutation = `
+	mutation verifyDeviceCode($params: VerifyDeviceCodeInput!) {
+		verify_device_code(params: $params) {
+			message
+		}
+	}
+`;
+
+export default function Device() {
+	const searchParams = new URLSearchParams(
+		hasWindow() ? window.location.search : ``
+	);
+	const [userCode, setUserCode] = React.useState(
+		searchParams.get('user_code') || ''
+	);
+	const [loading, setLoading] = React.useState(false);
+	const [message, setMessage] = React.useState('');
+	const [error, setError] = React.useState('');
+	const { token, authorizerRef } = useAuthorizer();
+
+	const onVerify = async (approve: boolean) => {
+		setLoading(true);
+		setError('');
+		setMessage('');
+		try {
+			const res = await authorizerRef.graphqlQuery({
+				query: verifyDeviceCodeMutation,
+				variables: {
+					params: {
+						user_code: userCode,
+						approve,
+					},
+				},
+				headers: {
+					Authorization: `Bearer ${token?.access_token}`,
+				},
+			});
+			setMessage(res.verify_device_code.message);
+		} catch (err: any) {
+			setError(err.message || String(err));
+		}
+		setLoading(false);
+	};
+
+	return (
+		<div>
+			<h1>Connect a device</h1>
+			<p>Enter the code displayed on your device.</p>
+			<input
+				type="text"
+				value={userCode}
+				placeholder="XXXX-XXXX"
+				onChange={(e) => setUserCode(e.target.value)}
+				style={{ padding: '8px', fontSize: '18px', letterSpacing: '2px' }}
+			/>
+			<br />
+			<br />
+			{message && <p style={{ color: '#10B981' }}>{message}</p>}
+			{error && <p style={{ color: '#EF4444' }}>{error}</p>}
+			{loading ? (
+				<h3>Processing....</h3>
+			) : (
+				!message && (
+					<div>
+						<button
+							type="button"
+							disabled={!userCode}
+							onClick={() => onVerify(true)}
+							style={{ marginRight: '8px' }}
+						>
+							Approve
+						</button>
+						<button
+							type="button"
+							disabled={!userCode}
+							onClick={() => onVerify(false)}
+						>
+							Deny
+						</button>
+					</div>
+				)
+			)}
+		</div>
+	);
+}
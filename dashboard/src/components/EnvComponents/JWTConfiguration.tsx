@@ -18,6 +18,7 @@ const JSTConfigurations = ({
   HMACEncryptionType,
   RSAEncryptionType,
   ECDSAEncryptionType,
+  EdDSAEncryptionType,
 }: any) => {
   const [isNotSmallerScreen] = useMediaQuery("(min-width:600px)");
 
@@ -61,6 +62,7 @@ const JSTConfigurations = ({
                 ...HMACEncryptionType,
                 ...RSAEncryptionType,
                 ...ECDSAEncryptionType,
+                ...EdDSAEncryptionType,
               }}
             />
           </Flex>
@@ -16,7 +16,11 @@ import {
 	FaLinkedin,
 	FaApple,
 } from 'react-icons/fa';
-import { TextInputType, HiddenInputType } from '../../constants';
+import {
+	TextInputType,
+	HiddenInputType,
+	TextAreaInputType,
+} from '../../constants';
 
 const OAuthConfig = ({
 	envVariables,
@@ -68,6 +72,24 @@ const OAuthConfig = ({
 							/>
 						</Center>
 					</Flex>
+					<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+						<Flex w="30%" justifyContent="start" alignItems="center">
+							<Text fontSize="sm">SCIM Token</Text>
+						</Flex>
+						<Center
+							w={isNotSmallerScreen ? '70%' : '100%'}
+							mt={isNotSmallerScreen ? '0' : '3'}
+						>
+							<InputField
+								variables={envVariables}
+								setVariables={setVariables}
+								fieldVisibility={fieldVisibility}
+								setFieldVisibility={setFieldVisibility}
+								inputType={HiddenInputType.SCIM_TOKEN}
+								placeholder="SCIM Token"
+							/>
+						</Center>
+					</Flex>
 				</Stack>
 				<Divider mt={5} mb={2} color="blackAlpha.700" />
 				<Text fontSize="md" paddingTop="2%" fontWeight="bold" mb={4}>
@@ -264,6 +286,119 @@ const OAuthConfig = ({
 							/>
 						</Center>
 					</Flex>
+					<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+						<Flex
+							w={isNotSmallerScreen ? '30%' : '60%'}
+							justifyContent="start"
+							direction="column"
+						>
+							<Text fontSize="sm">OpenID Connect Providers:</Text>
+							<Text fontSize="xs" color="blackAlpha.500">
+								(JSON array of name, display_name, issuer_url, client_id,
+								client_secret, scopes & claim_mapping)
+							</Text>
+						</Flex>
+						<Center
+							w={isNotSmallerScreen ? '70%' : '100%'}
+							mt={isNotSmallerScreen ? '0' : '3'}
+						>
+							<InputField
+								variables={envVariables}
+								setVariables={setVariables}
+								inputType={TextAreaInputType.OIDC_PROVIDERS}
+								placeholder='[{"name": "keycloak", "issuer_url": "https://keycloak.example.com/realms/master", "client_id": "", "client_secret": ""}]'
+								minH="15vh"
+							/>
+						</Center>
+					</Flex>
+					<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+						<Flex
+							w={isNotSmallerScreen ? '30%' : '60%'}
+							justifyContent="start"
+							direction="column"
+						>
+							<Text fontSize="sm">SAML IdP SSO URL:</Text>
+						</Flex>
+						<Center
+							w={isNotSmallerScreen ? '70%' : '100%'}
+							mt={isNotSmallerScreen ? '0' : '3'}
+						>
+							<InputField
+								variables={envVariables}
+								setVariables={setVariables}
+								inputType={TextInputType.SAML_IDP_SSO_URL}
+								placeholder="https://idp.example.com/sso/saml"
+							/>
+						</Center>
+					</Flex>
+					<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+						<Flex
+							w={isNotSmallerScreen ? '30%' : '60%'}
+							justifyContent="start"
+							direction="column"
+						>
+							<Text fontSize="sm">SAML IdP Entity ID:</Text>
+						</Flex>
+						<Center
+							w={isNotSmallerScreen ? '70%' : '100%'}
+							mt={isNotSmallerScreen ? '0' : '3'}
+						>
+							<InputField
+								variables={envVariables}
+								setVariables={setVariables}
+								inputType={TextInputType.SAML_IDP_ENTITY_ID}
+								placeholder="https://idp.example.com/metadata"
+							/>
+						</Center>
+					</Flex>
+					<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+						<Flex
+							w={isNotSmallerScreen ? '30%' : '60%'}
+							justifyContent="start"
+							direction="column"
+						>
+							<Text fontSize="sm">SAML IdP Certificate:</Text>
+							<Text fontSize="xs" color="blackAlpha.500">
+								(PEM or base64 encoded signing certificate)
+							</Text>
+						</Flex>
+						<Center
+							w={isNotSmallerScreen ? '70%' : '100%'}
+							mt={isNotSmallerScreen ? '0' : '3'}
+						>
+							<InputField
+								variables={envVariables}
+								setVariables={setVariables}
+								inputType={TextAreaInputType.SAML_IDP_CERTIFICATE}
+								placeholder="-----BEGIN CERTIFICATE-----"
+								minH="15vh"
+							/>
+						</Center>
+					</Flex>
+					<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+						<Flex
+							w={isNotSmallerScreen ? '30%' : '60%'}
+							justifyContent="start"
+							direction="column"
+						>
+							<Text fontSize="sm">SAML Attribute Mapping:</Text>
+							<Text fontSize="xs" color="blackAlpha.500">
+								(JSON object of user field & saml attribute name)
+							</Text>
+						</Flex>
+						<Center
+							w={isNotSmallerScreen ? '70%' : '100%'}
+							mt={isNotSmallerScreen ? '0' : '3'}
+						>
+							<InputField
+								variables={envVariables}
+								setVariables={setVariables}
+								inputType={TextAreaInputType.SAML_ATTRIBUTE_MAPPING}
+								placeholder='{"email": "email", "given_name": "firstName", "roles": "groups"}'
+								minH="15vh"
+							/>
+						</Center>
+					</Flex>
 				</Stack>
 			</Box>
 		</div>
@@ -20,6 +20,7 @@ import { useClient } from 'urql';
 import { FaSave } from 'react-icons/fa';
 import {
 	ECDSAEncryptionType,
+	EdDSAEncryptionType,
 	HMACEncryptionType,
 	RSAEncryptionType,
 	SelectInputType,
@@ -157,6 +158,7 @@ const GenerateKeysModal = ({ jwtType, getData }: propTypes) => {
 									...HMACEncryptionType,
 									...RSAEncryptionType,
 									...ECDSAEncryptionType,
+									...EdDSAEncryptionType,
 								}}
 							/>
 						</Flex>
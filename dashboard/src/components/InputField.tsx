@@ -52,6 +52,7 @@ const InputField = ({
 		ROLES: false,
 		DEFAULT_ROLES: false,
 		PROTECTED_ROLES: false,
+		MFA_REQUIRED_ROLES: false,
 		ALLOWED_ORIGINS: false,
 		roles: false,
 	});
@@ -59,6 +60,7 @@ const InputField = ({
 		ROLES: '',
 		DEFAULT_ROLES: '',
 		PROTECTED_ROLES: '',
+		MFA_REQUIRED_ROLES: '',
 		ALLOWED_ORIGINS: '',
 		roles: '',
 	});
@@ -3,12 +3,19 @@ export const LOGO_URL =
 
 export const TextInputType = {
 	ACCESS_TOKEN_EXPIRY_TIME: 'ACCESS_TOKEN_EXPIRY_TIME',
+	REFRESH_TOKEN_EXPIRY_TIME: 'REFRESH_TOKEN_EXPIRY_TIME',
+	REFRESH_TOKEN_MAX_LIFETIME: 'REFRESH_TOKEN_MAX_LIFETIME',
+	MAX_ACTIVE_SESSIONS: 'MAX_ACTIVE_SESSIONS',
+	SESSION_IDLE_TIMEOUT: 'SESSION_IDLE_TIMEOUT',
+	SESSION_MAX_LIFETIME: 'SESSION_MAX_LIFETIME',
 	CLIENT_ID: 'CLIENT_ID',
 	GOOGLE_CLIENT_ID: 'GOOGLE_CLIENT_ID',
 	GITHUB_CLIENT_ID: 'GITHUB_CLIENT_ID',
 	FACEBOOK_CLIENT_ID: 'FACEBOOK_CLIENT_ID',
 	LINKEDIN_CLIENT_ID: 'LINKEDIN_CLIENT_ID',
 	APPLE_CLIENT_ID: 'APPLE_CLIENT_ID',
+	SAML_IDP_ENTITY_ID: 'SAML_IDP_ENTITY_ID',
+	SAML_IDP_SSO_URL: 'SAML_IDP_SSO_URL',
 	JWT_ROLE_CLAIM: 'JWT_ROLE_CLAIM',
 	REDIS_URL: 'REDIS_URL',
 	SMTP_HOST: 'SMTP_HOST',
@@ -39,12 +46,14 @@ export const HiddenInputType = {
 	SMTP_PASSWORD: 'SMTP_PASSWORD',
 	ADMIN_SECRET: 'ADMIN_SECRET',
 	OLD_ADMIN_SECRET: 'OLD_ADMIN_SECRET',
+	SCIM_TOKEN: 'SCIM_TOKEN',
 };
 
 export const ArrayInputType = {
 	ROLES: 'ROLES',
 	DEFAULT_ROLES: 'DEFAULT_ROLES',
 	PROTECTED_ROLES: 'PROTECTED_ROLES',
+	MFA_REQUIRED_ROLES: 'MFA_REQUIRED_ROLES',
 	ALLOWED_ORIGINS: 'ALLOWED_ORIGINS',
 	USER_ROLES: 'roles',
 };
@@ -58,6 +67,9 @@ export const TextAreaInputType = {
 	CUSTOM_ACCESS_TOKEN_SCRIPT: 'CUSTOM_ACCESS_TOKEN_SCRIPT',
 	JWT_PRIVATE_KEY: 'JWT_PRIVATE_KEY',
 	JWT_PUBLIC_KEY: 'JWT_PUBLIC_KEY',
+	OIDC_PROVIDERS: 'OIDC_PROVIDERS',
+	SAML_IDP_CERTIFICATE: 'SAML_IDP_CERTIFICATE',
+	SAML_ATTRIBUTE_MAPPING: 'SAML_ATTRIBUTE_MAPPING',
 };
 
 export const SwitchInputType = {
@@ -97,6 +109,10 @@ export const ECDSAEncryptionType = {
 	ES512: 'ES512',
 };
 
+export const EdDSAEncryptionType = {
+	EdDSA: 'EdDSA',
+};
+
 export interface envVarTypes {
 	GOOGLE_CLIENT_ID: string;
 	GOOGLE_CLIENT_SECRET: string;
@@ -108,9 +124,15 @@ export interface envVarTypes {
 	LINKEDIN_CLIENT_SECRET: string;
 	APPLE_CLIENT_ID: string;
 	APPLE_CLIENT_SECRET: string;
+	OIDC_PROVIDERS: string;
+	SAML_IDP_ENTITY_ID: string;
+	SAML_IDP_SSO_URL: string;
+	SAML_IDP_CERTIFICATE: string;
+	SAML_ATTRIBUTE_MAPPING: string;
 	ROLES: [string] | [];
 	DEFAULT_ROLES: [string] | [];
 	PROTECTED_ROLES: [string] | [];
+	MFA_REQUIRED_ROLES: [string] | [];
 	JWT_TYPE: string;
 	JWT_SECRET: string;
 	JWT_ROLE_CLAIM: string;
@@ -134,10 +156,16 @@ export interface envVarTypes {
 	DISABLE_SIGN_UP: boolean;
 	DISABLE_STRONG_PASSWORD: boolean;
 	OLD_ADMIN_SECRET: string;
+	SCIM_TOKEN: string;
 	DATABASE_NAME: string;
 	DATABASE_TYPE: string;
 	DATABASE_URL: string;
 	ACCESS_TOKEN_EXPIRY_TIME: string;
+	REFRESH_TOKEN_EXPIRY_TIME: string;
+	REFRESH_TOKEN_MAX_LIFETIME: string;
+	MAX_ACTIVE_SESSIONS: string;
+	SESSION_IDLE_TIMEOUT: string;
+	SESSION_MAX_LIFETIME: string;
 }
 
 export const envSubViews = {
@@ -181,6 +209,7 @@ export const webhookEventNames = {
 	USER_DELETED: 'user.deleted',
 	USER_ACCESS_ENABLED: 'user.access_enabled',
 	USER_ACCESS_REVOKED: 'user.access_revoked',
+	USER_REFRESH_TOKEN_REUSED: 'user.refresh_token_reused',
 };
 
 export enum webhookVerifiedStatus {
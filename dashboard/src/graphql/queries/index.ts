@@ -20,6 +20,7 @@ export const EnvVariablesQuery = `
     _env{
       CLIENT_ID,
       CLIENT_SECRET,
+      SCIM_TOKEN,
 	    GOOGLE_CLIENT_ID,
       GOOGLE_CLIENT_SECRET,
       GITHUB_CLIENT_ID,
@@ -30,8 +31,14 @@ export const EnvVariablesQuery = `
       LINKEDIN_CLIENT_SECRET,
       APPLE_CLIENT_ID,
       APPLE_CLIENT_SECRET,
+      OIDC_PROVIDERS,
+      SAML_IDP_ENTITY_ID,
+      SAML_IDP_SSO_URL,
+      SAML_IDP_CERTIFICATE,
+      SAML_ATTRIBUTE_MAPPING,
       DEFAULT_ROLES,
       PROTECTED_ROLES,
+      MFA_REQUIRED_ROLES,
       ROLES,
       JWT_TYPE,
       JWT_SECRET,
@@ -60,13 +67,18 @@ export const EnvVariablesQuery = `
       DATABASE_TYPE,
       DATABASE_URL,
       ACCESS_TOKEN_EXPIRY_TIME,
+      REFRESH_TOKEN_EXPIRY_TIME,
+      REFRESH_TOKEN_MAX_LIFETIME,
+      MAX_ACTIVE_SESSIONS,
+      SESSION_IDLE_TIMEOUT,
+      SESSION_MAX_LIFETIME,
     }
   }
 `;
 
 export const UserDetailsQuery = `
-  query($params: PaginatedInput) {
-    _users(params: $params) {
+  query($params: PaginatedInput, $filter: UsersFilterInput) {
+    _users(params: $params, filter: $filter) {
       pagination {
         limit
         page
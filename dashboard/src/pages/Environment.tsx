@@ -12,6 +12,7 @@ import {
 	HMACEncryptionType,
 	RSAEncryptionType,
 	ECDSAEncryptionType,
+	EdDSAEncryptionType,
 	envVarTypes,
 	envSubViews,
 } from '../constants';
@@ -50,9 +51,15 @@ const Environment = () => {
 		LINKEDIN_CLIENT_SECRET: '',
 		APPLE_CLIENT_ID: '',
 		APPLE_CLIENT_SECRET: '',
+		OIDC_PROVIDERS: '',
+		SAML_IDP_ENTITY_ID: '',
+		SAML_IDP_SSO_URL: '',
+		SAML_IDP_CERTIFICATE: '',
+		SAML_ATTRIBUTE_MAPPING: '',
 		ROLES: [],
 		DEFAULT_ROLES: [],
 		PROTECTED_ROLES: [],
+		MFA_REQUIRED_ROLES: [],
 		JWT_TYPE: '',
 		JWT_SECRET: '',
 		JWT_ROLE_CLAIM: '',
@@ -76,10 +83,16 @@ const Environment = () => {
 		DISABLE_SIGN_UP: false,
 		DISABLE_STRONG_PASSWORD: false,
 		OLD_ADMIN_SECRET: '',
+		SCIM_TOKEN: '',
 		DATABASE_NAME: '',
 		DATABASE_TYPE: '',
 		DATABASE_URL: '',
 		ACCESS_TOKEN_EXPIRY_TIME: '',
+		REFRESH_TOKEN_EXPIRY_TIME: '',
+		REFRESH_TOKEN_MAX_LIFETIME: '',
+		MAX_ACTIVE_SESSIONS: '',
+		SESSION_IDLE_TIMEOUT: '',
+		SESSION_MAX_LIFETIME: '',
 	});
 
 	const [fieldVisibility, setFieldVisibility] = React.useState<
@@ -94,6 +107,7 @@ const Environment = () => {
 		SMTP_PASSWORD: false,
 		ADMIN_SECRET: false,
 		OLD_ADMIN_SECRET: false,
+		SCIM_TOKEN: false,
 	});
 
 	const { sec } = useParams();
@@ -225,6 +239,7 @@ const Environment = () => {
 						HMACEncryptionType={HMACEncryptionType}
 						RSAEncryptionType={RSAEncryptionType}
 						ECDSAEncryptionType={ECDSAEncryptionType}
+						EdDSAEncryptionType={EdDSAEncryptionType}
 						getData={getData}
 					/>
 				);
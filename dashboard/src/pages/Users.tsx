@@ -5,6 +5,7 @@ import {
 	Box,
 	Flex,
 	IconButton,
+	Input,
 	NumberDecrementStepper,
 	NumberIncrementStepper,
 	NumberInput,
@@ -107,6 +108,7 @@ export default function Users() {
 			maxPages: 1,
 		});
 	const [userList, setUserList] = React.useState<userDataTypes[]>([]);
+	const [searchEmail, setSearchEmail] = React.useState<string>('');
 	const [loading, setLoading] = React.useState<boolean>(false);
 	const [disableInviteMembers, setDisableInviteMembers] =
 		React.useState<boolean>(true);
@@ -120,6 +122,7 @@ export default function Users() {
 						page: paginationProps.page,
 					},
 				},
+				filter: searchEmail.trim() ? { email: searchEmail.trim() } : null,
 			})
 			.toPromise();
 		if (data?._users) {
@@ -136,6 +139,8 @@ export default function Users() {
 						maxPages,
 						page: 1,
 					});
+				} else {
+					setUserList([]);
 				}
 			}
 		}
@@ -156,7 +161,7 @@ export default function Users() {
 	}, []);
 	React.useEffect(() => {
 		updateUserList();
-	}, [paginationProps.page, paginationProps.limit]);
+	}, [paginationProps.page, paginationProps.limit, searchEmail]);
 
 	const paginationHandler = (value: Record<string, number>) => {
 		setPaginationProps({ ...paginationProps, ...value });
@@ -257,6 +262,13 @@ export default function Users() {
 				<Text fontSize="md" fontWeight="bold">
 					Users
 				</Text>
+				<Input
+					maxW="300px"
+					size="sm"
+					placeholder="Search by email"
+					value={searchEmail}
+					onChange={(e) => setSearchEmail(e.target.value)}
+				/>
 				<InviteMembersModal
 					disabled={disableInviteMembers}
 					updateUserList={updateUserList}
@@ -0,0 +1,114 @@
+// Package consent implements the consent given by user to the oauth clients
+// for the scopes requested in the authorization request
+package consent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// Request is the consent request saved in the state store
+// till the user approves or denies it on the consent page
+type Request struct {
+	Challenge string   `json:"challenge"`
+	UserID    string   `json:"user_id"`
+	ClientID  string   `json:"client_id"`
+	Scope     []string `json:"scope"`
+	// query of the authorization request, used to resume the authorization once user responds
+	AuthorizeQuery string `json:"authorize_query"`
+	Status         string `json:"status"`
+	ExpiresAt      int64  `json:"expires_at"`
+}
+
+// IsExpired returns true if consent request is expired
+func (r *Request) IsExpired() bool {
+	return r.ExpiresAt < time.Now().Unix()
+}
+
+// NewRequest creates the pending consent request & saves it in the state store
+func NewRequest(userID, clientID string, scope []string, authorizeQuery string) (*Request, error) {
+	request := &Request{
+		Challenge:      uuid.New().String(),
+		UserID:         userID,
+		ClientID:       clientID,
+		Scope:          scope,
+		AuthorizeQuery: authorizeQuery,
+		Status:         constants.ConsentRequestStatusPending,
+		ExpiresAt:      time.Now().Add(constants.ConsentRequestExpiry).Unix(),
+	}
+	if err := Save(request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// Save saves the consent request in the state store
+func Save(request *Request) error {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	// keep the request in store only till it expires
+	expiresIn := time.Until(time.Unix(request.ExpiresAt, 0))
+	if expiresIn < time.Second {
+		expiresIn = time.Second
+	}
+	return memorystore.Provider.SetStateWithExpiry(constants.ConsentRequestStatePrefix+request.Challenge, string(data), expiresIn)
+}
+
+// Get returns the consent request for the consent challenge
+func Get(challenge string) (*Request, error) {
+	if challenge == "" {
+		return nil, errors.New("consent request not found")
+	}
+	data, err := memorystore.Provider.GetState(constants.ConsentRequestStatePrefix + challenge)
+	if err != nil || data == "" {
+		return nil, errors.New("consent request not found")
+	}
+
+	var request Request
+	if err := json.Unmarshal([]byte(data), &request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// Remove removes the consent request from the state store
+func Remove(request *Request) {
+	memorystore.Provider.RemoveState(constants.ConsentRequestStatePrefix + request.Challenge)
+}
+
+// IsGranted returns true if user has already granted all the scopes to the client
+func IsGranted(ctx context.Context, userID, clientID string, scope []string) bool {
+	grant, err := db.Provider.GetGrantByUserIDAndClientID(ctx, userID, clientID)
+	if err != nil || grant.ID == "" {
+		return false
+	}
+	return grant.HasScope(scope)
+}
+
+// SaveGrant saves the scopes granted by user to the client,
+// scopes are added to the ones granted earlier
+func SaveGrant(ctx context.Context, userID, clientID string, scope []string) (models.Grant, error) {
+	grant, err := db.Provider.GetGrantByUserIDAndClientID(ctx, userID, clientID)
+	if err != nil || grant.ID == "" {
+		grant = models.Grant{
+			UserID:   userID,
+			ClientID: clientID,
+		}
+		grant.AddScope(scope)
+		return db.Provider.AddGrant(ctx, grant)
+	}
+
+	grant.AddScope(scope)
+	return db.Provider.UpdateGrant(ctx, grant)
+}
@@ -15,4 +15,29 @@ const (
 	AuthRecipeMethodLinkedIn = "linkedin"
 	// AuthRecipeMethodApple is the apple auth method
 	AuthRecipeMethodApple = "apple"
+	// AuthRecipeMethodWebauthn is the webauthn (passkey) auth method
+	AuthRecipeMethodWebauthn = "webauthn"
+	// AuthRecipeMethodMobileOTP is the mobile_otp auth method
+	AuthRecipeMethodMobileOTP = "mobile_otp"
+	// AuthRecipeMethodOIDC is the auth method used for sessions of generic OpenID Connect providers,
+	// providers are configured with OIDC_PROVIDERS and their names are used as signup methods
+	AuthRecipeMethodOIDC = "oidc"
+	// AuthRecipeMethodSAML is the saml auth method
+	AuthRecipeMethodSAML = "saml"
 )
+
+// SessionNamespaces are the auth methods under which the user sessions
+// are saved in the session store i.e. with key authMethod:userId
+var SessionNamespaces = []string{
+	AuthRecipeMethodBasicAuth,
+	AuthRecipeMethodMagicLinkLogin,
+	AuthRecipeMethodApple,
+	AuthRecipeMethodFacebook,
+	AuthRecipeMethodGithub,
+	AuthRecipeMethodGoogle,
+	AuthRecipeMethodLinkedIn,
+	AuthRecipeMethodWebauthn,
+	AuthRecipeMethodMobileOTP,
+	AuthRecipeMethodOIDC,
+	AuthRecipeMethodSAML,
+}
@@ -0,0 +1,28 @@
+package constants
+
+const (
+	// PromptNone is the prompt value of authorization request that must not show any ui
+	PromptNone = "none"
+	// PromptLogin is the prompt value of authorization request that forces the re-authentication
+	PromptLogin = "login"
+	// PromptConsent is the prompt value of authorization request that forces the consent of user
+	PromptConsent = "consent"
+
+	// ACRSingleFactor is the acr claim value of authentication done with single factor
+	ACRSingleFactor = "1"
+	// ACRMultiFactor is the acr claim value of authentication done with multiple factors
+	ACRMultiFactor = "2"
+
+	// AMRPassword is the amr value (RFC 8176) of password authentication
+	AMRPassword = "pwd"
+	// AMROTP is the amr value of one time password / magic link authentication
+	AMROTP = "otp"
+	// AMRSMS is the amr value of authentication confirmed using sms
+	AMRSMS = "sms"
+	// AMRHardwareKey is the amr value of webauthn (passkey) authentication
+	AMRHardwareKey = "hwk"
+	// AMRFederated is the amr value of authentication done by social, oidc or saml provider
+	AMRFederated = "fed"
+	// AMRMultiFactor is the amr value of authentication done with multiple factors
+	AMRMultiFactor = "mfa"
+)
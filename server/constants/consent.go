@@ -0,0 +1,19 @@
+package constants
+
+import "time"
+
+const (
+	// ConsentRequestExpiry is the time within which user should respond to the consent request
+	ConsentRequestExpiry = 10 * time.Minute
+	// ConsentRequestStatePrefix is the prefix used to store consent request in the state store
+	ConsentRequestStatePrefix = "consent_request_"
+	// ConsentChallengeParam is the query param of consent request sent to consent page & authorize endpoint
+	ConsentChallengeParam = "consent_challenge"
+
+	// ConsentRequestStatusPending is the status of consent request waiting for user response
+	ConsentRequestStatusPending = "pending"
+	// ConsentRequestStatusApproved is the status of consent request approved by user
+	ConsentRequestStatusApproved = "approved"
+	// ConsentRequestStatusDenied is the status of consent request denied by user
+	ConsentRequestStatusDenied = "denied"
+)
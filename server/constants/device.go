@@ -0,0 +1,21 @@
+package constants
+
+import "time"
+
+const (
+	// DeviceCodeExpiry is the time within which user should approve the device authorization
+	DeviceCodeExpiry = 10 * time.Minute
+	// DevicePollingInterval is the minimum interval in seconds between the token requests of device
+	DevicePollingInterval = 5
+	// DeviceCodeStatePrefix is the prefix used to store device authorization in the state store
+	DeviceCodeStatePrefix = "device_code_"
+	// DeviceUserCodeStatePrefix is the prefix used to store device code for user code in the state store
+	DeviceUserCodeStatePrefix = "device_user_code_"
+
+	// DeviceAuthorizationStatusPending is the status of device authorization waiting for user approval
+	DeviceAuthorizationStatusPending = "pending"
+	// DeviceAuthorizationStatusApproved is the status of device authorization approved by user
+	DeviceAuthorizationStatusApproved = "approved"
+	// DeviceAuthorizationStatusDenied is the status of device authorization denied by user
+	DeviceAuthorizationStatusDenied = "denied"
+)
@@ -0,0 +1,17 @@
+package constants
+
+import "time"
+
+const (
+	// DPoPHeader is the header used to send DPoP proof & the authorization scheme of DPoP bound access token
+	DPoPHeader = "DPoP"
+	// DPoPProofType is the typ header of DPoP proof
+	DPoPProofType = "dpop+jwt"
+	// DPoPProofMaxAge is the time within which DPoP proof should be used after it is issued
+	DPoPProofMaxAge = time.Minute
+	// DPoPJtiStatePrefix is the prefix used to store jti of used DPoP proofs in the state store
+	DPoPJtiStatePrefix = "dpop_jti_"
+)
+
+// DPoPSigningAlgs are the asymmetric algorithms supported to sign DPoP proof
+var DPoPSigningAlgs = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
@@ -15,6 +15,20 @@ const (
 	EnvKeyPort = "PORT"
 	// EnvKeyAccessTokenExpiryTime key for env variable ACCESS_TOKEN_EXPIRY_TIME
 	EnvKeyAccessTokenExpiryTime = "ACCESS_TOKEN_EXPIRY_TIME"
+	// EnvKeyRefreshTokenExpiryTime key for env variable REFRESH_TOKEN_EXPIRY_TIME
+	EnvKeyRefreshTokenExpiryTime = "REFRESH_TOKEN_EXPIRY_TIME"
+	// EnvKeyRefreshTokenMaxLifetime key for env variable REFRESH_TOKEN_MAX_LIFETIME
+	// refresh token family can not be rotated beyond this lifetime & user has to login again
+	EnvKeyRefreshTokenMaxLifetime = "REFRESH_TOKEN_MAX_LIFETIME"
+	// EnvKeyMaxActiveSessions key for env variable MAX_ACTIVE_SESSIONS
+	// oldest sessions of user are ended when new login exceeds this number of sessions
+	EnvKeyMaxActiveSessions = "MAX_ACTIVE_SESSIONS"
+	// EnvKeySessionIdleTimeout key for env variable SESSION_IDLE_TIMEOUT
+	// session that is not used for this duration is ended
+	EnvKeySessionIdleTimeout = "SESSION_IDLE_TIMEOUT"
+	// EnvKeySessionMaxLifetime key for env variable SESSION_MAX_LIFETIME
+	// session is ended after this duration from login even if it is being used
+	EnvKeySessionMaxLifetime = "SESSION_MAX_LIFETIME"
 	// EnvKeyAdminSecret key for env variable ADMIN_SECRET
 	EnvKeyAdminSecret = "ADMIN_SECRET"
 	// EnvKeyDatabaseType key for env variable DATABASE_TYPE
@@ -47,6 +61,10 @@ const (
 	EnvKeySmtpPassword = "SMTP_PASSWORD"
 	// EnvKeySenderEmail key for env variable SENDER_EMAIL
 	EnvKeySenderEmail = "SENDER_EMAIL"
+	// EnvKeySmsProvider key for env variable SMS_PROVIDER
+	EnvKeySmsProvider = "SMS_PROVIDER"
+	// EnvKeySmsLogFile key for env variable SMS_LOG_FILE
+	EnvKeySmsLogFile = "SMS_LOG_FILE"
 	// EnvKeyJwtType key for env variable JWT_TYPE
 	EnvKeyJwtType = "JWT_TYPE"
 	// EnvKeyJwtSecret key for env variable JWT_SECRET
@@ -83,6 +101,18 @@ const (
 	EnvKeyAppleClientID = "APPLE_CLIENT_ID"
 	// EnvKeyAppleClientSecret key for env variable APPLE_CLIENT_SECRET
 	EnvKeyAppleClientSecret = "APPLE_CLIENT_SECRET"
+	// EnvKeyOIDCProviders key for env variable OIDC_PROVIDERS
+	// json array of generic OpenID Connect provider configs
+	EnvKeyOIDCProviders = "OIDC_PROVIDERS"
+	// EnvKeySamlIdpEntityID key for env variable SAML_IDP_ENTITY_ID
+	EnvKeySamlIdpEntityID = "SAML_IDP_ENTITY_ID"
+	// EnvKeySamlIdpSsoURL key for env variable SAML_IDP_SSO_URL
+	EnvKeySamlIdpSsoURL = "SAML_IDP_SSO_URL"
+	// EnvKeySamlIdpCertificate key for env variable SAML_IDP_CERTIFICATE
+	EnvKeySamlIdpCertificate = "SAML_IDP_CERTIFICATE"
+	// EnvKeySamlAttributeMapping key for env variable SAML_ATTRIBUTE_MAPPING
+	// json object of user field to saml attribute name
+	EnvKeySamlAttributeMapping = "SAML_ATTRIBUTE_MAPPING"
 	// EnvKeyOrganizationName key for env variable ORGANIZATION_NAME
 	EnvKeyOrganizationName = "ORGANIZATION_NAME"
 	// EnvKeyOrganizationLogo key for env variable ORGANIZATION_LOGO
@@ -95,10 +125,16 @@ const (
 	EnvKeyClientID = "CLIENT_ID"
 	// EnvKeyClientSecret key for env variable CLIENT_SECRET
 	EnvKeyClientSecret = "CLIENT_SECRET"
+	// EnvKeyScimToken key for env variable SCIM_TOKEN
+	// bearer token of SCIM provisioning endpoints, they are disabled if it is not set
+	EnvKeyScimToken = "SCIM_TOKEN"
 	// EnvKeyEncryptionKey key for env variable ENCRYPTION_KEY
 	EnvKeyEncryptionKey = "ENCRYPTION_KEY"
 	// EnvKeyJWK key for env variable JWK
 	EnvKeyJWK = "JWK"
+	// EnvKeyJwtKeys key for env variable JWT_KEYS
+	// json array of the next & previous signing keys, active key is stored in JWT_* env variables
+	EnvKeyJwtKeys = "JWT_KEYS"
 
 	// Boolean variables
 	// EnvKeyIsProd key for env variable IS_PROD
@@ -127,4 +163,6 @@ const (
 	EnvKeyDefaultRoles = "DEFAULT_ROLES"
 	// EnvKeyAllowedOrigins key for env variable ALLOWED_ORIGINS
 	EnvKeyAllowedOrigins = "ALLOWED_ORIGINS"
+	// EnvKeyMFARequiredRoles key for env variable MFA_REQUIRED_ROLES
+	EnvKeyMFARequiredRoles = "MFA_REQUIRED_ROLES"
 )
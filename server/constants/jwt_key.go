@@ -0,0 +1,12 @@
+package constants
+
+const (
+	// JWTKeyStatusNext is the status of key scheduled to replace the active key
+	JWTKeyStatusNext = "next"
+	// JWTKeyStatusActive is the status of key used to sign the tokens
+	JWTKeyStatusActive = "active"
+	// JWTKeyStatusPrevious is the status of replaced key that is still used to verify the tokens
+	JWTKeyStatusPrevious = "previous"
+	// JWTKeyStatusRetired is the status of key that is no longer used to verify the tokens
+	JWTKeyStatusRetired = "retired"
+)
@@ -0,0 +1,17 @@
+package constants
+
+import "time"
+
+const (
+	// MfaSessionExpiry is the time within which otp should be verified after login
+	MfaSessionExpiry = 5 * time.Minute
+	// MfaSessionStatePrefix is the prefix used to store pending mfa session in the state store
+	MfaSessionStatePrefix = "mfa_session_"
+	// MfaAttemptsStatePrefix is the prefix used to store invalid otp attempts of mfa session in the state store
+	MfaAttemptsStatePrefix = "mfa_attempts_"
+	// MfaEnrollmentStatePrefix is the prefix used to store that the email otp of mfa session is verified,
+	// authenticator app can be enrolled during login only after it
+	MfaEnrollmentStatePrefix = "mfa_enrollment_"
+	// TOTPTimeStepStatePrefix is the prefix used to store the last used totp time step of user in the state store
+	TOTPTimeStepStatePrefix = "totp_time_step_"
+)
@@ -0,0 +1,34 @@
+package constants
+
+const (
+	// GrantTypeAuthorizationCode is the grant type used to exchange code at /oauth/token
+	GrantTypeAuthorizationCode = "authorization_code"
+	// GrantTypeRefreshToken is the grant type used to rotate refresh token at /oauth/token
+	GrantTypeRefreshToken = "refresh_token"
+	// GrantTypeImplicit is the grant type used by response_type=token at /authorize
+	GrantTypeImplicit = "implicit"
+	// GrantTypeClientCredentials is the grant type used by services to get access token for the client itself
+	GrantTypeClientCredentials = "client_credentials"
+	// GrantTypeDeviceCode is the grant type used by devices to poll for token after user approval
+	GrantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// IDTokenEncryptionAlgRSAOAEP is the RSAES OAEP key management algorithm of encrypted id token
+	IDTokenEncryptionAlgRSAOAEP = "RSA-OAEP"
+	// IDTokenEncryptionAlgRSAOAEP256 is the RSAES OAEP using SHA-256 key management algorithm of encrypted id token
+	IDTokenEncryptionAlgRSAOAEP256 = "RSA-OAEP-256"
+	// IDTokenEncryptionAlgECDHES is the elliptic curve diffie-hellman key agreement algorithm of encrypted id token
+	IDTokenEncryptionAlgECDHES = "ECDH-ES"
+	// IDTokenEncryptionEncA256GCM is the content encryption of encrypted id token
+	IDTokenEncryptionEncA256GCM = "A256GCM"
+)
+
+var (
+	// DefaultClientGrantTypes are the grant types of oauth client when not specified
+	DefaultClientGrantTypes = []string{GrantTypeAuthorizationCode, GrantTypeRefreshToken}
+	// DefaultClientScopes are the scopes that oauth client can request when not specified
+	DefaultClientScopes = []string{"openid", "email", "profile", "offline_access"}
+	// IDTokenEncryptionAlgs are the supported key management algorithms of encrypted id token
+	IDTokenEncryptionAlgs = []string{IDTokenEncryptionAlgRSAOAEP, IDTokenEncryptionAlgRSAOAEP256, IDTokenEncryptionAlgECDHES}
+	// IDTokenEncryptionEncs are the supported content encryptions of encrypted id token
+	IDTokenEncryptionEncs = []string{IDTokenEncryptionEncA256GCM}
+)
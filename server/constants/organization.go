@@ -0,0 +1,8 @@
+package constants
+
+import "time"
+
+const (
+	// OrganizationInvitationExpiry is the time within which invited user should login to the organization
+	OrganizationInvitationExpiry = 7 * 24 * time.Hour
+)
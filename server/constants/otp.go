@@ -0,0 +1,12 @@
+package constants
+
+import "time"
+
+const (
+	// OtpExpiry is the time within which otp sent via sms should be verified
+	OtpExpiry = 5 * time.Minute
+	// OtpMaxAttempts is the number of invalid attempts after which otp is invalidated
+	OtpMaxAttempts = 5
+	// OtpAttemptsStatePrefix is the prefix used to store invalid otp attempts in the state store
+	OtpAttemptsStatePrefix = "otp_attempts_"
+)
@@ -2,3 +2,10 @@ package constants
 
 // DefaultLimit is the default limit for pagination
 var DefaultLimit = 10
+
+const (
+	// SortOrderAsc is the ascending sort order
+	SortOrderAsc = "asc"
+	// SortOrderDesc is the descending sort order
+	SortOrderDesc = "desc"
+)
@@ -0,0 +1,6 @@
+package constants
+
+const (
+	// RefreshTokenFamilyStatePrefix is the prefix used to store refresh token family in the state store
+	RefreshTokenFamilyStatePrefix = "refresh_token_family_"
+)
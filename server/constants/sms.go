@@ -0,0 +1,8 @@
+package constants
+
+const (
+	// SmsProviderLog is the sms provider that writes sms to the logs, should be used for local development only
+	SmsProviderLog = "log"
+	// SmsProviderFile is the sms provider that appends sms to the file set in SMS_LOG_FILE, should be used for local development only
+	SmsProviderFile = "file"
+)
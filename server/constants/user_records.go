@@ -0,0 +1,8 @@
+package constants
+
+const (
+	// UserRecordsFormatCSV is the csv format of users import & export
+	UserRecordsFormatCSV = "csv"
+	// UserRecordsFormatJSON is the json format of users import & export
+	UserRecordsFormatJSON = "json"
+)
@@ -9,4 +9,10 @@ const (
 	VerificationTypeUpdateEmail = "update_email"
 	// VerificationTypeForgotPassword is the forgot_password verification type
 	VerificationTypeForgotPassword = "forgot_password"
+	// VerificationTypeMobileOTPLogin is the mobile_otp_login verification type
+	VerificationTypeMobileOTPLogin = "mobile_otp_login"
+	// VerificationTypeVerifyPhoneNumber is the verify_phone_number verification type
+	VerificationTypeVerifyPhoneNumber = "verify_phone_number"
+	// VerificationTypeMfaEnrollment is the mfa_enrollment verification type
+	VerificationTypeMfaEnrollment = "mfa_enrollment"
 )
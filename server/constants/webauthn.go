@@ -0,0 +1,12 @@
+package constants
+
+import "time"
+
+const (
+	// WebauthnChallengeExpiry is the time within which webauthn ceremony should be completed
+	WebauthnChallengeExpiry = 5 * time.Minute
+	// WebauthnRegistrationStatePrefix is the prefix used to store registration challenge in the state store
+	WebauthnRegistrationStatePrefix = "webauthn_registration_"
+	// WebauthnLoginStatePrefix is the prefix used to store login challenge in the state store
+	WebauthnLoginStatePrefix = "webauthn_login_"
+)
@@ -15,4 +15,7 @@ const (
 	UserAccessEnabledWebhookEvent = `user.access_enabled`
 	// UserDeletedWebhookEvent name for user deleted event
 	UserDeletedWebhookEvent = `user.deleted`
+	// UserRefreshTokenReusedWebhookEvent name for refresh token reuse event
+	// This is triggered when rotated refresh token is presented again & its token family is revoked
+	UserRefreshTokenReusedWebhookEvent = `user.refresh_token_reused`
 )
@@ -1,8 +1,12 @@
 package crypto
 
 import (
+	gocrypto "crypto"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/memorystore"
@@ -32,8 +36,32 @@ func GetPubJWK(algo, keyID string, publicKey interface{}) (string, error) {
 	return string(jwkPublicKey), nil
 }
 
-// GenerateJWKBasedOnEnv generates JWK based on env
-// make sure clientID, jwtType, jwtSecret / public & private key pair is set
+// GetKeyID returns the key id used as kid header of jwt tokens & in JWKS.
+// It is the base64url encoded SHA-256 JWK thumbprint (RFC 7638) of the key,
+// so it is the same for a key on every instance & doesn't need to be stored
+func GetKeyID(publicKey interface{}) (string, error) {
+	var thumbprint []byte
+	switch key := publicKey.(type) {
+	case []byte:
+		// go-jose doesn't support thumbprint of symmetric keys
+		input := `{"k":"` + base64.RawURLEncoding.EncodeToString(key) + `","kty":"oct"}`
+		hash := sha256.Sum256([]byte(input))
+		thumbprint = hash[:]
+	case nil:
+		return "", errors.New("invalid key")
+	default:
+		var err error
+		jwk := jose.JSONWebKey{Key: publicKey}
+		thumbprint, err = jwk.Thumbprint(gocrypto.SHA256)
+		if err != nil {
+			return "", err
+		}
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// GenerateJWKBasedOnEnv generates JWK of the active signing key based on env
+// make sure jwtType, jwtSecret / public & private key pair is set
 // this is called while initializing app / when env is updated
 func GenerateJWKBasedOnEnv() (string, error) {
 	jwk := ""
@@ -41,10 +69,6 @@ func GenerateJWKBasedOnEnv() (string, error) {
 	if err != nil {
 		return jwk, err
 	}
-	clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
-	if err != nil {
-		return jwk, err
-	}
 
 	jwtSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtSecret)
 	if err != nil {
@@ -53,7 +77,11 @@ func GenerateJWKBasedOnEnv() (string, error) {
 
 	// check if jwt secret is provided
 	if IsHMACA(algo) {
-		jwk, err = GetPubJWK(algo, clientID, []byte(jwtSecret))
+		keyID, err := GetKeyID([]byte(jwtSecret))
+		if err != nil {
+			return "", err
+		}
+		jwk, err = GetPubJWK(algo, keyID, []byte(jwtSecret))
 		if err != nil {
 			return "", err
 		}
@@ -70,7 +98,11 @@ func GenerateJWKBasedOnEnv() (string, error) {
 			return "", err
 		}
 
-		jwk, err = GetPubJWK(algo, clientID, publicKeyInstance)
+		keyID, err := GetKeyID(publicKeyInstance)
+		if err != nil {
+			return "", err
+		}
+		jwk, err = GetPubJWK(algo, keyID, publicKeyInstance)
 		if err != nil {
 			return "", err
 		}
@@ -86,7 +118,27 @@ func GenerateJWKBasedOnEnv() (string, error) {
 			return "", err
 		}
 
-		jwk, err = GetPubJWK(algo, clientID, publicKeyInstance)
+		keyID, err := GetKeyID(publicKeyInstance)
+		if err != nil {
+			return "", err
+		}
+		jwk, err = GetPubJWK(algo, keyID, publicKeyInstance)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if IsEdDSA(algo) {
+		publicKeyInstance, err := ParseEdDSAPublicKeyFromPemStr(jwtPublicKey)
+		if err != nil {
+			return "", err
+		}
+
+		keyID, err := GetKeyID(publicKeyInstance)
+		if err != nil {
+			return "", err
+		}
+		jwk, err = GetPubJWK(algo, keyID, publicKeyInstance)
 		if err != nil {
 			return "", err
 		}
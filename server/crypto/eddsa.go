@@ -0,0 +1,131 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+)
+
+// NewEdDSAKey to generate new Ed25519 Key if env is not set
+// returns key instance, private key string, public key string, jwk string, error
+func NewEdDSAKey(algo, keyID string) (ed25519.PrivateKey, string, string, string, error) {
+	if !IsEdDSA(algo) {
+		return nil, "", "", "", errors.New("Invalid algo")
+	}
+	publicKey, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, "", "", "", err
+	}
+
+	privateKeyStr, publicKeyStr, err := AsEdDSAStr(key, publicKey)
+	if err != nil {
+		return nil, "", "", "", err
+	}
+
+	jwkPublicKey, err := GetPubJWK(algo, keyID, publicKey)
+	if err != nil {
+		return nil, "", "", "", err
+	}
+
+	return key, privateKeyStr, publicKeyStr, string(jwkPublicKey), err
+}
+
+// IsEdDSA checks if given string is valid EdDSA algo
+func IsEdDSA(algo string) bool {
+	switch algo {
+	case "EdDSA":
+		return true
+	default:
+		return false
+	}
+}
+
+// ExportEdDSAPrivateKeyAsPemStr to get Ed25519 private key as pem string
+func ExportEdDSAPrivateKeyAsPemStr(privkey ed25519.PrivateKey) (string, error) {
+	privkeyBytes, err := x509.MarshalPKCS8PrivateKey(privkey)
+	if err != nil {
+		return "", err
+	}
+	privkeyPem := pem.EncodeToMemory(
+		&pem.Block{
+			Type:  "PRIVATE KEY",
+			Bytes: privkeyBytes,
+		},
+	)
+	return string(privkeyPem), nil
+}
+
+// ExportEdDSAPublicKeyAsPemStr to get Ed25519 public key as pem string
+func ExportEdDSAPublicKeyAsPemStr(pubkey ed25519.PublicKey) (string, error) {
+	pubkeyBytes, err := x509.MarshalPKIXPublicKey(pubkey)
+	if err != nil {
+		return "", err
+	}
+	pubkeyPem := pem.EncodeToMemory(
+		&pem.Block{
+			Type:  "PUBLIC KEY",
+			Bytes: pubkeyBytes,
+		},
+	)
+
+	return string(pubkeyPem), nil
+}
+
+// ParseEdDSAPrivateKeyFromPemStr to parse Ed25519 private key from pem string
+func ParseEdDSAPrivateKeyFromPemStr(privPEM string) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privPEM))
+	if block == nil {
+		return nil, errors.New("failed to parse PEM block containing the key")
+	}
+
+	priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch priv := priv.(type) {
+	case ed25519.PrivateKey:
+		return priv, nil
+	default:
+		break // fall through
+	}
+	return nil, errors.New("Key type is not Ed25519")
+}
+
+// ParseEdDSAPublicKeyFromPemStr to parse Ed25519 public key from pem string
+func ParseEdDSAPublicKeyFromPemStr(pubPEM string) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pubPEM))
+	if block == nil {
+		return nil, errors.New("failed to parse PEM block containing the key")
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch pub := pub.(type) {
+	case ed25519.PublicKey:
+		return pub, nil
+	default:
+		break // fall through
+	}
+	return nil, errors.New("Key type is not Ed25519")
+}
+
+// AsEdDSAStr returns private, public key string or error
+func AsEdDSAStr(privateKey ed25519.PrivateKey, publicKey ed25519.PublicKey) (string, string, error) {
+	// Export the keys to pem string
+	privPem, err := ExportEdDSAPrivateKeyAsPemStr(privateKey)
+	if err != nil {
+		return "", "", err
+	}
+	pubPem, err := ExportEdDSAPublicKeyAsPemStr(publicKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	return privPem, pubPem, nil
+}
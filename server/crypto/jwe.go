@@ -0,0 +1,71 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+)
+
+// ParseEncryptionJWK parses the public JWK registered by client to encrypt the tokens
+// and validates that it can be used with the key management algorithm
+func ParseEncryptionJWK(jwk, alg string) (*jose.JSONWebKey, error) {
+	key := &jose.JSONWebKey{}
+	err := json.Unmarshal([]byte(jwk), key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwk: %s", err.Error())
+	}
+	if !key.IsPublic() {
+		return nil, errors.New("jwk must be a public key")
+	}
+	if key.Use != "" && key.Use != "enc" {
+		return nil, errors.New("jwk is not an encryption key")
+	}
+	if key.Algorithm != "" && key.Algorithm != alg {
+		return nil, fmt.Errorf("jwk can not be used with %s", alg)
+	}
+
+	switch alg {
+	case constants.IDTokenEncryptionAlgRSAOAEP, constants.IDTokenEncryptionAlgRSAOAEP256:
+		if _, ok := key.Key.(*rsa.PublicKey); !ok {
+			return nil, fmt.Errorf("RSA key is required for %s", alg)
+		}
+	case constants.IDTokenEncryptionAlgECDHES:
+		if _, ok := key.Key.(*ecdsa.PublicKey); !ok {
+			return nil, fmt.Errorf("EC key is required for %s", alg)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key management algorithm %s", alg)
+	}
+
+	return key, nil
+}
+
+// EncryptJWE encrypts the signed jwt token to the public JWK of client.
+// It returns the nested jwt (JWS in JWE) in compact serialization
+func EncryptJWE(signedToken, jwk, alg, enc string) (string, error) {
+	key, err := ParseEncryptionJWK(jwk, alg)
+	if err != nil {
+		return "", err
+	}
+
+	encrypter, err := jose.NewEncrypter(jose.ContentEncryption(enc), jose.Recipient{
+		Algorithm: jose.KeyAlgorithm(alg),
+		Key:       key,
+	}, (&jose.EncrypterOptions{}).WithType("JWT").WithContentType("JWT"))
+	if err != nil {
+		return "", err
+	}
+
+	object, err := encrypter.Encrypt([]byte(signedToken))
+	if err != nil {
+		return "", err
+	}
+
+	return object.CompactSerialize()
+}
@@ -0,0 +1,20 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// otpDigits is the number of digits in otp sent via sms
+const otpDigits = 6
+
+// GenerateOTP generates a random numeric otp
+func GenerateOTP() (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(otpDigits), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", otpDigits, n.Int64()), nil
+}
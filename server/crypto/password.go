@@ -0,0 +1,200 @@
+package crypto
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// passwordHash is the parsed hash of password generated by other systems
+type passwordHash struct {
+	salt []byte
+	hash []byte
+	// derive derives the key of password with the parameters of hash
+	derive func(password, salt []byte, keyLen int) []byte
+}
+
+// ComparePassword compares the password with its hash. Besides the bcrypt hashes
+// generated by EncryptPassword, argon2, scrypt & pbkdf2 hashes of imported users are supported.
+// It returns true if password matches a hash that must be replaced with bcrypt hash
+func ComparePassword(hashedPassword, password string) (bool, error) {
+	if isBcryptHash(hashedPassword) {
+		return false, bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	}
+
+	parsedHash, err := parsePasswordHash(hashedPassword)
+	if err != nil {
+		return false, err
+	}
+	key := parsedHash.derive([]byte(password), parsedHash.salt, len(parsedHash.hash))
+	if subtle.ConstantTimeCompare(key, parsedHash.hash) != 1 {
+		return false, errors.New("password does not match the hash")
+	}
+	return true, nil
+}
+
+// IsSupportedPasswordHash returns true if password hash can be compared by ComparePassword
+func IsSupportedPasswordHash(hashedPassword string) bool {
+	if isBcryptHash(hashedPassword) {
+		_, err := bcrypt.Cost([]byte(hashedPassword))
+		return err == nil
+	}
+	_, err := parsePasswordHash(hashedPassword)
+	return err == nil
+}
+
+// isBcryptHash returns true for $2a$, $2b$ & $2y$ bcrypt hashes
+func isBcryptHash(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, "$2")
+}
+
+// parsePasswordHash parses the argon2 & scrypt hashes in PHC string format,
+// pbkdf2 hashes in PHC, passlib & django format
+func parsePasswordHash(hashedPassword string) (*passwordHash, error) {
+	parts := strings.Split(hashedPassword, "$")
+	switch {
+	case strings.HasPrefix(hashedPassword, "$argon2"):
+		return parseArgon2Hash(parts)
+	case strings.HasPrefix(hashedPassword, "$scrypt$"):
+		return parseScryptHash(parts)
+	case strings.HasPrefix(hashedPassword, "$pbkdf2"):
+		// $pbkdf2-sha256$i=29000$salt$hash or $pbkdf2-sha256$29000$salt$hash
+		if len(parts) != 5 {
+			return nil, errors.New("invalid pbkdf2 hash")
+		}
+		salt, err := decodeHashBase64(parts[3])
+		if err != nil {
+			return nil, err
+		}
+		return parsePBKDF2Hash(strings.TrimPrefix(parts[1], "pbkdf2"), strings.TrimPrefix(parts[2], "i="), salt, parts[4])
+	case strings.HasPrefix(hashedPassword, "pbkdf2_"):
+		// django hashes, pbkdf2_sha256$260000$salt$hash with plain salt
+		if len(parts) != 4 {
+			return nil, errors.New("invalid pbkdf2 hash")
+		}
+		return parsePBKDF2Hash(strings.TrimPrefix(parts[0], "pbkdf2"), parts[1], []byte(parts[2]), parts[3])
+	}
+	return nil, errors.New("unsupported password hash")
+}
+
+// parseArgon2Hash parses $argon2id$v=19$m=65536,t=3,p=4$salt$hash
+func parseArgon2Hash(parts []string) (*passwordHash, error) {
+	if len(parts) != 6 || parts[2] != fmt.Sprintf("v=%d", argon2.Version) {
+		return nil, errors.New("invalid argon2 hash")
+	}
+	// parameters are bounded so that hash of imported user can not exhaust memory & cpu on login,
+	// memory is in KiB & upto 2 GiB recommended by RFC 9106 is allowed
+	var memory, iterations uint32
+	var parallelism uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism); err != nil || memory == 0 || memory > 1<<21 || iterations == 0 || iterations > 16 || parallelism == 0 || parallelism > 16 {
+		return nil, errors.New("invalid argon2 hash parameters")
+	}
+	salt, err := decodeHashBase64(parts[4])
+	if err != nil {
+		return nil, err
+	}
+	key, err := decodeHashBase64(parts[5])
+	if err != nil {
+		return nil, err
+	}
+
+	var derive func(password, salt []byte, keyLen int) []byte
+	switch parts[1] {
+	case "argon2id":
+		derive = func(password, salt []byte, keyLen int) []byte {
+			return argon2.IDKey(password, salt, iterations, memory, parallelism, uint32(keyLen))
+		}
+	case "argon2i":
+		derive = func(password, salt []byte, keyLen int) []byte {
+			return argon2.Key(password, salt, iterations, memory, parallelism, uint32(keyLen))
+		}
+	default:
+		return nil, errors.New("unsupported argon2 variant")
+	}
+	return &passwordHash{
+		salt:   salt,
+		hash:   key,
+		derive: derive,
+	}, nil
+}
+
+// parseScryptHash parses $scrypt$ln=15,r=8,p=1$salt$hash
+func parseScryptHash(parts []string) (*passwordHash, error) {
+	if len(parts) != 5 {
+		return nil, errors.New("invalid scrypt hash")
+	}
+	var logN uint
+	var r, p int
+	if _, err := fmt.Sscanf(parts[2], "ln=%d,r=%d,p=%d", &logN, &r, &p); err != nil || logN == 0 || logN > 30 || r < 1 || p < 1 || uint64(r)*uint64(p) >= 1<<30 {
+		return nil, errors.New("invalid scrypt hash parameters")
+	}
+	salt, err := decodeHashBase64(parts[3])
+	if err != nil {
+		return nil, err
+	}
+	key, err := decodeHashBase64(parts[4])
+	if err != nil {
+		return nil, err
+	}
+	return &passwordHash{
+		salt: salt,
+		hash: key,
+		derive: func(password, salt []byte, keyLen int) []byte {
+			// key is nil if memory required by the parameters is too large
+			key, _ := scrypt.Key(password, salt, 1<<logN, r, p, keyLen)
+			return key
+		},
+	}, nil
+}
+
+// parsePBKDF2Hash parses the pbkdf2 hash with digest -sha256 or _sha256 suffix
+func parsePBKDF2Hash(digest, iterations string, salt []byte, encodedKey string) (*passwordHash, error) {
+	var hashFunc func() hash.Hash
+	switch strings.TrimLeft(digest, "-_") {
+	case "", "sha1":
+		hashFunc = sha1.New
+	case "sha256":
+		hashFunc = sha256.New
+	case "sha512":
+		hashFunc = sha512.New
+	default:
+		return nil, errors.New("unsupported pbkdf2 digest")
+	}
+	iter, err := strconv.Atoi(iterations)
+	if err != nil || iter < 1 {
+		return nil, errors.New("invalid pbkdf2 iterations")
+	}
+	key, err := decodeHashBase64(encodedKey)
+	if err != nil {
+		return nil, err
+	}
+	return &passwordHash{
+		salt: salt,
+		hash: key,
+		derive: func(password, salt []byte, keyLen int) []byte {
+			return pbkdf2.Key(password, salt, iter, keyLen, hashFunc)
+		},
+	}, nil
+}
+
+// decodeHashBase64 decodes the salt & hash of password hashes,
+// they are base64 encoded with or without padding & with . instead of + in passlib hashes
+func decodeHashBase64(value string) ([]byte, error) {
+	decoded, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(strings.ReplaceAll(value, ".", "+"), "="))
+	if err != nil || len(decoded) == 0 {
+		return nil, errors.New("invalid password hash encoding")
+	}
+	return decoded, nil
+}
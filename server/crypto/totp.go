@@ -0,0 +1,105 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// totpPeriod is the time step in seconds for which a totp code is valid
+	totpPeriod = 30
+	// totpDigits is the number of digits in totp code
+	totpDigits = 6
+	// totpSkew is the number of time steps before and after the current one that are accepted
+	totpSkew = 1
+
+	// TOTPValidity is the duration for which a totp code is accepted,
+	// used time step should be remembered for this duration to prevent the replay of code
+	TOTPValidity = (2*totpSkew + 1) * totpPeriod * time.Second
+)
+
+// NewTOTPSecret generates a new base32 encoded secret that can be used with authenticator apps
+func NewTOTPSecret() (string, error) {
+	secret := make([]byte, 20)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret), nil
+}
+
+// GetTOTPURI returns the otpauth uri for given secret
+// this is the data that should be encoded in the QR code shown to the user
+func GetTOTPURI(secret, issuer, accountName string) string {
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(accountName)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", totpDigits))
+	params.Set("period", fmt.Sprintf("%d", totpPeriod))
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// GenerateTOTPCode generates the totp code for given secret and time as per RFC 6238
+func GenerateTOTPCode(secret string, t time.Time) (string, error) {
+	return generateHOTPCode(secret, uint64(t.Unix()/totpPeriod))
+}
+
+// ValidateTOTPCode validates the totp code for given secret and returns the time step of code.
+// Codes from the previous and next time step are also accepted to allow clock drift,
+// codes of the time steps up to lastTimeStep are rejected as they are already used
+func ValidateTOTPCode(secret, code string, lastTimeStep int64) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	counter := time.Now().Unix() / totpPeriod
+	for i := -totpSkew; i <= totpSkew; i++ {
+		timeStep := counter + int64(i)
+		if timeStep <= lastTimeStep {
+			continue
+		}
+		expectedCode, err := generateHOTPCode(secret, uint64(timeStep))
+		if err != nil {
+			return 0, false
+		}
+		if hmac.Equal([]byte(expectedCode), []byte(code)) {
+			return timeStep, true
+		}
+	}
+	return 0, false
+}
+
+// generateHOTPCode generates the hotp code for given secret and counter as per RFC 4226
+func generateHOTPCode(secret string, counter uint64) (string, error) {
+	secret = strings.ToUpper(strings.TrimSpace(secret))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+	if err != nil {
+		return "", err
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod), nil
+}
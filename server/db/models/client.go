@@ -0,0 +1,143 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+)
+
+// Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
+
+// Client model for db, it is the oauth client registered with authorizer
+// ID of the client is used as client_id
+type Client struct {
+	Key                         string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty"` // for arangodb
+	ID                          string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id"`
+	Name                        string `json:"name" bson:"name" cql:"name"`
+	ClientSecret                string `gorm:"type:text" json:"client_secret" bson:"client_secret" cql:"client_secret"` // hashed
+	RedirectURIs                string `gorm:"type:text" json:"redirect_uris" bson:"redirect_uris" cql:"redirect_uris"`
+	AllowedScopes               string `gorm:"type:text" json:"allowed_scopes" bson:"allowed_scopes" cql:"allowed_scopes"`
+	GrantTypes                  string `json:"grant_types" bson:"grant_types" cql:"grant_types"`
+	AccessTokenExpiryTime       string `json:"access_token_expiry_time" bson:"access_token_expiry_time" cql:"access_token_expiry_time"`
+	RefreshTokenExpiryTime      string `json:"refresh_token_expiry_time" bson:"refresh_token_expiry_time" cql:"refresh_token_expiry_time"`
+	PostLogoutRedirectURIs      string `gorm:"type:text" json:"post_logout_redirect_uris" bson:"post_logout_redirect_uris" cql:"post_logout_redirect_uris"`
+	BackchannelLogoutURI        string `gorm:"type:text" json:"backchannel_logout_uri" bson:"backchannel_logout_uri" cql:"backchannel_logout_uri"`
+	FrontchannelLogoutURI       string `gorm:"type:text" json:"frontchannel_logout_uri" bson:"frontchannel_logout_uri" cql:"frontchannel_logout_uri"`
+	IDTokenEncryptedResponseAlg string `json:"id_token_encrypted_response_alg" bson:"id_token_encrypted_response_alg" cql:"id_token_encrypted_response_alg"`
+	IDTokenEncryptedResponseEnc string `json:"id_token_encrypted_response_enc" bson:"id_token_encrypted_response_enc" cql:"id_token_encrypted_response_enc"`
+	IDTokenEncryptionKey        string `gorm:"type:text" json:"id_token_encryption_key" bson:"id_token_encryption_key" cql:"id_token_encryption_key"`
+	CreatedAt                   int64  `json:"created_at" bson:"created_at" cql:"created_at"`
+	UpdatedAt                   int64  `json:"updated_at" bson:"updated_at" cql:"updated_at"`
+}
+
+// splitList returns the values of comma separated list ignoring the empty values
+func splitList(list string) []string {
+	res := []string{}
+	for _, value := range strings.Split(list, ",") {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			res = append(res, value)
+		}
+	}
+	return res
+}
+
+// GetClientID returns the client_id of client
+func (c *Client) GetClientID() string {
+	id := c.ID
+	if strings.Contains(id, Collections.Client+"/") {
+		id = strings.TrimPrefix(id, Collections.Client+"/")
+	}
+	return id
+}
+
+// IsRedirectURIAllowed returns true if redirect uri is registered for the client
+func (c *Client) IsRedirectURIAllowed(redirectURI string) bool {
+	for _, uri := range splitList(c.RedirectURIs) {
+		if uri == redirectURI {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPostLogoutRedirectURIAllowed returns true if post logout redirect uri is registered for the client
+func (c *Client) IsPostLogoutRedirectURIAllowed(redirectURI string) bool {
+	for _, uri := range splitList(c.PostLogoutRedirectURIs) {
+		if uri == redirectURI {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIDTokenEncrypted returns true if id tokens issued to the client are encrypted
+// to the public JWK registered as IDTokenEncryptionKey
+func (c *Client) IsIDTokenEncrypted() bool {
+	return c.IDTokenEncryptedResponseAlg != ""
+}
+
+// IsGrantTypeAllowed returns true if client can use the grant type
+func (c *Client) IsGrantTypeAllowed(grantType string) bool {
+	for _, gt := range splitList(c.GrantTypes) {
+		if gt == grantType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsScopeAllowed returns true if all the scopes can be requested by the client
+func (c *Client) IsScopeAllowed(scope []string) bool {
+	allowedScopes := splitList(c.AllowedScopes)
+	for _, s := range scope {
+		isAllowed := false
+		for _, as := range allowedScopes {
+			if as == s {
+				isAllowed = true
+				break
+			}
+		}
+		if !isAllowed {
+			return false
+		}
+	}
+	return true
+}
+
+// AsAPIClient to return client as graphql response object
+func (c *Client) AsAPIClient() *model.Client {
+	res := &model.Client{
+		ID:                     c.GetClientID(),
+		Name:                   c.Name,
+		RedirectUris:           splitList(c.RedirectURIs),
+		AllowedScopes:          splitList(c.AllowedScopes),
+		GrantTypes:             splitList(c.GrantTypes),
+		PostLogoutRedirectUris: splitList(c.PostLogoutRedirectURIs),
+		CreatedAt:              refs.NewInt64Ref(c.CreatedAt),
+		UpdatedAt:              refs.NewInt64Ref(c.UpdatedAt),
+	}
+	if c.AccessTokenExpiryTime != "" {
+		res.AccessTokenExpiryTime = refs.NewStringRef(c.AccessTokenExpiryTime)
+	}
+	if c.RefreshTokenExpiryTime != "" {
+		res.RefreshTokenExpiryTime = refs.NewStringRef(c.RefreshTokenExpiryTime)
+	}
+	if c.BackchannelLogoutURI != "" {
+		res.BackchannelLogoutURI = refs.NewStringRef(c.BackchannelLogoutURI)
+	}
+	if c.FrontchannelLogoutURI != "" {
+		res.FrontchannelLogoutURI = refs.NewStringRef(c.FrontchannelLogoutURI)
+	}
+	if c.IDTokenEncryptedResponseAlg != "" {
+		res.IDTokenEncryptedResponseAlg = refs.NewStringRef(c.IDTokenEncryptedResponseAlg)
+	}
+	if c.IDTokenEncryptedResponseEnc != "" {
+		res.IDTokenEncryptedResponseEnc = refs.NewStringRef(c.IDTokenEncryptedResponseEnc)
+	}
+	if c.IDTokenEncryptionKey != "" {
+		res.IDTokenEncryptionKey = refs.NewStringRef(c.IDTokenEncryptionKey)
+	}
+	return res
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// Cursor is the position of last item of a page used by the cursor based pagination,
+// next page lists the items after it. Items are ordered by the value of sort field
+// & then by the id for the items having same value
+type Cursor struct {
+	// Value is the value of sort field of the item, int64 for timestamps & string for email
+	Value interface{} `json:"v"`
+	ID    string      `json:"id"`
+}
+
+// EncodeCursor returns the opaque cursor for the item with value of sort field & id
+func EncodeCursor(value interface{}, id string) string {
+	data, _ := json.Marshal(Cursor{
+		Value: value,
+		ID:    id,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes the opaque cursor returned by EncodeCursor
+func DecodeCursor(cursor string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	var res Cursor
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// timestamps are decoded as int64 instead of float64
+	decoder.UseNumber()
+	if err := decoder.Decode(&res); err != nil || res.ID == "" {
+		return nil, errors.New("invalid cursor")
+	}
+
+	switch value := res.Value.(type) {
+	case json.Number:
+		res.Value, err = value.Int64()
+		if err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+	case string:
+	default:
+		return nil, errors.New("invalid cursor")
+	}
+	return &res, nil
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+// Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
+
+// Grant model for db, it is the consent given by user to the oauth client for the scopes
+type Grant struct {
+	Key       string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty"` // for arangodb
+	ID        string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id"`
+	UserID    string `gorm:"uniqueIndex:idx_grant_user_client;type:char(36)" json:"user_id" bson:"user_id" cql:"user_id"`
+	ClientID  string `gorm:"uniqueIndex:idx_grant_user_client;type:char(36)" json:"client_id" bson:"client_id" cql:"client_id"`
+	Scope     string `gorm:"type:text" json:"scope" bson:"scope" cql:"scope"`
+	CreatedAt int64  `json:"created_at" bson:"created_at" cql:"created_at"`
+	UpdatedAt int64  `json:"updated_at" bson:"updated_at" cql:"updated_at"`
+}
+
+// GetScope returns the scopes granted to the client
+func (g *Grant) GetScope() []string {
+	return splitList(g.Scope)
+}
+
+// HasScope returns true if all the scopes are granted to the client
+func (g *Grant) HasScope(scope []string) bool {
+	granted := map[string]bool{}
+	for _, s := range g.GetScope() {
+		granted[s] = true
+	}
+	for _, s := range scope {
+		if !granted[s] {
+			return false
+		}
+	}
+	return true
+}
+
+// AddScope adds the scopes to the scopes granted to the client
+func (g *Grant) AddScope(scope []string) {
+	granted := g.GetScope()
+	for _, s := range scope {
+		s = strings.TrimSpace(s)
+		if s != "" && !g.HasScope([]string{s}) {
+			granted = append(granted, s)
+			g.Scope = strings.Join(granted, ",")
+		}
+	}
+}
+
+// AsAPIGrant returns the grant as graphql response
+func (g *Grant) AsAPIGrant(clientName string) *model.Grant {
+	return &model.Grant{
+		ID:         g.ID,
+		ClientID:   g.ClientID,
+		ClientName: clientName,
+		Scope:      g.GetScope(),
+		CreatedAt:  &g.CreatedAt,
+		UpdatedAt:  &g.UpdatedAt,
+	}
+}
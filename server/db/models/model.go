@@ -2,13 +2,19 @@ package models
 
 // Collections / Tables available for authorizer in the database
 type CollectionList struct {
-	User                string
-	VerificationRequest string
-	Session             string
-	Env                 string
-	Webhook             string
-	WebhookLog          string
-	EmailTemplate       string
+	User                   string
+	VerificationRequest    string
+	Session                string
+	Env                    string
+	Webhook                string
+	WebhookLog             string
+	EmailTemplate          string
+	WebauthnCredential     string
+	Client                 string
+	Grant                  string
+	Organization           string
+	OrganizationMember     string
+	OrganizationInvitation string
 }
 
 var (
@@ -16,12 +22,18 @@ var (
 	Prefix = "authorizer_"
 	// Collections / Tables available for authorizer in the database (used for dbs other than gorm)
 	Collections = CollectionList{
-		User:                Prefix + "users",
-		VerificationRequest: Prefix + "verification_requests",
-		Session:             Prefix + "sessions",
-		Env:                 Prefix + "env",
-		Webhook:             Prefix + "webhooks",
-		WebhookLog:          Prefix + "webhook_logs",
-		EmailTemplate:       Prefix + "email_templates",
+		User:                   Prefix + "users",
+		VerificationRequest:    Prefix + "verification_requests",
+		Session:                Prefix + "sessions",
+		Env:                    Prefix + "env",
+		Webhook:                Prefix + "webhooks",
+		WebhookLog:             Prefix + "webhook_logs",
+		EmailTemplate:          Prefix + "email_templates",
+		WebauthnCredential:     Prefix + "webauthn_credentials",
+		Client:                 Prefix + "clients",
+		Grant:                  Prefix + "grants",
+		Organization:           Prefix + "organizations",
+		OrganizationMember:     Prefix + "organization_members",
+		OrganizationInvitation: Prefix + "organization_invitations",
 	}
 )
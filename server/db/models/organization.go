@@ -0,0 +1,146 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+)
+
+// Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
+
+// Organization model for db, users are added to the organization as members with roles scoped to the organization
+type Organization struct {
+	Key          string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty"` // for arangodb
+	ID           string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id"`
+	Name         string `gorm:"unique" json:"name" bson:"name" cql:"name"`
+	DisplayName  string `json:"display_name" bson:"display_name" cql:"display_name"`
+	Roles        string `gorm:"type:text" json:"roles" bson:"roles" cql:"roles"`
+	DefaultRoles string `gorm:"type:text" json:"default_roles" bson:"default_roles" cql:"default_roles"`
+	CreatedAt    int64  `json:"created_at" bson:"created_at" cql:"created_at"`
+	UpdatedAt    int64  `json:"updated_at" bson:"updated_at" cql:"updated_at"`
+}
+
+// GetOrganizationID returns the id of organization
+func (o *Organization) GetOrganizationID() string {
+	id := o.ID
+	if strings.Contains(id, Collections.Organization+"/") {
+		id = strings.TrimPrefix(id, Collections.Organization+"/")
+	}
+	return id
+}
+
+// GetRoles returns the roles that can be assigned to the members of organization
+func (o *Organization) GetRoles() []string {
+	return splitList(o.Roles)
+}
+
+// GetDefaultRoles returns the roles assigned to the members added without roles
+func (o *Organization) GetDefaultRoles() []string {
+	return splitList(o.DefaultRoles)
+}
+
+// HasRoles returns true if all the roles can be assigned to the members of organization
+func (o *Organization) HasRoles(roles []string) bool {
+	return containsAll(o.GetRoles(), roles)
+}
+
+// AsAPIOrganization to return organization as graphql response object
+func (o *Organization) AsAPIOrganization() *model.Organization {
+	return &model.Organization{
+		ID:           o.GetOrganizationID(),
+		Name:         o.Name,
+		DisplayName:  refs.NewStringRef(o.DisplayName),
+		Roles:        o.GetRoles(),
+		DefaultRoles: o.GetDefaultRoles(),
+		CreatedAt:    refs.NewInt64Ref(o.CreatedAt),
+		UpdatedAt:    refs.NewInt64Ref(o.UpdatedAt),
+	}
+}
+
+// OrganizationMember model for db, it is the membership of user in the organization
+type OrganizationMember struct {
+	Key            string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty"` // for arangodb
+	ID             string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id"`
+	OrganizationID string `gorm:"uniqueIndex:idx_organization_member;type:char(36)" json:"organization_id" bson:"organization_id" cql:"organization_id"`
+	UserID         string `gorm:"uniqueIndex:idx_organization_member;type:char(36)" json:"user_id" bson:"user_id" cql:"user_id"`
+	Roles          string `gorm:"type:text" json:"roles" bson:"roles" cql:"roles"`
+	CreatedAt      int64  `json:"created_at" bson:"created_at" cql:"created_at"`
+	UpdatedAt      int64  `json:"updated_at" bson:"updated_at" cql:"updated_at"`
+}
+
+// GetRoles returns the roles of member in the organization
+func (m *OrganizationMember) GetRoles() []string {
+	return splitList(m.Roles)
+}
+
+// HasRoles returns true if member has all the roles in the organization
+func (m *OrganizationMember) HasRoles(roles []string) bool {
+	return containsAll(m.GetRoles(), roles)
+}
+
+// AsAPIOrganizationMember to return organization member as graphql response object
+func (m *OrganizationMember) AsAPIOrganizationMember() *model.OrganizationMember {
+	id := m.ID
+	if strings.Contains(id, Collections.OrganizationMember+"/") {
+		id = strings.TrimPrefix(id, Collections.OrganizationMember+"/")
+	}
+
+	return &model.OrganizationMember{
+		ID:             id,
+		OrganizationID: m.OrganizationID,
+		UserID:         m.UserID,
+		Roles:          m.GetRoles(),
+		CreatedAt:      refs.NewInt64Ref(m.CreatedAt),
+		UpdatedAt:      refs.NewInt64Ref(m.UpdatedAt),
+	}
+}
+
+// OrganizationInvitation model for db, invited user becomes the member of organization on login
+type OrganizationInvitation struct {
+	Key            string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty"` // for arangodb
+	ID             string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id"`
+	OrganizationID string `gorm:"uniqueIndex:idx_organization_invitation;type:char(36)" json:"organization_id" bson:"organization_id" cql:"organization_id"`
+	Email          string `gorm:"uniqueIndex:idx_organization_invitation;type:varchar(256)" json:"email" bson:"email" cql:"email"`
+	Roles          string `gorm:"type:text" json:"roles" bson:"roles" cql:"roles"`
+	ExpiresAt      int64  `json:"expires_at" bson:"expires_at" cql:"expires_at"`
+	CreatedAt      int64  `json:"created_at" bson:"created_at" cql:"created_at"`
+	UpdatedAt      int64  `json:"updated_at" bson:"updated_at" cql:"updated_at"`
+}
+
+// GetRoles returns the roles assigned to the member on accepting the invitation
+func (i *OrganizationInvitation) GetRoles() []string {
+	return splitList(i.Roles)
+}
+
+// AsAPIOrganizationInvitation to return organization invitation as graphql response object
+func (i *OrganizationInvitation) AsAPIOrganizationInvitation() *model.OrganizationInvitation {
+	id := i.ID
+	if strings.Contains(id, Collections.OrganizationInvitation+"/") {
+		id = strings.TrimPrefix(id, Collections.OrganizationInvitation+"/")
+	}
+
+	return &model.OrganizationInvitation{
+		ID:             id,
+		OrganizationID: i.OrganizationID,
+		Email:          i.Email,
+		Roles:          i.GetRoles(),
+		ExpiresAt:      i.ExpiresAt,
+		CreatedAt:      refs.NewInt64Ref(i.CreatedAt),
+		UpdatedAt:      refs.NewInt64Ref(i.UpdatedAt),
+	}
+}
+
+// containsAll returns true if all the values are in the list
+func containsAll(list []string, values []string) bool {
+	exists := map[string]bool{}
+	for _, value := range list {
+		exists[value] = true
+	}
+	for _, value := range values {
+		if !exists[value] {
+			return false
+		}
+	}
+	return true
+}
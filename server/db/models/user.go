@@ -29,36 +29,40 @@ type User struct {
 	Picture               *string `gorm:"type:text" json:"picture" bson:"picture" cql:"picture"`
 	Roles                 string  `json:"roles" bson:"roles" cql:"roles"`
 	RevokedTimestamp      *int64  `json:"revoked_timestamp" bson:"revoked_timestamp" cql:"revoked_timestamp"`
+	TOTPSecret            *string `gorm:"type:text" json:"totp_secret" bson:"totp_secret" cql:"totp_secret"` // encrypted
+	TOTPVerifiedAt        *int64  `json:"totp_verified_at" bson:"totp_verified_at" cql:"totp_verified_at"`
 	UpdatedAt             int64   `json:"updated_at" bson:"updated_at" cql:"updated_at"`
-	CreatedAt             int64   `json:"created_at" bson:"created_at" cql:"created_at"`
+	CreatedAt             int64   `gorm:"index" json:"created_at" bson:"created_at" cql:"created_at"`
 }
 
 func (user *User) AsAPIUser() *model.User {
 	isEmailVerified := user.EmailVerifiedAt != nil
 	isPhoneVerified := user.PhoneNumberVerifiedAt != nil
+	isMultiFactorAuthEnabled := user.TOTPVerifiedAt != nil
 
 	id := user.ID
 	if strings.Contains(id, Collections.WebhookLog+"/") {
 		id = strings.TrimPrefix(id, Collections.WebhookLog+"/")
 	}
 	return &model.User{
-		ID:                  id,
-		Email:               user.Email,
-		EmailVerified:       isEmailVerified,
-		SignupMethods:       user.SignupMethods,
-		GivenName:           user.GivenName,
-		FamilyName:          user.FamilyName,
-		MiddleName:          user.MiddleName,
-		Nickname:            user.Nickname,
-		PreferredUsername:   refs.NewStringRef(user.Email),
-		Gender:              user.Gender,
-		Birthdate:           user.Birthdate,
-		PhoneNumber:         user.PhoneNumber,
-		PhoneNumberVerified: &isPhoneVerified,
-		Picture:             user.Picture,
-		Roles:               strings.Split(user.Roles, ","),
-		RevokedTimestamp:    user.RevokedTimestamp,
-		CreatedAt:           refs.NewInt64Ref(user.CreatedAt),
-		UpdatedAt:           refs.NewInt64Ref(user.UpdatedAt),
+		ID:                       id,
+		Email:                    user.Email,
+		EmailVerified:            isEmailVerified,
+		SignupMethods:            user.SignupMethods,
+		GivenName:                user.GivenName,
+		FamilyName:               user.FamilyName,
+		MiddleName:               user.MiddleName,
+		Nickname:                 user.Nickname,
+		PreferredUsername:        refs.NewStringRef(user.Email),
+		Gender:                   user.Gender,
+		Birthdate:                user.Birthdate,
+		PhoneNumber:              user.PhoneNumber,
+		PhoneNumberVerified:      &isPhoneVerified,
+		Picture:                  user.Picture,
+		Roles:                    strings.Split(user.Roles, ","),
+		RevokedTimestamp:         user.RevokedTimestamp,
+		IsMultiFactorAuthEnabled: &isMultiFactorAuthEnabled,
+		CreatedAt:                refs.NewInt64Ref(user.CreatedAt),
+		UpdatedAt:                refs.NewInt64Ref(user.UpdatedAt),
 	}
 }
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+)
+
+// UserSortFields are the fields users can be sorted by
+var UserSortFields = []string{"created_at", "updated_at", "email"}
+
+// UserFilter is used to search & sort the users listed by admin.
+// Zero value lists all the users with the latest users first
+type UserFilter struct {
+	// Email matches the users with email containing it, case insensitive
+	Email string
+	// Role & SignupMethod match the users with it in their comma separated roles & signup methods
+	Role            string
+	SignupMethod    string
+	IsEmailVerified *bool
+	IsPhoneVerified *bool
+	IsRevoked       *bool
+	// CreatedAfter & CreatedBefore are the inclusive range of created_at
+	CreatedAfter  *int64
+	CreatedBefore *int64
+	// SortBy is one of UserSortFields, created_at by default
+	SortBy string
+	// SortOrder is asc or desc, desc by default
+	SortOrder string
+}
+
+// GetSortBy returns the field users are sorted by
+func (f *UserFilter) GetSortBy() string {
+	for _, field := range UserSortFields {
+		if f.SortBy == field {
+			return field
+		}
+	}
+	return "created_at"
+}
+
+// IsAscending returns true if users are sorted in ascending order
+func (f *UserFilter) IsAscending() bool {
+	return f.SortOrder == constants.SortOrderAsc
+}
+
+// GetSortOrder returns the sort order, asc or desc
+func (f *UserFilter) GetSortOrder() string {
+	if f.IsAscending() {
+		return constants.SortOrderAsc
+	}
+	return constants.SortOrderDesc
+}
+
+// MatchesEmail returns true if email contains the email of filter, case insensitive.
+// It is used by the databases that can not match the part of text natively
+func (f *UserFilter) MatchesEmail(email string) bool {
+	return f.Email == "" || strings.Contains(strings.ToLower(email), strings.ToLower(f.Email))
+}
+
+// SortValue returns the value of sort field of user, used for the cursor of user
+func (f *UserFilter) SortValue(user *User) interface{} {
+	switch f.GetSortBy() {
+	case "email":
+		return user.Email
+	case "updated_at":
+		return user.UpdatedAt
+	default:
+		return user.CreatedAt
+	}
+}
@@ -0,0 +1,165 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/refs"
+)
+
+// UserRecordFields are the fields of user record, in the order of csv columns
+var UserRecordFields = []string{"id", "email", "email_verified_at", "password_hash", "signup_methods", "given_name", "family_name", "middle_name", "nickname", "gender", "birthdate", "phone_number", "phone_number_verified_at", "picture", "roles", "revoked_timestamp"}
+
+// UserRecord is the user in the csv & json files of users import & export.
+// PasswordHash is the bcrypt, argon2, scrypt or pbkdf2 hash of password
+type UserRecord struct {
+	ID                    string  `json:"id"`
+	Email                 string  `json:"email"`
+	EmailVerifiedAt       *int64  `json:"email_verified_at"`
+	PasswordHash          *string `json:"password_hash"`
+	SignupMethods         string  `json:"signup_methods"`
+	GivenName             *string `json:"given_name"`
+	FamilyName            *string `json:"family_name"`
+	MiddleName            *string `json:"middle_name"`
+	Nickname              *string `json:"nickname"`
+	Gender                *string `json:"gender"`
+	Birthdate             *string `json:"birthdate"`
+	PhoneNumber           *string `json:"phone_number"`
+	PhoneNumberVerifiedAt *int64  `json:"phone_number_verified_at"`
+	Picture               *string `json:"picture"`
+	Roles                 string  `json:"roles"`
+	RevokedTimestamp      *int64  `json:"revoked_timestamp"`
+}
+
+// NewUserRecord returns the record of user for export
+func NewUserRecord(user User) UserRecord {
+	return UserRecord{
+		ID:                    user.ID,
+		Email:                 user.Email,
+		EmailVerifiedAt:       user.EmailVerifiedAt,
+		PasswordHash:          user.Password,
+		SignupMethods:         user.SignupMethods,
+		GivenName:             user.GivenName,
+		FamilyName:            user.FamilyName,
+		MiddleName:            user.MiddleName,
+		Nickname:              user.Nickname,
+		Gender:                user.Gender,
+		Birthdate:             user.Birthdate,
+		PhoneNumber:           user.PhoneNumber,
+		PhoneNumberVerifiedAt: user.PhoneNumberVerifiedAt,
+		Picture:               user.Picture,
+		Roles:                 user.Roles,
+		RevokedTimestamp:      user.RevokedTimestamp,
+	}
+}
+
+// AsUser returns the user to be imported from record
+func (r *UserRecord) AsUser() User {
+	return User{
+		ID:                    r.ID,
+		Email:                 r.Email,
+		EmailVerifiedAt:       r.EmailVerifiedAt,
+		Password:              r.PasswordHash,
+		SignupMethods:         r.SignupMethods,
+		GivenName:             r.GivenName,
+		FamilyName:            r.FamilyName,
+		MiddleName:            r.MiddleName,
+		Nickname:              r.Nickname,
+		Gender:                r.Gender,
+		Birthdate:             r.Birthdate,
+		PhoneNumber:           r.PhoneNumber,
+		PhoneNumberVerifiedAt: r.PhoneNumberVerifiedAt,
+		Picture:               r.Picture,
+		Roles:                 r.Roles,
+		RevokedTimestamp:      r.RevokedTimestamp,
+	}
+}
+
+// fields returns the pointers to the fields of record by their name
+func (r *UserRecord) fields() map[string]interface{} {
+	return map[string]interface{}{
+		"id":                       &r.ID,
+		"email":                    &r.Email,
+		"email_verified_at":        &r.EmailVerifiedAt,
+		"password_hash":            &r.PasswordHash,
+		"signup_methods":           &r.SignupMethods,
+		"given_name":               &r.GivenName,
+		"family_name":              &r.FamilyName,
+		"middle_name":              &r.MiddleName,
+		"nickname":                 &r.Nickname,
+		"gender":                   &r.Gender,
+		"birthdate":                &r.Birthdate,
+		"phone_number":             &r.PhoneNumber,
+		"phone_number_verified_at": &r.PhoneNumberVerifiedAt,
+		"picture":                  &r.Picture,
+		"roles":                    &r.Roles,
+		"revoked_timestamp":        &r.RevokedTimestamp,
+	}
+}
+
+// CSVRow returns the csv row of record with the columns of UserRecordFields,
+// empty values are used for the fields that are not set
+func (r *UserRecord) CSVRow() []string {
+	fields := r.fields()
+	row := make([]string, len(UserRecordFields))
+	for i, name := range UserRecordFields {
+		switch value := fields[name].(type) {
+		case *string:
+			row[i] = *value
+		case **string:
+			row[i] = refs.StringValue(*value)
+		case **int64:
+			if *value != nil {
+				row[i] = strconv.FormatInt(**value, 10)
+			}
+		}
+	}
+	return row
+}
+
+// ValidateUserRecordsCSVHeader validates the header of users csv,
+// columns can be in any order but must be one of UserRecordFields
+func ValidateUserRecordsCSVHeader(header []string) error {
+	hasEmail := false
+	for _, column := range header {
+		if _, ok := (&UserRecord{}).fields()[strings.TrimSpace(column)]; !ok {
+			return fmt.Errorf("invalid column %s", column)
+		}
+		hasEmail = hasEmail || strings.TrimSpace(column) == "email"
+	}
+	if !hasEmail {
+		return fmt.Errorf("email column is required")
+	}
+	return nil
+}
+
+// NewUserRecordFromCSV returns the record from csv row with the columns of header
+func NewUserRecordFromCSV(header, row []string) (UserRecord, error) {
+	var record UserRecord
+	if len(row) != len(header) {
+		return record, fmt.Errorf("expected %d columns, found %d", len(header), len(row))
+	}
+
+	fields := record.fields()
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		value := strings.TrimSpace(row[i])
+		if value == "" {
+			continue
+		}
+		switch field := fields[column].(type) {
+		case *string:
+			*field = value
+		case **string:
+			*field = refs.NewStringRef(value)
+		case **int64:
+			timestamp, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return record, fmt.Errorf("invalid %s, unix timestamp is expected", column)
+			}
+			*field = refs.NewInt64Ref(timestamp)
+		}
+	}
+	return record, nil
+}
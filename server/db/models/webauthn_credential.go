@@ -0,0 +1,16 @@
+package models
+
+// Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
+
+// WebauthnCredential model for db
+type WebauthnCredential struct {
+	Key          string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty"` // for arangodb
+	ID           string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id"`
+	UserID       string `gorm:"type:char(36)" json:"user_id" bson:"user_id" cql:"user_id"`
+	CredentialID string `gorm:"unique;type:varchar(512)" json:"credential_id" bson:"credential_id" cql:"credential_id"`
+	PublicKey    string `gorm:"type:text" json:"public_key" bson:"public_key" cql:"public_key"`
+	SignCount    int64  `json:"sign_count" bson:"sign_count" cql:"sign_count"`
+	AAGUID       string `json:"aaguid" bson:"aaguid" cql:"aaguid"`
+	CreatedAt    int64  `json:"created_at" bson:"created_at" cql:"created_at"`
+	UpdatedAt    int64  `json:"updated_at" bson:"updated_at" cql:"updated_at"`
+}
@@ -9,6 +9,7 @@ import (
 	arangoDriver "github.com/arangodb/go-driver"
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/google/uuid"
 )
 
@@ -49,17 +50,23 @@ func (p *provider) UpdateEmailTemplate(ctx context.Context, emailTemplate models
 func (p *provider) ListEmailTemplate(ctx context.Context, pagination model.Pagination) (*model.EmailTemplates, error) {
 	emailTemplates := []*model.EmailTemplate{}
 
-	query := fmt.Sprintf("FOR d in %s SORT d.created_at DESC LIMIT %d, %d RETURN d", models.Collections.EmailTemplate, pagination.Offset, pagination.Limit)
+	paginationQuery, bindVars, err := paginate(pagination, "created_at", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("FOR d in %s%s RETURN d", models.Collections.EmailTemplate, paginationQuery)
 
 	sctx := driver.WithQueryFullCount(ctx)
-	cursor, err := p.db.Query(sctx, query, nil)
+	cursor, err := p.db.Query(sctx, query, bindVars)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close()
 
 	paginationClone := pagination
-	paginationClone.Total = cursor.Statistics().FullCount()
+	if pagination.After == nil {
+		paginationClone.Total = cursor.Statistics().FullCount()
+	}
 
 	for {
 		var emailTemplate models.EmailTemplate
@@ -72,7 +79,13 @@ func (p *provider) ListEmailTemplate(ctx context.Context, pagination model.Pagin
 		}
 
 		if meta.Key != "" {
+			// extra item is fetched to know if there is next page
+			if int64(len(emailTemplates)) == pagination.Limit {
+				paginationClone.HasNextPage = true
+				break
+			}
 			emailTemplates = append(emailTemplates, emailTemplate.AsAPIEmailTemplate())
+			paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(emailTemplate.CreatedAt, emailTemplate.ID))
 		}
 	}
 
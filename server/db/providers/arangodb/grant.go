@@ -0,0 +1,119 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	arangoDriver "github.com/arangodb/go-driver"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/google/uuid"
+)
+
+// AddGrant to save the scopes granted by user to oauth client
+func (p *provider) AddGrant(ctx context.Context, grant models.Grant) (models.Grant, error) {
+	if grant.ID == "" {
+		grant.ID = uuid.New().String()
+	}
+
+	grant.Key = grant.ID
+	grant.CreatedAt = time.Now().Unix()
+	grant.UpdatedAt = time.Now().Unix()
+	grantCollection, _ := p.db.Collection(ctx, models.Collections.Grant)
+	meta, err := grantCollection.CreateDocument(ctx, grant)
+	if err != nil {
+		return grant, err
+	}
+	grant.Key = meta.Key
+	grant.ID = meta.ID.String()
+
+	return grant, nil
+}
+
+// UpdateGrant to update the scopes granted by user to oauth client
+func (p *provider) UpdateGrant(ctx context.Context, grant models.Grant) (models.Grant, error) {
+	grant.UpdatedAt = time.Now().Unix()
+	grantCollection, _ := p.db.Collection(ctx, models.Collections.Grant)
+	meta, err := grantCollection.UpdateDocument(ctx, grant.Key, grant)
+	if err != nil {
+		return grant, err
+	}
+	grant.Key = meta.Key
+	grant.ID = meta.ID.String()
+
+	return grant, nil
+}
+
+// GetGrantByUserIDAndClientID to get the scopes granted by user to oauth client
+func (p *provider) GetGrantByUserIDAndClientID(ctx context.Context, userID, clientID string) (models.Grant, error) {
+	var grant models.Grant
+	query := fmt.Sprintf("FOR d in %s FILTER d.user_id == @user_id AND d.client_id == @client_id RETURN d", models.Collections.Grant)
+	bindVars := map[string]interface{}{
+		"user_id":   userID,
+		"client_id": clientID,
+	}
+
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return grant, err
+	}
+	defer cursor.Close()
+
+	for {
+		if !cursor.HasMore() {
+			if grant.Key == "" {
+				return grant, fmt.Errorf("grant not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &grant)
+		if err != nil {
+			return grant, err
+		}
+	}
+
+	return grant, nil
+}
+
+// ListGrantsByUserID to get all the oauth clients granted access by user
+func (p *provider) ListGrantsByUserID(ctx context.Context, userID string) ([]models.Grant, error) {
+	grants := []models.Grant{}
+	query := fmt.Sprintf("FOR d in %s FILTER d.user_id == @user_id SORT d.created_at DESC RETURN d", models.Collections.Grant)
+	bindVars := map[string]interface{}{
+		"user_id": userID,
+	}
+
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	for {
+		var grant models.Grant
+		meta, err := cursor.ReadDocument(ctx, &grant)
+
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if meta.Key != "" {
+			grants = append(grants, grant)
+		}
+	}
+
+	return grants, nil
+}
+
+// DeleteGrant to revoke the access granted by user to oauth client
+func (p *provider) DeleteGrant(ctx context.Context, grant models.Grant) error {
+	grantCollection, _ := p.db.Collection(ctx, models.Collections.Grant)
+	_, err := grantCollection.RemoveDocument(ctx, grant.Key)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,454 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	arangoDriver "github.com/arangodb/go-driver"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/google/uuid"
+)
+
+// AddOrganization to add organization
+func (p *provider) AddOrganization(ctx context.Context, organization models.Organization) (models.Organization, error) {
+	if organization.ID == "" {
+		organization.ID = uuid.New().String()
+	}
+
+	organization.Key = organization.ID
+	organization.CreatedAt = time.Now().Unix()
+	organization.UpdatedAt = time.Now().Unix()
+	organizationCollection, _ := p.db.Collection(ctx, models.Collections.Organization)
+	meta, err := organizationCollection.CreateDocument(ctx, organization)
+	if err != nil {
+		return organization, err
+	}
+	// key is used as organization id in the memberships
+	organization.Key = meta.Key
+	organization.ID = meta.Key
+
+	return organization, nil
+}
+
+// UpdateOrganization to update organization
+func (p *provider) UpdateOrganization(ctx context.Context, organization models.Organization) (models.Organization, error) {
+	organization.UpdatedAt = time.Now().Unix()
+	organizationCollection, _ := p.db.Collection(ctx, models.Collections.Organization)
+	meta, err := organizationCollection.UpdateDocument(ctx, organization.Key, organization)
+	if err != nil {
+		return organization, err
+	}
+	organization.Key = meta.Key
+	organization.ID = meta.Key
+
+	return organization, nil
+}
+
+// DeleteOrganization to delete organization along with its members & invitations
+func (p *provider) DeleteOrganization(ctx context.Context, organization models.Organization) error {
+	organizationCollection, _ := p.db.Collection(ctx, models.Collections.Organization)
+	_, err := organizationCollection.RemoveDocument(ctx, organization.Key)
+	if err != nil {
+		return err
+	}
+
+	bindVars := map[string]interface{}{
+		"organization_id": organization.GetOrganizationID(),
+	}
+	query := fmt.Sprintf(`FOR d IN %s FILTER d.organization_id == @organization_id REMOVE { _key: d._key } IN %s`, models.Collections.OrganizationMember, models.Collections.OrganizationMember)
+	memberCursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return err
+	}
+	defer memberCursor.Close()
+
+	query = fmt.Sprintf(`FOR d IN %s FILTER d.organization_id == @organization_id REMOVE { _key: d._key } IN %s`, models.Collections.OrganizationInvitation, models.Collections.OrganizationInvitation)
+	invitationCursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return err
+	}
+	defer invitationCursor.Close()
+
+	return nil
+}
+
+// GetOrganizationByID to get organization by id
+func (p *provider) GetOrganizationByID(ctx context.Context, organizationID string) (models.Organization, error) {
+	var organization models.Organization
+	query := fmt.Sprintf("FOR d in %s FILTER d._key == @organization_id RETURN d", models.Collections.Organization)
+	bindVars := map[string]interface{}{
+		"organization_id": organizationID,
+	}
+
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return organization, err
+	}
+	defer cursor.Close()
+
+	for {
+		if !cursor.HasMore() {
+			if organization.Key == "" {
+				return organization, fmt.Errorf("organization not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &organization)
+		if err != nil {
+			return organization, err
+		}
+	}
+
+	return organization, nil
+}
+
+// GetOrganizationByName to get organization by name
+func (p *provider) GetOrganizationByName(ctx context.Context, name string) (models.Organization, error) {
+	var organization models.Organization
+	query := fmt.Sprintf("FOR d in %s FILTER d.name == @name RETURN d", models.Collections.Organization)
+	bindVars := map[string]interface{}{
+		"name": name,
+	}
+
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return organization, err
+	}
+	defer cursor.Close()
+
+	for {
+		if !cursor.HasMore() {
+			if organization.Key == "" {
+				return organization, fmt.Errorf("organization not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &organization)
+		if err != nil {
+			return organization, err
+		}
+	}
+
+	return organization, nil
+}
+
+// ListOrganizations to list organizations
+func (p *provider) ListOrganizations(ctx context.Context, pagination model.Pagination) (*model.Organizations, error) {
+	organizations := []*model.Organization{}
+
+	paginationQuery, bindVars, err := paginate(pagination, "created_at", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("FOR d in %s%s RETURN d", models.Collections.Organization, paginationQuery)
+
+	sctx := arangoDriver.WithQueryFullCount(ctx)
+	cursor, err := p.db.Query(sctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	paginationClone := pagination
+	if pagination.After == nil {
+		paginationClone.Total = cursor.Statistics().FullCount()
+	}
+
+	for {
+		var organization models.Organization
+		meta, err := cursor.ReadDocument(ctx, &organization)
+
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if meta.Key != "" {
+			// extra item is fetched to know if there is next page
+			if int64(len(organizations)) == pagination.Limit {
+				paginationClone.HasNextPage = true
+				break
+			}
+			organizations = append(organizations, organization.AsAPIOrganization())
+			paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(organization.CreatedAt, organization.ID))
+		}
+	}
+
+	return &model.Organizations{
+		Pagination:    &paginationClone,
+		Organizations: organizations,
+	}, nil
+}
+
+// AddOrganizationMember to add user as the member of organization
+func (p *provider) AddOrganizationMember(ctx context.Context, member models.OrganizationMember) (models.OrganizationMember, error) {
+	if member.ID == "" {
+		member.ID = uuid.New().String()
+	}
+
+	member.Key = member.ID
+	member.CreatedAt = time.Now().Unix()
+	member.UpdatedAt = time.Now().Unix()
+	organizationMemberCollection, _ := p.db.Collection(ctx, models.Collections.OrganizationMember)
+	meta, err := organizationMemberCollection.CreateDocument(ctx, member)
+	if err != nil {
+		return member, err
+	}
+	member.Key = meta.Key
+	member.ID = meta.ID.String()
+
+	return member, nil
+}
+
+// UpdateOrganizationMember to update the roles of member in organization
+func (p *provider) UpdateOrganizationMember(ctx context.Context, member models.OrganizationMember) (models.OrganizationMember, error) {
+	member.UpdatedAt = time.Now().Unix()
+	organizationMemberCollection, _ := p.db.Collection(ctx, models.Collections.OrganizationMember)
+	meta, err := organizationMemberCollection.UpdateDocument(ctx, member.Key, member)
+	if err != nil {
+		return member, err
+	}
+	member.Key = meta.Key
+	member.ID = meta.ID.String()
+
+	return member, nil
+}
+
+// DeleteOrganizationMember to remove user from organization
+func (p *provider) DeleteOrganizationMember(ctx context.Context, member models.OrganizationMember) error {
+	organizationMemberCollection, _ := p.db.Collection(ctx, models.Collections.OrganizationMember)
+	_, err := organizationMemberCollection.RemoveDocument(ctx, member.Key)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetOrganizationMember to get the membership of user in organization
+func (p *provider) GetOrganizationMember(ctx context.Context, organizationID, userID string) (models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	query := fmt.Sprintf("FOR d in %s FILTER d.organization_id == @organization_id AND d.user_id == @user_id RETURN d", models.Collections.OrganizationMember)
+	bindVars := map[string]interface{}{
+		"organization_id": organizationID,
+		"user_id":         userID,
+	}
+
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return member, err
+	}
+	defer cursor.Close()
+
+	for {
+		if !cursor.HasMore() {
+			if member.Key == "" {
+				return member, fmt.Errorf("organization member not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &member)
+		if err != nil {
+			return member, err
+		}
+	}
+
+	return member, nil
+}
+
+// ListOrganizationMembers to list the members of organization
+func (p *provider) ListOrganizationMembers(ctx context.Context, pagination model.Pagination, organizationID string) (*model.OrganizationMembers, error) {
+	members := []*model.OrganizationMember{}
+	filterQuery := " FILTER d.organization_id == @organization_id"
+	bindVariables := map[string]interface{}{
+		"organization_id": organizationID,
+	}
+
+	paginationQuery, paginationBindVariables, err := paginate(pagination, "created_at", false, bindVariables)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("FOR d in %s%s%s RETURN d", models.Collections.OrganizationMember, filterQuery, paginationQuery)
+
+	sctx := arangoDriver.WithQueryFullCount(ctx)
+	cursor, err := p.db.Query(sctx, query, paginationBindVariables)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	paginationClone := pagination
+	if pagination.After == nil {
+		paginationClone.Total = cursor.Statistics().FullCount()
+	}
+
+	for {
+		var member models.OrganizationMember
+		meta, err := cursor.ReadDocument(ctx, &member)
+
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if meta.Key != "" {
+			// extra item is fetched to know if there is next page
+			if int64(len(members)) == pagination.Limit {
+				paginationClone.HasNextPage = true
+				break
+			}
+			members = append(members, member.AsAPIOrganizationMember())
+			paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(member.CreatedAt, member.ID))
+		}
+	}
+
+	return &model.OrganizationMembers{
+		Pagination: &paginationClone,
+		Members:    members,
+	}, nil
+}
+
+// ListOrganizationMembersByUserID to get all the organizations memberships of user
+func (p *provider) ListOrganizationMembersByUserID(ctx context.Context, userID string) ([]models.OrganizationMember, error) {
+	members := []models.OrganizationMember{}
+	query := fmt.Sprintf("FOR d in %s FILTER d.user_id == @user_id SORT d.created_at DESC RETURN d", models.Collections.OrganizationMember)
+	bindVars := map[string]interface{}{
+		"user_id": userID,
+	}
+
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	for {
+		var member models.OrganizationMember
+		meta, err := cursor.ReadDocument(ctx, &member)
+
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if meta.Key != "" {
+			members = append(members, member)
+		}
+	}
+
+	return members, nil
+}
+
+// AddOrganizationInvitation to invite email to organization
+func (p *provider) AddOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) (models.OrganizationInvitation, error) {
+	if invitation.ID == "" {
+		invitation.ID = uuid.New().String()
+	}
+
+	invitation.Key = invitation.ID
+	invitation.CreatedAt = time.Now().Unix()
+	invitation.UpdatedAt = time.Now().Unix()
+	organizationInvitationCollection, _ := p.db.Collection(ctx, models.Collections.OrganizationInvitation)
+	meta, err := organizationInvitationCollection.CreateDocument(ctx, invitation)
+	if err != nil {
+		return invitation, err
+	}
+	invitation.Key = meta.Key
+	invitation.ID = meta.ID.String()
+
+	return invitation, nil
+}
+
+// UpdateOrganizationInvitation to update the roles & expiry of invitation
+func (p *provider) UpdateOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) (models.OrganizationInvitation, error) {
+	invitation.UpdatedAt = time.Now().Unix()
+	organizationInvitationCollection, _ := p.db.Collection(ctx, models.Collections.OrganizationInvitation)
+	meta, err := organizationInvitationCollection.UpdateDocument(ctx, invitation.Key, invitation)
+	if err != nil {
+		return invitation, err
+	}
+	invitation.Key = meta.Key
+	invitation.ID = meta.ID.String()
+
+	return invitation, nil
+}
+
+// GetOrganizationInvitation to get the invitation of email to organization
+func (p *provider) GetOrganizationInvitation(ctx context.Context, organizationID, email string) (models.OrganizationInvitation, error) {
+	var invitation models.OrganizationInvitation
+	query := fmt.Sprintf("FOR d in %s FILTER d.organization_id == @organization_id AND d.email == @email RETURN d", models.Collections.OrganizationInvitation)
+	bindVars := map[string]interface{}{
+		"organization_id": organizationID,
+		"email":           email,
+	}
+
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return invitation, err
+	}
+	defer cursor.Close()
+
+	for {
+		if !cursor.HasMore() {
+			if invitation.Key == "" {
+				return invitation, fmt.Errorf("organization invitation not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &invitation)
+		if err != nil {
+			return invitation, err
+		}
+	}
+
+	return invitation, nil
+}
+
+// ListOrganizationInvitations to list the pending invitations of organization
+func (p *provider) ListOrganizationInvitations(ctx context.Context, organizationID string) ([]models.OrganizationInvitation, error) {
+	invitations := []models.OrganizationInvitation{}
+	query := fmt.Sprintf("FOR d in %s FILTER d.organization_id == @organization_id SORT d.created_at DESC RETURN d", models.Collections.OrganizationInvitation)
+	bindVars := map[string]interface{}{
+		"organization_id": organizationID,
+	}
+
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	for {
+		var invitation models.OrganizationInvitation
+		meta, err := cursor.ReadDocument(ctx, &invitation)
+
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if meta.Key != "" {
+			invitations = append(invitations, invitation)
+		}
+	}
+
+	return invitations, nil
+}
+
+// DeleteOrganizationInvitation to delete invitation once it is accepted or revoked
+func (p *provider) DeleteOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) error {
+	organizationInvitationCollection, _ := p.db.Collection(ctx, models.Collections.OrganizationInvitation)
+	_, err := organizationInvitationCollection.RemoveDocument(ctx, invitation.Key)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
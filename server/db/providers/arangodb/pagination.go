@@ -0,0 +1,41 @@
+package arangodb
+
+import (
+	"fmt"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+// paginate returns the AQL to list the page of items ordered by sort field & _id,
+// along with the bind variables of filter & pagination.
+// Items after the cursor are listed if pagination has after cursor, else the items from offset.
+// It fetches limit + 1 items, the extra item is used to know if there is next page.
+// Full count of the query is the total only for the pages listed by offset,
+// it does not include the items before cursor & is not used for the pages after cursor
+func paginate(pagination model.Pagination, sortBy string, ascending bool, bindVars map[string]interface{}) (string, map[string]interface{}, error) {
+	order, operator := "DESC", "<"
+	if ascending {
+		order, operator = "ASC", ">"
+	}
+	paginationBindVars := map[string]interface{}{}
+	for key, value := range bindVars {
+		paginationBindVars[key] = value
+	}
+
+	query := ""
+	offset := pagination.Offset
+	if pagination.After != nil {
+		cursor, err := models.DecodeCursor(*pagination.After)
+		if err != nil {
+			return "", nil, err
+		}
+		query = fmt.Sprintf(" FILTER (d.%s %s @cursor_value OR (d.%s == @cursor_value AND d._id %s @cursor_id))", sortBy, operator, sortBy, operator)
+		paginationBindVars["cursor_value"] = cursor.Value
+		paginationBindVars["cursor_id"] = cursor.ID
+		offset = 0
+	}
+	query += fmt.Sprintf(" SORT d.%s %s, d._id %s LIMIT %d, %d", sortBy, order, order, offset, pagination.Limit+1)
+
+	return query, paginationBindVars, nil
+}
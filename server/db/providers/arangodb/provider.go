@@ -68,6 +68,8 @@ func NewProvider() (*provider, error) {
 		Unique: true,
 		Sparse: true,
 	})
+	// users are listed by the creation time
+	userCollection.EnsurePersistentIndex(ctx, []string{"created_at"}, &arangoDriver.EnsurePersistentIndexOptions{})
 
 	verificationRequestCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.VerificationRequest)
 	if !verificationRequestCollectionExists {
@@ -148,6 +150,93 @@ func NewProvider() (*provider, error) {
 		Sparse: true,
 	})
 
+	webauthnCredentialCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.WebauthnCredential)
+	if !webauthnCredentialCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.WebauthnCredential, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	webauthnCredentialCollection, _ := arangodb.Collection(nil, models.Collections.WebauthnCredential)
+	webauthnCredentialCollection.EnsureHashIndex(ctx, []string{"credential_id"}, &arangoDriver.EnsureHashIndexOptions{
+		Unique: true,
+		Sparse: true,
+	})
+	webauthnCredentialCollection.EnsureHashIndex(ctx, []string{"user_id"}, &arangoDriver.EnsureHashIndexOptions{
+		Sparse: true,
+	})
+
+	clientCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.Client)
+	if !clientCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.Client, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	grantCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.Grant)
+	if !grantCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.Grant, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	grantCollection, _ := arangodb.Collection(nil, models.Collections.Grant)
+	grantCollection.EnsureHashIndex(ctx, []string{"user_id", "client_id"}, &arangoDriver.EnsureHashIndexOptions{
+		Unique: true,
+		Sparse: true,
+	})
+	grantCollection.EnsureHashIndex(ctx, []string{"client_id"}, &arangoDriver.EnsureHashIndexOptions{
+		Sparse: true,
+	})
+
+	organizationCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.Organization)
+	if !organizationCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.Organization, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	organizationCollection, _ := arangodb.Collection(nil, models.Collections.Organization)
+	organizationCollection.EnsureHashIndex(ctx, []string{"name"}, &arangoDriver.EnsureHashIndexOptions{
+		Unique: true,
+		Sparse: true,
+	})
+
+	organizationMemberCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.OrganizationMember)
+	if !organizationMemberCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.OrganizationMember, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	organizationMemberCollection, _ := arangodb.Collection(nil, models.Collections.OrganizationMember)
+	organizationMemberCollection.EnsureHashIndex(ctx, []string{"organization_id", "user_id"}, &arangoDriver.EnsureHashIndexOptions{
+		Unique: true,
+		Sparse: true,
+	})
+	organizationMemberCollection.EnsureHashIndex(ctx, []string{"user_id"}, &arangoDriver.EnsureHashIndexOptions{
+		Sparse: true,
+	})
+
+	organizationInvitationCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.OrganizationInvitation)
+	if !organizationInvitationCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.OrganizationInvitation, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	organizationInvitationCollection, _ := arangodb.Collection(nil, models.Collections.OrganizationInvitation)
+	organizationInvitationCollection.EnsureHashIndex(ctx, []string{"organization_id", "email"}, &arangoDriver.EnsureHashIndexOptions{
+		Unique: true,
+		Sparse: true,
+	})
+
 	return &provider{
 		db: arangodb,
 	}, err
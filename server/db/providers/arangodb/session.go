@@ -2,8 +2,10 @@ package arangodb
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	arangoDriver "github.com/arangodb/go-driver"
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/google/uuid"
 )
@@ -14,6 +16,7 @@ func (p *provider) AddSession(ctx context.Context, session models.Session) error
 		session.ID = uuid.New().String()
 	}
 
+	session.Key = session.ID
 	session.CreatedAt = time.Now().Unix()
 	session.UpdatedAt = time.Now().Unix()
 	sessionCollection, _ := p.db.Collection(ctx, models.Collections.Session)
@@ -23,3 +26,48 @@ func (p *provider) AddSession(ctx context.Context, session models.Session) error
 	}
 	return nil
 }
+
+// ListSessionsByUserID to get all the sessions information of user
+func (p *provider) ListSessionsByUserID(ctx context.Context, userID string) ([]models.Session, error) {
+	sessions := []models.Session{}
+	query := fmt.Sprintf("FOR d in %s FILTER d.user_id == @user_id SORT d.created_at DESC RETURN d", models.Collections.Session)
+	bindVars := map[string]interface{}{
+		"user_id": userID,
+	}
+
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	for {
+		var session models.Session
+		meta, err := cursor.ReadDocument(ctx, &session)
+
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if meta.Key != "" {
+			// id of session is saved as the document key
+			session.ID = meta.Key
+			sessions = append(sessions, session)
+		}
+	}
+
+	return sessions, nil
+}
+
+// DeleteSession to delete session information from database
+func (p *provider) DeleteSession(ctx context.Context, session models.Session) error {
+	sessionCollection, _ := p.db.Collection(ctx, models.Collections.Session)
+	_, err := sessionCollection.RemoveDocument(ctx, session.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package arangodb
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/arangodb/go-driver"
@@ -11,6 +12,7 @@ import (
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/google/uuid"
 )
 
@@ -73,24 +75,101 @@ func (p *provider) DeleteUser(ctx context.Context, user models.User) error {
 	}
 	defer cursor.Close()
 
+	query = fmt.Sprintf(`FOR d IN %s FILTER d.user_id == @user_id REMOVE { _key: d._key } IN %s`, models.Collections.WebauthnCredential, models.Collections.WebauthnCredential)
+	credentialCursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return err
+	}
+	defer credentialCursor.Close()
+
+	query = fmt.Sprintf(`FOR d IN %s FILTER d.user_id == @user_id REMOVE { _key: d._key } IN %s`, models.Collections.Grant, models.Collections.Grant)
+	grantCursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return err
+	}
+	defer grantCursor.Close()
+
+	query = fmt.Sprintf(`FOR d IN %s FILTER d.user_id == @user_id REMOVE { _key: d._key } IN %s`, models.Collections.OrganizationMember, models.Collections.OrganizationMember)
+	organizationMemberCursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return err
+	}
+	defer organizationMemberCursor.Close()
+
 	return nil
 }
 
-// ListUsers to get list of users from database
-func (p *provider) ListUsers(ctx context.Context, pagination model.Pagination) (*model.Users, error) {
+// filterUsers returns the AQL filter & bind variables of users matching the filter
+func filterUsers(filter models.UserFilter) (string, map[string]interface{}) {
+	filters := []string{}
+	bindVars := map[string]interface{}{}
+	if filter.Email != "" {
+		filters = append(filters, "CONTAINS(LOWER(d.email), @email)")
+		bindVars["email"] = strings.ToLower(filter.Email)
+	}
+	// roles & signup methods are comma separated
+	if filter.Role != "" {
+		filters = append(filters, "@role IN SPLIT(d.roles, ',')")
+		bindVars["role"] = filter.Role
+	}
+	if filter.SignupMethod != "" {
+		filters = append(filters, "@signup_method IN SPLIT(d.signup_methods, ',')")
+		bindVars["signup_method"] = filter.SignupMethod
+	}
+	isSet := func(attribute string, value bool) string {
+		if value {
+			return fmt.Sprintf("d.%s != null", attribute)
+		}
+		return fmt.Sprintf("d.%s == null", attribute)
+	}
+	if filter.IsEmailVerified != nil {
+		filters = append(filters, isSet("email_verified_at", *filter.IsEmailVerified))
+	}
+	if filter.IsPhoneVerified != nil {
+		filters = append(filters, isSet("phone_number_verified_at", *filter.IsPhoneVerified))
+	}
+	if filter.IsRevoked != nil {
+		filters = append(filters, isSet("revoked_timestamp", *filter.IsRevoked))
+	}
+	if filter.CreatedAfter != nil {
+		filters = append(filters, "d.created_at >= @created_after")
+		bindVars["created_after"] = *filter.CreatedAfter
+	}
+	if filter.CreatedBefore != nil {
+		filters = append(filters, "d.created_at <= @created_before")
+		bindVars["created_before"] = *filter.CreatedBefore
+	}
+
+	query := ""
+	for _, f := range filters {
+		query += " FILTER " + f
+	}
+	return query, bindVars
+}
+
+// ListUsers to get list of users matching the filter from database
+func (p *provider) ListUsers(ctx context.Context, pagination model.Pagination, filter models.UserFilter) (*model.Users, error) {
 	var users []*model.User
 	sctx := driver.WithQueryFullCount(ctx)
 
-	query := fmt.Sprintf("FOR d in %s SORT d.created_at DESC LIMIT %d, %d RETURN d", models.Collections.User, pagination.Offset, pagination.Limit)
+	filterQuery, bindVars := filterUsers(filter)
+	// sort field & order are validated by the filter
+	paginationQuery, paginationBindVars, err := paginate(pagination, filter.GetSortBy(), filter.IsAscending(), bindVars)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("FOR d in %s%s%s RETURN d", models.Collections.User, filterQuery, paginationQuery)
 
-	cursor, err := p.db.Query(sctx, query, nil)
+	cursor, err := p.db.Query(sctx, query, paginationBindVars)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close()
 
 	paginationClone := pagination
-	paginationClone.Total = cursor.Statistics().FullCount()
+	if pagination.After == nil {
+		paginationClone.Total = cursor.Statistics().FullCount()
+	}
 
 	for {
 		var user models.User
@@ -103,7 +182,13 @@ func (p *provider) ListUsers(ctx context.Context, pagination model.Pagination) (
 		}
 
 		if meta.Key != "" {
+			// extra item is fetched to know if there is next page
+			if int64(len(users)) == pagination.Limit {
+				paginationClone.HasNextPage = true
+				break
+			}
 			users = append(users, user.AsAPIUser())
+			paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(filter.SortValue(&user), user.ID))
 		}
 	}
 
@@ -144,6 +229,37 @@ func (p *provider) GetUserByEmail(ctx context.Context, email string) (models.Use
 	return user, nil
 }
 
+// GetUserByPhoneNumber to get user information from database using phone number
+func (p *provider) GetUserByPhoneNumber(ctx context.Context, phoneNumber string) (models.User, error) {
+	var user models.User
+
+	query := fmt.Sprintf("FOR d in %s FILTER d.phone_number == @phone_number RETURN d", models.Collections.User)
+	bindVars := map[string]interface{}{
+		"phone_number": phoneNumber,
+	}
+
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return user, err
+	}
+	defer cursor.Close()
+
+	for {
+		if !cursor.HasMore() {
+			if user.Key == "" {
+				return user, fmt.Errorf("user not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &user)
+		if err != nil {
+			return user, err
+		}
+	}
+
+	return user, nil
+}
+
 // GetUserByID to get user information from database using user ID
 func (p *provider) GetUserByID(ctx context.Context, id string) (models.User, error) {
 	var user models.User
@@ -8,6 +8,7 @@ import (
 	"github.com/arangodb/go-driver"
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/google/uuid"
 )
 
@@ -96,16 +97,22 @@ func (p *provider) GetVerificationRequestByEmail(ctx context.Context, email stri
 func (p *provider) ListVerificationRequests(ctx context.Context, pagination model.Pagination) (*model.VerificationRequests, error) {
 	var verificationRequests []*model.VerificationRequest
 	sctx := driver.WithQueryFullCount(ctx)
-	query := fmt.Sprintf("FOR d in %s SORT d.created_at DESC LIMIT %d, %d RETURN d", models.Collections.VerificationRequest, pagination.Offset, pagination.Limit)
+	paginationQuery, bindVars, err := paginate(pagination, "created_at", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("FOR d in %s%s RETURN d", models.Collections.VerificationRequest, paginationQuery)
 
-	cursor, err := p.db.Query(sctx, query, nil)
+	cursor, err := p.db.Query(sctx, query, bindVars)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close()
 
 	paginationClone := pagination
-	paginationClone.Total = cursor.Statistics().FullCount()
+	if pagination.After == nil {
+		paginationClone.Total = cursor.Statistics().FullCount()
+	}
 
 	for {
 		var verificationRequest models.VerificationRequest
@@ -118,7 +125,13 @@ func (p *provider) ListVerificationRequests(ctx context.Context, pagination mode
 		}
 
 		if meta.Key != "" {
+			// extra item is fetched to know if there is next page
+			if int64(len(verificationRequests)) == pagination.Limit {
+				paginationClone.HasNextPage = true
+				break
+			}
 			verificationRequests = append(verificationRequests, verificationRequest.AsAPIVerificationRequest())
+			paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(verificationRequest.CreatedAt, verificationRequest.ID))
 		}
 
 	}
@@ -0,0 +1,107 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	arangoDriver "github.com/arangodb/go-driver"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/google/uuid"
+)
+
+// AddWebauthnCredential to save webauthn credential information in database
+func (p *provider) AddWebauthnCredential(ctx context.Context, credential models.WebauthnCredential) (models.WebauthnCredential, error) {
+	if credential.ID == "" {
+		credential.ID = uuid.New().String()
+	}
+
+	credential.Key = credential.ID
+	credential.CreatedAt = time.Now().Unix()
+	credential.UpdatedAt = time.Now().Unix()
+	credentialCollection, _ := p.db.Collection(ctx, models.Collections.WebauthnCredential)
+	meta, err := credentialCollection.CreateDocument(ctx, credential)
+	if err != nil {
+		return credential, err
+	}
+	credential.Key = meta.Key
+	credential.ID = meta.ID.String()
+
+	return credential, nil
+}
+
+// UpdateWebauthnCredential to update webauthn credential information in database
+func (p *provider) UpdateWebauthnCredential(ctx context.Context, credential models.WebauthnCredential) (models.WebauthnCredential, error) {
+	credential.UpdatedAt = time.Now().Unix()
+	credentialCollection, _ := p.db.Collection(ctx, models.Collections.WebauthnCredential)
+	meta, err := credentialCollection.UpdateDocument(ctx, credential.Key, credential)
+	if err != nil {
+		return credential, err
+	}
+	credential.Key = meta.Key
+	credential.ID = meta.ID.String()
+
+	return credential, nil
+}
+
+// GetWebauthnCredentialByCredentialID to get webauthn credential using credential id generated by authenticator
+func (p *provider) GetWebauthnCredentialByCredentialID(ctx context.Context, credentialID string) (models.WebauthnCredential, error) {
+	var credential models.WebauthnCredential
+	query := fmt.Sprintf("FOR d in %s FILTER d.credential_id == @credential_id RETURN d", models.Collections.WebauthnCredential)
+	bindVars := map[string]interface{}{
+		"credential_id": credentialID,
+	}
+
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return credential, err
+	}
+	defer cursor.Close()
+
+	for {
+		if !cursor.HasMore() {
+			if credential.Key == "" {
+				return credential, fmt.Errorf("webauthn credential not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &credential)
+		if err != nil {
+			return credential, err
+		}
+	}
+
+	return credential, nil
+}
+
+// ListWebauthnCredentialsByUserID to get all the webauthn credentials registered by user
+func (p *provider) ListWebauthnCredentialsByUserID(ctx context.Context, userID string) ([]models.WebauthnCredential, error) {
+	credentials := []models.WebauthnCredential{}
+	query := fmt.Sprintf("FOR d in %s FILTER d.user_id == @user_id SORT d.created_at DESC RETURN d", models.Collections.WebauthnCredential)
+	bindVars := map[string]interface{}{
+		"user_id": userID,
+	}
+
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	for {
+		var credential models.WebauthnCredential
+		meta, err := cursor.ReadDocument(ctx, &credential)
+
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if meta.Key != "" {
+			credentials = append(credentials, credential)
+		}
+	}
+
+	return credentials, nil
+}
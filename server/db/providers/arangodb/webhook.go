@@ -9,6 +9,7 @@ import (
 	arangoDriver "github.com/arangodb/go-driver"
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/google/uuid"
 )
 
@@ -47,17 +48,23 @@ func (p *provider) UpdateWebhook(ctx context.Context, webhook models.Webhook) (*
 func (p *provider) ListWebhook(ctx context.Context, pagination model.Pagination) (*model.Webhooks, error) {
 	webhooks := []*model.Webhook{}
 
-	query := fmt.Sprintf("FOR d in %s SORT d.created_at DESC LIMIT %d, %d RETURN d", models.Collections.Webhook, pagination.Offset, pagination.Limit)
+	paginationQuery, bindVars, err := paginate(pagination, "created_at", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("FOR d in %s%s RETURN d", models.Collections.Webhook, paginationQuery)
 
 	sctx := driver.WithQueryFullCount(ctx)
-	cursor, err := p.db.Query(sctx, query, nil)
+	cursor, err := p.db.Query(sctx, query, bindVars)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close()
 
 	paginationClone := pagination
-	paginationClone.Total = cursor.Statistics().FullCount()
+	if pagination.After == nil {
+		paginationClone.Total = cursor.Statistics().FullCount()
+	}
 
 	for {
 		var webhook models.Webhook
@@ -70,7 +77,13 @@ func (p *provider) ListWebhook(ctx context.Context, pagination model.Pagination)
 		}
 
 		if meta.Key != "" {
+			// extra item is fetched to know if there is next page
+			if int64(len(webhooks)) == pagination.Limit {
+				paginationClone.HasNextPage = true
+				break
+			}
 			webhooks = append(webhooks, webhook.AsAPIWebhook())
+			paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(webhook.CreatedAt, webhook.ID))
 		}
 	}
 
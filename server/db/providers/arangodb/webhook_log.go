@@ -9,6 +9,7 @@ import (
 	arangoDriver "github.com/arangodb/go-driver"
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/google/uuid"
 )
 
@@ -33,25 +34,32 @@ func (p *provider) AddWebhookLog(ctx context.Context, webhookLog models.WebhookL
 func (p *provider) ListWebhookLogs(ctx context.Context, pagination model.Pagination, webhookID string) (*model.WebhookLogs, error) {
 	webhookLogs := []*model.WebhookLog{}
 	bindVariables := map[string]interface{}{}
-
-	query := fmt.Sprintf("FOR d in %s SORT d.created_at DESC LIMIT %d, %d RETURN d", models.Collections.WebhookLog, pagination.Offset, pagination.Limit)
+	filterQuery := ""
 
 	if webhookID != "" {
-		query = fmt.Sprintf("FOR d in %s FILTER d.webhook_id == @webhook_id SORT d.created_at DESC LIMIT %d, %d RETURN d", models.Collections.WebhookLog, pagination.Offset, pagination.Limit)
+		filterQuery = " FILTER d.webhook_id == @webhook_id"
 		bindVariables = map[string]interface{}{
 			"webhook_id": webhookID,
 		}
 	}
 
+	paginationQuery, paginationBindVariables, err := paginate(pagination, "created_at", false, bindVariables)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("FOR d in %s%s%s RETURN d", models.Collections.WebhookLog, filterQuery, paginationQuery)
+
 	sctx := driver.WithQueryFullCount(ctx)
-	cursor, err := p.db.Query(sctx, query, bindVariables)
+	cursor, err := p.db.Query(sctx, query, paginationBindVariables)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close()
 
 	paginationClone := pagination
-	paginationClone.Total = cursor.Statistics().FullCount()
+	if pagination.After == nil {
+		paginationClone.Total = cursor.Statistics().FullCount()
+	}
 
 	for {
 		var webhookLog models.WebhookLog
@@ -64,7 +72,13 @@ func (p *provider) ListWebhookLogs(ctx context.Context, pagination model.Paginat
 		}
 
 		if meta.Key != "" {
+			// extra item is fetched to know if there is next page
+			if int64(len(webhookLogs)) == pagination.Limit {
+				paginationClone.HasNextPage = true
+				break
+			}
 			webhookLogs = append(webhookLogs, webhookLog.AsAPIWebhookLog())
+			paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(webhookLog.CreatedAt, webhookLog.ID))
 		}
 	}
 
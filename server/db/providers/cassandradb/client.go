@@ -0,0 +1,108 @@
+package cassandradb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+)
+
+// AddClient to register oauth client
+func (p *provider) AddClient(ctx context.Context, client models.Client) (models.Client, error) {
+	if client.ID == "" {
+		client.ID = uuid.New().String()
+	}
+
+	client.Key = client.ID
+	client.CreatedAt = time.Now().Unix()
+	client.UpdatedAt = time.Now().Unix()
+
+	insertQuery := fmt.Sprintf("INSERT INTO %s (id, name, client_secret, redirect_uris, allowed_scopes, grant_types, access_token_expiry_time, refresh_token_expiry_time, post_logout_redirect_uris, backchannel_logout_uri, frontchannel_logout_uri, id_token_encrypted_response_alg, id_token_encrypted_response_enc, id_token_encryption_key, created_at, updated_at) VALUES ('%s', '%s', '%s', '%s', '%s', '%s', '%s', '%s', '%s', '%s', '%s', '%s', '%s', '%s', %d, %d)", KeySpace+"."+models.Collections.Client, client.ID, client.Name, client.ClientSecret, client.RedirectURIs, client.AllowedScopes, client.GrantTypes, client.AccessTokenExpiryTime, client.RefreshTokenExpiryTime, client.PostLogoutRedirectURIs, client.BackchannelLogoutURI, client.FrontchannelLogoutURI, client.IDTokenEncryptedResponseAlg, client.IDTokenEncryptedResponseEnc, client.IDTokenEncryptionKey, client.CreatedAt, client.UpdatedAt)
+	err := p.db.Query(insertQuery).Exec()
+	if err != nil {
+		return client, err
+	}
+
+	return client, nil
+}
+
+// UpdateClient to update oauth client
+func (p *provider) UpdateClient(ctx context.Context, client models.Client) (models.Client, error) {
+	client.UpdatedAt = time.Now().Unix()
+
+	query := fmt.Sprintf("UPDATE %s SET name = '%s', client_secret = '%s', redirect_uris = '%s', allowed_scopes = '%s', grant_types = '%s', access_token_expiry_time = '%s', refresh_token_expiry_time = '%s', post_logout_redirect_uris = '%s', backchannel_logout_uri = '%s', frontchannel_logout_uri = '%s', id_token_encrypted_response_alg = '%s', id_token_encrypted_response_enc = '%s', id_token_encryption_key = '%s', updated_at = %d WHERE id = '%s'", KeySpace+"."+models.Collections.Client, client.Name, client.ClientSecret, client.RedirectURIs, client.AllowedScopes, client.GrantTypes, client.AccessTokenExpiryTime, client.RefreshTokenExpiryTime, client.PostLogoutRedirectURIs, client.BackchannelLogoutURI, client.FrontchannelLogoutURI, client.IDTokenEncryptedResponseAlg, client.IDTokenEncryptedResponseEnc, client.IDTokenEncryptionKey, client.UpdatedAt, client.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return client, err
+	}
+
+	return client, nil
+}
+
+// ListClients to list oauth clients
+func (p *provider) ListClients(ctx context.Context, pagination model.Pagination) (*model.Clients, error) {
+	clients := []*model.Client{}
+	paginationClone := pagination
+
+	totalCountQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s`, KeySpace+"."+models.Collections.Client)
+	err := p.countTotal(&paginationClone, totalCountQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	// there is no offset in cassandra
+	// so we fetch till limit + offset
+	// and return the results from offset to limit
+	query := fmt.Sprintf("SELECT id, name, client_secret, redirect_uris, allowed_scopes, grant_types, access_token_expiry_time, refresh_token_expiry_time, post_logout_redirect_uris, backchannel_logout_uri, frontchannel_logout_uri, id_token_encrypted_response_alg, id_token_encrypted_response_enc, id_token_encryption_key, created_at, updated_at FROM %s LIMIT %d", KeySpace+"."+models.Collections.Client, pagination.Limit+pagination.Offset)
+
+	scanner := p.db.Query(query).Iter().Scanner()
+	counter := int64(0)
+	for scanner.Next() {
+		if counter >= pagination.Offset {
+			var client models.Client
+			err := scanner.Scan(&client.ID, &client.Name, &client.ClientSecret, &client.RedirectURIs, &client.AllowedScopes, &client.GrantTypes, &client.AccessTokenExpiryTime, &client.RefreshTokenExpiryTime, &client.PostLogoutRedirectURIs, &client.BackchannelLogoutURI, &client.FrontchannelLogoutURI, &client.IDTokenEncryptedResponseAlg, &client.IDTokenEncryptedResponseEnc, &client.IDTokenEncryptionKey, &client.CreatedAt, &client.UpdatedAt)
+			if err != nil {
+				return nil, err
+			}
+			clients = append(clients, client.AsAPIClient())
+		}
+		counter++
+	}
+
+	return &model.Clients{
+		Pagination: &paginationClone,
+		Clients:    clients,
+	}, nil
+}
+
+// GetClientByID to get oauth client by client_id
+func (p *provider) GetClientByID(ctx context.Context, clientID string) (models.Client, error) {
+	var client models.Client
+	query := fmt.Sprintf("SELECT id, name, client_secret, redirect_uris, allowed_scopes, grant_types, access_token_expiry_time, refresh_token_expiry_time, post_logout_redirect_uris, backchannel_logout_uri, frontchannel_logout_uri, id_token_encrypted_response_alg, id_token_encrypted_response_enc, id_token_encryption_key, created_at, updated_at FROM %s WHERE id = '%s' LIMIT 1", KeySpace+"."+models.Collections.Client, clientID)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&client.ID, &client.Name, &client.ClientSecret, &client.RedirectURIs, &client.AllowedScopes, &client.GrantTypes, &client.AccessTokenExpiryTime, &client.RefreshTokenExpiryTime, &client.PostLogoutRedirectURIs, &client.BackchannelLogoutURI, &client.FrontchannelLogoutURI, &client.IDTokenEncryptedResponseAlg, &client.IDTokenEncryptedResponseEnc, &client.IDTokenEncryptionKey, &client.CreatedAt, &client.UpdatedAt)
+	if err != nil {
+		return client, err
+	}
+
+	return client, nil
+}
+
+// DeleteClient to delete oauth client
+func (p *provider) DeleteClient(ctx context.Context, client models.Client) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = '%s'", KeySpace+"."+models.Collections.Client, client.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return err
+	}
+
+	err = p.deleteGrantsBy("client_id", client.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
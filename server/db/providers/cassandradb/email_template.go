@@ -95,28 +95,24 @@ func (p *provider) ListEmailTemplate(ctx context.Context, pagination model.Pagin
 	paginationClone := pagination
 
 	totalCountQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s`, KeySpace+"."+models.Collections.EmailTemplate)
-	err := p.db.Query(totalCountQuery).Consistency(gocql.One).Scan(&paginationClone.Total)
+	err := p.countTotal(&paginationClone, totalCountQuery)
 	if err != nil {
 		return nil, err
 	}
 
-	// there is no offset in cassandra
-	// so we fetch till limit + offset
-	// and return the results from offset to limit
-	query := fmt.Sprintf("SELECT id, event_name, template, created_at, updated_at FROM %s LIMIT %d", KeySpace+"."+models.Collections.EmailTemplate, pagination.Limit+pagination.Offset)
-
-	scanner := p.db.Query(query).Iter().Scanner()
-	counter := int64(0)
-	for scanner.Next() {
-		if counter >= pagination.Offset {
-			var emailTemplate models.EmailTemplate
-			err := scanner.Scan(&emailTemplate.ID, &emailTemplate.EventName, &emailTemplate.Template, &emailTemplate.CreatedAt, &emailTemplate.UpdatedAt)
-			if err != nil {
-				return nil, err
-			}
-			emailTemplates = append(emailTemplates, emailTemplate.AsAPIEmailTemplate())
+	query := fmt.Sprintf("SELECT id, event_name, template, created_at, updated_at FROM %s", KeySpace+"."+models.Collections.EmailTemplate)
+
+	err = paginate(p.db.Query(query), &paginationClone, func(scanner gocql.Scanner) error {
+		var emailTemplate models.EmailTemplate
+		err := scanner.Scan(&emailTemplate.ID, &emailTemplate.EventName, &emailTemplate.Template, &emailTemplate.CreatedAt, &emailTemplate.UpdatedAt)
+		if err != nil {
+			return err
 		}
-		counter++
+		emailTemplates = append(emailTemplates, emailTemplate.AsAPIEmailTemplate())
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &model.EmailTemplates{
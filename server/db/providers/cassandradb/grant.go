@@ -0,0 +1,111 @@
+package cassandradb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+)
+
+// AddGrant to save the scopes granted by user to oauth client
+func (p *provider) AddGrant(ctx context.Context, grant models.Grant) (models.Grant, error) {
+	if grant.ID == "" {
+		grant.ID = uuid.New().String()
+	}
+
+	grant.Key = grant.ID
+	grant.CreatedAt = time.Now().Unix()
+	grant.UpdatedAt = time.Now().Unix()
+
+	existingGrant, _ := p.GetGrantByUserIDAndClientID(ctx, grant.UserID, grant.ClientID)
+	if existingGrant.ID != "" {
+		return grant, fmt.Errorf("grant already exists")
+	}
+
+	insertQuery := fmt.Sprintf("INSERT INTO %s (id, user_id, client_id, scope, created_at, updated_at) VALUES ('%s', '%s', '%s', '%s', %d, %d)", KeySpace+"."+models.Collections.Grant, grant.ID, grant.UserID, grant.ClientID, grant.Scope, grant.CreatedAt, grant.UpdatedAt)
+	err := p.db.Query(insertQuery).Exec()
+	if err != nil {
+		return grant, err
+	}
+
+	return grant, nil
+}
+
+// UpdateGrant to update the scopes granted by user to oauth client
+func (p *provider) UpdateGrant(ctx context.Context, grant models.Grant) (models.Grant, error) {
+	grant.UpdatedAt = time.Now().Unix()
+
+	query := fmt.Sprintf("UPDATE %s SET scope = '%s', updated_at = %d WHERE id = '%s'", KeySpace+"."+models.Collections.Grant, grant.Scope, grant.UpdatedAt, grant.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return grant, err
+	}
+
+	return grant, nil
+}
+
+// GetGrantByUserIDAndClientID to get the scopes granted by user to oauth client
+func (p *provider) GetGrantByUserIDAndClientID(ctx context.Context, userID, clientID string) (models.Grant, error) {
+	var grant models.Grant
+	query := fmt.Sprintf("SELECT id, user_id, client_id, scope, created_at, updated_at FROM %s WHERE user_id = '%s' AND client_id = '%s' LIMIT 1 ALLOW FILTERING", KeySpace+"."+models.Collections.Grant, userID, clientID)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&grant.ID, &grant.UserID, &grant.ClientID, &grant.Scope, &grant.CreatedAt, &grant.UpdatedAt)
+	if err != nil {
+		return grant, err
+	}
+
+	return grant, nil
+}
+
+// ListGrantsByUserID to get all the oauth clients granted access by user
+func (p *provider) ListGrantsByUserID(ctx context.Context, userID string) ([]models.Grant, error) {
+	grants := []models.Grant{}
+	query := fmt.Sprintf("SELECT id, user_id, client_id, scope, created_at, updated_at FROM %s WHERE user_id = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.Grant, userID)
+	scanner := p.db.Query(query).Iter().Scanner()
+	for scanner.Next() {
+		var grant models.Grant
+		err := scanner.Scan(&grant.ID, &grant.UserID, &grant.ClientID, &grant.Scope, &grant.CreatedAt, &grant.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, grant)
+	}
+
+	return grants, nil
+}
+
+// DeleteGrant to revoke the access granted by user to oauth client
+func (p *provider) DeleteGrant(ctx context.Context, grant models.Grant) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = '%s'", KeySpace+"."+models.Collections.Grant, grant.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// deleteGrantsBy deletes all the grants matching the column value
+func (p *provider) deleteGrantsBy(column, value string) error {
+	getGrantsQuery := fmt.Sprintf("SELECT id FROM %s WHERE %s = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.Grant, column, value)
+	scanner := p.db.Query(getGrantsQuery).Iter().Scanner()
+	grantIDs := ""
+	for scanner.Next() {
+		var grantID string
+		err := scanner.Scan(&grantID)
+		if err != nil {
+			return err
+		}
+		grantIDs += fmt.Sprintf("'%s',", grantID)
+	}
+	grantIDs = strings.TrimSuffix(grantIDs, ",")
+	if grantIDs == "" {
+		return nil
+	}
+
+	deleteGrantsQuery := fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", KeySpace+"."+models.Collections.Grant, grantIDs)
+	return p.db.Query(deleteGrantsQuery).Exec()
+}
@@ -0,0 +1,340 @@
+package cassandradb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+)
+
+// AddOrganization to add organization
+func (p *provider) AddOrganization(ctx context.Context, organization models.Organization) (models.Organization, error) {
+	if organization.ID == "" {
+		organization.ID = uuid.New().String()
+	}
+
+	organization.Key = organization.ID
+	organization.CreatedAt = time.Now().Unix()
+	organization.UpdatedAt = time.Now().Unix()
+
+	existingOrganization, _ := p.GetOrganizationByName(ctx, organization.Name)
+	if existingOrganization.ID != "" {
+		return organization, fmt.Errorf("organization with %s name already exists", organization.Name)
+	}
+
+	insertQuery := fmt.Sprintf("INSERT INTO %s (id, name, display_name, roles, default_roles, created_at, updated_at) VALUES ('%s', '%s', '%s', '%s', '%s', %d, %d)", KeySpace+"."+models.Collections.Organization, organization.ID, organization.Name, organization.DisplayName, organization.Roles, organization.DefaultRoles, organization.CreatedAt, organization.UpdatedAt)
+	err := p.db.Query(insertQuery).Exec()
+	if err != nil {
+		return organization, err
+	}
+
+	return organization, nil
+}
+
+// UpdateOrganization to update organization
+func (p *provider) UpdateOrganization(ctx context.Context, organization models.Organization) (models.Organization, error) {
+	organization.UpdatedAt = time.Now().Unix()
+
+	existingOrganization, _ := p.GetOrganizationByName(ctx, organization.Name)
+	if existingOrganization.ID != "" && existingOrganization.ID != organization.ID {
+		return organization, fmt.Errorf("organization with %s name already exists", organization.Name)
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET name = '%s', display_name = '%s', roles = '%s', default_roles = '%s', updated_at = %d WHERE id = '%s'", KeySpace+"."+models.Collections.Organization, organization.Name, organization.DisplayName, organization.Roles, organization.DefaultRoles, organization.UpdatedAt, organization.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return organization, err
+	}
+
+	return organization, nil
+}
+
+// DeleteOrganization to delete organization along with its members & invitations
+func (p *provider) DeleteOrganization(ctx context.Context, organization models.Organization) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = '%s'", KeySpace+"."+models.Collections.Organization, organization.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return err
+	}
+
+	err = p.deleteOrganizationItemsBy(models.Collections.OrganizationMember, "organization_id", organization.ID)
+	if err != nil {
+		return err
+	}
+
+	err = p.deleteOrganizationItemsBy(models.Collections.OrganizationInvitation, "organization_id", organization.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetOrganizationByID to get organization by id
+func (p *provider) GetOrganizationByID(ctx context.Context, organizationID string) (models.Organization, error) {
+	var organization models.Organization
+	query := fmt.Sprintf("SELECT id, name, display_name, roles, default_roles, created_at, updated_at FROM %s WHERE id = '%s' LIMIT 1", KeySpace+"."+models.Collections.Organization, organizationID)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&organization.ID, &organization.Name, &organization.DisplayName, &organization.Roles, &organization.DefaultRoles, &organization.CreatedAt, &organization.UpdatedAt)
+	if err != nil {
+		return organization, err
+	}
+
+	return organization, nil
+}
+
+// GetOrganizationByName to get organization by name
+func (p *provider) GetOrganizationByName(ctx context.Context, name string) (models.Organization, error) {
+	var organization models.Organization
+	query := fmt.Sprintf("SELECT id, name, display_name, roles, default_roles, created_at, updated_at FROM %s WHERE name = '%s' LIMIT 1 ALLOW FILTERING", KeySpace+"."+models.Collections.Organization, name)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&organization.ID, &organization.Name, &organization.DisplayName, &organization.Roles, &organization.DefaultRoles, &organization.CreatedAt, &organization.UpdatedAt)
+	if err != nil {
+		return organization, err
+	}
+
+	return organization, nil
+}
+
+// ListOrganizations to list organizations
+func (p *provider) ListOrganizations(ctx context.Context, pagination model.Pagination) (*model.Organizations, error) {
+	organizations := []*model.Organization{}
+	paginationClone := pagination
+
+	totalCountQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s`, KeySpace+"."+models.Collections.Organization)
+	err := p.countTotal(&paginationClone, totalCountQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT id, name, display_name, roles, default_roles, created_at, updated_at FROM %s", KeySpace+"."+models.Collections.Organization)
+
+	err = paginate(p.db.Query(query), &paginationClone, func(scanner gocql.Scanner) error {
+		var organization models.Organization
+		err := scanner.Scan(&organization.ID, &organization.Name, &organization.DisplayName, &organization.Roles, &organization.DefaultRoles, &organization.CreatedAt, &organization.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		organizations = append(organizations, organization.AsAPIOrganization())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Organizations{
+		Pagination:    &paginationClone,
+		Organizations: organizations,
+	}, nil
+}
+
+// AddOrganizationMember to add user as the member of organization
+func (p *provider) AddOrganizationMember(ctx context.Context, member models.OrganizationMember) (models.OrganizationMember, error) {
+	if member.ID == "" {
+		member.ID = uuid.New().String()
+	}
+
+	member.Key = member.ID
+	member.CreatedAt = time.Now().Unix()
+	member.UpdatedAt = time.Now().Unix()
+
+	existingMember, _ := p.GetOrganizationMember(ctx, member.OrganizationID, member.UserID)
+	if existingMember.ID != "" {
+		return member, fmt.Errorf("organization member already exists")
+	}
+
+	insertQuery := fmt.Sprintf("INSERT INTO %s (id, organization_id, user_id, roles, created_at, updated_at) VALUES ('%s', '%s', '%s', '%s', %d, %d)", KeySpace+"."+models.Collections.OrganizationMember, member.ID, member.OrganizationID, member.UserID, member.Roles, member.CreatedAt, member.UpdatedAt)
+	err := p.db.Query(insertQuery).Exec()
+	if err != nil {
+		return member, err
+	}
+
+	return member, nil
+}
+
+// UpdateOrganizationMember to update the roles of member in organization
+func (p *provider) UpdateOrganizationMember(ctx context.Context, member models.OrganizationMember) (models.OrganizationMember, error) {
+	member.UpdatedAt = time.Now().Unix()
+
+	query := fmt.Sprintf("UPDATE %s SET roles = '%s', updated_at = %d WHERE id = '%s'", KeySpace+"."+models.Collections.OrganizationMember, member.Roles, member.UpdatedAt, member.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return member, err
+	}
+
+	return member, nil
+}
+
+// DeleteOrganizationMember to remove user from organization
+func (p *provider) DeleteOrganizationMember(ctx context.Context, member models.OrganizationMember) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = '%s'", KeySpace+"."+models.Collections.OrganizationMember, member.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetOrganizationMember to get the membership of user in organization
+func (p *provider) GetOrganizationMember(ctx context.Context, organizationID, userID string) (models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	query := fmt.Sprintf("SELECT id, organization_id, user_id, roles, created_at, updated_at FROM %s WHERE organization_id = '%s' AND user_id = '%s' LIMIT 1 ALLOW FILTERING", KeySpace+"."+models.Collections.OrganizationMember, organizationID, userID)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&member.ID, &member.OrganizationID, &member.UserID, &member.Roles, &member.CreatedAt, &member.UpdatedAt)
+	if err != nil {
+		return member, err
+	}
+
+	return member, nil
+}
+
+// ListOrganizationMembers to list the members of organization
+func (p *provider) ListOrganizationMembers(ctx context.Context, pagination model.Pagination, organizationID string) (*model.OrganizationMembers, error) {
+	members := []*model.OrganizationMember{}
+	paginationClone := pagination
+
+	totalCountQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE organization_id = '%s' ALLOW FILTERING`, KeySpace+"."+models.Collections.OrganizationMember, organizationID)
+	err := p.countTotal(&paginationClone, totalCountQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT id, organization_id, user_id, roles, created_at, updated_at FROM %s WHERE organization_id = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.OrganizationMember, organizationID)
+
+	err = paginate(p.db.Query(query), &paginationClone, func(scanner gocql.Scanner) error {
+		var member models.OrganizationMember
+		err := scanner.Scan(&member.ID, &member.OrganizationID, &member.UserID, &member.Roles, &member.CreatedAt, &member.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		members = append(members, member.AsAPIOrganizationMember())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.OrganizationMembers{
+		Pagination: &paginationClone,
+		Members:    members,
+	}, nil
+}
+
+// ListOrganizationMembersByUserID to get all the organizations memberships of user
+func (p *provider) ListOrganizationMembersByUserID(ctx context.Context, userID string) ([]models.OrganizationMember, error) {
+	members := []models.OrganizationMember{}
+	query := fmt.Sprintf("SELECT id, organization_id, user_id, roles, created_at, updated_at FROM %s WHERE user_id = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.OrganizationMember, userID)
+	scanner := p.db.Query(query).Iter().Scanner()
+	for scanner.Next() {
+		var member models.OrganizationMember
+		err := scanner.Scan(&member.ID, &member.OrganizationID, &member.UserID, &member.Roles, &member.CreatedAt, &member.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+// AddOrganizationInvitation to invite email to organization
+func (p *provider) AddOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) (models.OrganizationInvitation, error) {
+	if invitation.ID == "" {
+		invitation.ID = uuid.New().String()
+	}
+
+	invitation.Key = invitation.ID
+	invitation.CreatedAt = time.Now().Unix()
+	invitation.UpdatedAt = time.Now().Unix()
+
+	existingInvitation, _ := p.GetOrganizationInvitation(ctx, invitation.OrganizationID, invitation.Email)
+	if existingInvitation.ID != "" {
+		return invitation, fmt.Errorf("organization invitation already exists")
+	}
+
+	insertQuery := fmt.Sprintf("INSERT INTO %s (id, organization_id, email, roles, expires_at, created_at, updated_at) VALUES ('%s', '%s', '%s', '%s', %d, %d, %d)", KeySpace+"."+models.Collections.OrganizationInvitation, invitation.ID, invitation.OrganizationID, invitation.Email, invitation.Roles, invitation.ExpiresAt, invitation.CreatedAt, invitation.UpdatedAt)
+	err := p.db.Query(insertQuery).Exec()
+	if err != nil {
+		return invitation, err
+	}
+
+	return invitation, nil
+}
+
+// UpdateOrganizationInvitation to update the roles & expiry of invitation
+func (p *provider) UpdateOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) (models.OrganizationInvitation, error) {
+	invitation.UpdatedAt = time.Now().Unix()
+
+	query := fmt.Sprintf("UPDATE %s SET roles = '%s', expires_at = %d, updated_at = %d WHERE id = '%s'", KeySpace+"."+models.Collections.OrganizationInvitation, invitation.Roles, invitation.ExpiresAt, invitation.UpdatedAt, invitation.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return invitation, err
+	}
+
+	return invitation, nil
+}
+
+// GetOrganizationInvitation to get the invitation of email to organization
+func (p *provider) GetOrganizationInvitation(ctx context.Context, organizationID, email string) (models.OrganizationInvitation, error) {
+	var invitation models.OrganizationInvitation
+	query := fmt.Sprintf("SELECT id, organization_id, email, roles, expires_at, created_at, updated_at FROM %s WHERE organization_id = '%s' AND email = '%s' LIMIT 1 ALLOW FILTERING", KeySpace+"."+models.Collections.OrganizationInvitation, organizationID, email)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&invitation.ID, &invitation.OrganizationID, &invitation.Email, &invitation.Roles, &invitation.ExpiresAt, &invitation.CreatedAt, &invitation.UpdatedAt)
+	if err != nil {
+		return invitation, err
+	}
+
+	return invitation, nil
+}
+
+// ListOrganizationInvitations to list the pending invitations of organization
+func (p *provider) ListOrganizationInvitations(ctx context.Context, organizationID string) ([]models.OrganizationInvitation, error) {
+	invitations := []models.OrganizationInvitation{}
+	query := fmt.Sprintf("SELECT id, organization_id, email, roles, expires_at, created_at, updated_at FROM %s WHERE organization_id = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.OrganizationInvitation, organizationID)
+	scanner := p.db.Query(query).Iter().Scanner()
+	for scanner.Next() {
+		var invitation models.OrganizationInvitation
+		err := scanner.Scan(&invitation.ID, &invitation.OrganizationID, &invitation.Email, &invitation.Roles, &invitation.ExpiresAt, &invitation.CreatedAt, &invitation.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	return invitations, nil
+}
+
+// DeleteOrganizationInvitation to delete invitation once it is accepted or revoked
+func (p *provider) DeleteOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = '%s'", KeySpace+"."+models.Collections.OrganizationInvitation, invitation.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// deleteOrganizationItemsBy deletes all the members or invitations in collection matching the column value
+func (p *provider) deleteOrganizationItemsBy(collection, column, value string) error {
+	getItemsQuery := fmt.Sprintf("SELECT id FROM %s WHERE %s = '%s' ALLOW FILTERING", KeySpace+"."+collection, column, value)
+	scanner := p.db.Query(getItemsQuery).Iter().Scanner()
+	itemIDs := ""
+	for scanner.Next() {
+		var itemID string
+		err := scanner.Scan(&itemID)
+		if err != nil {
+			return err
+		}
+		itemIDs += fmt.Sprintf("'%s',", itemID)
+	}
+	itemIDs = strings.TrimSuffix(itemIDs, ",")
+	if itemIDs == "" {
+		return nil
+	}
+
+	deleteItemsQuery := fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", KeySpace+"."+collection, itemIDs)
+	return p.db.Query(deleteItemsQuery).Exec()
+}
@@ -0,0 +1,55 @@
+package cassandradb
+
+import (
+	"encoding/base64"
+	"errors"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/gocql/gocql"
+)
+
+// paginate fetches the page of query & scans its items with scan.
+// There is no offset in cassandra, so the page of limit + offset items is fetched
+// & the items before offset are skipped.
+// Only the rows of fetched page are read, as reading past them makes the iterator fetch the next pages.
+// Paging state of cassandra is used as the cursor, offset is 0 if pagination has after cursor
+func paginate(query *gocql.Query, pagination *model.Pagination, scan func(scanner gocql.Scanner) error) error {
+	var pageState []byte
+	if pagination.After != nil {
+		var err error
+		pageState, err = base64.RawURLEncoding.DecodeString(*pagination.After)
+		if err != nil || len(pageState) == 0 {
+			return errors.New("invalid cursor")
+		}
+	}
+
+	iter := query.PageSize(int(pagination.Offset + pagination.Limit)).PageState(pageState).Iter()
+	scanner := iter.Scanner()
+	for i := 0; i < iter.NumRows() && scanner.Next(); i++ {
+		if int64(i) < pagination.Offset {
+			continue
+		}
+		if err := scan(scanner); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if pageState := iter.PageState(); len(pageState) > 0 {
+		pagination.HasNextPage = true
+		pagination.EndCursor = refs.NewStringRef(base64.RawURLEncoding.EncodeToString(pageState))
+	}
+	return nil
+}
+
+// countTotal sets the total of pagination to the count of query.
+// Items are only counted for the pages listed by offset, the pages after cursor are listed without counting all the items
+func (p *provider) countTotal(pagination *model.Pagination, countQuery string, values ...interface{}) error {
+	if pagination.After != nil {
+		return nil
+	}
+	return p.db.Query(countQuery, values...).Consistency(gocql.One).Scan(&pagination.Total)
+}
@@ -149,16 +149,30 @@ func NewProvider() (*provider, error) {
 		return nil, err
 	}
 
-	userCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, email text, email_verified_at bigint, password text, signup_methods text, given_name text, family_name text, middle_name text, nickname text, gender text, birthdate text, phone_number text, phone_number_verified_at bigint, picture text, roles text, updated_at bigint, created_at bigint, revoked_timestamp bigint, PRIMARY KEY (id))", KeySpace, models.Collections.User)
+	userCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, email text, email_verified_at bigint, password text, signup_methods text, given_name text, family_name text, middle_name text, nickname text, gender text, birthdate text, phone_number text, phone_number_verified_at bigint, picture text, roles text, updated_at bigint, created_at bigint, revoked_timestamp bigint, totp_secret text, totp_verified_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.User)
 	err = session.Query(userCollectionQuery).Exec()
 	if err != nil {
 		return nil, err
 	}
+	// add the totp columns for user tables created before mfa support
+	// error is ignored as cassandra fails to alter table if the column already exists
+	userTOTPAlterQuery := fmt.Sprintf("ALTER TABLE %s.%s ADD (totp_secret text, totp_verified_at bigint)", KeySpace, models.Collections.User)
+	session.Query(userTOTPAlterQuery).Exec()
 	userIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_user_email ON %s.%s (email)", KeySpace, models.Collections.User)
 	err = session.Query(userIndexQuery).Exec()
 	if err != nil {
 		return nil, err
 	}
+	userIndexQuery = fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_user_phone_number ON %s.%s (phone_number)", KeySpace, models.Collections.User)
+	err = session.Query(userIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	userSortCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (sort_by text, sort_value text, id text, email text, roles set<text>, signup_methods set<text>, email_verified boolean, phone_number_verified boolean, revoked boolean, created_at bigint, PRIMARY KEY (sort_by, sort_value, id))", KeySpace, userSortCollection)
+	err = session.Query(userSortCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
 
 	// token is reserved keyword in cassandra, hence we need to use jwt_token
 	verificationRequestCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, jwt_token text, identifier text, expires_at bigint, email text, nonce text, redirect_uri text, created_at bigint, updated_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.VerificationRequest)
@@ -215,7 +229,95 @@ func NewProvider() (*provider, error) {
 		return nil, err
 	}
 
-	return &provider{
+	webauthnCredentialCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, user_id text, credential_id text, public_key text, sign_count bigint, aaguid text, updated_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.WebauthnCredential)
+	err = session.Query(webauthnCredentialCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	webauthnCredentialIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_webauthn_credential_user_id ON %s.%s (user_id)", KeySpace, models.Collections.WebauthnCredential)
+	err = session.Query(webauthnCredentialIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	webauthnCredentialIndexQuery = fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_webauthn_credential_credential_id ON %s.%s (credential_id)", KeySpace, models.Collections.WebauthnCredential)
+	err = session.Query(webauthnCredentialIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	clientCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, name text, client_secret text, redirect_uris text, allowed_scopes text, grant_types text, access_token_expiry_time text, refresh_token_expiry_time text, post_logout_redirect_uris text, backchannel_logout_uri text, frontchannel_logout_uri text, id_token_encrypted_response_alg text, id_token_encrypted_response_enc text, id_token_encryption_key text, updated_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.Client)
+	err = session.Query(clientCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	// add the logout columns for client tables created before oidc logout support
+	// error is ignored as cassandra fails to alter table if the column already exists
+	clientLogoutAlterQuery := fmt.Sprintf("ALTER TABLE %s.%s ADD (post_logout_redirect_uris text, backchannel_logout_uri text, frontchannel_logout_uri text)", KeySpace, models.Collections.Client)
+	session.Query(clientLogoutAlterQuery).Exec()
+	clientIDTokenEncryptionAlterQuery := fmt.Sprintf("ALTER TABLE %s.%s ADD (id_token_encrypted_response_alg text, id_token_encrypted_response_enc text, id_token_encryption_key text)", KeySpace, models.Collections.Client)
+	session.Query(clientIDTokenEncryptionAlterQuery).Exec()
+
+	grantCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, user_id text, client_id text, scope text, updated_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.Grant)
+	err = session.Query(grantCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	grantIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_grant_user_id ON %s.%s (user_id)", KeySpace, models.Collections.Grant)
+	err = session.Query(grantIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	grantIndexQuery = fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_grant_client_id ON %s.%s (client_id)", KeySpace, models.Collections.Grant)
+	err = session.Query(grantIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	organizationCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, name text, display_name text, roles text, default_roles text, updated_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.Organization)
+	err = session.Query(organizationCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	organizationIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_organization_name ON %s.%s (name)", KeySpace, models.Collections.Organization)
+	err = session.Query(organizationIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	organizationMemberCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, organization_id text, user_id text, roles text, updated_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.OrganizationMember)
+	err = session.Query(organizationMemberCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	organizationMemberIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_organization_member_organization_id ON %s.%s (organization_id)", KeySpace, models.Collections.OrganizationMember)
+	err = session.Query(organizationMemberIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	organizationMemberIndexQuery = fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_organization_member_user_id ON %s.%s (user_id)", KeySpace, models.Collections.OrganizationMember)
+	err = session.Query(organizationMemberIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	organizationInvitationCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, organization_id text, email text, roles text, expires_at bigint, updated_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.OrganizationInvitation)
+	err = session.Query(organizationInvitationCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	organizationInvitationIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_organization_invitation_organization_id ON %s.%s (organization_id)", KeySpace, models.Collections.OrganizationInvitation)
+	err = session.Query(organizationInvitationIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &provider{
 		db: session,
-	}, err
+	}
+	err = p.addExistingUsersToSort()
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
 }
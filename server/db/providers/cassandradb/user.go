@@ -12,6 +12,7 @@ import (
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/gocql/gocql"
 	"github.com/google/uuid"
 )
@@ -71,7 +72,15 @@ func (p *provider) AddUser(ctx context.Context, user models.User) (models.User,
 
 	query := fmt.Sprintf("INSERT INTO %s %s VALUES %s IF NOT EXISTS", KeySpace+"."+models.Collections.User, fields, values)
 
-	err = p.db.Query(query).Exec()
+	applied, err := p.db.Query(query).MapScanCAS(map[string]interface{}{})
+	if err != nil {
+		return user, err
+	}
+	if !applied {
+		return user, nil
+	}
+
+	err = p.updateUserSort(nil, &user)
 	if err != nil {
 		return user, err
 	}
@@ -121,6 +130,12 @@ func (p *provider) UpdateUser(ctx context.Context, user models.User) (models.Use
 	updateFields = strings.Trim(updateFields, " ")
 	updateFields = strings.TrimSuffix(updateFields, ",")
 
+	// rows of previous user are replaced in the lookup table of sort fields
+	var previousUser *models.User
+	if previous, err := p.GetUserByID(ctx, user.ID); err == nil {
+		previousUser = &previous
+	}
+
 	query := fmt.Sprintf("UPDATE %s SET %s WHERE id = '%s'", KeySpace+"."+models.Collections.User, updateFields, user.ID)
 
 	err = p.db.Query(query).Exec()
@@ -128,17 +143,32 @@ func (p *provider) UpdateUser(ctx context.Context, user models.User) (models.Use
 		return user, err
 	}
 
+	err = p.updateUserSort(previousUser, &user)
+	if err != nil {
+		return user, err
+	}
+
 	return user, nil
 }
 
 // DeleteUser to delete user information from database
 func (p *provider) DeleteUser(ctx context.Context, user models.User) error {
+	// stored user is removed from the lookup table of sort fields, as the user passed may be outdated
+	if storedUser, err := p.GetUserByID(ctx, user.ID); err == nil {
+		user = storedUser
+	}
+
 	query := fmt.Sprintf("DELETE FROM %s WHERE id = '%s'", KeySpace+"."+models.Collections.User, user.ID)
 	err := p.db.Query(query).Exec()
 	if err != nil {
 		return err
 	}
 
+	err = p.updateUserSort(&user, nil)
+	if err != nil {
+		return err
+	}
+
 	getSessionsQuery := fmt.Sprintf("SELECT id FROM %s WHERE user_id = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.Session, user.ID)
 	scanner := p.db.Query(getSessionsQuery).Iter().Scanner()
 	sessionIDs := ""
@@ -157,37 +187,174 @@ func (p *provider) DeleteUser(ctx context.Context, user models.User) error {
 		return err
 	}
 
+	getWebauthnCredentialsQuery := fmt.Sprintf("SELECT id FROM %s WHERE user_id = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.WebauthnCredential, user.ID)
+	scanner = p.db.Query(getWebauthnCredentialsQuery).Iter().Scanner()
+	webauthnCredentialIDs := ""
+	for scanner.Next() {
+		var credentialID string
+		err = scanner.Scan(&credentialID)
+		if err != nil {
+			return err
+		}
+		webauthnCredentialIDs += fmt.Sprintf("'%s',", credentialID)
+	}
+	webauthnCredentialIDs = strings.TrimSuffix(webauthnCredentialIDs, ",")
+	if webauthnCredentialIDs != "" {
+		deleteWebauthnCredentialsQuery := fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", KeySpace+"."+models.Collections.WebauthnCredential, webauthnCredentialIDs)
+		err = p.db.Query(deleteWebauthnCredentialsQuery).Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = p.deleteGrantsBy("user_id", user.ID)
+	if err != nil {
+		return err
+	}
+
+	err = p.deleteOrganizationItemsBy(models.Collections.OrganizationMember, "user_id", user.ID)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
-// ListUsers to get list of users from database
-func (p *provider) ListUsers(ctx context.Context, pagination model.Pagination) (*model.Users, error) {
+// ListUsers to get list of users matching the filter from database.
+// users are filtered & sorted in the lookup table of sort fields, except email
+// which is matched on the fetched rows as cassandra can not match the part of text
+func (p *provider) ListUsers(ctx context.Context, pagination model.Pagination, filter models.UserFilter) (*model.Users, error) {
 	responseUsers := []*model.User{}
 	paginationClone := pagination
-	totalCountQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s`, KeySpace+"."+models.Collections.User)
-	err := p.db.Query(totalCountQuery).Consistency(gocql.One).Scan(&paginationClone.Total)
-	if err != nil {
-		return nil, err
+
+	conditions := []string{"sort_by = ?"}
+	values := []interface{}{filter.GetSortBy()}
+	if filter.Role != "" {
+		conditions = append(conditions, "roles CONTAINS ?")
+		values = append(values, filter.Role)
+	}
+	if filter.SignupMethod != "" {
+		conditions = append(conditions, "signup_methods CONTAINS ?")
+		values = append(values, filter.SignupMethod)
+	}
+	if filter.IsEmailVerified != nil {
+		conditions = append(conditions, "email_verified = ?")
+		values = append(values, *filter.IsEmailVerified)
+	}
+	if filter.IsPhoneVerified != nil {
+		conditions = append(conditions, "phone_number_verified = ?")
+		values = append(values, *filter.IsPhoneVerified)
+	}
+	if filter.IsRevoked != nil {
+		conditions = append(conditions, "revoked = ?")
+		values = append(values, *filter.IsRevoked)
+	}
+	if filter.CreatedAfter != nil {
+		conditions = append(conditions, "created_at >= ?")
+		values = append(values, *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		conditions = append(conditions, "created_at <= ?")
+		values = append(values, *filter.CreatedBefore)
+	}
+
+	order := "DESC"
+	operator := "<"
+	if filter.IsAscending() {
+		order = "ASC"
+		operator = ">"
+	}
+	pageConditions := append([]string{}, conditions...)
+	pageValues := append([]interface{}{}, values...)
+	offset := pagination.Offset
+	if pagination.After != nil {
+		cursor, err := models.DecodeCursor(*pagination.After)
+		if err != nil {
+			return nil, err
+		}
+		pageConditions = append(pageConditions, fmt.Sprintf("(sort_value, id) %s (?, ?)", operator))
+		pageValues = append(pageValues, userSortValue(cursor.Value), cursor.ID)
+		offset = 0
 	}
 
-	// there is no offset in cassandra
-	// so we fetch till limit + offset
-	// and return the results from offset to limit
-	query := fmt.Sprintf("SELECT id, email, email_verified_at, password, signup_methods, given_name, family_name, middle_name, nickname, birthdate, phone_number, phone_number_verified_at, picture, roles, revoked_timestamp, created_at, updated_at FROM %s LIMIT %d", KeySpace+"."+models.Collections.User, pagination.Limit+pagination.Offset)
+	// one more row is read to know if there is next page
+	size := offset + pagination.Limit + 1
+	query := fmt.Sprintf("SELECT id, email FROM %s WHERE %s ORDER BY sort_value %s, id %s", KeySpace+"."+userSortCollection, strings.Join(pageConditions, " AND "), order, order)
+	if filter.Email == "" {
+		query += fmt.Sprintf(" LIMIT %d", size)
+	}
+	query += " ALLOW FILTERING"
 
-	scanner := p.db.Query(query).Iter().Scanner()
-	counter := int64(0)
-	for scanner.Next() {
-		if counter >= pagination.Offset {
+	ids := []string{}
+	skipped := int64(0)
+	var id, email string
+	iter := p.db.Query(query, pageValues...).PageSize(int(size)).Iter()
+	for int64(len(ids)) <= pagination.Limit && iter.Scan(&id, &email) {
+		if !filter.MatchesEmail(email) {
+			continue
+		}
+		if skipped < offset {
+			skipped++
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	paginationClone.HasNextPage = int64(len(ids)) > pagination.Limit
+	if paginationClone.HasNextPage {
+		ids = ids[:pagination.Limit]
+	}
+
+	if len(ids) > 0 {
+		users := map[string]models.User{}
+		query = fmt.Sprintf("SELECT %s FROM %s WHERE id IN ?", "id, email, email_verified_at, password, signup_methods, given_name, family_name, middle_name, nickname, birthdate, phone_number, phone_number_verified_at, picture, roles, revoked_timestamp, totp_secret, totp_verified_at, created_at, updated_at", KeySpace+"."+models.Collections.User)
+		scanner := p.db.Query(query, ids).Iter().Scanner()
+		for scanner.Next() {
 			var user models.User
-			err := scanner.Scan(&user.ID, &user.Email, &user.EmailVerifiedAt, &user.Password, &user.SignupMethods, &user.GivenName, &user.FamilyName, &user.MiddleName, &user.Nickname, &user.Birthdate, &user.PhoneNumber, &user.PhoneNumberVerifiedAt, &user.Picture, &user.Roles, &user.RevokedTimestamp, &user.CreatedAt, &user.UpdatedAt)
+			err := scanner.Scan(&user.ID, &user.Email, &user.EmailVerifiedAt, &user.Password, &user.SignupMethods, &user.GivenName, &user.FamilyName, &user.MiddleName, &user.Nickname, &user.Birthdate, &user.PhoneNumber, &user.PhoneNumberVerifiedAt, &user.Picture, &user.Roles, &user.RevokedTimestamp, &user.TOTPSecret, &user.TOTPVerifiedAt, &user.CreatedAt, &user.UpdatedAt)
 			if err != nil {
 				return nil, err
 			}
+			users[user.ID] = user
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			user, ok := users[id]
+			if !ok {
+				continue
+			}
 			responseUsers = append(responseUsers, user.AsAPIUser())
+			paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(filter.SortValue(&user), user.ID))
+		}
+	}
+
+	if filter.Email == "" {
+		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s ALLOW FILTERING", KeySpace+"."+userSortCollection, strings.Join(conditions, " AND "))
+		err := p.countTotal(&paginationClone, countQuery, values...)
+		if err != nil {
+			return nil, err
+		}
+	} else if pagination.After == nil {
+		countQuery := fmt.Sprintf("SELECT email FROM %s WHERE %s ALLOW FILTERING", KeySpace+"."+userSortCollection, strings.Join(conditions, " AND "))
+		scanner := p.db.Query(countQuery, values...).Iter().Scanner()
+		for scanner.Next() {
+			err := scanner.Scan(&email)
+			if err != nil {
+				return nil, err
+			}
+			if filter.MatchesEmail(email) {
+				paginationClone.Total++
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
 		}
-		counter++
 	}
+
 	return &model.Users{
 		Users:      responseUsers,
 		Pagination: &paginationClone,
@@ -197,8 +364,19 @@ func (p *provider) ListUsers(ctx context.Context, pagination model.Pagination) (
 // GetUserByEmail to get user information from database using email address
 func (p *provider) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
 	var user models.User
-	query := fmt.Sprintf("SELECT id, email, email_verified_at, password, signup_methods, given_name, family_name, middle_name, nickname, birthdate, phone_number, phone_number_verified_at, picture, roles, revoked_timestamp, created_at, updated_at FROM %s WHERE email = '%s' LIMIT 1 ALLOW FILTERING", KeySpace+"."+models.Collections.User, email)
-	err := p.db.Query(query).Consistency(gocql.One).Scan(&user.ID, &user.Email, &user.EmailVerifiedAt, &user.Password, &user.SignupMethods, &user.GivenName, &user.FamilyName, &user.MiddleName, &user.Nickname, &user.Birthdate, &user.PhoneNumber, &user.PhoneNumberVerifiedAt, &user.Picture, &user.Roles, &user.RevokedTimestamp, &user.CreatedAt, &user.UpdatedAt)
+	query := fmt.Sprintf("SELECT id, email, email_verified_at, password, signup_methods, given_name, family_name, middle_name, nickname, birthdate, phone_number, phone_number_verified_at, picture, roles, revoked_timestamp, totp_secret, totp_verified_at, created_at, updated_at FROM %s WHERE email = '%s' LIMIT 1 ALLOW FILTERING", KeySpace+"."+models.Collections.User, email)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&user.ID, &user.Email, &user.EmailVerifiedAt, &user.Password, &user.SignupMethods, &user.GivenName, &user.FamilyName, &user.MiddleName, &user.Nickname, &user.Birthdate, &user.PhoneNumber, &user.PhoneNumberVerifiedAt, &user.Picture, &user.Roles, &user.RevokedTimestamp, &user.TOTPSecret, &user.TOTPVerifiedAt, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		return user, err
+	}
+	return user, nil
+}
+
+// GetUserByPhoneNumber to get user information from database using phone number
+func (p *provider) GetUserByPhoneNumber(ctx context.Context, phoneNumber string) (models.User, error) {
+	var user models.User
+	query := fmt.Sprintf("SELECT id, email, email_verified_at, password, signup_methods, given_name, family_name, middle_name, nickname, birthdate, phone_number, phone_number_verified_at, picture, roles, revoked_timestamp, totp_secret, totp_verified_at, created_at, updated_at FROM %s WHERE phone_number = '%s' LIMIT 1 ALLOW FILTERING", KeySpace+"."+models.Collections.User, phoneNumber)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&user.ID, &user.Email, &user.EmailVerifiedAt, &user.Password, &user.SignupMethods, &user.GivenName, &user.FamilyName, &user.MiddleName, &user.Nickname, &user.Birthdate, &user.PhoneNumber, &user.PhoneNumberVerifiedAt, &user.Picture, &user.Roles, &user.RevokedTimestamp, &user.TOTPSecret, &user.TOTPVerifiedAt, &user.CreatedAt, &user.UpdatedAt)
 	if err != nil {
 		return user, err
 	}
@@ -208,10 +386,81 @@ func (p *provider) GetUserByEmail(ctx context.Context, email string) (models.Use
 // GetUserByID to get user information from database using user ID
 func (p *provider) GetUserByID(ctx context.Context, id string) (models.User, error) {
 	var user models.User
-	query := fmt.Sprintf("SELECT id, email, email_verified_at, password, signup_methods, given_name, family_name, middle_name, nickname, birthdate, phone_number, phone_number_verified_at, picture, roles, revoked_timestamp, created_at, updated_at FROM %s WHERE id = '%s' LIMIT 1", KeySpace+"."+models.Collections.User, id)
-	err := p.db.Query(query).Consistency(gocql.One).Scan(&user.ID, &user.Email, &user.EmailVerifiedAt, &user.Password, &user.SignupMethods, &user.GivenName, &user.FamilyName, &user.MiddleName, &user.Nickname, &user.Birthdate, &user.PhoneNumber, &user.PhoneNumberVerifiedAt, &user.Picture, &user.Roles, &user.RevokedTimestamp, &user.CreatedAt, &user.UpdatedAt)
+	query := fmt.Sprintf("SELECT id, email, email_verified_at, password, signup_methods, given_name, family_name, middle_name, nickname, birthdate, phone_number, phone_number_verified_at, picture, roles, revoked_timestamp, totp_secret, totp_verified_at, created_at, updated_at FROM %s WHERE id = '%s' LIMIT 1", KeySpace+"."+models.Collections.User, id)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&user.ID, &user.Email, &user.EmailVerifiedAt, &user.Password, &user.SignupMethods, &user.GivenName, &user.FamilyName, &user.MiddleName, &user.Nickname, &user.Birthdate, &user.PhoneNumber, &user.PhoneNumberVerifiedAt, &user.Picture, &user.Roles, &user.RevokedTimestamp, &user.TOTPSecret, &user.TOTPVerifiedAt, &user.CreatedAt, &user.UpdatedAt)
 	if err != nil {
 		return user, err
 	}
 	return user, nil
 }
+
+// userSortCollection is the lookup table of users with a partition for each sort field,
+// clustered by the value of sort field so that users can be filtered, sorted & paginated in cql
+var userSortCollection = models.Collections.User + "_by_sort"
+
+// userSortValue returns the value of sort field saved in the lookup table,
+// timestamps are zero padded so that they are ordered as text
+func userSortValue(value interface{}) string {
+	if timestamp, ok := value.(int64); ok {
+		return fmt.Sprintf("%020d", timestamp)
+	}
+	return fmt.Sprint(value)
+}
+
+// userSortSet returns the comma separated values of user as set
+func userSortSet(values string) []string {
+	set := []string{}
+	for _, value := range strings.Split(values, ",") {
+		if value != "" {
+			set = append(set, value)
+		}
+	}
+	return set
+}
+
+// updateUserSort replaces the rows of previous user in the lookup table of sort fields with the rows of user,
+// previous user is nil for the added user & user is nil for the deleted user
+func (p *provider) updateUserSort(previousUser, user *models.User) error {
+	batch := p.db.NewBatch(gocql.LoggedBatch)
+	for _, sortBy := range models.UserSortFields {
+		filter := models.UserFilter{SortBy: sortBy}
+		if previousUser != nil {
+			query := fmt.Sprintf("DELETE FROM %s WHERE sort_by = ? AND sort_value = ? AND id = ?", KeySpace+"."+userSortCollection)
+			batch.Query(query, sortBy, userSortValue(filter.SortValue(previousUser)), previousUser.ID)
+		}
+		if user != nil {
+			query := fmt.Sprintf("INSERT INTO %s (sort_by, sort_value, id, email, roles, signup_methods, email_verified, phone_number_verified, revoked, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", KeySpace+"."+userSortCollection)
+			batch.Query(query, sortBy, userSortValue(filter.SortValue(user)), user.ID, user.Email, userSortSet(user.Roles), userSortSet(user.SignupMethods), user.EmailVerifiedAt != nil, user.PhoneNumberVerifiedAt != nil, user.RevokedTimestamp != nil, user.CreatedAt)
+		}
+	}
+	return p.db.ExecuteBatch(batch)
+}
+
+// addExistingUsersToSort adds the users saved before the lookup table of sort fields was created to it.
+// It is done once, when the lookup table is empty
+func (p *provider) addExistingUsersToSort() error {
+	var id string
+	query := fmt.Sprintf("SELECT id FROM %s WHERE sort_by = ? LIMIT 1", KeySpace+"."+userSortCollection)
+	err := p.db.Query(query, models.UserSortFields[0]).Consistency(gocql.One).Scan(&id)
+	if err == nil {
+		return nil
+	}
+	if err != gocql.ErrNotFound {
+		return err
+	}
+
+	query = fmt.Sprintf("SELECT %s FROM %s", "id, email, email_verified_at, password, signup_methods, given_name, family_name, middle_name, nickname, birthdate, phone_number, phone_number_verified_at, picture, roles, revoked_timestamp, totp_secret, totp_verified_at, created_at, updated_at", KeySpace+"."+models.Collections.User)
+	scanner := p.db.Query(query).Iter().Scanner()
+	for scanner.Next() {
+		var user models.User
+		err = scanner.Scan(&user.ID, &user.Email, &user.EmailVerifiedAt, &user.Password, &user.SignupMethods, &user.GivenName, &user.FamilyName, &user.MiddleName, &user.Nickname, &user.Birthdate, &user.PhoneNumber, &user.PhoneNumberVerifiedAt, &user.Picture, &user.Roles, &user.RevokedTimestamp, &user.TOTPSecret, &user.TOTPVerifiedAt, &user.CreatedAt, &user.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		err = p.updateUserSort(nil, &user)
+		if err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
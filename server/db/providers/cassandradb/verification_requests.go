@@ -59,28 +59,24 @@ func (p *provider) ListVerificationRequests(ctx context.Context, pagination mode
 
 	paginationClone := pagination
 	totalCountQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s`, KeySpace+"."+models.Collections.VerificationRequest)
-	err := p.db.Query(totalCountQuery).Consistency(gocql.One).Scan(&paginationClone.Total)
+	err := p.countTotal(&paginationClone, totalCountQuery)
 	if err != nil {
 		return nil, err
 	}
 
-	// there is no offset in cassandra
-	// so we fetch till limit + offset
-	// and return the results from offset to limit
-	query := fmt.Sprintf(`SELECT id, jwt_token, identifier, expires_at, email, nonce, redirect_uri, created_at, updated_at FROM %s LIMIT %d`, KeySpace+"."+models.Collections.VerificationRequest, pagination.Limit+pagination.Offset)
-
-	scanner := p.db.Query(query).Iter().Scanner()
-	counter := int64(0)
-	for scanner.Next() {
-		if counter >= pagination.Offset {
-			var verificationRequest models.VerificationRequest
-			err := scanner.Scan(&verificationRequest.ID, &verificationRequest.Token, &verificationRequest.Identifier, &verificationRequest.ExpiresAt, &verificationRequest.Email, &verificationRequest.Nonce, &verificationRequest.RedirectURI, &verificationRequest.CreatedAt, &verificationRequest.UpdatedAt)
-			if err != nil {
-				return nil, err
-			}
-			verificationRequests = append(verificationRequests, verificationRequest.AsAPIVerificationRequest())
+	query := fmt.Sprintf(`SELECT id, jwt_token, identifier, expires_at, email, nonce, redirect_uri, created_at, updated_at FROM %s`, KeySpace+"."+models.Collections.VerificationRequest)
+
+	err = paginate(p.db.Query(query), &paginationClone, func(scanner gocql.Scanner) error {
+		var verificationRequest models.VerificationRequest
+		err := scanner.Scan(&verificationRequest.ID, &verificationRequest.Token, &verificationRequest.Identifier, &verificationRequest.ExpiresAt, &verificationRequest.Email, &verificationRequest.Nonce, &verificationRequest.RedirectURI, &verificationRequest.CreatedAt, &verificationRequest.UpdatedAt)
+		if err != nil {
+			return err
 		}
-		counter++
+		verificationRequests = append(verificationRequests, verificationRequest.AsAPIVerificationRequest())
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &model.VerificationRequests{
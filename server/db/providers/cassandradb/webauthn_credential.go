@@ -0,0 +1,77 @@
+package cassandradb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+)
+
+// AddWebauthnCredential to save webauthn credential information in database
+func (p *provider) AddWebauthnCredential(ctx context.Context, credential models.WebauthnCredential) (models.WebauthnCredential, error) {
+	if credential.ID == "" {
+		credential.ID = uuid.New().String()
+	}
+
+	credential.Key = credential.ID
+	credential.CreatedAt = time.Now().Unix()
+	credential.UpdatedAt = time.Now().Unix()
+
+	existingCredential, _ := p.GetWebauthnCredentialByCredentialID(ctx, credential.CredentialID)
+	if existingCredential.ID != "" {
+		return credential, fmt.Errorf("webauthn credential already exists")
+	}
+
+	insertQuery := fmt.Sprintf("INSERT INTO %s (id, user_id, credential_id, public_key, sign_count, aaguid, created_at, updated_at) VALUES ('%s', '%s', '%s', '%s', %d, '%s', %d, %d)", KeySpace+"."+models.Collections.WebauthnCredential, credential.ID, credential.UserID, credential.CredentialID, credential.PublicKey, credential.SignCount, credential.AAGUID, credential.CreatedAt, credential.UpdatedAt)
+	err := p.db.Query(insertQuery).Exec()
+	if err != nil {
+		return credential, err
+	}
+
+	return credential, nil
+}
+
+// UpdateWebauthnCredential to update webauthn credential information in database
+func (p *provider) UpdateWebauthnCredential(ctx context.Context, credential models.WebauthnCredential) (models.WebauthnCredential, error) {
+	credential.UpdatedAt = time.Now().Unix()
+
+	query := fmt.Sprintf("UPDATE %s SET sign_count = %d, updated_at = %d WHERE id = '%s'", KeySpace+"."+models.Collections.WebauthnCredential, credential.SignCount, credential.UpdatedAt, credential.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return credential, err
+	}
+
+	return credential, nil
+}
+
+// GetWebauthnCredentialByCredentialID to get webauthn credential using credential id generated by authenticator
+func (p *provider) GetWebauthnCredentialByCredentialID(ctx context.Context, credentialID string) (models.WebauthnCredential, error) {
+	var credential models.WebauthnCredential
+	query := fmt.Sprintf("SELECT id, user_id, credential_id, public_key, sign_count, aaguid, created_at, updated_at FROM %s WHERE credential_id = '%s' LIMIT 1 ALLOW FILTERING", KeySpace+"."+models.Collections.WebauthnCredential, credentialID)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&credential.ID, &credential.UserID, &credential.CredentialID, &credential.PublicKey, &credential.SignCount, &credential.AAGUID, &credential.CreatedAt, &credential.UpdatedAt)
+	if err != nil {
+		return credential, err
+	}
+
+	return credential, nil
+}
+
+// ListWebauthnCredentialsByUserID to get all the webauthn credentials registered by user
+func (p *provider) ListWebauthnCredentialsByUserID(ctx context.Context, userID string) ([]models.WebauthnCredential, error) {
+	credentials := []models.WebauthnCredential{}
+	query := fmt.Sprintf("SELECT id, user_id, credential_id, public_key, sign_count, aaguid, created_at, updated_at FROM %s WHERE user_id = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.WebauthnCredential, userID)
+	scanner := p.db.Query(query).Iter().Scanner()
+	for scanner.Next() {
+		var credential models.WebauthnCredential
+		err := scanner.Scan(&credential.ID, &credential.UserID, &credential.CredentialID, &credential.PublicKey, &credential.SignCount, &credential.AAGUID, &credential.CreatedAt, &credential.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, credential)
+	}
+
+	return credentials, nil
+}
@@ -94,28 +94,24 @@ func (p *provider) ListWebhook(ctx context.Context, pagination model.Pagination)
 	paginationClone := pagination
 
 	totalCountQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s`, KeySpace+"."+models.Collections.Webhook)
-	err := p.db.Query(totalCountQuery).Consistency(gocql.One).Scan(&paginationClone.Total)
+	err := p.countTotal(&paginationClone, totalCountQuery)
 	if err != nil {
 		return nil, err
 	}
 
-	// there is no offset in cassandra
-	// so we fetch till limit + offset
-	// and return the results from offset to limit
-	query := fmt.Sprintf("SELECT id, event_name, endpoint, headers, enabled, created_at, updated_at FROM %s LIMIT %d", KeySpace+"."+models.Collections.Webhook, pagination.Limit+pagination.Offset)
+	query := fmt.Sprintf("SELECT id, event_name, endpoint, headers, enabled, created_at, updated_at FROM %s", KeySpace+"."+models.Collections.Webhook)
 
-	scanner := p.db.Query(query).Iter().Scanner()
-	counter := int64(0)
-	for scanner.Next() {
-		if counter >= pagination.Offset {
-			var webhook models.Webhook
-			err := scanner.Scan(&webhook.ID, &webhook.EventName, &webhook.EndPoint, &webhook.Headers, &webhook.Enabled, &webhook.CreatedAt, &webhook.UpdatedAt)
-			if err != nil {
-				return nil, err
-			}
-			webhooks = append(webhooks, webhook.AsAPIWebhook())
+	err = paginate(p.db.Query(query), &paginationClone, func(scanner gocql.Scanner) error {
+		var webhook models.Webhook
+		err := scanner.Scan(&webhook.ID, &webhook.EventName, &webhook.EndPoint, &webhook.Headers, &webhook.Enabled, &webhook.CreatedAt, &webhook.UpdatedAt)
+		if err != nil {
+			return err
 		}
-		counter++
+		webhooks = append(webhooks, webhook.AsAPIWebhook())
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &model.Webhooks{
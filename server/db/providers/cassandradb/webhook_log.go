@@ -34,33 +34,29 @@ func (p *provider) ListWebhookLogs(ctx context.Context, pagination model.Paginat
 	webhookLogs := []*model.WebhookLog{}
 	paginationClone := pagination
 	totalCountQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s`, KeySpace+"."+models.Collections.WebhookLog)
-	// there is no offset in cassandra
-	// so we fetch till limit + offset
-	// and return the results from offset to limit
-	query := fmt.Sprintf("SELECT id, http_status, response, request, webhook_id, created_at, updated_at FROM %s LIMIT %d", KeySpace+"."+models.Collections.WebhookLog, pagination.Limit+pagination.Offset)
+	query := fmt.Sprintf("SELECT id, http_status, response, request, webhook_id, created_at, updated_at FROM %s", KeySpace+"."+models.Collections.WebhookLog)
 
 	if webhookID != "" {
 		totalCountQuery = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE webhook_id='%s' ALLOW FILTERING`, KeySpace+"."+models.Collections.WebhookLog, webhookID)
-		query = fmt.Sprintf("SELECT id, http_status, response, request, webhook_id, created_at, updated_at FROM %s WHERE webhook_id = '%s' LIMIT %d ALLOW FILTERING", KeySpace+"."+models.Collections.WebhookLog, webhookID, pagination.Limit+pagination.Offset)
+		query = fmt.Sprintf("SELECT id, http_status, response, request, webhook_id, created_at, updated_at FROM %s WHERE webhook_id = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.WebhookLog, webhookID)
 	}
 
-	err := p.db.Query(totalCountQuery).Consistency(gocql.One).Scan(&paginationClone.Total)
+	err := p.countTotal(&paginationClone, totalCountQuery)
 	if err != nil {
 		return nil, err
 	}
 
-	scanner := p.db.Query(query).Iter().Scanner()
-	counter := int64(0)
-	for scanner.Next() {
-		if counter >= pagination.Offset {
-			var webhookLog models.WebhookLog
-			err := scanner.Scan(&webhookLog.ID, &webhookLog.HttpStatus, &webhookLog.Response, &webhookLog.Request, &webhookLog.WebhookID, &webhookLog.CreatedAt, &webhookLog.UpdatedAt)
-			if err != nil {
-				return nil, err
-			}
-			webhookLogs = append(webhookLogs, webhookLog.AsAPIWebhookLog())
+	err = paginate(p.db.Query(query), &paginationClone, func(scanner gocql.Scanner) error {
+		var webhookLog models.WebhookLog
+		err := scanner.Scan(&webhookLog.ID, &webhookLog.HttpStatus, &webhookLog.Response, &webhookLog.Request, &webhookLog.WebhookID, &webhookLog.CreatedAt, &webhookLog.UpdatedAt)
+		if err != nil {
+			return err
 		}
-		counter++
+		webhookLogs = append(webhookLogs, webhookLog.AsAPIWebhookLog())
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &model.WebhookLogs{
@@ -0,0 +1,108 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddClient to register oauth client
+func (p *provider) AddClient(ctx context.Context, client models.Client) (models.Client, error) {
+	if client.ID == "" {
+		client.ID = uuid.New().String()
+	}
+
+	client.Key = client.ID
+	client.CreatedAt = time.Now().Unix()
+	client.UpdatedAt = time.Now().Unix()
+	clientCollection := p.db.Collection(models.Collections.Client, options.Collection())
+	_, err := clientCollection.InsertOne(ctx, client)
+	if err != nil {
+		return client, err
+	}
+
+	return client, nil
+}
+
+// UpdateClient to update oauth client
+func (p *provider) UpdateClient(ctx context.Context, client models.Client) (models.Client, error) {
+	client.UpdatedAt = time.Now().Unix()
+	clientCollection := p.db.Collection(models.Collections.Client, options.Collection())
+	_, err := clientCollection.UpdateOne(ctx, bson.M{"_id": bson.M{"$eq": client.ID}}, bson.M{"$set": client}, options.MergeUpdateOptions())
+	if err != nil {
+		return client, err
+	}
+
+	return client, nil
+}
+
+// ListClients to list oauth clients
+func (p *provider) ListClients(ctx context.Context, pagination model.Pagination) (*model.Clients, error) {
+	clients := []*model.Client{}
+	opts := options.Find()
+	opts.SetLimit(pagination.Limit)
+	opts.SetSkip(pagination.Offset)
+	opts.SetSort(bson.M{"created_at": -1})
+
+	paginationClone := pagination
+
+	clientCollection := p.db.Collection(models.Collections.Client, options.Collection())
+	err := countTotal(ctx, clientCollection, bson.M{}, &paginationClone)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := clientCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var client models.Client
+		err := cursor.Decode(&client)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, client.AsAPIClient())
+	}
+
+	return &model.Clients{
+		Pagination: &paginationClone,
+		Clients:    clients,
+	}, nil
+}
+
+// GetClientByID to get oauth client by client_id
+func (p *provider) GetClientByID(ctx context.Context, clientID string) (models.Client, error) {
+	var client models.Client
+	clientCollection := p.db.Collection(models.Collections.Client, options.Collection())
+	err := clientCollection.FindOne(ctx, bson.M{"_id": clientID}).Decode(&client)
+	if err != nil {
+		return client, err
+	}
+
+	return client, nil
+}
+
+// DeleteClient to delete oauth client
+func (p *provider) DeleteClient(ctx context.Context, client models.Client) error {
+	clientCollection := p.db.Collection(models.Collections.Client, options.Collection())
+	_, err := clientCollection.DeleteOne(ctx, bson.M{"_id": client.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+
+	grantCollection := p.db.Collection(models.Collections.Grant, options.Collection())
+	_, err = grantCollection.DeleteMany(ctx, bson.M{"client_id": client.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -45,34 +46,39 @@ func (p *provider) UpdateEmailTemplate(ctx context.Context, emailTemplate models
 // ListEmailTemplates to list EmailTemplate
 func (p *provider) ListEmailTemplate(ctx context.Context, pagination model.Pagination) (*model.EmailTemplates, error) {
 	var emailTemplates []*model.EmailTemplate
-	opts := options.Find()
-	opts.SetLimit(pagination.Limit)
-	opts.SetSkip(pagination.Offset)
-	opts.SetSort(bson.M{"created_at": -1})
+	opts, query, err := paginate(bson.M{}, pagination, "created_at", false)
+	if err != nil {
+		return nil, err
+	}
 
 	paginationClone := pagination
 
 	emailTemplateCollection := p.db.Collection(models.Collections.EmailTemplate, options.Collection())
-	count, err := emailTemplateCollection.CountDocuments(ctx, bson.M{}, options.Count())
+	err = countTotal(ctx, emailTemplateCollection, bson.M{}, &paginationClone)
 	if err != nil {
 		return nil, err
 	}
 
-	paginationClone.Total = count
-
-	cursor, err := emailTemplateCollection.Find(ctx, bson.M{}, opts)
+	cursor, err := emailTemplateCollection.Find(ctx, query, opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
 	for cursor.Next(ctx) {
+		// extra item is fetched to know if there is next page
+		if int64(len(emailTemplates)) == pagination.Limit {
+			paginationClone.HasNextPage = true
+			break
+		}
+
 		var emailTemplate models.EmailTemplate
 		err := cursor.Decode(&emailTemplate)
 		if err != nil {
 			return nil, err
 		}
 		emailTemplates = append(emailTemplates, emailTemplate.AsAPIEmailTemplate())
+		paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(emailTemplate.CreatedAt, emailTemplate.ID))
 	}
 
 	return &model.EmailTemplates{
@@ -0,0 +1,88 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddGrant to save the scopes granted by user to oauth client
+func (p *provider) AddGrant(ctx context.Context, grant models.Grant) (models.Grant, error) {
+	if grant.ID == "" {
+		grant.ID = uuid.New().String()
+	}
+
+	grant.Key = grant.ID
+	grant.CreatedAt = time.Now().Unix()
+	grant.UpdatedAt = time.Now().Unix()
+	grantCollection := p.db.Collection(models.Collections.Grant, options.Collection())
+	_, err := grantCollection.InsertOne(ctx, grant)
+	if err != nil {
+		return grant, err
+	}
+
+	return grant, nil
+}
+
+// UpdateGrant to update the scopes granted by user to oauth client
+func (p *provider) UpdateGrant(ctx context.Context, grant models.Grant) (models.Grant, error) {
+	grant.UpdatedAt = time.Now().Unix()
+	grantCollection := p.db.Collection(models.Collections.Grant, options.Collection())
+	_, err := grantCollection.UpdateOne(ctx, bson.M{"_id": bson.M{"$eq": grant.ID}}, bson.M{"$set": grant}, options.MergeUpdateOptions())
+	if err != nil {
+		return grant, err
+	}
+
+	return grant, nil
+}
+
+// GetGrantByUserIDAndClientID to get the scopes granted by user to oauth client
+func (p *provider) GetGrantByUserIDAndClientID(ctx context.Context, userID, clientID string) (models.Grant, error) {
+	var grant models.Grant
+	grantCollection := p.db.Collection(models.Collections.Grant, options.Collection())
+	err := grantCollection.FindOne(ctx, bson.M{"user_id": userID, "client_id": clientID}).Decode(&grant)
+	if err != nil {
+		return grant, err
+	}
+
+	return grant, nil
+}
+
+// ListGrantsByUserID to get all the oauth clients granted access by user
+func (p *provider) ListGrantsByUserID(ctx context.Context, userID string) ([]models.Grant, error) {
+	grants := []models.Grant{}
+	opts := options.Find()
+	opts.SetSort(bson.M{"created_at": -1})
+	grantCollection := p.db.Collection(models.Collections.Grant, options.Collection())
+	cursor, err := grantCollection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var grant models.Grant
+		err := cursor.Decode(&grant)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, grant)
+	}
+
+	return grants, nil
+}
+
+// DeleteGrant to revoke the access granted by user to oauth client
+func (p *provider) DeleteGrant(ctx context.Context, grant models.Grant) error {
+	grantCollection := p.db.Collection(models.Collections.Grant, options.Collection())
+	_, err := grantCollection.DeleteOne(ctx, bson.M{"_id": grant.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,333 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddOrganization to add organization
+func (p *provider) AddOrganization(ctx context.Context, organization models.Organization) (models.Organization, error) {
+	if organization.ID == "" {
+		organization.ID = uuid.New().String()
+	}
+
+	organization.Key = organization.ID
+	organization.CreatedAt = time.Now().Unix()
+	organization.UpdatedAt = time.Now().Unix()
+	organizationCollection := p.db.Collection(models.Collections.Organization, options.Collection())
+	_, err := organizationCollection.InsertOne(ctx, organization)
+	if err != nil {
+		return organization, err
+	}
+
+	return organization, nil
+}
+
+// UpdateOrganization to update organization
+func (p *provider) UpdateOrganization(ctx context.Context, organization models.Organization) (models.Organization, error) {
+	organization.UpdatedAt = time.Now().Unix()
+	organizationCollection := p.db.Collection(models.Collections.Organization, options.Collection())
+	_, err := organizationCollection.UpdateOne(ctx, bson.M{"_id": bson.M{"$eq": organization.ID}}, bson.M{"$set": organization}, options.MergeUpdateOptions())
+	if err != nil {
+		return organization, err
+	}
+
+	return organization, nil
+}
+
+// DeleteOrganization to delete organization along with its members & invitations
+func (p *provider) DeleteOrganization(ctx context.Context, organization models.Organization) error {
+	organizationCollection := p.db.Collection(models.Collections.Organization, options.Collection())
+	_, err := organizationCollection.DeleteOne(ctx, bson.M{"_id": organization.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+
+	organizationMemberCollection := p.db.Collection(models.Collections.OrganizationMember, options.Collection())
+	_, err = organizationMemberCollection.DeleteMany(ctx, bson.M{"organization_id": organization.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+
+	organizationInvitationCollection := p.db.Collection(models.Collections.OrganizationInvitation, options.Collection())
+	_, err = organizationInvitationCollection.DeleteMany(ctx, bson.M{"organization_id": organization.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetOrganizationByID to get organization by id
+func (p *provider) GetOrganizationByID(ctx context.Context, organizationID string) (models.Organization, error) {
+	var organization models.Organization
+	organizationCollection := p.db.Collection(models.Collections.Organization, options.Collection())
+	err := organizationCollection.FindOne(ctx, bson.M{"_id": organizationID}).Decode(&organization)
+	if err != nil {
+		return organization, err
+	}
+
+	return organization, nil
+}
+
+// GetOrganizationByName to get organization by name
+func (p *provider) GetOrganizationByName(ctx context.Context, name string) (models.Organization, error) {
+	var organization models.Organization
+	organizationCollection := p.db.Collection(models.Collections.Organization, options.Collection())
+	err := organizationCollection.FindOne(ctx, bson.M{"name": name}).Decode(&organization)
+	if err != nil {
+		return organization, err
+	}
+
+	return organization, nil
+}
+
+// ListOrganizations to list organizations
+func (p *provider) ListOrganizations(ctx context.Context, pagination model.Pagination) (*model.Organizations, error) {
+	organizations := []*model.Organization{}
+	opts, query, err := paginate(bson.M{}, pagination, "created_at", false)
+	if err != nil {
+		return nil, err
+	}
+
+	paginationClone := pagination
+
+	organizationCollection := p.db.Collection(models.Collections.Organization, options.Collection())
+	err = countTotal(ctx, organizationCollection, bson.M{}, &paginationClone)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := organizationCollection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		// extra item is fetched to know if there is next page
+		if int64(len(organizations)) == pagination.Limit {
+			paginationClone.HasNextPage = true
+			break
+		}
+
+		var organization models.Organization
+		err := cursor.Decode(&organization)
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, organization.AsAPIOrganization())
+		paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(organization.CreatedAt, organization.ID))
+	}
+
+	return &model.Organizations{
+		Pagination:    &paginationClone,
+		Organizations: organizations,
+	}, nil
+}
+
+// AddOrganizationMember to add user as the member of organization
+func (p *provider) AddOrganizationMember(ctx context.Context, member models.OrganizationMember) (models.OrganizationMember, error) {
+	if member.ID == "" {
+		member.ID = uuid.New().String()
+	}
+
+	member.Key = member.ID
+	member.CreatedAt = time.Now().Unix()
+	member.UpdatedAt = time.Now().Unix()
+	organizationMemberCollection := p.db.Collection(models.Collections.OrganizationMember, options.Collection())
+	_, err := organizationMemberCollection.InsertOne(ctx, member)
+	if err != nil {
+		return member, err
+	}
+
+	return member, nil
+}
+
+// UpdateOrganizationMember to update the roles of member in organization
+func (p *provider) UpdateOrganizationMember(ctx context.Context, member models.OrganizationMember) (models.OrganizationMember, error) {
+	member.UpdatedAt = time.Now().Unix()
+	organizationMemberCollection := p.db.Collection(models.Collections.OrganizationMember, options.Collection())
+	_, err := organizationMemberCollection.UpdateOne(ctx, bson.M{"_id": bson.M{"$eq": member.ID}}, bson.M{"$set": member}, options.MergeUpdateOptions())
+	if err != nil {
+		return member, err
+	}
+
+	return member, nil
+}
+
+// DeleteOrganizationMember to remove user from organization
+func (p *provider) DeleteOrganizationMember(ctx context.Context, member models.OrganizationMember) error {
+	organizationMemberCollection := p.db.Collection(models.Collections.OrganizationMember, options.Collection())
+	_, err := organizationMemberCollection.DeleteOne(ctx, bson.M{"_id": member.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetOrganizationMember to get the membership of user in organization
+func (p *provider) GetOrganizationMember(ctx context.Context, organizationID, userID string) (models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	organizationMemberCollection := p.db.Collection(models.Collections.OrganizationMember, options.Collection())
+	err := organizationMemberCollection.FindOne(ctx, bson.M{"organization_id": organizationID, "user_id": userID}).Decode(&member)
+	if err != nil {
+		return member, err
+	}
+
+	return member, nil
+}
+
+// ListOrganizationMembers to list the members of organization
+func (p *provider) ListOrganizationMembers(ctx context.Context, pagination model.Pagination, organizationID string) (*model.OrganizationMembers, error) {
+	members := []*model.OrganizationMember{}
+	query := bson.M{"organization_id": organizationID}
+	opts, paginatedQuery, err := paginate(query, pagination, "created_at", false)
+	if err != nil {
+		return nil, err
+	}
+
+	paginationClone := pagination
+
+	organizationMemberCollection := p.db.Collection(models.Collections.OrganizationMember, options.Collection())
+	err = countTotal(ctx, organizationMemberCollection, query, &paginationClone)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := organizationMemberCollection.Find(ctx, paginatedQuery, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		// extra item is fetched to know if there is next page
+		if int64(len(members)) == pagination.Limit {
+			paginationClone.HasNextPage = true
+			break
+		}
+
+		var member models.OrganizationMember
+		err := cursor.Decode(&member)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member.AsAPIOrganizationMember())
+		paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(member.CreatedAt, member.ID))
+	}
+
+	return &model.OrganizationMembers{
+		Pagination: &paginationClone,
+		Members:    members,
+	}, nil
+}
+
+// ListOrganizationMembersByUserID to get all the organizations memberships of user
+func (p *provider) ListOrganizationMembersByUserID(ctx context.Context, userID string) ([]models.OrganizationMember, error) {
+	members := []models.OrganizationMember{}
+	opts := options.Find()
+	opts.SetSort(bson.M{"created_at": -1})
+	organizationMemberCollection := p.db.Collection(models.Collections.OrganizationMember, options.Collection())
+	cursor, err := organizationMemberCollection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var member models.OrganizationMember
+		err := cursor.Decode(&member)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+// AddOrganizationInvitation to invite email to organization
+func (p *provider) AddOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) (models.OrganizationInvitation, error) {
+	if invitation.ID == "" {
+		invitation.ID = uuid.New().String()
+	}
+
+	invitation.Key = invitation.ID
+	invitation.CreatedAt = time.Now().Unix()
+	invitation.UpdatedAt = time.Now().Unix()
+	organizationInvitationCollection := p.db.Collection(models.Collections.OrganizationInvitation, options.Collection())
+	_, err := organizationInvitationCollection.InsertOne(ctx, invitation)
+	if err != nil {
+		return invitation, err
+	}
+
+	return invitation, nil
+}
+
+// UpdateOrganizationInvitation to update the roles & expiry of invitation
+func (p *provider) UpdateOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) (models.OrganizationInvitation, error) {
+	invitation.UpdatedAt = time.Now().Unix()
+	organizationInvitationCollection := p.db.Collection(models.Collections.OrganizationInvitation, options.Collection())
+	_, err := organizationInvitationCollection.UpdateOne(ctx, bson.M{"_id": bson.M{"$eq": invitation.ID}}, bson.M{"$set": invitation}, options.MergeUpdateOptions())
+	if err != nil {
+		return invitation, err
+	}
+
+	return invitation, nil
+}
+
+// GetOrganizationInvitation to get the invitation of email to organization
+func (p *provider) GetOrganizationInvitation(ctx context.Context, organizationID, email string) (models.OrganizationInvitation, error) {
+	var invitation models.OrganizationInvitation
+	organizationInvitationCollection := p.db.Collection(models.Collections.OrganizationInvitation, options.Collection())
+	err := organizationInvitationCollection.FindOne(ctx, bson.M{"organization_id": organizationID, "email": email}).Decode(&invitation)
+	if err != nil {
+		return invitation, err
+	}
+
+	return invitation, nil
+}
+
+// ListOrganizationInvitations to list the pending invitations of organization
+func (p *provider) ListOrganizationInvitations(ctx context.Context, organizationID string) ([]models.OrganizationInvitation, error) {
+	invitations := []models.OrganizationInvitation{}
+	opts := options.Find()
+	opts.SetSort(bson.M{"created_at": -1})
+	organizationInvitationCollection := p.db.Collection(models.Collections.OrganizationInvitation, options.Collection())
+	cursor, err := organizationInvitationCollection.Find(ctx, bson.M{"organization_id": organizationID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var invitation models.OrganizationInvitation
+		err := cursor.Decode(&invitation)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	return invitations, nil
+}
+
+// DeleteOrganizationInvitation to delete invitation once it is accepted or revoked
+func (p *provider) DeleteOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) error {
+	organizationInvitationCollection := p.db.Collection(models.Collections.OrganizationInvitation, options.Collection())
+	_, err := organizationInvitationCollection.DeleteOne(ctx, bson.M{"_id": invitation.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// paginate returns the find options & query to list the page of items ordered by sort field & _id.
+// Items after the cursor are listed if pagination has after cursor, else the items from offset.
+// It fetches limit + 1 items, the extra item is used to know if there is next page
+func paginate(query bson.M, pagination model.Pagination, sortBy string, ascending bool) (*options.FindOptions, bson.M, error) {
+	order, operator := -1, "$lt"
+	if ascending {
+		order, operator = 1, "$gt"
+	}
+	opts := options.Find()
+	opts.SetLimit(pagination.Limit + 1)
+	opts.SetSort(bson.D{{Key: sortBy, Value: order}, {Key: "_id", Value: order}})
+
+	if pagination.After == nil {
+		opts.SetSkip(pagination.Offset)
+		return opts, query, nil
+	}
+
+	cursor, err := models.DecodeCursor(*pagination.After)
+	if err != nil {
+		return nil, nil, err
+	}
+	return opts, bson.M{
+		"$and": bson.A{
+			query,
+			bson.M{
+				"$or": bson.A{
+					bson.M{sortBy: bson.M{operator: cursor.Value}},
+					bson.M{sortBy: cursor.Value, "_id": bson.M{operator: cursor.ID}},
+				},
+			},
+		},
+	}, nil
+}
+
+// countTotal sets the total of pagination to the number of documents in collection matching the query.
+// Documents are only counted for the pages listed by offset, the pages after cursor are listed without counting all the documents
+func countTotal(ctx context.Context, collection *mongo.Collection, query interface{}, pagination *model.Pagination) error {
+	if pagination.After != nil {
+		return nil
+	}
+	count, err := collection.CountDocuments(ctx, query, options.Count())
+	if err != nil {
+		return err
+	}
+	pagination.Total = count
+	return nil
+}
@@ -56,6 +56,12 @@ func NewProvider() (*provider, error) {
 			}),
 		},
 	}, options.CreateIndexes())
+	// users are listed by the creation time
+	userCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.M{"created_at": 1},
+		},
+	}, options.CreateIndexes())
 
 	mongodb.CreateCollection(ctx, models.Collections.VerificationRequest, options.CreateCollection())
 	verificationRequestCollection := mongodb.Collection(models.Collections.VerificationRequest, options.Collection())
@@ -110,6 +116,71 @@ func NewProvider() (*provider, error) {
 		},
 	}, options.CreateIndexes())
 
+	mongodb.CreateCollection(ctx, models.Collections.WebauthnCredential, options.CreateCollection())
+	webauthnCredentialCollection := mongodb.Collection(models.Collections.WebauthnCredential, options.Collection())
+	webauthnCredentialCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"credential_id": 1},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+	}, options.CreateIndexes())
+	webauthnCredentialCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"user_id": 1},
+			Options: options.Index().SetSparse(true),
+		},
+	}, options.CreateIndexes())
+
+	mongodb.CreateCollection(ctx, models.Collections.Client, options.CreateCollection())
+
+	mongodb.CreateCollection(ctx, models.Collections.Grant, options.CreateCollection())
+	grantCollection := mongodb.Collection(models.Collections.Grant, options.Collection())
+	grantCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "client_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+	}, options.CreateIndexes())
+	grantCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"client_id": 1},
+			Options: options.Index().SetSparse(true),
+		},
+	}, options.CreateIndexes())
+
+	mongodb.CreateCollection(ctx, models.Collections.Organization, options.CreateCollection())
+	organizationCollection := mongodb.Collection(models.Collections.Organization, options.Collection())
+	organizationCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"name": 1},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+	}, options.CreateIndexes())
+
+	mongodb.CreateCollection(ctx, models.Collections.OrganizationMember, options.CreateCollection())
+	organizationMemberCollection := mongodb.Collection(models.Collections.OrganizationMember, options.Collection())
+	organizationMemberCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "organization_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+	}, options.CreateIndexes())
+	organizationMemberCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"user_id": 1},
+			Options: options.Index().SetSparse(true),
+		},
+	}, options.CreateIndexes())
+
+	mongodb.CreateCollection(ctx, models.Collections.OrganizationInvitation, options.CreateCollection())
+	organizationInvitationCollection := mongodb.Collection(models.Collections.OrganizationInvitation, options.Collection())
+	organizationInvitationCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "organization_id", Value: 1}, {Key: "email", Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+	}, options.CreateIndexes())
+
 	return &provider{
 		db: mongodb,
 	}, nil
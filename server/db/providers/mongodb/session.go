@@ -6,6 +6,7 @@ import (
 
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -25,3 +26,38 @@ func (p *provider) AddSession(ctx context.Context, session models.Session) error
 	}
 	return nil
 }
+
+// ListSessionsByUserID to get all the sessions information of user
+func (p *provider) ListSessionsByUserID(ctx context.Context, userID string) ([]models.Session, error) {
+	sessions := []models.Session{}
+	opts := options.Find()
+	opts.SetSort(bson.M{"created_at": -1})
+	sessionCollection := p.db.Collection(models.Collections.Session, options.Collection())
+	cursor, err := sessionCollection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var session models.Session
+		err := cursor.Decode(&session)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+// DeleteSession to delete session information from database
+func (p *provider) DeleteSession(ctx context.Context, session models.Session) error {
+	sessionCollection := p.db.Collection(models.Collections.Session, options.Collection())
+	_, err := sessionCollection.DeleteOne(ctx, bson.M{"_id": session.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
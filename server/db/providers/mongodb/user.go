@@ -2,14 +2,17 @@ package mongodb
 
 import (
 	"context"
+	"regexp"
 	"time"
 
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -63,40 +66,105 @@ func (p *provider) DeleteUser(ctx context.Context, user models.User) error {
 		return err
 	}
 
+	webauthnCredentialCollection := p.db.Collection(models.Collections.WebauthnCredential, options.Collection())
+	_, err = webauthnCredentialCollection.DeleteMany(ctx, bson.M{"user_id": user.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+
+	grantCollection := p.db.Collection(models.Collections.Grant, options.Collection())
+	_, err = grantCollection.DeleteMany(ctx, bson.M{"user_id": user.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+
+	organizationMemberCollection := p.db.Collection(models.Collections.OrganizationMember, options.Collection())
+	_, err = organizationMemberCollection.DeleteMany(ctx, bson.M{"user_id": user.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
-// ListUsers to get list of users from database
-func (p *provider) ListUsers(ctx context.Context, pagination model.Pagination) (*model.Users, error) {
-	var users []*model.User
-	opts := options.Find()
-	opts.SetLimit(pagination.Limit)
-	opts.SetSkip(pagination.Offset)
-	opts.SetSort(bson.M{"created_at": -1})
+// filterUsers returns the mongo filter of users matching the filter
+func filterUsers(filter models.UserFilter) bson.M {
+	query := bson.M{}
+	if filter.Email != "" {
+		query["email"] = primitive.Regex{Pattern: regexp.QuoteMeta(filter.Email), Options: "i"}
+	}
+	// roles & signup methods are comma separated
+	if filter.Role != "" {
+		query["roles"] = primitive.Regex{Pattern: "(^|,)" + regexp.QuoteMeta(filter.Role) + "(,|$)"}
+	}
+	if filter.SignupMethod != "" {
+		query["signup_methods"] = primitive.Regex{Pattern: "(^|,)" + regexp.QuoteMeta(filter.SignupMethod) + "(,|$)"}
+	}
+	isSet := func(value bool) bson.M {
+		if value {
+			return bson.M{"$ne": nil}
+		}
+		return bson.M{"$eq": nil}
+	}
+	if filter.IsEmailVerified != nil {
+		query["email_verified_at"] = isSet(*filter.IsEmailVerified)
+	}
+	if filter.IsPhoneVerified != nil {
+		query["phone_number_verified_at"] = isSet(*filter.IsPhoneVerified)
+	}
+	if filter.IsRevoked != nil {
+		query["revoked_timestamp"] = isSet(*filter.IsRevoked)
+	}
+	createdAt := bson.M{}
+	if filter.CreatedAfter != nil {
+		createdAt["$gte"] = *filter.CreatedAfter
+	}
+	if filter.CreatedBefore != nil {
+		createdAt["$lte"] = *filter.CreatedBefore
+	}
+	if len(createdAt) > 0 {
+		query["created_at"] = createdAt
+	}
+	return query
+}
 
+// ListUsers to get list of users matching the filter from database
+func (p *provider) ListUsers(ctx context.Context, pagination model.Pagination, filter models.UserFilter) (*model.Users, error) {
+	var users []*model.User
 	paginationClone := pagination
+	query := filterUsers(filter)
+	// sort field & order are validated by the filter
+	opts, paginatedQuery, err := paginate(query, pagination, filter.GetSortBy(), filter.IsAscending())
+	if err != nil {
+		return nil, err
+	}
 
 	userCollection := p.db.Collection(models.Collections.User, options.Collection())
-	count, err := userCollection.CountDocuments(ctx, bson.M{}, options.Count())
+	err = countTotal(ctx, userCollection, query, &paginationClone)
 	if err != nil {
 		return nil, err
 	}
 
-	paginationClone.Total = count
-
-	cursor, err := userCollection.Find(ctx, bson.M{}, opts)
+	cursor, err := userCollection.Find(ctx, paginatedQuery, opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
 	for cursor.Next(ctx) {
+		// extra item is fetched to know if there is next page
+		if int64(len(users)) == pagination.Limit {
+			paginationClone.HasNextPage = true
+			break
+		}
+
 		var user models.User
 		err := cursor.Decode(&user)
 		if err != nil {
 			return nil, err
 		}
 		users = append(users, user.AsAPIUser())
+		paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(filter.SortValue(&user), user.ID))
 	}
 
 	return &model.Users{
@@ -117,6 +185,18 @@ func (p *provider) GetUserByEmail(ctx context.Context, email string) (models.Use
 	return user, nil
 }
 
+// GetUserByPhoneNumber to get user information from database using phone number
+func (p *provider) GetUserByPhoneNumber(ctx context.Context, phoneNumber string) (models.User, error) {
+	var user models.User
+	userCollection := p.db.Collection(models.Collections.User, options.Collection())
+	err := userCollection.FindOne(ctx, bson.M{"phone_number": phoneNumber}).Decode(&user)
+	if err != nil {
+		return user, err
+	}
+
+	return user, nil
+}
+
 // GetUserByID to get user information from database using user ID
 func (p *provider) GetUserByID(ctx context.Context, id string) (models.User, error) {
 	var user models.User
@@ -6,6 +6,7 @@ import (
 
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -59,30 +60,39 @@ func (p *provider) GetVerificationRequestByEmail(ctx context.Context, email stri
 func (p *provider) ListVerificationRequests(ctx context.Context, pagination model.Pagination) (*model.VerificationRequests, error) {
 	var verificationRequests []*model.VerificationRequest
 
-	opts := options.Find()
-	opts.SetLimit(pagination.Limit)
-	opts.SetSkip(pagination.Offset)
-	opts.SetSort(bson.M{"created_at": -1})
+	opts, query, err := paginate(bson.M{}, pagination, "created_at", false)
+	if err != nil {
+		return nil, err
+	}
 
 	verificationRequestCollection := p.db.Collection(models.Collections.VerificationRequest, options.Collection())
 
-	verificationRequestCollectionCount, err := verificationRequestCollection.CountDocuments(ctx, bson.M{})
 	paginationClone := pagination
-	paginationClone.Total = verificationRequestCollectionCount
+	err = countTotal(ctx, verificationRequestCollection, bson.M{}, &paginationClone)
+	if err != nil {
+		return nil, err
+	}
 
-	cursor, err := verificationRequestCollection.Find(ctx, bson.M{}, opts)
+	cursor, err := verificationRequestCollection.Find(ctx, query, opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
 	for cursor.Next(ctx) {
+		// extra item is fetched to know if there is next page
+		if int64(len(verificationRequests)) == pagination.Limit {
+			paginationClone.HasNextPage = true
+			break
+		}
+
 		var verificationRequest models.VerificationRequest
 		err := cursor.Decode(&verificationRequest)
 		if err != nil {
 			return nil, err
 		}
 		verificationRequests = append(verificationRequests, verificationRequest.AsAPIVerificationRequest())
+		paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(verificationRequest.CreatedAt, verificationRequest.ID))
 	}
 
 	return &model.VerificationRequests{
@@ -0,0 +1,77 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddWebauthnCredential to save webauthn credential information in database
+func (p *provider) AddWebauthnCredential(ctx context.Context, credential models.WebauthnCredential) (models.WebauthnCredential, error) {
+	if credential.ID == "" {
+		credential.ID = uuid.New().String()
+	}
+
+	credential.Key = credential.ID
+	credential.CreatedAt = time.Now().Unix()
+	credential.UpdatedAt = time.Now().Unix()
+	credentialCollection := p.db.Collection(models.Collections.WebauthnCredential, options.Collection())
+	_, err := credentialCollection.InsertOne(ctx, credential)
+	if err != nil {
+		return credential, err
+	}
+
+	return credential, nil
+}
+
+// UpdateWebauthnCredential to update webauthn credential information in database
+func (p *provider) UpdateWebauthnCredential(ctx context.Context, credential models.WebauthnCredential) (models.WebauthnCredential, error) {
+	credential.UpdatedAt = time.Now().Unix()
+	credentialCollection := p.db.Collection(models.Collections.WebauthnCredential, options.Collection())
+	_, err := credentialCollection.UpdateOne(ctx, bson.M{"_id": bson.M{"$eq": credential.ID}}, bson.M{"$set": credential}, options.MergeUpdateOptions())
+	if err != nil {
+		return credential, err
+	}
+
+	return credential, nil
+}
+
+// GetWebauthnCredentialByCredentialID to get webauthn credential using credential id generated by authenticator
+func (p *provider) GetWebauthnCredentialByCredentialID(ctx context.Context, credentialID string) (models.WebauthnCredential, error) {
+	var credential models.WebauthnCredential
+	credentialCollection := p.db.Collection(models.Collections.WebauthnCredential, options.Collection())
+	err := credentialCollection.FindOne(ctx, bson.M{"credential_id": credentialID}).Decode(&credential)
+	if err != nil {
+		return credential, err
+	}
+
+	return credential, nil
+}
+
+// ListWebauthnCredentialsByUserID to get all the webauthn credentials registered by user
+func (p *provider) ListWebauthnCredentialsByUserID(ctx context.Context, userID string) ([]models.WebauthnCredential, error) {
+	credentials := []models.WebauthnCredential{}
+	opts := options.Find()
+	opts.SetSort(bson.M{"created_at": -1})
+	credentialCollection := p.db.Collection(models.Collections.WebauthnCredential, options.Collection())
+	cursor, err := credentialCollection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var credential models.WebauthnCredential
+		err := cursor.Decode(&credential)
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, credential)
+	}
+
+	return credentials, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -44,34 +45,39 @@ func (p *provider) UpdateWebhook(ctx context.Context, webhook models.Webhook) (*
 // ListWebhooks to list webhook
 func (p *provider) ListWebhook(ctx context.Context, pagination model.Pagination) (*model.Webhooks, error) {
 	var webhooks []*model.Webhook
-	opts := options.Find()
-	opts.SetLimit(pagination.Limit)
-	opts.SetSkip(pagination.Offset)
-	opts.SetSort(bson.M{"created_at": -1})
+	opts, query, err := paginate(bson.M{}, pagination, "created_at", false)
+	if err != nil {
+		return nil, err
+	}
 
 	paginationClone := pagination
 
 	webhookCollection := p.db.Collection(models.Collections.Webhook, options.Collection())
-	count, err := webhookCollection.CountDocuments(ctx, bson.M{}, options.Count())
+	err = countTotal(ctx, webhookCollection, bson.M{}, &paginationClone)
 	if err != nil {
 		return nil, err
 	}
 
-	paginationClone.Total = count
-
-	cursor, err := webhookCollection.Find(ctx, bson.M{}, opts)
+	cursor, err := webhookCollection.Find(ctx, query, opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
 	for cursor.Next(ctx) {
+		// extra item is fetched to know if there is next page
+		if int64(len(webhooks)) == pagination.Limit {
+			paginationClone.HasNextPage = true
+			break
+		}
+
 		var webhook models.Webhook
 		err := cursor.Decode(&webhook)
 		if err != nil {
 			return nil, err
 		}
 		webhooks = append(webhooks, webhook.AsAPIWebhook())
+		paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(webhook.CreatedAt, webhook.ID))
 	}
 
 	return &model.Webhooks{
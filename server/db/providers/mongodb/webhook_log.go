@@ -6,6 +6,7 @@ import (
 
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -32,10 +33,6 @@ func (p *provider) AddWebhookLog(ctx context.Context, webhookLog models.WebhookL
 // ListWebhookLogs to list webhook logs
 func (p *provider) ListWebhookLogs(ctx context.Context, pagination model.Pagination, webhookID string) (*model.WebhookLogs, error) {
 	webhookLogs := []*model.WebhookLog{}
-	opts := options.Find()
-	opts.SetLimit(pagination.Limit)
-	opts.SetSkip(pagination.Offset)
-	opts.SetSort(bson.M{"created_at": -1})
 
 	paginationClone := pagination
 	query := bson.M{}
@@ -44,27 +41,37 @@ func (p *provider) ListWebhookLogs(ctx context.Context, pagination model.Paginat
 		query = bson.M{"webhook_id": webhookID}
 	}
 
-	webhookLogCollection := p.db.Collection(models.Collections.WebhookLog, options.Collection())
-	count, err := webhookLogCollection.CountDocuments(ctx, query, options.Count())
+	opts, paginatedQuery, err := paginate(query, pagination, "created_at", false)
 	if err != nil {
 		return nil, err
 	}
 
-	paginationClone.Total = count
+	webhookLogCollection := p.db.Collection(models.Collections.WebhookLog, options.Collection())
+	err = countTotal(ctx, webhookLogCollection, query, &paginationClone)
+	if err != nil {
+		return nil, err
+	}
 
-	cursor, err := webhookLogCollection.Find(ctx, query, opts)
+	cursor, err := webhookLogCollection.Find(ctx, paginatedQuery, opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
 	for cursor.Next(ctx) {
+		// extra item is fetched to know if there is next page
+		if int64(len(webhookLogs)) == pagination.Limit {
+			paginationClone.HasNextPage = true
+			break
+		}
+
 		var webhookLog models.WebhookLog
 		err := cursor.Decode(&webhookLog)
 		if err != nil {
 			return nil, err
 		}
 		webhookLogs = append(webhookLogs, webhookLog.AsAPIWebhookLog())
+		paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(webhookLog.CreatedAt, webhookLog.ID))
 	}
 
 	return &model.WebhookLogs{
@@ -0,0 +1,44 @@
+package provider_template
+
+import (
+	"context"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/google/uuid"
+)
+
+// AddClient to register oauth client
+func (p *provider) AddClient(ctx context.Context, client models.Client) (models.Client, error) {
+	if client.ID == "" {
+		client.ID = uuid.New().String()
+	}
+
+	client.Key = client.ID
+	client.CreatedAt = time.Now().Unix()
+	client.UpdatedAt = time.Now().Unix()
+	return client, nil
+}
+
+// UpdateClient to update oauth client
+func (p *provider) UpdateClient(ctx context.Context, client models.Client) (models.Client, error) {
+	client.UpdatedAt = time.Now().Unix()
+	return client, nil
+}
+
+// ListClients to list oauth clients
+func (p *provider) ListClients(ctx context.Context, pagination model.Pagination) (*model.Clients, error) {
+	return nil, nil
+}
+
+// GetClientByID to get oauth client by client_id
+func (p *provider) GetClientByID(ctx context.Context, clientID string) (models.Client, error) {
+	var client models.Client
+	return client, nil
+}
+
+// DeleteClient to delete oauth client
+func (p *provider) DeleteClient(ctx context.Context, client models.Client) error {
+	return nil
+}
@@ -0,0 +1,43 @@
+package provider_template
+
+import (
+	"context"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/google/uuid"
+)
+
+// AddGrant to save the scopes granted by user to oauth client
+func (p *provider) AddGrant(ctx context.Context, grant models.Grant) (models.Grant, error) {
+	if grant.ID == "" {
+		grant.ID = uuid.New().String()
+	}
+
+	grant.Key = grant.ID
+	grant.CreatedAt = time.Now().Unix()
+	grant.UpdatedAt = time.Now().Unix()
+	return grant, nil
+}
+
+// UpdateGrant to update the scopes granted by user to oauth client
+func (p *provider) UpdateGrant(ctx context.Context, grant models.Grant) (models.Grant, error) {
+	grant.UpdatedAt = time.Now().Unix()
+	return grant, nil
+}
+
+// GetGrantByUserIDAndClientID to get the scopes granted by user to oauth client
+func (p *provider) GetGrantByUserIDAndClientID(ctx context.Context, userID, clientID string) (models.Grant, error) {
+	var grant models.Grant
+	return grant, nil
+}
+
+// ListGrantsByUserID to get all the oauth clients granted access by user
+func (p *provider) ListGrantsByUserID(ctx context.Context, userID string) ([]models.Grant, error) {
+	return nil, nil
+}
+
+// DeleteGrant to revoke the access granted by user to oauth client
+func (p *provider) DeleteGrant(ctx context.Context, grant models.Grant) error {
+	return nil
+}
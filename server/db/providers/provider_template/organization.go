@@ -0,0 +1,123 @@
+package provider_template
+
+import (
+	"context"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/google/uuid"
+)
+
+// AddOrganization to add organization
+func (p *provider) AddOrganization(ctx context.Context, organization models.Organization) (models.Organization, error) {
+	if organization.ID == "" {
+		organization.ID = uuid.New().String()
+	}
+
+	organization.Key = organization.ID
+	organization.CreatedAt = time.Now().Unix()
+	organization.UpdatedAt = time.Now().Unix()
+	return organization, nil
+}
+
+// UpdateOrganization to update organization
+func (p *provider) UpdateOrganization(ctx context.Context, organization models.Organization) (models.Organization, error) {
+	organization.UpdatedAt = time.Now().Unix()
+	return organization, nil
+}
+
+// DeleteOrganization to delete organization along with its members & invitations
+func (p *provider) DeleteOrganization(ctx context.Context, organization models.Organization) error {
+	return nil
+}
+
+// GetOrganizationByID to get organization by id
+func (p *provider) GetOrganizationByID(ctx context.Context, organizationID string) (models.Organization, error) {
+	var organization models.Organization
+	return organization, nil
+}
+
+// GetOrganizationByName to get organization by name
+func (p *provider) GetOrganizationByName(ctx context.Context, name string) (models.Organization, error) {
+	var organization models.Organization
+	return organization, nil
+}
+
+// ListOrganizations to list organizations
+func (p *provider) ListOrganizations(ctx context.Context, pagination model.Pagination) (*model.Organizations, error) {
+	return nil, nil
+}
+
+// AddOrganizationMember to add user as the member of organization
+func (p *provider) AddOrganizationMember(ctx context.Context, member models.OrganizationMember) (models.OrganizationMember, error) {
+	if member.ID == "" {
+		member.ID = uuid.New().String()
+	}
+
+	member.Key = member.ID
+	member.CreatedAt = time.Now().Unix()
+	member.UpdatedAt = time.Now().Unix()
+	return member, nil
+}
+
+// UpdateOrganizationMember to update the roles of member in organization
+func (p *provider) UpdateOrganizationMember(ctx context.Context, member models.OrganizationMember) (models.OrganizationMember, error) {
+	member.UpdatedAt = time.Now().Unix()
+	return member, nil
+}
+
+// DeleteOrganizationMember to remove user from organization
+func (p *provider) DeleteOrganizationMember(ctx context.Context, member models.OrganizationMember) error {
+	return nil
+}
+
+// GetOrganizationMember to get the membership of user in organization
+func (p *provider) GetOrganizationMember(ctx context.Context, organizationID, userID string) (models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	return member, nil
+}
+
+// ListOrganizationMembers to list the members of organization
+func (p *provider) ListOrganizationMembers(ctx context.Context, pagination model.Pagination, organizationID string) (*model.OrganizationMembers, error) {
+	return nil, nil
+}
+
+// ListOrganizationMembersByUserID to get all the organizations memberships of user
+func (p *provider) ListOrganizationMembersByUserID(ctx context.Context, userID string) ([]models.OrganizationMember, error) {
+	return nil, nil
+}
+
+// AddOrganizationInvitation to invite email to organization
+func (p *provider) AddOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) (models.OrganizationInvitation, error) {
+	if invitation.ID == "" {
+		invitation.ID = uuid.New().String()
+	}
+
+	invitation.Key = invitation.ID
+	invitation.CreatedAt = time.Now().Unix()
+	invitation.UpdatedAt = time.Now().Unix()
+	return invitation, nil
+}
+
+// UpdateOrganizationInvitation to update the roles & expiry of invitation
+func (p *provider) UpdateOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) (models.OrganizationInvitation, error) {
+	invitation.UpdatedAt = time.Now().Unix()
+	return invitation, nil
+}
+
+// GetOrganizationInvitation to get the invitation of email to organization
+func (p *provider) GetOrganizationInvitation(ctx context.Context, organizationID, email string) (models.OrganizationInvitation, error) {
+	var invitation models.OrganizationInvitation
+	return invitation, nil
+}
+
+// ListOrganizationInvitations to list the pending invitations of organization
+func (p *provider) ListOrganizationInvitations(ctx context.Context, organizationID string) ([]models.OrganizationInvitation, error) {
+	return nil, nil
+}
+
+// DeleteOrganizationInvitation to delete invitation once it is accepted or revoked
+func (p *provider) DeleteOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) error {
+	return nil
+}
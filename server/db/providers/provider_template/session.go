@@ -19,7 +19,12 @@ func (p *provider) AddSession(ctx context.Context, session models.Session) error
 	return nil
 }
 
+// ListSessionsByUserID to get all the sessions information of user
+func (p *provider) ListSessionsByUserID(ctx context.Context, userID string) ([]models.Session, error) {
+	return nil, nil
+}
+
 // DeleteSession to delete session information from database
-func (p *provider) DeleteSession(ctx context.Context, userId string) error {
+func (p *provider) DeleteSession(ctx context.Context, session models.Session) error {
 	return nil
 }
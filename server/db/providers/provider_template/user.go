@@ -42,8 +42,8 @@ func (p *provider) DeleteUser(ctx context.Context, user models.User) error {
 	return nil
 }
 
-// ListUsers to get list of users from database
-func (p *provider) ListUsers(ctx context.Context, pagination model.Pagination) (*model.Users, error) {
+// ListUsers to get list of users matching the filter from database
+func (p *provider) ListUsers(ctx context.Context, pagination model.Pagination, filter models.UserFilter) (*model.Users, error) {
 	return nil, nil
 }
 
@@ -54,6 +54,13 @@ func (p *provider) GetUserByEmail(ctx context.Context, email string) (models.Use
 	return user, nil
 }
 
+// GetUserByPhoneNumber to get user information from database using phone number
+func (p *provider) GetUserByPhoneNumber(ctx context.Context, phoneNumber string) (models.User, error) {
+	var user models.User
+
+	return user, nil
+}
+
 // GetUserByID to get user information from database using user ID
 func (p *provider) GetUserByID(ctx context.Context, id string) (models.User, error) {
 	var user models.User
@@ -0,0 +1,38 @@
+package provider_template
+
+import (
+	"context"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/google/uuid"
+)
+
+// AddWebauthnCredential to save webauthn credential information in database
+func (p *provider) AddWebauthnCredential(ctx context.Context, credential models.WebauthnCredential) (models.WebauthnCredential, error) {
+	if credential.ID == "" {
+		credential.ID = uuid.New().String()
+	}
+
+	credential.Key = credential.ID
+	credential.CreatedAt = time.Now().Unix()
+	credential.UpdatedAt = time.Now().Unix()
+	return credential, nil
+}
+
+// UpdateWebauthnCredential to update webauthn credential information in database
+func (p *provider) UpdateWebauthnCredential(ctx context.Context, credential models.WebauthnCredential) (models.WebauthnCredential, error) {
+	credential.UpdatedAt = time.Now().Unix()
+	return credential, nil
+}
+
+// GetWebauthnCredentialByCredentialID to get webauthn credential using credential id generated by authenticator
+func (p *provider) GetWebauthnCredentialByCredentialID(ctx context.Context, credentialID string) (models.WebauthnCredential, error) {
+	var credential models.WebauthnCredential
+	return credential, nil
+}
+
+// ListWebauthnCredentialsByUserID to get all the webauthn credentials registered by user
+func (p *provider) ListWebauthnCredentialsByUserID(ctx context.Context, userID string) ([]models.WebauthnCredential, error) {
+	return nil, nil
+}
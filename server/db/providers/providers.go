@@ -14,10 +14,12 @@ type Provider interface {
 	UpdateUser(ctx context.Context, user models.User) (models.User, error)
 	// DeleteUser to delete user information from database
 	DeleteUser(ctx context.Context, user models.User) error
-	// ListUsers to get list of users from database
-	ListUsers(ctx context.Context, pagination model.Pagination) (*model.Users, error)
+	// ListUsers to get list of users matching the filter from database
+	ListUsers(ctx context.Context, pagination model.Pagination, filter models.UserFilter) (*model.Users, error)
 	// GetUserByEmail to get user information from database using email address
 	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+	// GetUserByPhoneNumber to get user information from database using phone number
+	GetUserByPhoneNumber(ctx context.Context, phoneNumber string) (models.User, error)
 	// GetUserByID to get user information from database using user ID
 	GetUserByID(ctx context.Context, id string) (models.User, error)
 
@@ -34,6 +36,10 @@ type Provider interface {
 
 	// AddSession to save session information in database
 	AddSession(ctx context.Context, session models.Session) error
+	// ListSessionsByUserID to get all the sessions information of user
+	ListSessionsByUserID(ctx context.Context, userID string) ([]models.Session, error)
+	// DeleteSession to delete session information from database
+	DeleteSession(ctx context.Context, session models.Session) error
 
 	// AddEnv to save environment information in database
 	AddEnv(ctx context.Context, env models.Env) (models.Env, error)
@@ -72,4 +78,72 @@ type Provider interface {
 	GetEmailTemplateByEventName(ctx context.Context, eventName string) (*model.EmailTemplate, error)
 	// DeleteEmailTemplate to delete EmailTemplate
 	DeleteEmailTemplate(ctx context.Context, emailTemplate *model.EmailTemplate) error
+
+	// AddWebauthnCredential to save webauthn credential information in database
+	AddWebauthnCredential(ctx context.Context, credential models.WebauthnCredential) (models.WebauthnCredential, error)
+	// UpdateWebauthnCredential to update webauthn credential information in database
+	UpdateWebauthnCredential(ctx context.Context, credential models.WebauthnCredential) (models.WebauthnCredential, error)
+	// GetWebauthnCredentialByCredentialID to get webauthn credential using credential id generated by authenticator
+	GetWebauthnCredentialByCredentialID(ctx context.Context, credentialID string) (models.WebauthnCredential, error)
+	// ListWebauthnCredentialsByUserID to get all the webauthn credentials registered by user
+	ListWebauthnCredentialsByUserID(ctx context.Context, userID string) ([]models.WebauthnCredential, error)
+
+	// AddClient to register oauth client
+	AddClient(ctx context.Context, client models.Client) (models.Client, error)
+	// UpdateClient to update oauth client
+	UpdateClient(ctx context.Context, client models.Client) (models.Client, error)
+	// ListClients to list oauth clients
+	ListClients(ctx context.Context, pagination model.Pagination) (*model.Clients, error)
+	// GetClientByID to get oauth client by client_id
+	GetClientByID(ctx context.Context, clientID string) (models.Client, error)
+	// DeleteClient to delete oauth client
+	DeleteClient(ctx context.Context, client models.Client) error
+
+	// AddGrant to save the scopes granted by user to oauth client
+	AddGrant(ctx context.Context, grant models.Grant) (models.Grant, error)
+	// UpdateGrant to update the scopes granted by user to oauth client
+	UpdateGrant(ctx context.Context, grant models.Grant) (models.Grant, error)
+	// GetGrantByUserIDAndClientID to get the scopes granted by user to oauth client
+	GetGrantByUserIDAndClientID(ctx context.Context, userID, clientID string) (models.Grant, error)
+	// ListGrantsByUserID to get all the oauth clients granted access by user
+	ListGrantsByUserID(ctx context.Context, userID string) ([]models.Grant, error)
+	// DeleteGrant to revoke the access granted by user to oauth client
+	DeleteGrant(ctx context.Context, grant models.Grant) error
+
+	// AddOrganization to add organization
+	AddOrganization(ctx context.Context, organization models.Organization) (models.Organization, error)
+	// UpdateOrganization to update organization
+	UpdateOrganization(ctx context.Context, organization models.Organization) (models.Organization, error)
+	// DeleteOrganization to delete organization along with its members & invitations
+	DeleteOrganization(ctx context.Context, organization models.Organization) error
+	// GetOrganizationByID to get organization by id
+	GetOrganizationByID(ctx context.Context, organizationID string) (models.Organization, error)
+	// GetOrganizationByName to get organization by name
+	GetOrganizationByName(ctx context.Context, name string) (models.Organization, error)
+	// ListOrganizations to list organizations
+	ListOrganizations(ctx context.Context, pagination model.Pagination) (*model.Organizations, error)
+
+	// AddOrganizationMember to add user as the member of organization
+	AddOrganizationMember(ctx context.Context, member models.OrganizationMember) (models.OrganizationMember, error)
+	// UpdateOrganizationMember to update the roles of member in organization
+	UpdateOrganizationMember(ctx context.Context, member models.OrganizationMember) (models.OrganizationMember, error)
+	// DeleteOrganizationMember to remove user from organization
+	DeleteOrganizationMember(ctx context.Context, member models.OrganizationMember) error
+	// GetOrganizationMember to get the membership of user in organization
+	GetOrganizationMember(ctx context.Context, organizationID, userID string) (models.OrganizationMember, error)
+	// ListOrganizationMembers to list the members of organization
+	ListOrganizationMembers(ctx context.Context, pagination model.Pagination, organizationID string) (*model.OrganizationMembers, error)
+	// ListOrganizationMembersByUserID to get all the organizations memberships of user
+	ListOrganizationMembersByUserID(ctx context.Context, userID string) ([]models.OrganizationMember, error)
+
+	// AddOrganizationInvitation to invite email to organization
+	AddOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) (models.OrganizationInvitation, error)
+	// UpdateOrganizationInvitation to update the roles & expiry of invitation
+	UpdateOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) (models.OrganizationInvitation, error)
+	// GetOrganizationInvitation to get the invitation of email to organization
+	GetOrganizationInvitation(ctx context.Context, organizationID, email string) (models.OrganizationInvitation, error)
+	// ListOrganizationInvitations to list the pending invitations of organization
+	ListOrganizationInvitations(ctx context.Context, organizationID string) ([]models.OrganizationInvitation, error)
+	// DeleteOrganizationInvitation to delete invitation once it is accepted or revoked
+	DeleteOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) error
 }
@@ -0,0 +1,93 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/google/uuid"
+)
+
+// AddClient to register oauth client
+func (p *provider) AddClient(ctx context.Context, client models.Client) (models.Client, error) {
+	if client.ID == "" {
+		client.ID = uuid.New().String()
+	}
+
+	client.Key = client.ID
+	client.CreatedAt = time.Now().Unix()
+	client.UpdatedAt = time.Now().Unix()
+	result := p.db.Create(&client)
+	if result.Error != nil {
+		return client, result.Error
+	}
+
+	return client, nil
+}
+
+// UpdateClient to update oauth client
+func (p *provider) UpdateClient(ctx context.Context, client models.Client) (models.Client, error) {
+	client.UpdatedAt = time.Now().Unix()
+	result := p.db.Save(&client)
+	if result.Error != nil {
+		return client, result.Error
+	}
+
+	return client, nil
+}
+
+// ListClients to list oauth clients
+func (p *provider) ListClients(ctx context.Context, pagination model.Pagination) (*model.Clients, error) {
+	var clients []models.Client
+
+	result := p.db.Limit(int(pagination.Limit)).Offset(int(pagination.Offset)).Order("created_at DESC").Find(&clients)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	total, err := countTotal(p.db.Model(&models.Client{}), pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	paginationClone := pagination
+	paginationClone.Total = total
+
+	responseClients := []*model.Client{}
+	for _, c := range clients {
+		responseClients = append(responseClients, c.AsAPIClient())
+	}
+	return &model.Clients{
+		Pagination: &paginationClone,
+		Clients:    responseClients,
+	}, nil
+}
+
+// GetClientByID to get oauth client by client_id
+func (p *provider) GetClientByID(ctx context.Context, clientID string) (models.Client, error) {
+	var client models.Client
+	result := p.db.Where("id = ?", clientID).First(&client)
+	if result.Error != nil {
+		return client, result.Error
+	}
+
+	return client, nil
+}
+
+// DeleteClient to delete oauth client
+func (p *provider) DeleteClient(ctx context.Context, client models.Client) error {
+	result := p.db.Delete(&models.Client{
+		ID: client.ID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = p.db.Where("client_id = ?", client.ID).Delete(&models.Grant{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
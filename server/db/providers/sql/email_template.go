@@ -6,6 +6,7 @@ import (
 
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/google/uuid"
 )
 
@@ -41,23 +42,31 @@ func (p *provider) UpdateEmailTemplate(ctx context.Context, emailTemplate models
 func (p *provider) ListEmailTemplate(ctx context.Context, pagination model.Pagination) (*model.EmailTemplates, error) {
 	var emailTemplates []models.EmailTemplate
 
-	result := p.db.Limit(int(pagination.Limit)).Offset(int(pagination.Offset)).Order("created_at DESC").Find(&emailTemplates)
+	query, err := paginate(p.db, pagination, "created_at", false)
+	if err != nil {
+		return nil, err
+	}
+	result := query.Find(&emailTemplates)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	var total int64
-	totalRes := p.db.Model(&models.EmailTemplate{}).Count(&total)
-	if totalRes.Error != nil {
-		return nil, totalRes.Error
+	total, err := countTotal(p.db.Model(&models.EmailTemplate{}), pagination)
+	if err != nil {
+		return nil, err
 	}
 
 	paginationClone := pagination
 	paginationClone.Total = total
+	if int64(len(emailTemplates)) > pagination.Limit {
+		paginationClone.HasNextPage = true
+		emailTemplates = emailTemplates[:pagination.Limit]
+	}
 
 	responseEmailTemplates := []*model.EmailTemplate{}
 	for _, w := range emailTemplates {
 		responseEmailTemplates = append(responseEmailTemplates, w.AsAPIEmailTemplate())
+		paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(w.CreatedAt, w.ID))
 	}
 	return &model.EmailTemplates{
 		Pagination:     &paginationClone,
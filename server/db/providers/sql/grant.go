@@ -0,0 +1,71 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/google/uuid"
+)
+
+// AddGrant to save the scopes granted by user to oauth client
+func (p *provider) AddGrant(ctx context.Context, grant models.Grant) (models.Grant, error) {
+	if grant.ID == "" {
+		grant.ID = uuid.New().String()
+	}
+
+	grant.Key = grant.ID
+	grant.CreatedAt = time.Now().Unix()
+	grant.UpdatedAt = time.Now().Unix()
+	result := p.db.Create(&grant)
+	if result.Error != nil {
+		return grant, result.Error
+	}
+
+	return grant, nil
+}
+
+// UpdateGrant to update the scopes granted by user to oauth client
+func (p *provider) UpdateGrant(ctx context.Context, grant models.Grant) (models.Grant, error) {
+	grant.UpdatedAt = time.Now().Unix()
+	result := p.db.Save(&grant)
+	if result.Error != nil {
+		return grant, result.Error
+	}
+
+	return grant, nil
+}
+
+// GetGrantByUserIDAndClientID to get the scopes granted by user to oauth client
+func (p *provider) GetGrantByUserIDAndClientID(ctx context.Context, userID, clientID string) (models.Grant, error) {
+	var grant models.Grant
+	result := p.db.Where("user_id = ? AND client_id = ?", userID, clientID).First(&grant)
+	if result.Error != nil {
+		return grant, result.Error
+	}
+
+	return grant, nil
+}
+
+// ListGrantsByUserID to get all the oauth clients granted access by user
+func (p *provider) ListGrantsByUserID(ctx context.Context, userID string) ([]models.Grant, error) {
+	var grants []models.Grant
+	result := p.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&grants)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return grants, nil
+}
+
+// DeleteGrant to revoke the access granted by user to oauth client
+func (p *provider) DeleteGrant(ctx context.Context, grant models.Grant) error {
+	result := p.db.Delete(&models.Grant{
+		ID: grant.ID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
@@ -0,0 +1,279 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/google/uuid"
+)
+
+// AddOrganization to add organization
+func (p *provider) AddOrganization(ctx context.Context, organization models.Organization) (models.Organization, error) {
+	if organization.ID == "" {
+		organization.ID = uuid.New().String()
+	}
+
+	organization.Key = organization.ID
+	organization.CreatedAt = time.Now().Unix()
+	organization.UpdatedAt = time.Now().Unix()
+	result := p.db.Create(&organization)
+	if result.Error != nil {
+		return organization, result.Error
+	}
+
+	return organization, nil
+}
+
+// UpdateOrganization to update organization
+func (p *provider) UpdateOrganization(ctx context.Context, organization models.Organization) (models.Organization, error) {
+	organization.UpdatedAt = time.Now().Unix()
+	result := p.db.Save(&organization)
+	if result.Error != nil {
+		return organization, result.Error
+	}
+
+	return organization, nil
+}
+
+// DeleteOrganization to delete organization along with its members & invitations
+func (p *provider) DeleteOrganization(ctx context.Context, organization models.Organization) error {
+	result := p.db.Delete(&models.Organization{
+		ID: organization.ID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = p.db.Where("organization_id = ?", organization.ID).Delete(&models.OrganizationMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = p.db.Where("organization_id = ?", organization.ID).Delete(&models.OrganizationInvitation{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetOrganizationByID to get organization by id
+func (p *provider) GetOrganizationByID(ctx context.Context, organizationID string) (models.Organization, error) {
+	var organization models.Organization
+	result := p.db.Where("id = ?", organizationID).First(&organization)
+	if result.Error != nil {
+		return organization, result.Error
+	}
+
+	return organization, nil
+}
+
+// GetOrganizationByName to get organization by name
+func (p *provider) GetOrganizationByName(ctx context.Context, name string) (models.Organization, error) {
+	var organization models.Organization
+	result := p.db.Where("name = ?", name).First(&organization)
+	if result.Error != nil {
+		return organization, result.Error
+	}
+
+	return organization, nil
+}
+
+// ListOrganizations to list organizations
+func (p *provider) ListOrganizations(ctx context.Context, pagination model.Pagination) (*model.Organizations, error) {
+	var organizations []models.Organization
+
+	query, err := paginate(p.db, pagination, "created_at", false)
+	if err != nil {
+		return nil, err
+	}
+	result := query.Find(&organizations)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	total, err := countTotal(p.db.Model(&models.Organization{}), pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	paginationClone := pagination
+	paginationClone.Total = total
+	if int64(len(organizations)) > pagination.Limit {
+		paginationClone.HasNextPage = true
+		organizations = organizations[:pagination.Limit]
+	}
+
+	responseOrganizations := []*model.Organization{}
+	for _, o := range organizations {
+		responseOrganizations = append(responseOrganizations, o.AsAPIOrganization())
+		paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(o.CreatedAt, o.ID))
+	}
+	return &model.Organizations{
+		Pagination:    &paginationClone,
+		Organizations: responseOrganizations,
+	}, nil
+}
+
+// AddOrganizationMember to add user as the member of organization
+func (p *provider) AddOrganizationMember(ctx context.Context, member models.OrganizationMember) (models.OrganizationMember, error) {
+	if member.ID == "" {
+		member.ID = uuid.New().String()
+	}
+
+	member.Key = member.ID
+	member.CreatedAt = time.Now().Unix()
+	member.UpdatedAt = time.Now().Unix()
+	result := p.db.Create(&member)
+	if result.Error != nil {
+		return member, result.Error
+	}
+
+	return member, nil
+}
+
+// UpdateOrganizationMember to update the roles of member in organization
+func (p *provider) UpdateOrganizationMember(ctx context.Context, member models.OrganizationMember) (models.OrganizationMember, error) {
+	member.UpdatedAt = time.Now().Unix()
+	result := p.db.Save(&member)
+	if result.Error != nil {
+		return member, result.Error
+	}
+
+	return member, nil
+}
+
+// DeleteOrganizationMember to remove user from organization
+func (p *provider) DeleteOrganizationMember(ctx context.Context, member models.OrganizationMember) error {
+	result := p.db.Delete(&models.OrganizationMember{
+		ID: member.ID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetOrganizationMember to get the membership of user in organization
+func (p *provider) GetOrganizationMember(ctx context.Context, organizationID, userID string) (models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	result := p.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).First(&member)
+	if result.Error != nil {
+		return member, result.Error
+	}
+
+	return member, nil
+}
+
+// ListOrganizationMembers to list the members of organization
+func (p *provider) ListOrganizationMembers(ctx context.Context, pagination model.Pagination, organizationID string) (*model.OrganizationMembers, error) {
+	var members []models.OrganizationMember
+
+	query, err := paginate(p.db.Where("organization_id = ?", organizationID), pagination, "created_at", false)
+	if err != nil {
+		return nil, err
+	}
+	result := query.Find(&members)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	total, err := countTotal(p.db.Model(&models.OrganizationMember{}).Where("organization_id = ?", organizationID), pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	paginationClone := pagination
+	paginationClone.Total = total
+	if int64(len(members)) > pagination.Limit {
+		paginationClone.HasNextPage = true
+		members = members[:pagination.Limit]
+	}
+
+	responseMembers := []*model.OrganizationMember{}
+	for _, m := range members {
+		responseMembers = append(responseMembers, m.AsAPIOrganizationMember())
+		paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(m.CreatedAt, m.ID))
+	}
+	return &model.OrganizationMembers{
+		Pagination: &paginationClone,
+		Members:    responseMembers,
+	}, nil
+}
+
+// ListOrganizationMembersByUserID to get all the organizations memberships of user
+func (p *provider) ListOrganizationMembersByUserID(ctx context.Context, userID string) ([]models.OrganizationMember, error) {
+	var members []models.OrganizationMember
+	result := p.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&members)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return members, nil
+}
+
+// AddOrganizationInvitation to invite email to organization
+func (p *provider) AddOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) (models.OrganizationInvitation, error) {
+	if invitation.ID == "" {
+		invitation.ID = uuid.New().String()
+	}
+
+	invitation.Key = invitation.ID
+	invitation.CreatedAt = time.Now().Unix()
+	invitation.UpdatedAt = time.Now().Unix()
+	result := p.db.Create(&invitation)
+	if result.Error != nil {
+		return invitation, result.Error
+	}
+
+	return invitation, nil
+}
+
+// UpdateOrganizationInvitation to update the roles & expiry of invitation
+func (p *provider) UpdateOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) (models.OrganizationInvitation, error) {
+	invitation.UpdatedAt = time.Now().Unix()
+	result := p.db.Save(&invitation)
+	if result.Error != nil {
+		return invitation, result.Error
+	}
+
+	return invitation, nil
+}
+
+// GetOrganizationInvitation to get the invitation of email to organization
+func (p *provider) GetOrganizationInvitation(ctx context.Context, organizationID, email string) (models.OrganizationInvitation, error) {
+	var invitation models.OrganizationInvitation
+	result := p.db.Where("organization_id = ? AND email = ?", organizationID, email).First(&invitation)
+	if result.Error != nil {
+		return invitation, result.Error
+	}
+
+	return invitation, nil
+}
+
+// ListOrganizationInvitations to list the pending invitations of organization
+func (p *provider) ListOrganizationInvitations(ctx context.Context, organizationID string) ([]models.OrganizationInvitation, error) {
+	var invitations []models.OrganizationInvitation
+	result := p.db.Where("organization_id = ?", organizationID).Order("created_at DESC").Find(&invitations)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return invitations, nil
+}
+
+// DeleteOrganizationInvitation to delete invitation once it is accepted or revoked
+func (p *provider) DeleteOrganizationInvitation(ctx context.Context, invitation models.OrganizationInvitation) error {
+	result := p.db.Delete(&models.OrganizationInvitation{
+		ID: invitation.ID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
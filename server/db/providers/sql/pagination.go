@@ -0,0 +1,41 @@
+package sql
+
+import (
+	"fmt"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"gorm.io/gorm"
+)
+
+// paginate orders the query by sort field & id and lists the page of items.
+// Items after the cursor are listed if pagination has after cursor, else the items from offset.
+// It fetches limit + 1 items, the extra item is used to know if there is next page
+func paginate(query *gorm.DB, pagination model.Pagination, sortBy string, ascending bool) (*gorm.DB, error) {
+	order, operator := "DESC", "<"
+	if ascending {
+		order, operator = "ASC", ">"
+	}
+	query = query.Order(sortBy + " " + order).Order("id " + order).Limit(int(pagination.Limit) + 1)
+
+	if pagination.After == nil {
+		return query.Offset(int(pagination.Offset)), nil
+	}
+
+	cursor, err := models.DecodeCursor(*pagination.After)
+	if err != nil {
+		return nil, err
+	}
+	return query.Where(fmt.Sprintf("(%s %s ? OR (%s = ? AND id %s ?))", sortBy, operator, sortBy, operator), cursor.Value, cursor.Value, cursor.ID), nil
+}
+
+// countTotal returns the number of items of query for the total of pagination.
+// Items are only counted for the pages listed by offset, the pages after cursor are listed without counting all the items
+func countTotal(query *gorm.DB, pagination model.Pagination) (int64, error) {
+	var total int64
+	if pagination.After != nil {
+		return total, nil
+	}
+	err := query.Count(&total).Error
+	return total, err
+}
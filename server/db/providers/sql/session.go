@@ -27,3 +27,26 @@ func (p *provider) AddSession(ctx context.Context, session models.Session) error
 	}
 	return nil
 }
+
+// ListSessionsByUserID to get all the sessions information of user
+func (p *provider) ListSessionsByUserID(ctx context.Context, userID string) ([]models.Session, error) {
+	var sessions []models.Session
+	result := p.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&sessions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return sessions, nil
+}
+
+// DeleteSession to delete session information from database
+func (p *provider) DeleteSession(ctx context.Context, session models.Session) error {
+	result := p.db.Delete(&models.Session{
+		ID: session.ID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
@@ -2,13 +2,16 @@ package sql
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
@@ -68,29 +71,97 @@ func (p *provider) DeleteUser(ctx context.Context, user models.User) error {
 		return result.Error
 	}
 
+	result = p.db.Where("user_id = ?", user.ID).Delete(&models.WebauthnCredential{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = p.db.Where("user_id = ?", user.ID).Delete(&models.Grant{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = p.db.Where("user_id = ?", user.ID).Delete(&models.OrganizationMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+
 	return nil
 }
 
-// ListUsers to get list of users from database
-func (p *provider) ListUsers(ctx context.Context, pagination model.Pagination) (*model.Users, error) {
+// filterUsers returns the query of users matching the filter
+func (p *provider) filterUsers(filter models.UserFilter) *gorm.DB {
+	query := p.db.Model(&models.User{})
+	if filter.Email != "" {
+		query = query.Where("LOWER(email) LIKE ?", "%"+strings.ToLower(filter.Email)+"%")
+	}
+	// roles & signup methods are comma separated
+	if filter.Role != "" {
+		query = query.Where("(roles = ? OR roles LIKE ? OR roles LIKE ? OR roles LIKE ?)", filter.Role, filter.Role+",%", "%,"+filter.Role, "%,"+filter.Role+",%")
+	}
+	if filter.SignupMethod != "" {
+		query = query.Where("(signup_methods = ? OR signup_methods LIKE ? OR signup_methods LIKE ? OR signup_methods LIKE ?)", filter.SignupMethod, filter.SignupMethod+",%", "%,"+filter.SignupMethod, "%,"+filter.SignupMethod+",%")
+	}
+	if filter.IsEmailVerified != nil {
+		if *filter.IsEmailVerified {
+			query = query.Where("email_verified_at IS NOT NULL")
+		} else {
+			query = query.Where("email_verified_at IS NULL")
+		}
+	}
+	if filter.IsPhoneVerified != nil {
+		if *filter.IsPhoneVerified {
+			query = query.Where("phone_number_verified_at IS NOT NULL")
+		} else {
+			query = query.Where("phone_number_verified_at IS NULL")
+		}
+	}
+	if filter.IsRevoked != nil {
+		if *filter.IsRevoked {
+			query = query.Where("revoked_timestamp IS NOT NULL")
+		} else {
+			query = query.Where("revoked_timestamp IS NULL")
+		}
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at <= ?", *filter.CreatedBefore)
+	}
+	return query
+}
+
+// ListUsers to get list of users matching the filter from database
+func (p *provider) ListUsers(ctx context.Context, pagination model.Pagination, filter models.UserFilter) (*model.Users, error) {
 	var users []models.User
-	result := p.db.Limit(int(pagination.Limit)).Offset(int(pagination.Offset)).Order("created_at DESC").Find(&users)
+	// sort field & order are validated by the filter
+	query, err := paginate(p.filterUsers(filter), pagination, filter.GetSortBy(), filter.IsAscending())
+	if err != nil {
+		return nil, err
+	}
+	result := query.Find(&users)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
+	paginationClone := pagination
+	if int64(len(users)) > pagination.Limit {
+		paginationClone.HasNextPage = true
+		users = users[:pagination.Limit]
+	}
+
 	responseUsers := []*model.User{}
 	for _, user := range users {
 		responseUsers = append(responseUsers, user.AsAPIUser())
+		paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(filter.SortValue(&user), user.ID))
 	}
 
-	var total int64
-	totalRes := p.db.Model(&models.User{}).Count(&total)
-	if totalRes.Error != nil {
-		return nil, totalRes.Error
+	total, err := countTotal(p.filterUsers(filter), pagination)
+	if err != nil {
+		return nil, err
 	}
 
-	paginationClone := pagination
 	paginationClone.Total = total
 
 	return &model.Users{
@@ -110,6 +181,17 @@ func (p *provider) GetUserByEmail(ctx context.Context, email string) (models.Use
 	return user, nil
 }
 
+// GetUserByPhoneNumber to get user information from database using phone number
+func (p *provider) GetUserByPhoneNumber(ctx context.Context, phoneNumber string) (models.User, error) {
+	var user models.User
+	result := p.db.Where("phone_number = ?", phoneNumber).First(&user)
+	if result.Error != nil {
+		return user, result.Error
+	}
+
+	return user, nil
+}
+
 // GetUserByID to get user information from database using user ID
 func (p *provider) GetUserByID(ctx context.Context, id string) (models.User, error) {
 	var user models.User
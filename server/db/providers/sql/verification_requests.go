@@ -6,6 +6,7 @@ import (
 
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/google/uuid"
 	"gorm.io/gorm/clause"
 )
@@ -60,23 +61,32 @@ func (p *provider) GetVerificationRequestByEmail(ctx context.Context, email stri
 func (p *provider) ListVerificationRequests(ctx context.Context, pagination model.Pagination) (*model.VerificationRequests, error) {
 	var verificationRequests []models.VerificationRequest
 
-	result := p.db.Limit(int(pagination.Limit)).Offset(int(pagination.Offset)).Order("created_at DESC").Find(&verificationRequests)
+	query, err := paginate(p.db, pagination, "created_at", false)
+	if err != nil {
+		return nil, err
+	}
+	result := query.Find(&verificationRequests)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
+	paginationClone := pagination
+	if int64(len(verificationRequests)) > pagination.Limit {
+		paginationClone.HasNextPage = true
+		verificationRequests = verificationRequests[:pagination.Limit]
+	}
+
 	responseVerificationRequests := []*model.VerificationRequest{}
 	for _, v := range verificationRequests {
 		responseVerificationRequests = append(responseVerificationRequests, v.AsAPIVerificationRequest())
+		paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(v.CreatedAt, v.ID))
 	}
 
-	var total int64
-	totalRes := p.db.Model(&models.VerificationRequest{}).Count(&total)
-	if totalRes.Error != nil {
-		return nil, totalRes.Error
+	total, err := countTotal(p.db.Model(&models.VerificationRequest{}), pagination)
+	if err != nil {
+		return nil, err
 	}
 
-	paginationClone := pagination
 	paginationClone.Total = total
 
 	return &model.VerificationRequests{
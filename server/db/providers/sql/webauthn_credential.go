@@ -0,0 +1,59 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/google/uuid"
+)
+
+// AddWebauthnCredential to save webauthn credential information in database
+func (p *provider) AddWebauthnCredential(ctx context.Context, credential models.WebauthnCredential) (models.WebauthnCredential, error) {
+	if credential.ID == "" {
+		credential.ID = uuid.New().String()
+	}
+
+	credential.Key = credential.ID
+	credential.CreatedAt = time.Now().Unix()
+	credential.UpdatedAt = time.Now().Unix()
+	result := p.db.Create(&credential)
+	if result.Error != nil {
+		return credential, result.Error
+	}
+
+	return credential, nil
+}
+
+// UpdateWebauthnCredential to update webauthn credential information in database
+func (p *provider) UpdateWebauthnCredential(ctx context.Context, credential models.WebauthnCredential) (models.WebauthnCredential, error) {
+	credential.UpdatedAt = time.Now().Unix()
+	result := p.db.Save(&credential)
+	if result.Error != nil {
+		return credential, result.Error
+	}
+
+	return credential, nil
+}
+
+// GetWebauthnCredentialByCredentialID to get webauthn credential using credential id generated by authenticator
+func (p *provider) GetWebauthnCredentialByCredentialID(ctx context.Context, credentialID string) (models.WebauthnCredential, error) {
+	var credential models.WebauthnCredential
+	result := p.db.Where("credential_id = ?", credentialID).First(&credential)
+	if result.Error != nil {
+		return credential, result.Error
+	}
+
+	return credential, nil
+}
+
+// ListWebauthnCredentialsByUserID to get all the webauthn credentials registered by user
+func (p *provider) ListWebauthnCredentialsByUserID(ctx context.Context, userID string) ([]models.WebauthnCredential, error) {
+	var credentials []models.WebauthnCredential
+	result := p.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&credentials)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return credentials, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/google/uuid"
 )
 
@@ -41,23 +42,31 @@ func (p *provider) UpdateWebhook(ctx context.Context, webhook models.Webhook) (*
 func (p *provider) ListWebhook(ctx context.Context, pagination model.Pagination) (*model.Webhooks, error) {
 	var webhooks []models.Webhook
 
-	result := p.db.Limit(int(pagination.Limit)).Offset(int(pagination.Offset)).Order("created_at DESC").Find(&webhooks)
+	query, err := paginate(p.db, pagination, "created_at", false)
+	if err != nil {
+		return nil, err
+	}
+	result := query.Find(&webhooks)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	var total int64
-	totalRes := p.db.Model(&models.Webhook{}).Count(&total)
-	if totalRes.Error != nil {
-		return nil, totalRes.Error
+	total, err := countTotal(p.db.Model(&models.Webhook{}), pagination)
+	if err != nil {
+		return nil, err
 	}
 
 	paginationClone := pagination
 	paginationClone.Total = total
+	if int64(len(webhooks)) > pagination.Limit {
+		paginationClone.HasNextPage = true
+		webhooks = webhooks[:pagination.Limit]
+	}
 
 	responseWebhooks := []*model.Webhook{}
 	for _, w := range webhooks {
 		responseWebhooks = append(responseWebhooks, w.AsAPIWebhook())
+		paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(w.CreatedAt, w.ID))
 	}
 	return &model.Webhooks{
 		Pagination: &paginationClone,
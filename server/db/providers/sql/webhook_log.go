@@ -6,6 +6,7 @@ import (
 
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -34,32 +35,37 @@ func (p *provider) AddWebhookLog(ctx context.Context, webhookLog models.WebhookL
 // ListWebhookLogs to list webhook logs
 func (p *provider) ListWebhookLogs(ctx context.Context, pagination model.Pagination, webhookID string) (*model.WebhookLogs, error) {
 	var webhookLogs []models.WebhookLog
-	var result *gorm.DB
-	var totalRes *gorm.DB
-	var total int64
 
+	query := p.db.Model(&models.WebhookLog{})
 	if webhookID != "" {
-		result = p.db.Where("webhook_id = ?", webhookID).Limit(int(pagination.Limit)).Offset(int(pagination.Offset)).Order("created_at DESC").Find(&webhookLogs)
-		totalRes = p.db.Where("webhook_id = ?", webhookID).Model(&models.WebhookLog{}).Count(&total)
-	} else {
-		result = p.db.Limit(int(pagination.Limit)).Offset(int(pagination.Offset)).Order("created_at DESC").Find(&webhookLogs)
-		totalRes = p.db.Model(&models.WebhookLog{}).Count(&total)
+		query = query.Where("webhook_id = ?", webhookID)
 	}
 
-	if result.Error != nil {
-		return nil, result.Error
+	total, err := countTotal(query.Session(&gorm.Session{}), pagination)
+	if err != nil {
+		return nil, err
 	}
 
-	if totalRes.Error != nil {
-		return nil, totalRes.Error
+	query, err = paginate(query, pagination, "created_at", false)
+	if err != nil {
+		return nil, err
+	}
+	result := query.Find(&webhookLogs)
+	if result.Error != nil {
+		return nil, result.Error
 	}
 
 	paginationClone := pagination
 	paginationClone.Total = total
+	if int64(len(webhookLogs)) > pagination.Limit {
+		paginationClone.HasNextPage = true
+		webhookLogs = webhookLogs[:pagination.Limit]
+	}
 
 	responseWebhookLogs := []*model.WebhookLog{}
 	for _, w := range webhookLogs {
 		responseWebhookLogs = append(responseWebhookLogs, w.AsAPIWebhookLog())
+		paginationClone.EndCursor = refs.NewStringRef(models.EncodeCursor(w.CreatedAt, w.ID))
 	}
 	return &model.WebhookLogs{
 		WebhookLogs: responseWebhookLogs,
@@ -0,0 +1,128 @@
+// Package device implements the state of OAuth 2.0 device authorization grant (RFC 8628)
+package device
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// userCodeCharset excludes vowels & similar looking characters as recommended by RFC 8628
+const userCodeCharset = "BCDFGHJKLMNPQRSTVWXZ"
+
+// Authorization is the device authorization saved in the state store
+// till the device exchanges it for token or it expires
+type Authorization struct {
+	DeviceCode   string   `json:"device_code"`
+	UserCode     string   `json:"user_code"`
+	ClientID     string   `json:"client_id"`
+	Scope        []string `json:"scope"`
+	Status       string   `json:"status"`
+	ExpiresAt    int64    `json:"expires_at"`
+	Interval     int64    `json:"interval"`
+	LastPolledAt int64    `json:"last_polled_at"`
+	// set when user approves the device
+	UserID      string   `json:"user_id"`
+	Roles       []string `json:"roles"`
+	LoginMethod string   `json:"login_method"`
+}
+
+// IsExpired returns true if device authorization is expired
+func (a *Authorization) IsExpired() bool {
+	return a.ExpiresAt < time.Now().Unix()
+}
+
+// NewAuthorization creates the pending device authorization for client & saves it in the state store
+func NewAuthorization(clientID string, scope []string) (*Authorization, error) {
+	userCode, err := generateUserCode()
+	if err != nil {
+		return nil, err
+	}
+
+	authorization := &Authorization{
+		DeviceCode: uuid.New().String(),
+		UserCode:   userCode,
+		ClientID:   clientID,
+		Scope:      scope,
+		Status:     constants.DeviceAuthorizationStatusPending,
+		ExpiresAt:  time.Now().Add(constants.DeviceCodeExpiry).Unix(),
+		Interval:   constants.DevicePollingInterval,
+	}
+	if err := Save(authorization); err != nil {
+		return nil, err
+	}
+	if err := memorystore.Provider.SetStateWithExpiry(constants.DeviceUserCodeStatePrefix+NormalizeUserCode(userCode), authorization.DeviceCode, constants.DeviceCodeExpiry); err != nil {
+		return nil, err
+	}
+
+	return authorization, nil
+}
+
+// Save saves the device authorization in the state store,
+// it is removed from the store after the device code expiry
+func Save(authorization *Authorization) error {
+	data, err := json.Marshal(authorization)
+	if err != nil {
+		return err
+	}
+	return memorystore.Provider.SetStateWithExpiry(constants.DeviceCodeStatePrefix+authorization.DeviceCode, string(data), constants.DeviceCodeExpiry)
+}
+
+// GetByDeviceCode returns the device authorization for device code
+func GetByDeviceCode(deviceCode string) (*Authorization, error) {
+	data, err := memorystore.Provider.GetState(constants.DeviceCodeStatePrefix + deviceCode)
+	if err != nil || data == "" {
+		return nil, errors.New("device authorization not found")
+	}
+
+	var authorization Authorization
+	if err := json.Unmarshal([]byte(data), &authorization); err != nil {
+		return nil, err
+	}
+	return &authorization, nil
+}
+
+// GetByUserCode returns the device authorization for user code entered by user
+func GetByUserCode(userCode string) (*Authorization, error) {
+	deviceCode, err := memorystore.Provider.GetState(constants.DeviceUserCodeStatePrefix + NormalizeUserCode(userCode))
+	if err != nil || deviceCode == "" {
+		return nil, errors.New("device authorization not found")
+	}
+	return GetByDeviceCode(deviceCode)
+}
+
+// Remove removes the device authorization from the state store
+func Remove(authorization *Authorization) {
+	memorystore.Provider.RemoveState(constants.DeviceCodeStatePrefix + authorization.DeviceCode)
+	memorystore.Provider.RemoveState(constants.DeviceUserCodeStatePrefix + NormalizeUserCode(authorization.UserCode))
+}
+
+// NormalizeUserCode removes the separators & converts user code to upper case,
+// so that user can enter the code in any format
+func NormalizeUserCode(userCode string) string {
+	userCode = strings.ToUpper(userCode)
+	userCode = strings.ReplaceAll(userCode, "-", "")
+	return strings.ReplaceAll(userCode, " ", "")
+}
+
+// generateUserCode generates the user code in XXXX-XXXX format
+func generateUserCode() (string, error) {
+	code := make([]byte, 8)
+	max := big.NewInt(int64(len(userCodeCharset)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = userCodeCharset[n.Int64()]
+	}
+	return string(code[:4]) + "-" + string(code[4:]), nil
+}
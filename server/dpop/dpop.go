@@ -0,0 +1,145 @@
+// Package dpop implements the validation of proof of possession for
+// OAuth 2.0 Demonstrating Proof of Possession (RFC 9449)
+package dpop
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
+)
+
+// HasProof returns true if DPoP proof is sent with the request
+func HasProof(gc *gin.Context) bool {
+	return len(gc.Request.Header.Values(constants.DPoPHeader)) > 0
+}
+
+// ValidateProof validates the DPoP proof sent with the request & returns
+// the JWK thumbprint of the key that signed the proof.
+// Proof must be bound to the access token if it is presented with access token
+func ValidateProof(gc *gin.Context, accessToken string) (string, error) {
+	proofs := gc.Request.Header.Values(constants.DPoPHeader)
+	if len(proofs) != 1 {
+		return "", errors.New("exactly one dpop proof is required")
+	}
+
+	var jwk *jose.JSONWebKey
+	parser := &jwt.Parser{
+		ValidMethods: constants.DPoPSigningAlgs,
+		// iat is validated with the allowed clock skew
+		SkipClaimsValidation: true,
+	}
+	claims := jwt.MapClaims{}
+	_, err := parser.ParseWithClaims(proofs[0], claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Header["typ"] != constants.DPoPProofType {
+			return nil, errors.New("invalid dpop proof type")
+		}
+		jwkBytes, err := json.Marshal(token.Header["jwk"])
+		if err != nil {
+			return nil, err
+		}
+		jwk = &jose.JSONWebKey{}
+		if err := json.Unmarshal(jwkBytes, jwk); err != nil {
+			return nil, fmt.Errorf("invalid dpop proof jwk: %s", err.Error())
+		}
+		if !jwk.IsPublic() {
+			return nil, errors.New("dpop proof jwk must be a public key")
+		}
+		return jwk.Key, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("invalid dpop proof: %s", err.Error())
+	}
+
+	if claims["htm"] != gc.Request.Method {
+		return "", errors.New("dpop proof is not issued for the request method")
+	}
+	htu, _ := claims["htu"].(string)
+	if !isRequestURI(htu, strings.TrimSuffix(parsers.GetHost(gc), "/")+gc.Request.URL.Path) {
+		return "", errors.New("dpop proof is not issued for the request uri")
+	}
+
+	iat, ok := claims["iat"].(float64)
+	if !ok {
+		return "", errors.New("dpop proof iat is required")
+	}
+	issuedAt := time.Unix(int64(iat), 0)
+	if time.Since(issuedAt) > constants.DPoPProofMaxAge || time.Until(issuedAt) > constants.DPoPProofMaxAge {
+		return "", errors.New("dpop proof is expired")
+	}
+
+	if accessToken != "" {
+		hash := sha256.Sum256([]byte(accessToken))
+		if claims["ath"] != base64.RawURLEncoding.EncodeToString(hash[:]) {
+			return "", errors.New("dpop proof is not issued for the access token")
+		}
+	}
+
+	// proof can be used only once
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return "", errors.New("dpop proof jti is required")
+	}
+	if used, _ := memorystore.Provider.GetState(constants.DPoPJtiStatePrefix + jti); used != "" {
+		return "", errors.New("dpop proof is already used")
+	}
+	// proof is not accepted after max age of its iat, hence jti is not needed after it
+	expiresIn := time.Until(issuedAt.Add(constants.DPoPProofMaxAge))
+	if expiresIn < time.Second {
+		expiresIn = time.Second
+	}
+	if err := memorystore.Provider.SetStateWithExpiry(constants.DPoPJtiStatePrefix+jti, strconv.FormatInt(issuedAt.Unix(), 10), expiresIn); err != nil {
+		return "", err
+	}
+
+	return Thumbprint(jwk)
+}
+
+// Thumbprint returns the base64url encoded JWK SHA-256 thumbprint (RFC 7638)
+// used as jkt confirmation claim of the tokens bound to the key
+func Thumbprint(jwk *jose.JSONWebKey) (string, error) {
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// GetJKT returns the jkt confirmation claim of token, empty string if token is not bound to DPoP key
+func GetJKT(claims map[string]interface{}) string {
+	cnf, ok := claims["cnf"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	jkt, _ := cnf["jkt"].(string)
+	return jkt
+}
+
+// isRequestURI compares htu claim with the request uri, query & fragment of htu are ignored
+func isRequestURI(htu, requestURI string) bool {
+	htuURL, err := url.Parse(htu)
+	if err != nil || htu == "" {
+		return false
+	}
+	reqURL, err := url.Parse(requestURI)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(htuURL.Scheme, reqURL.Scheme) &&
+		strings.EqualFold(htuURL.Host, reqURL.Host) &&
+		htuURL.Path == reqURL.Path
+}
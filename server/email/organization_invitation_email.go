@@ -0,0 +1,65 @@
+package email
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// SendOrganizationInvitationMail to send organization invitation email,
+// invitation is accepted when user logs in to the organization with login url
+func SendOrganizationInvitationMail(toEmail, organizationName, loginURL string) error {
+	// The receiver needs to be in slice as the receive supports multiple receiver
+	Receiver := []string{toEmail}
+
+	Subject := "You are invited to join " + organizationName
+	message := `
+	<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd">
+    <html xmlns="http://www.w3.org/1999/xhtml" xmlns:o="urn:schemas-microsoft-com:office:office">
+        <head>
+            <meta charset="UTF-8">
+            <meta content="width=device-width, initial-scale=1" name="viewport">
+            <meta name="x-apple-disable-message-reformatting">
+            <meta http-equiv="X-UA-Compatible" content="IE=edge">
+            <meta content="telephone=no" name="format-detection">
+            <title></title>
+        </head>
+        <body style="font-family: sans-serif;">
+            <table width="600" cellspacing="0" cellpadding="0" bgcolor="#ffffff" align="center" style="padding:20px 0px;">
+                <tbody>
+                    <tr>
+                        <td style="font-size:0;padding:10px" align="center"><a target="_blank" clicktracking="off"><img src="{{.org_logo}}" alt="icon" style="display: block;" title="icon" width="30"></a></td>
+                    </tr>
+                    <tr style="background: rgb(249,250,251);padding: 10px;margin-bottom:10px;border-radius:5px;">
+                        <td align="center" style="padding:10px;padding-bottom:30px;">
+                            <p>Hi there 👋</p>
+                            <p>You are invited to join <b>{{.organization_name}}</b> on <b>{{.org_name}}</b>. Please login by clicking the button below to accept the invitation.</p> <br/>
+                            <a clicktracking="off" href="{{.login_url}}" class="es-button" target="_blank" style="text-decoration: none;padding:10px 15px;background-color: rgba(59,130,246,1);color: #fff;font-size: 1em;border-radius:5px;">Accept Invitation</a>
+                        </td>
+                    </tr>
+                </tbody>
+            </table>
+        </body>
+    </html>
+	`
+	data := make(map[string]interface{}, 4)
+	var err error
+	data["org_logo"], err = memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyOrganizationLogo)
+	if err != nil {
+		return err
+	}
+	data["org_name"], err = memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyOrganizationName)
+	if err != nil {
+		return err
+	}
+	data["organization_name"] = organizationName
+	data["login_url"] = loginURL
+	message = addEmailTemplate(message, data, "organization_invitation_email.tmpl")
+
+	err = SendMail(Receiver, Subject, message)
+	if err != nil {
+		log.Warn("error sending email: ", err)
+	}
+	return err
+}
@@ -0,0 +1,64 @@
+package email
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// SendOtpMail to send the otp that user should enter to verify the email
+func SendOtpMail(toEmail, otp string) error {
+	// The receiver needs to be in slice as the receive supports multiple receiver
+	Receiver := []string{toEmail}
+
+	Subject := "OTP for your multi factor authentication"
+	message := `
+	<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd">
+    <html xmlns="http://www.w3.org/1999/xhtml" xmlns:o="urn:schemas-microsoft-com:office:office">
+        <head>
+            <meta charset="UTF-8">
+            <meta content="width=device-width, initial-scale=1" name="viewport">
+            <meta name="x-apple-disable-message-reformatting">
+            <meta http-equiv="X-UA-Compatible" content="IE=edge">
+            <meta content="telephone=no" name="format-detection">
+            <title></title>
+        </head>
+        <body style="font-family: sans-serif;">
+            <table width="600" cellspacing="0" cellpadding="0" bgcolor="#ffffff" align="center" style="padding:20px 0px;">
+                <tbody>
+                    <tr>
+                        <td style="font-size:0;padding:10px" align="center"><a target="_blank" clicktracking="off"><img src="{{.org_logo}}" alt="icon" style="display: block;" title="icon" width="30"></a></td>
+                    </tr>
+                    <tr style="background: rgb(249,250,251);padding: 10px;margin-bottom:10px;border-radius:5px;">
+                        <td align="center" style="padding:10px;padding-bottom:30px;">
+                            <p>Hey there 👋</p>
+                            <p>Please use the following otp to set up multi factor authentication for <b>{{.org_name}}</b>. It expires in {{.expiry_minutes}} minutes.</p> <br/>
+                            <p style="font-size: 1.5em;letter-spacing: 5px;"><b>{{.otp}}</b></p>
+                        </td>
+                    </tr>
+                </tbody>
+            </table>
+        </body>
+    </html>
+	`
+	data := make(map[string]interface{}, 4)
+	var err error
+	data["org_logo"], err = memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyOrganizationLogo)
+	if err != nil {
+		return err
+	}
+	data["org_name"], err = memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyOrganizationName)
+	if err != nil {
+		return err
+	}
+	data["otp"] = otp
+	data["expiry_minutes"] = int(constants.OtpExpiry.Minutes())
+	message = addEmailTemplate(message, data, "otp_email.tmpl")
+
+	err = SendMail(Receiver, Subject, message)
+	if err != nil {
+		log.Warn("error sending email: ", err)
+	}
+	return err
+}
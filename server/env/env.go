@@ -50,12 +50,20 @@ func InitAllEnv() error {
 	osAuthorizerURL := os.Getenv(constants.EnvKeyAuthorizerURL)
 	osPort := os.Getenv(constants.EnvKeyPort)
 	osAccessTokenExpiryTime := os.Getenv(constants.EnvKeyAccessTokenExpiryTime)
+	osRefreshTokenExpiryTime := os.Getenv(constants.EnvKeyRefreshTokenExpiryTime)
+	osRefreshTokenMaxLifetime := os.Getenv(constants.EnvKeyRefreshTokenMaxLifetime)
+	osMaxActiveSessions := os.Getenv(constants.EnvKeyMaxActiveSessions)
+	osSessionIdleTimeout := os.Getenv(constants.EnvKeySessionIdleTimeout)
+	osSessionMaxLifetime := os.Getenv(constants.EnvKeySessionMaxLifetime)
 	osAdminSecret := os.Getenv(constants.EnvKeyAdminSecret)
+	osScimToken := os.Getenv(constants.EnvKeyScimToken)
 	osSmtpHost := os.Getenv(constants.EnvKeySmtpHost)
 	osSmtpPort := os.Getenv(constants.EnvKeySmtpPort)
 	osSmtpUsername := os.Getenv(constants.EnvKeySmtpUsername)
 	osSmtpPassword := os.Getenv(constants.EnvKeySmtpPassword)
 	osSenderEmail := os.Getenv(constants.EnvKeySenderEmail)
+	osSmsProvider := os.Getenv(constants.EnvKeySmsProvider)
+	osSmsLogFile := os.Getenv(constants.EnvKeySmsLogFile)
 	osJwtType := os.Getenv(constants.EnvKeyJwtType)
 	osJwtSecret := os.Getenv(constants.EnvKeyJwtSecret)
 	osJwtPrivateKey := os.Getenv(constants.EnvKeyJwtPrivateKey)
@@ -72,6 +80,11 @@ func InitAllEnv() error {
 	osLinkedInClientSecret := os.Getenv(constants.EnvKeyLinkedInClientSecret)
 	osAppleClientID := os.Getenv(constants.EnvKeyAppleClientID)
 	osAppleClientSecret := os.Getenv(constants.EnvKeyAppleClientSecret)
+	osOIDCProviders := os.Getenv(constants.EnvKeyOIDCProviders)
+	osSamlIdpEntityID := os.Getenv(constants.EnvKeySamlIdpEntityID)
+	osSamlIdpSsoURL := os.Getenv(constants.EnvKeySamlIdpSsoURL)
+	osSamlIdpCertificate := os.Getenv(constants.EnvKeySamlIdpCertificate)
+	osSamlAttributeMapping := os.Getenv(constants.EnvKeySamlAttributeMapping)
 	osResetPasswordURL := os.Getenv(constants.EnvKeyResetPasswordURL)
 	osOrganizationName := os.Getenv(constants.EnvKeyOrganizationName)
 	osOrganizationLogo := os.Getenv(constants.EnvKeyOrganizationLogo)
@@ -90,6 +103,7 @@ func InitAllEnv() error {
 	osRoles := os.Getenv(constants.EnvKeyRoles)
 	osDefaultRoles := os.Getenv(constants.EnvKeyDefaultRoles)
 	osProtectedRoles := os.Getenv(constants.EnvKeyProtectedRoles)
+	osMFARequiredRoles := os.Getenv(constants.EnvKeyMFARequiredRoles)
 
 	ienv, ok := envData[constants.EnvKeyEnv]
 	if !ok || ienv == "" {
@@ -147,6 +161,44 @@ func InitAllEnv() error {
 		envData[constants.EnvKeyAccessTokenExpiryTime] = osAccessTokenExpiryTime
 	}
 
+	if val, ok := envData[constants.EnvKeyRefreshTokenExpiryTime]; !ok || val == "" {
+		envData[constants.EnvKeyRefreshTokenExpiryTime] = osRefreshTokenExpiryTime
+		if envData[constants.EnvKeyRefreshTokenExpiryTime] == "" {
+			envData[constants.EnvKeyRefreshTokenExpiryTime] = "8760h"
+		}
+	}
+	if osRefreshTokenExpiryTime != "" && envData[constants.EnvKeyRefreshTokenExpiryTime] != osRefreshTokenExpiryTime {
+		envData[constants.EnvKeyRefreshTokenExpiryTime] = osRefreshTokenExpiryTime
+	}
+
+	if val, ok := envData[constants.EnvKeyRefreshTokenMaxLifetime]; !ok || val == "" {
+		envData[constants.EnvKeyRefreshTokenMaxLifetime] = osRefreshTokenMaxLifetime
+	}
+	if osRefreshTokenMaxLifetime != "" && envData[constants.EnvKeyRefreshTokenMaxLifetime] != osRefreshTokenMaxLifetime {
+		envData[constants.EnvKeyRefreshTokenMaxLifetime] = osRefreshTokenMaxLifetime
+	}
+
+	if val, ok := envData[constants.EnvKeyMaxActiveSessions]; !ok || val == "" {
+		envData[constants.EnvKeyMaxActiveSessions] = osMaxActiveSessions
+	}
+	if osMaxActiveSessions != "" && envData[constants.EnvKeyMaxActiveSessions] != osMaxActiveSessions {
+		envData[constants.EnvKeyMaxActiveSessions] = osMaxActiveSessions
+	}
+
+	if val, ok := envData[constants.EnvKeySessionIdleTimeout]; !ok || val == "" {
+		envData[constants.EnvKeySessionIdleTimeout] = osSessionIdleTimeout
+	}
+	if osSessionIdleTimeout != "" && envData[constants.EnvKeySessionIdleTimeout] != osSessionIdleTimeout {
+		envData[constants.EnvKeySessionIdleTimeout] = osSessionIdleTimeout
+	}
+
+	if val, ok := envData[constants.EnvKeySessionMaxLifetime]; !ok || val == "" {
+		envData[constants.EnvKeySessionMaxLifetime] = osSessionMaxLifetime
+	}
+	if osSessionMaxLifetime != "" && envData[constants.EnvKeySessionMaxLifetime] != osSessionMaxLifetime {
+		envData[constants.EnvKeySessionMaxLifetime] = osSessionMaxLifetime
+	}
+
 	if val, ok := envData[constants.EnvKeyAdminSecret]; !ok || val == "" {
 		envData[constants.EnvKeyAdminSecret] = osAdminSecret
 	}
@@ -154,6 +206,13 @@ func InitAllEnv() error {
 		envData[constants.EnvKeyAdminSecret] = osAdminSecret
 	}
 
+	if val, ok := envData[constants.EnvKeyScimToken]; !ok || val == "" {
+		envData[constants.EnvKeyScimToken] = osScimToken
+	}
+	if osScimToken != "" && envData[constants.EnvKeyScimToken] != osScimToken {
+		envData[constants.EnvKeyScimToken] = osScimToken
+	}
+
 	if val, ok := envData[constants.EnvKeySmtpHost]; !ok || val == "" {
 		envData[constants.EnvKeySmtpHost] = osSmtpHost
 	}
@@ -189,6 +248,20 @@ func InitAllEnv() error {
 		envData[constants.EnvKeySenderEmail] = osSenderEmail
 	}
 
+	if val, ok := envData[constants.EnvKeySmsProvider]; !ok || val == "" {
+		envData[constants.EnvKeySmsProvider] = osSmsProvider
+	}
+	if osSmsProvider != "" && envData[constants.EnvKeySmsProvider] != osSmsProvider {
+		envData[constants.EnvKeySmsProvider] = osSmsProvider
+	}
+
+	if val, ok := envData[constants.EnvKeySmsLogFile]; !ok || val == "" {
+		envData[constants.EnvKeySmsLogFile] = osSmsLogFile
+	}
+	if osSmsLogFile != "" && envData[constants.EnvKeySmsLogFile] != osSmsLogFile {
+		envData[constants.EnvKeySmsLogFile] = osSmsLogFile
+	}
+
 	algoVal, ok := envData[constants.EnvKeyJwtType]
 	algo := ""
 	if !ok || algoVal == "" {
@@ -199,13 +272,13 @@ func InitAllEnv() error {
 		}
 	} else {
 		algo = algoVal.(string)
-		if !crypto.IsHMACA(algo) && !crypto.IsRSA(algo) && !crypto.IsECDSA(algo) {
+		if !crypto.IsHMACA(algo) && !crypto.IsRSA(algo) && !crypto.IsECDSA(algo) && !crypto.IsEdDSA(algo) {
 			log.Debug("Invalid JWT Algorithm")
 			return errors.New("invalid JWT_TYPE")
 		}
 	}
 	if osJwtType != "" && osJwtType != algo {
-		if !crypto.IsHMACA(osJwtType) && !crypto.IsRSA(osJwtType) && !crypto.IsECDSA(osJwtType) {
+		if !crypto.IsHMACA(osJwtType) && !crypto.IsRSA(osJwtType) && !crypto.IsECDSA(osJwtType) && !crypto.IsEdDSA(osJwtType) {
 			log.Debug("Invalid JWT Algorithm")
 			return errors.New("invalid JWT_TYPE")
 		}
@@ -228,7 +301,7 @@ func InitAllEnv() error {
 		}
 	}
 
-	if crypto.IsRSA(algo) || crypto.IsECDSA(algo) {
+	if crypto.IsRSA(algo) || crypto.IsECDSA(algo) || crypto.IsEdDSA(algo) {
 		privateKey, publicKey := "", ""
 
 		if val, ok := envData[constants.EnvKeyJwtPrivateKey]; !ok || val == "" {
@@ -245,7 +318,7 @@ func InitAllEnv() error {
 			publicKey = osJwtPublicKey
 		}
 
-		// if algo is RSA / ECDSA, then we need to have both private and public key
+		// if algo is RSA / ECDSA / EdDSA, then we need to have both private and public key
 		// if either of them is not present generate new keys
 		if privateKey == "" || publicKey == "" {
 			if crypto.IsRSA(algo) {
@@ -258,6 +331,11 @@ func InitAllEnv() error {
 				if err != nil {
 					return err
 				}
+			} else if crypto.IsEdDSA(algo) {
+				_, privateKey, publicKey, _, err = crypto.NewEdDSAKey(algo, clientID)
+				if err != nil {
+					return err
+				}
 			}
 		} else {
 			// parse keys to make sure they are valid
@@ -282,6 +360,16 @@ func InitAllEnv() error {
 				if err != nil {
 					return err
 				}
+			} else if crypto.IsEdDSA(algo) {
+				_, err = crypto.ParseEdDSAPrivateKeyFromPemStr(privateKey)
+				if err != nil {
+					return err
+				}
+
+				_, err := crypto.ParseEdDSAPublicKeyFromPemStr(publicKey)
+				if err != nil {
+					return err
+				}
 			}
 		}
 
@@ -378,6 +466,41 @@ func InitAllEnv() error {
 		envData[constants.EnvKeyAppleClientSecret] = osAppleClientSecret
 	}
 
+	if val, ok := envData[constants.EnvKeyOIDCProviders]; !ok || val == "" {
+		envData[constants.EnvKeyOIDCProviders] = osOIDCProviders
+	}
+	if osOIDCProviders != "" && envData[constants.EnvKeyOIDCProviders] != osOIDCProviders {
+		envData[constants.EnvKeyOIDCProviders] = osOIDCProviders
+	}
+
+	if val, ok := envData[constants.EnvKeySamlIdpEntityID]; !ok || val == "" {
+		envData[constants.EnvKeySamlIdpEntityID] = osSamlIdpEntityID
+	}
+	if osSamlIdpEntityID != "" && envData[constants.EnvKeySamlIdpEntityID] != osSamlIdpEntityID {
+		envData[constants.EnvKeySamlIdpEntityID] = osSamlIdpEntityID
+	}
+
+	if val, ok := envData[constants.EnvKeySamlIdpSsoURL]; !ok || val == "" {
+		envData[constants.EnvKeySamlIdpSsoURL] = osSamlIdpSsoURL
+	}
+	if osSamlIdpSsoURL != "" && envData[constants.EnvKeySamlIdpSsoURL] != osSamlIdpSsoURL {
+		envData[constants.EnvKeySamlIdpSsoURL] = osSamlIdpSsoURL
+	}
+
+	if val, ok := envData[constants.EnvKeySamlIdpCertificate]; !ok || val == "" {
+		envData[constants.EnvKeySamlIdpCertificate] = osSamlIdpCertificate
+	}
+	if osSamlIdpCertificate != "" && envData[constants.EnvKeySamlIdpCertificate] != osSamlIdpCertificate {
+		envData[constants.EnvKeySamlIdpCertificate] = osSamlIdpCertificate
+	}
+
+	if val, ok := envData[constants.EnvKeySamlAttributeMapping]; !ok || val == "" {
+		envData[constants.EnvKeySamlAttributeMapping] = osSamlAttributeMapping
+	}
+	if osSamlAttributeMapping != "" && envData[constants.EnvKeySamlAttributeMapping] != osSamlAttributeMapping {
+		envData[constants.EnvKeySamlAttributeMapping] = osSamlAttributeMapping
+	}
+
 	if val, ok := envData[constants.EnvKeyResetPasswordURL]; !ok || val == "" {
 		envData[constants.EnvKeyResetPasswordURL] = strings.TrimPrefix(osResetPasswordURL, "/")
 	}
@@ -548,6 +671,13 @@ func InitAllEnv() error {
 		envData[constants.EnvKeyProtectedRoles] = osProtectedRoles
 	}
 
+	if val, ok := envData[constants.EnvKeyMFARequiredRoles]; !ok || val == "" {
+		envData[constants.EnvKeyMFARequiredRoles] = osMFARequiredRoles
+	}
+	if osMFARequiredRoles != "" && envData[constants.EnvKeyMFARequiredRoles] != osMFARequiredRoles {
+		envData[constants.EnvKeyMFARequiredRoles] = osMFARequiredRoles
+	}
+
 	err = memorystore.Provider.UpdateEnvStore(envData)
 	if err != nil {
 		log.Debug("Error while updating env store: ", err)
@@ -2,11 +2,39 @@
 
 package model
 
+type AddClientRequest struct {
+	Name                        string   `json:"name"`
+	RedirectUris                []string `json:"redirect_uris"`
+	AllowedScopes               []string `json:"allowed_scopes"`
+	GrantTypes                  []string `json:"grant_types"`
+	AccessTokenExpiryTime       *string  `json:"access_token_expiry_time"`
+	RefreshTokenExpiryTime      *string  `json:"refresh_token_expiry_time"`
+	PostLogoutRedirectUris      []string `json:"post_logout_redirect_uris"`
+	BackchannelLogoutURI        *string  `json:"backchannel_logout_uri"`
+	FrontchannelLogoutURI       *string  `json:"frontchannel_logout_uri"`
+	IDTokenEncryptedResponseAlg *string  `json:"id_token_encrypted_response_alg"`
+	IDTokenEncryptedResponseEnc *string  `json:"id_token_encrypted_response_enc"`
+	IDTokenEncryptionKey        *string  `json:"id_token_encryption_key"`
+}
+
 type AddEmailTemplateRequest struct {
 	EventName string `json:"event_name"`
 	Template  string `json:"template"`
 }
 
+type AddOrganizationMemberRequest struct {
+	OrganizationID string   `json:"organization_id"`
+	UserID         string   `json:"user_id"`
+	Roles          []string `json:"roles"`
+}
+
+type AddOrganizationRequest struct {
+	Name         string   `json:"name"`
+	DisplayName  *string  `json:"display_name"`
+	Roles        []string `json:"roles"`
+	DefaultRoles []string `json:"default_roles"`
+}
+
 type AddWebhookRequest struct {
 	EventName string                 `json:"event_name"`
 	Endpoint  string                 `json:"endpoint"`
@@ -23,12 +51,71 @@ type AdminSignupInput struct {
 }
 
 type AuthResponse struct {
+	Message                  string          `json:"message"`
+	AccessToken              *string         `json:"access_token"`
+	IDToken                  *string         `json:"id_token"`
+	RefreshToken             *string         `json:"refresh_token"`
+	ExpiresIn                *int64          `json:"expires_in"`
+	User                     *User           `json:"user"`
+	ShouldShowTotpScreen     *bool           `json:"should_show_totp_screen"`
+	ShouldShowEmailOtpScreen *bool           `json:"should_show_email_otp_screen"`
+	TotpEnrollment           *TOTPEnrollment `json:"totp_enrollment"`
+}
+
+type Client struct {
+	ID                          string   `json:"id"`
+	Name                        string   `json:"name"`
+	RedirectUris                []string `json:"redirect_uris"`
+	AllowedScopes               []string `json:"allowed_scopes"`
+	GrantTypes                  []string `json:"grant_types"`
+	AccessTokenExpiryTime       *string  `json:"access_token_expiry_time"`
+	RefreshTokenExpiryTime      *string  `json:"refresh_token_expiry_time"`
+	PostLogoutRedirectUris      []string `json:"post_logout_redirect_uris"`
+	BackchannelLogoutURI        *string  `json:"backchannel_logout_uri"`
+	FrontchannelLogoutURI       *string  `json:"frontchannel_logout_uri"`
+	IDTokenEncryptedResponseAlg *string  `json:"id_token_encrypted_response_alg"`
+	IDTokenEncryptedResponseEnc *string  `json:"id_token_encrypted_response_enc"`
+	IDTokenEncryptionKey        *string  `json:"id_token_encryption_key"`
+	CreatedAt                   *int64   `json:"created_at"`
+	UpdatedAt                   *int64   `json:"updated_at"`
+}
+
+type ClientRequest struct {
+	ID string `json:"id"`
+}
+
+type ClientResponse struct {
 	Message      string  `json:"message"`
-	AccessToken  *string `json:"access_token"`
-	IDToken      *string `json:"id_token"`
-	RefreshToken *string `json:"refresh_token"`
-	ExpiresIn    *int64  `json:"expires_in"`
-	User         *User   `json:"user"`
+	Client       *Client `json:"client"`
+	ClientSecret *string `json:"client_secret"`
+}
+
+type Clients struct {
+	Pagination *Pagination `json:"pagination"`
+	Clients    []*Client   `json:"clients"`
+}
+
+type ConfirmTOTPInput struct {
+	Otp string `json:"otp"`
+}
+
+type ConsentInput struct {
+	ConsentChallenge string `json:"consent_challenge"`
+	Approve          bool   `json:"approve"`
+}
+
+type ConsentRequest struct {
+	ClientID   string   `json:"client_id"`
+	ClientName string   `json:"client_name"`
+	Scope      []string `json:"scope"`
+}
+
+type ConsentRequestInput struct {
+	ConsentChallenge string `json:"consent_challenge"`
+}
+
+type ConsentResponse struct {
+	RedirectURI string `json:"redirect_uri"`
 }
 
 type DeleteEmailTemplateRequest struct {
@@ -54,6 +141,11 @@ type EmailTemplates struct {
 
 type Env struct {
 	AccessTokenExpiryTime      *string  `json:"ACCESS_TOKEN_EXPIRY_TIME"`
+	RefreshTokenExpiryTime     *string  `json:"REFRESH_TOKEN_EXPIRY_TIME"`
+	RefreshTokenMaxLifetime    *string  `json:"REFRESH_TOKEN_MAX_LIFETIME"`
+	MaxActiveSessions          *string  `json:"MAX_ACTIVE_SESSIONS"`
+	SessionIDLeTimeout         *string  `json:"SESSION_IDLE_TIMEOUT"`
+	SessionMaxLifetime         *string  `json:"SESSION_MAX_LIFETIME"`
 	AdminSecret                *string  `json:"ADMIN_SECRET"`
 	DatabaseName               *string  `json:"DATABASE_NAME"`
 	DatabaseURL                *string  `json:"DATABASE_URL"`
@@ -64,6 +156,7 @@ type Env struct {
 	DatabasePort               *string  `json:"DATABASE_PORT"`
 	ClientID                   string   `json:"CLIENT_ID"`
 	ClientSecret               string   `json:"CLIENT_SECRET"`
+	ScimToken                  *string  `json:"SCIM_TOKEN"`
 	CustomAccessTokenScript    *string  `json:"CUSTOM_ACCESS_TOKEN_SCRIPT"`
 	SMTPHost                   *string  `json:"SMTP_HOST"`
 	SMTPPort                   *string  `json:"SMTP_PORT"`
@@ -88,6 +181,7 @@ type Env struct {
 	Roles                      []string `json:"ROLES"`
 	ProtectedRoles             []string `json:"PROTECTED_ROLES"`
 	DefaultRoles               []string `json:"DEFAULT_ROLES"`
+	MfaRequiredRoles           []string `json:"MFA_REQUIRED_ROLES"`
 	JwtRoleClaim               *string  `json:"JWT_ROLE_CLAIM"`
 	GoogleClientID             *string  `json:"GOOGLE_CLIENT_ID"`
 	GoogleClientSecret         *string  `json:"GOOGLE_CLIENT_SECRET"`
@@ -99,6 +193,11 @@ type Env struct {
 	LinkedinClientSecret       *string  `json:"LINKEDIN_CLIENT_SECRET"`
 	AppleClientID              *string  `json:"APPLE_CLIENT_ID"`
 	AppleClientSecret          *string  `json:"APPLE_CLIENT_SECRET"`
+	OidcProviders              *string  `json:"OIDC_PROVIDERS"`
+	SamlIDPEntityID            *string  `json:"SAML_IDP_ENTITY_ID"`
+	SamlIDPSsoURL              *string  `json:"SAML_IDP_SSO_URL"`
+	SamlIDPCertificate         *string  `json:"SAML_IDP_CERTIFICATE"`
+	SamlAttributeMapping       *string  `json:"SAML_ATTRIBUTE_MAPPING"`
 	OrganizationName           *string  `json:"ORGANIZATION_NAME"`
 	OrganizationLogo           *string  `json:"ORGANIZATION_LOGO"`
 }
@@ -124,21 +223,75 @@ type GenerateJWTKeysResponse struct {
 	PrivateKey *string `json:"private_key"`
 }
 
+type Grant struct {
+	ID         string   `json:"id"`
+	ClientID   string   `json:"client_id"`
+	ClientName string   `json:"client_name"`
+	Scope      []string `json:"scope"`
+	CreatedAt  *int64   `json:"created_at"`
+	UpdatedAt  *int64   `json:"updated_at"`
+}
+
+type ImportUserError struct {
+	Row     int64   `json:"row"`
+	Email   *string `json:"email"`
+	Message string  `json:"message"`
+}
+
+type ImportUsersInput struct {
+	Format string `json:"format"`
+	Data   string `json:"data"`
+}
+
+type ImportUsersResponse struct {
+	Message  string             `json:"message"`
+	Imported int64              `json:"imported"`
+	Errors   []*ImportUserError `json:"errors"`
+}
+
 type InviteMemberInput struct {
 	Emails      []string `json:"emails"`
 	RedirectURI *string  `json:"redirect_uri"`
 }
 
+type InviteOrganizationMembersRequest struct {
+	OrganizationID string   `json:"organization_id"`
+	Emails         []string `json:"emails"`
+	Roles          []string `json:"roles"`
+	RedirectURI    *string  `json:"redirect_uri"`
+}
+
+type JWTKey struct {
+	Kid           string  `json:"kid"`
+	Type          string  `json:"type"`
+	Status        string  `json:"status"`
+	PublicKey     *string `json:"public_key"`
+	CreatedAt     *int64  `json:"created_at"`
+	ActivatesAt   *int64  `json:"activates_at"`
+	DeactivatedAt *int64  `json:"deactivated_at"`
+	RetiresAt     *int64  `json:"retires_at"`
+}
+
+type JWTKeys struct {
+	Keys []*JWTKey `json:"keys"`
+}
+
+type ListOrganizationMembersRequest struct {
+	OrganizationID string           `json:"organization_id"`
+	Pagination     *PaginationInput `json:"pagination"`
+}
+
 type ListWebhookLogRequest struct {
 	Pagination *PaginationInput `json:"pagination"`
 	WebhookID  *string          `json:"webhook_id"`
 }
 
 type LoginInput struct {
-	Email    string   `json:"email"`
-	Password string   `json:"password"`
-	Roles    []string `json:"roles"`
-	Scope    []string `json:"scope"`
+	Email          string   `json:"email"`
+	Password       string   `json:"password"`
+	Roles          []string `json:"roles"`
+	Scope          []string `json:"scope"`
+	OrganizationID *string  `json:"organization_id"`
 }
 
 type MagicLinkLoginInput struct {
@@ -150,38 +303,105 @@ type MagicLinkLoginInput struct {
 }
 
 type Meta struct {
-	Version                      string `json:"version"`
-	ClientID                     string `json:"client_id"`
-	IsGoogleLoginEnabled         bool   `json:"is_google_login_enabled"`
-	IsFacebookLoginEnabled       bool   `json:"is_facebook_login_enabled"`
-	IsGithubLoginEnabled         bool   `json:"is_github_login_enabled"`
-	IsLinkedinLoginEnabled       bool   `json:"is_linkedin_login_enabled"`
-	IsAppleLoginEnabled          bool   `json:"is_apple_login_enabled"`
-	IsEmailVerificationEnabled   bool   `json:"is_email_verification_enabled"`
-	IsBasicAuthenticationEnabled bool   `json:"is_basic_authentication_enabled"`
-	IsMagicLinkLoginEnabled      bool   `json:"is_magic_link_login_enabled"`
-	IsSignUpEnabled              bool   `json:"is_sign_up_enabled"`
-	IsStrongPasswordEnabled      bool   `json:"is_strong_password_enabled"`
+	Version                      string          `json:"version"`
+	ClientID                     string          `json:"client_id"`
+	IsGoogleLoginEnabled         bool            `json:"is_google_login_enabled"`
+	IsFacebookLoginEnabled       bool            `json:"is_facebook_login_enabled"`
+	IsGithubLoginEnabled         bool            `json:"is_github_login_enabled"`
+	IsLinkedinLoginEnabled       bool            `json:"is_linkedin_login_enabled"`
+	IsAppleLoginEnabled          bool            `json:"is_apple_login_enabled"`
+	IsEmailVerificationEnabled   bool            `json:"is_email_verification_enabled"`
+	IsBasicAuthenticationEnabled bool            `json:"is_basic_authentication_enabled"`
+	IsMagicLinkLoginEnabled      bool            `json:"is_magic_link_login_enabled"`
+	IsSignUpEnabled              bool            `json:"is_sign_up_enabled"`
+	IsStrongPasswordEnabled      bool            `json:"is_strong_password_enabled"`
+	OidcProviders                []*OIDCProvider `json:"oidc_providers"`
+	IsSamlLoginEnabled           bool            `json:"is_saml_login_enabled"`
 }
 
 type OAuthRevokeInput struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+type OIDCProvider struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+type Organization struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	DisplayName  *string  `json:"display_name"`
+	Roles        []string `json:"roles"`
+	DefaultRoles []string `json:"default_roles"`
+	CreatedAt    *int64   `json:"created_at"`
+	UpdatedAt    *int64   `json:"updated_at"`
+}
+
+type OrganizationInvitation struct {
+	ID             string   `json:"id"`
+	OrganizationID string   `json:"organization_id"`
+	Email          string   `json:"email"`
+	Roles          []string `json:"roles"`
+	ExpiresAt      int64    `json:"expires_at"`
+	CreatedAt      *int64   `json:"created_at"`
+	UpdatedAt      *int64   `json:"updated_at"`
+}
+
+type OrganizationInvitationRequest struct {
+	OrganizationID string `json:"organization_id"`
+	Email          string `json:"email"`
+}
+
+type OrganizationMember struct {
+	ID             string        `json:"id"`
+	OrganizationID string        `json:"organization_id"`
+	UserID         string        `json:"user_id"`
+	Roles          []string      `json:"roles"`
+	User           *User         `json:"user"`
+	Organization   *Organization `json:"organization"`
+	CreatedAt      *int64        `json:"created_at"`
+	UpdatedAt      *int64        `json:"updated_at"`
+}
+
+type OrganizationMemberRequest struct {
+	OrganizationID string `json:"organization_id"`
+	UserID         string `json:"user_id"`
+}
+
+type OrganizationMembers struct {
+	Pagination *Pagination           `json:"pagination"`
+	Members    []*OrganizationMember `json:"members"`
+}
+
+type OrganizationRequest struct {
+	ID string `json:"id"`
+}
+
+type Organizations struct {
+	Pagination    *Pagination     `json:"pagination"`
+	Organizations []*Organization `json:"organizations"`
+}
+
 type PaginatedInput struct {
 	Pagination *PaginationInput `json:"pagination"`
 }
 
 type Pagination struct {
-	Limit  int64 `json:"limit"`
-	Page   int64 `json:"page"`
-	Offset int64 `json:"offset"`
-	Total  int64 `json:"total"`
+	Limit       int64   `json:"limit"`
+	Page        int64   `json:"page"`
+	Offset      int64   `json:"offset"`
+	Total       int64   `json:"total"`
+	After       *string `json:"after"`
+	EndCursor   *string `json:"end_cursor"`
+	HasNextPage bool    `json:"has_next_page"`
 }
 
 type PaginationInput struct {
-	Limit *int64 `json:"limit"`
-	Page  *int64 `json:"page"`
+	Limit *int64  `json:"limit"`
+	Page  *int64  `json:"page"`
+	First *int64  `json:"first"`
+	After *string `json:"after"`
 }
 
 type ResendVerifyEmailInput struct {
@@ -199,9 +419,36 @@ type Response struct {
 	Message string `json:"message"`
 }
 
+type RetireJWTKeyInput struct {
+	Kid string `json:"kid"`
+}
+
+type RevokeGrantInput struct {
+	ClientID string `json:"client_id"`
+}
+
+type RevokeSessionInput struct {
+	Nonce string `json:"nonce"`
+}
+
+type RevokeUserSessionInput struct {
+	UserID string `json:"user_id"`
+	Nonce  string `json:"nonce"`
+}
+
+type RotateJWTKeyInput struct {
+	Type        *string `json:"type"`
+	ActivatesAt *int64  `json:"activates_at"`
+}
+
+type SendOTPInput struct {
+	PhoneNumber string `json:"phone_number"`
+}
+
 type SessionQueryInput struct {
-	Roles []string `json:"roles"`
-	Scope []string `json:"scope"`
+	Roles          []string `json:"roles"`
+	Scope          []string `json:"scope"`
+	OrganizationID *string  `json:"organization_id"`
 }
 
 type SignUpInput struct {
@@ -221,6 +468,11 @@ type SignUpInput struct {
 	RedirectURI     *string  `json:"redirect_uri"`
 }
 
+type TOTPEnrollment struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
 type TestEndpointRequest struct {
 	Endpoint  string                 `json:"endpoint"`
 	EventName string                 `json:"event_name"`
@@ -236,6 +488,23 @@ type UpdateAccessInput struct {
 	UserID string `json:"user_id"`
 }
 
+type UpdateClientRequest struct {
+	ID                          string   `json:"id"`
+	Name                        *string  `json:"name"`
+	RedirectUris                []string `json:"redirect_uris"`
+	AllowedScopes               []string `json:"allowed_scopes"`
+	GrantTypes                  []string `json:"grant_types"`
+	AccessTokenExpiryTime       *string  `json:"access_token_expiry_time"`
+	RefreshTokenExpiryTime      *string  `json:"refresh_token_expiry_time"`
+	PostLogoutRedirectUris      []string `json:"post_logout_redirect_uris"`
+	BackchannelLogoutURI        *string  `json:"backchannel_logout_uri"`
+	FrontchannelLogoutURI       *string  `json:"frontchannel_logout_uri"`
+	IDTokenEncryptedResponseAlg *string  `json:"id_token_encrypted_response_alg"`
+	IDTokenEncryptedResponseEnc *string  `json:"id_token_encrypted_response_enc"`
+	IDTokenEncryptionKey        *string  `json:"id_token_encryption_key"`
+	RegenerateClientSecret      *bool    `json:"regenerate_client_secret"`
+}
+
 type UpdateEmailTemplateRequest struct {
 	ID        string  `json:"id"`
 	EventName *string `json:"event_name"`
@@ -244,9 +513,15 @@ type UpdateEmailTemplateRequest struct {
 
 type UpdateEnvInput struct {
 	AccessTokenExpiryTime      *string  `json:"ACCESS_TOKEN_EXPIRY_TIME"`
+	RefreshTokenExpiryTime     *string  `json:"REFRESH_TOKEN_EXPIRY_TIME"`
+	RefreshTokenMaxLifetime    *string  `json:"REFRESH_TOKEN_MAX_LIFETIME"`
+	MaxActiveSessions          *string  `json:"MAX_ACTIVE_SESSIONS"`
+	SessionIDLeTimeout         *string  `json:"SESSION_IDLE_TIMEOUT"`
+	SessionMaxLifetime         *string  `json:"SESSION_MAX_LIFETIME"`
 	AdminSecret                *string  `json:"ADMIN_SECRET"`
 	CustomAccessTokenScript    *string  `json:"CUSTOM_ACCESS_TOKEN_SCRIPT"`
 	OldAdminSecret             *string  `json:"OLD_ADMIN_SECRET"`
+	ScimToken                  *string  `json:"SCIM_TOKEN"`
 	SMTPHost                   *string  `json:"SMTP_HOST"`
 	SMTPPort                   *string  `json:"SMTP_PORT"`
 	SMTPUsername               *string  `json:"SMTP_USERNAME"`
@@ -269,6 +544,7 @@ type UpdateEnvInput struct {
 	Roles                      []string `json:"ROLES"`
 	ProtectedRoles             []string `json:"PROTECTED_ROLES"`
 	DefaultRoles               []string `json:"DEFAULT_ROLES"`
+	MfaRequiredRoles           []string `json:"MFA_REQUIRED_ROLES"`
 	JwtRoleClaim               *string  `json:"JWT_ROLE_CLAIM"`
 	GoogleClientID             *string  `json:"GOOGLE_CLIENT_ID"`
 	GoogleClientSecret         *string  `json:"GOOGLE_CLIENT_SECRET"`
@@ -280,10 +556,29 @@ type UpdateEnvInput struct {
 	LinkedinClientSecret       *string  `json:"LINKEDIN_CLIENT_SECRET"`
 	AppleClientID              *string  `json:"APPLE_CLIENT_ID"`
 	AppleClientSecret          *string  `json:"APPLE_CLIENT_SECRET"`
+	OidcProviders              *string  `json:"OIDC_PROVIDERS"`
+	SamlIDPEntityID            *string  `json:"SAML_IDP_ENTITY_ID"`
+	SamlIDPSsoURL              *string  `json:"SAML_IDP_SSO_URL"`
+	SamlIDPCertificate         *string  `json:"SAML_IDP_CERTIFICATE"`
+	SamlAttributeMapping       *string  `json:"SAML_ATTRIBUTE_MAPPING"`
 	OrganizationName           *string  `json:"ORGANIZATION_NAME"`
 	OrganizationLogo           *string  `json:"ORGANIZATION_LOGO"`
 }
 
+type UpdateOrganizationMemberRequest struct {
+	OrganizationID string   `json:"organization_id"`
+	UserID         string   `json:"user_id"`
+	Roles          []string `json:"roles"`
+}
+
+type UpdateOrganizationRequest struct {
+	ID           string   `json:"id"`
+	Name         *string  `json:"name"`
+	DisplayName  *string  `json:"display_name"`
+	Roles        []string `json:"roles"`
+	DefaultRoles []string `json:"default_roles"`
+}
+
 type UpdateProfileInput struct {
 	OldPassword        *string `json:"old_password"`
 	NewPassword        *string `json:"new_password"`
@@ -323,24 +618,39 @@ type UpdateWebhookRequest struct {
 }
 
 type User struct {
-	ID                  string   `json:"id"`
-	Email               string   `json:"email"`
-	EmailVerified       bool     `json:"email_verified"`
-	SignupMethods       string   `json:"signup_methods"`
-	GivenName           *string  `json:"given_name"`
-	FamilyName          *string  `json:"family_name"`
-	MiddleName          *string  `json:"middle_name"`
-	Nickname            *string  `json:"nickname"`
-	PreferredUsername   *string  `json:"preferred_username"`
-	Gender              *string  `json:"gender"`
-	Birthdate           *string  `json:"birthdate"`
-	PhoneNumber         *string  `json:"phone_number"`
-	PhoneNumberVerified *bool    `json:"phone_number_verified"`
-	Picture             *string  `json:"picture"`
-	Roles               []string `json:"roles"`
-	CreatedAt           *int64   `json:"created_at"`
-	UpdatedAt           *int64   `json:"updated_at"`
-	RevokedTimestamp    *int64   `json:"revoked_timestamp"`
+	ID                       string   `json:"id"`
+	Email                    string   `json:"email"`
+	EmailVerified            bool     `json:"email_verified"`
+	SignupMethods            string   `json:"signup_methods"`
+	GivenName                *string  `json:"given_name"`
+	FamilyName               *string  `json:"family_name"`
+	MiddleName               *string  `json:"middle_name"`
+	Nickname                 *string  `json:"nickname"`
+	PreferredUsername        *string  `json:"preferred_username"`
+	Gender                   *string  `json:"gender"`
+	Birthdate                *string  `json:"birthdate"`
+	PhoneNumber              *string  `json:"phone_number"`
+	PhoneNumberVerified      *bool    `json:"phone_number_verified"`
+	Picture                  *string  `json:"picture"`
+	Roles                    []string `json:"roles"`
+	CreatedAt                *int64   `json:"created_at"`
+	UpdatedAt                *int64   `json:"updated_at"`
+	RevokedTimestamp         *int64   `json:"revoked_timestamp"`
+	IsMultiFactorAuthEnabled *bool    `json:"is_multi_factor_auth_enabled"`
+}
+
+type UserSession struct {
+	Nonce       string  `json:"nonce"`
+	LoginMethod string  `json:"login_method"`
+	UserAgent   *string `json:"user_agent"`
+	IP          *string `json:"ip"`
+	CreatedAt   *int64  `json:"created_at"`
+	LastUsedAt  *int64  `json:"last_used_at"`
+	IsCurrent   bool    `json:"is_current"`
+}
+
+type UserSessionsInput struct {
+	UserID string `json:"user_id"`
 }
 
 type Users struct {
@@ -348,6 +658,22 @@ type Users struct {
 	Users      []*User     `json:"users"`
 }
 
+type UsersFilterInput struct {
+	Email           *string `json:"email"`
+	Role            *string `json:"role"`
+	SignupMethod    *string `json:"signup_method"`
+	IsEmailVerified *bool   `json:"is_email_verified"`
+	IsPhoneVerified *bool   `json:"is_phone_verified"`
+	IsRevoked       *bool   `json:"is_revoked"`
+	CreatedAfter    *int64  `json:"created_after"`
+	CreatedBefore   *int64  `json:"created_before"`
+}
+
+type UsersSortInput struct {
+	SortBy    *string `json:"sort_by"`
+	SortOrder *string `json:"sort_order"`
+}
+
 type ValidateJWTTokenInput struct {
 	TokenType string   `json:"token_type"`
 	Token     string   `json:"token"`
@@ -375,10 +701,44 @@ type VerificationRequests struct {
 	VerificationRequests []*VerificationRequest `json:"verification_requests"`
 }
 
+type VerifyDeviceCodeInput struct {
+	UserCode string `json:"user_code"`
+	Approve  bool   `json:"approve"`
+}
+
 type VerifyEmailInput struct {
 	Token string `json:"token"`
 }
 
+type VerifyOTPRequest struct {
+	Email       *string `json:"email"`
+	PhoneNumber *string `json:"phone_number"`
+	Otp         string  `json:"otp"`
+}
+
+type WebauthnLoginInput struct {
+	ID                string   `json:"id"`
+	ClientDataJSON    string   `json:"client_data_json"`
+	AuthenticatorData string   `json:"authenticator_data"`
+	Signature         string   `json:"signature"`
+	Roles             []string `json:"roles"`
+	Scope             []string `json:"scope"`
+}
+
+type WebauthnLoginOptionsInput struct {
+	Email *string `json:"email"`
+}
+
+type WebauthnOptionsResponse struct {
+	Options map[string]interface{} `json:"options"`
+}
+
+type WebauthnRegisterInput struct {
+	ID                string `json:"id"`
+	ClientDataJSON    string `json:"client_data_json"`
+	AttestationObject string `json:"attestation_object"`
+}
+
 type Webhook struct {
 	ID        string                 `json:"id"`
 	EventName *string                `json:"event_name"`
@@ -51,6 +51,58 @@ func (r *mutationResolver) Revoke(ctx context.Context, params model.OAuthRevokeI
 	return resolvers.RevokeResolver(ctx, params)
 }
 
+func (r *mutationResolver) VerifyOtp(ctx context.Context, params model.VerifyOTPRequest) (*model.AuthResponse, error) {
+	return resolvers.VerifyOtpResolver(ctx, params)
+}
+
+func (r *mutationResolver) SendOtp(ctx context.Context, params model.SendOTPInput) (*model.Response, error) {
+	return resolvers.SendOtpResolver(ctx, params)
+}
+
+func (r *mutationResolver) EnrollTotp(ctx context.Context) (*model.TOTPEnrollment, error) {
+	return resolvers.EnrollTotpResolver(ctx)
+}
+
+func (r *mutationResolver) ConfirmTotp(ctx context.Context, params model.ConfirmTOTPInput) (*model.Response, error) {
+	return resolvers.ConfirmTotpResolver(ctx, params)
+}
+
+func (r *mutationResolver) WebauthnRegistrationOptions(ctx context.Context) (*model.WebauthnOptionsResponse, error) {
+	return resolvers.WebauthnRegistrationOptionsResolver(ctx)
+}
+
+func (r *mutationResolver) WebauthnRegister(ctx context.Context, params model.WebauthnRegisterInput) (*model.Response, error) {
+	return resolvers.WebauthnRegisterResolver(ctx, params)
+}
+
+func (r *mutationResolver) WebauthnLoginOptions(ctx context.Context, params *model.WebauthnLoginOptionsInput) (*model.WebauthnOptionsResponse, error) {
+	return resolvers.WebauthnLoginOptionsResolver(ctx, params)
+}
+
+func (r *mutationResolver) WebauthnLogin(ctx context.Context, params model.WebauthnLoginInput) (*model.AuthResponse, error) {
+	return resolvers.WebauthnLoginResolver(ctx, params)
+}
+
+func (r *mutationResolver) VerifyDeviceCode(ctx context.Context, params model.VerifyDeviceCodeInput) (*model.Response, error) {
+	return resolvers.VerifyDeviceCodeResolver(ctx, params)
+}
+
+func (r *mutationResolver) Consent(ctx context.Context, params model.ConsentInput) (*model.ConsentResponse, error) {
+	return resolvers.ConsentResolver(ctx, params)
+}
+
+func (r *mutationResolver) RevokeGrant(ctx context.Context, params model.RevokeGrantInput) (*model.Response, error) {
+	return resolvers.RevokeGrantResolver(ctx, params)
+}
+
+func (r *mutationResolver) RevokeSession(ctx context.Context, params model.RevokeSessionInput) (*model.Response, error) {
+	return resolvers.RevokeSessionResolver(ctx, params)
+}
+
+func (r *mutationResolver) RevokeOtherSessions(ctx context.Context) (*model.Response, error) {
+	return resolvers.RevokeOtherSessionsResolver(ctx)
+}
+
 func (r *mutationResolver) DeleteUser(ctx context.Context, params model.DeleteUserInput) (*model.Response, error) {
 	return resolvers.DeleteUserResolver(ctx, params)
 }
@@ -91,6 +143,14 @@ func (r *mutationResolver) GenerateJwtKeys(ctx context.Context, params model.Gen
 	return resolvers.GenerateJWTKeysResolver(ctx, params)
 }
 
+func (r *mutationResolver) RotateJwtKey(ctx context.Context, params model.RotateJWTKeyInput) (*model.JWTKey, error) {
+	return resolvers.RotateJWTKeyResolver(ctx, params)
+}
+
+func (r *mutationResolver) RetireJwtKey(ctx context.Context, params model.RetireJWTKeyInput) (*model.JWTKey, error) {
+	return resolvers.RetireJWTKeyResolver(ctx, params)
+}
+
 func (r *mutationResolver) AddWebhook(ctx context.Context, params model.AddWebhookRequest) (*model.Response, error) {
 	return resolvers.AddWebhookResolver(ctx, params)
 }
@@ -119,6 +179,58 @@ func (r *mutationResolver) DeleteEmailTemplate(ctx context.Context, params model
 	return resolvers.DeleteEmailTemplateResolver(ctx, params)
 }
 
+func (r *mutationResolver) AddClient(ctx context.Context, params model.AddClientRequest) (*model.ClientResponse, error) {
+	return resolvers.AddClientResolver(ctx, params)
+}
+
+func (r *mutationResolver) UpdateClient(ctx context.Context, params model.UpdateClientRequest) (*model.ClientResponse, error) {
+	return resolvers.UpdateClientResolver(ctx, params)
+}
+
+func (r *mutationResolver) DeleteClient(ctx context.Context, params model.ClientRequest) (*model.Response, error) {
+	return resolvers.DeleteClientResolver(ctx, params)
+}
+
+func (r *mutationResolver) RevokeUserSession(ctx context.Context, params model.RevokeUserSessionInput) (*model.Response, error) {
+	return resolvers.RevokeUserSessionResolver(ctx, params)
+}
+
+func (r *mutationResolver) ImportUsers(ctx context.Context, params model.ImportUsersInput) (*model.ImportUsersResponse, error) {
+	return resolvers.ImportUsersResolver(ctx, params)
+}
+
+func (r *mutationResolver) AddOrganization(ctx context.Context, params model.AddOrganizationRequest) (*model.Organization, error) {
+	return resolvers.AddOrganizationResolver(ctx, params)
+}
+
+func (r *mutationResolver) UpdateOrganization(ctx context.Context, params model.UpdateOrganizationRequest) (*model.Organization, error) {
+	return resolvers.UpdateOrganizationResolver(ctx, params)
+}
+
+func (r *mutationResolver) DeleteOrganization(ctx context.Context, params model.OrganizationRequest) (*model.Response, error) {
+	return resolvers.DeleteOrganizationResolver(ctx, params)
+}
+
+func (r *mutationResolver) AddOrganizationMember(ctx context.Context, params model.AddOrganizationMemberRequest) (*model.OrganizationMember, error) {
+	return resolvers.AddOrganizationMemberResolver(ctx, params)
+}
+
+func (r *mutationResolver) UpdateOrganizationMember(ctx context.Context, params model.UpdateOrganizationMemberRequest) (*model.OrganizationMember, error) {
+	return resolvers.UpdateOrganizationMemberResolver(ctx, params)
+}
+
+func (r *mutationResolver) RemoveOrganizationMember(ctx context.Context, params model.OrganizationMemberRequest) (*model.Response, error) {
+	return resolvers.RemoveOrganizationMemberResolver(ctx, params)
+}
+
+func (r *mutationResolver) InviteOrganizationMembers(ctx context.Context, params model.InviteOrganizationMembersRequest) (*model.Response, error) {
+	return resolvers.InviteOrganizationMembersResolver(ctx, params)
+}
+
+func (r *mutationResolver) RevokeOrganizationInvitation(ctx context.Context, params model.OrganizationInvitationRequest) (*model.Response, error) {
+	return resolvers.RevokeOrganizationInvitationResolver(ctx, params)
+}
+
 func (r *queryResolver) Meta(ctx context.Context) (*model.Meta, error) {
 	return resolvers.MetaResolver(ctx)
 }
@@ -135,8 +247,24 @@ func (r *queryResolver) ValidateJwtToken(ctx context.Context, params model.Valid
 	return resolvers.ValidateJwtTokenResolver(ctx, params)
 }
 
-func (r *queryResolver) Users(ctx context.Context, params *model.PaginatedInput) (*model.Users, error) {
-	return resolvers.UsersResolver(ctx, params)
+func (r *queryResolver) ConsentRequest(ctx context.Context, params model.ConsentRequestInput) (*model.ConsentRequest, error) {
+	return resolvers.ConsentRequestResolver(ctx, params)
+}
+
+func (r *queryResolver) Grants(ctx context.Context) ([]*model.Grant, error) {
+	return resolvers.GrantsResolver(ctx)
+}
+
+func (r *queryResolver) Sessions(ctx context.Context) ([]*model.UserSession, error) {
+	return resolvers.SessionsResolver(ctx)
+}
+
+func (r *queryResolver) UserOrganizations(ctx context.Context) ([]*model.OrganizationMember, error) {
+	return resolvers.UserOrganizationsResolver(ctx)
+}
+
+func (r *queryResolver) Users(ctx context.Context, params *model.PaginatedInput, filter *model.UsersFilterInput, sort *model.UsersSortInput) (*model.Users, error) {
+	return resolvers.UsersResolver(ctx, params, filter, sort)
 }
 
 func (r *queryResolver) VerificationRequests(ctx context.Context, params *model.PaginatedInput) (*model.VerificationRequests, error) {
@@ -167,6 +295,38 @@ func (r *queryResolver) EmailTemplates(ctx context.Context, params *model.Pagina
 	return resolvers.EmailTemplatesResolver(ctx, params)
 }
 
+func (r *queryResolver) Client(ctx context.Context, params model.ClientRequest) (*model.Client, error) {
+	return resolvers.ClientResolver(ctx, params)
+}
+
+func (r *queryResolver) Clients(ctx context.Context, params *model.PaginatedInput) (*model.Clients, error) {
+	return resolvers.ClientsResolver(ctx, params)
+}
+
+func (r *queryResolver) JwtKeys(ctx context.Context) (*model.JWTKeys, error) {
+	return resolvers.JWTKeysResolver(ctx)
+}
+
+func (r *queryResolver) UserSessions(ctx context.Context, params model.UserSessionsInput) ([]*model.UserSession, error) {
+	return resolvers.UserSessionsResolver(ctx, params)
+}
+
+func (r *queryResolver) Organization(ctx context.Context, params model.OrganizationRequest) (*model.Organization, error) {
+	return resolvers.OrganizationResolver(ctx, params)
+}
+
+func (r *queryResolver) Organizations(ctx context.Context, params *model.PaginatedInput) (*model.Organizations, error) {
+	return resolvers.OrganizationsResolver(ctx, params)
+}
+
+func (r *queryResolver) OrganizationMembers(ctx context.Context, params model.ListOrganizationMembersRequest) (*model.OrganizationMembers, error) {
+	return resolvers.OrganizationMembersResolver(ctx, params)
+}
+
+func (r *queryResolver) OrganizationInvitations(ctx context.Context, params model.OrganizationRequest) ([]*model.OrganizationInvitation, error) {
+	return resolvers.OrganizationInvitationsResolver(ctx, params)
+}
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
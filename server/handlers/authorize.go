@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -10,20 +12,29 @@ import (
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/authorizerdev/authorizer/server/consent"
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/cookie"
 	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
 )
 
 // AuthorizeHandler is the handler for the /authorize route
 // required params
 // ?redirect_uri = redirect url
-// ?response_mode = to decide if result should be html or re-direct
+// ?response_type = space separated combination of code, token & id_token
+// ?response_mode = query, fragment, form_post or web_message to decide if result should be html or re-direct
 // state[recommended] = to prevent CSRF attack (for authorizer its compulsory)
 // code_challenge = to prevent CSRF attack
 // code_challenge_method = to prevent CSRF attack [only sh256 is supported]
+// prompt = space separated values, none returns login_required / consent_required error instead of showing ui,
+// login forces the re-authentication & consent forces the consent page
+// max_age = maximum seconds since the authentication of user, user has to login again once it is over
+// login_hint = email, phone number or id of the user, user has to login again if the session is of another user
+// nonce = to prevent replay attack, required for id_token response type & returned in the id token
 
 // check the flow for generating and verifying codes: https://developer.okta.com/blog/2019/08/22/okta-authjs-pkce#:~:text=PKCE%20works%20by%20having%20the,is%20called%20the%20Code%20Challenge.
 func AuthorizeHandler() gin.HandlerFunc {
@@ -36,6 +47,11 @@ func AuthorizeHandler() gin.HandlerFunc {
 		clientID := strings.TrimSpace(gc.Query("client_id"))
 		template := "authorize.tmpl"
 		responseMode := strings.TrimSpace(gc.Query("response_mode"))
+		prompt := strings.Fields(gc.Query("prompt"))
+		isPromptNone := utils.StringSliceContains(prompt, constants.PromptNone)
+		loginHint := strings.TrimSpace(gc.Query("login_hint"))
+		maxAgeString := strings.TrimSpace(gc.Query("max_age"))
+		nonce := strings.TrimSpace(gc.Query("nonce"))
 
 		var scope []string
 		if scopeString == "" {
@@ -44,25 +60,81 @@ func AuthorizeHandler() gin.HandlerFunc {
 			scope = strings.Split(scopeString, " ")
 		}
 
+		if responseType == "" {
+			responseType = "token"
+		}
+
+		responseTypes := strings.Fields(responseType)
+		isResponseTypeCode := utils.StringSliceContains(responseTypes, "code")
+		isResponseTypeToken := utils.StringSliceContains(responseTypes, "token")
+		isResponseTypeIDToken := utils.StringSliceContains(responseTypes, "id_token")
+		isValidResponseType := true
+		for _, t := range responseTypes {
+			if t != "code" && t != "token" && t != "id_token" {
+				isValidResponseType = false
+			}
+		}
+
+		// as per oauth 2.0 multiple response types, tokens are returned in fragment by default
 		if responseMode == "" {
 			responseMode = "query"
+			if isResponseTypeToken || isResponseTypeIDToken {
+				responseMode = "fragment"
+			}
 		}
 
-		if responseMode != "query" && responseMode != "web_message" {
+		if responseMode != "query" && responseMode != "fragment" && responseMode != "form_post" && responseMode != "web_message" {
 			log.Debug("Invalid response_mode: ", responseMode)
 			gc.JSON(400, gin.H{"error": "invalid response mode"})
+			return
+		}
+
+		// tokens must not be leaked in the query string
+		if responseMode == "query" && (isResponseTypeToken || isResponseTypeIDToken) {
+			log.Debug("Invalid response_mode for response_type: ", responseType)
+			gc.JSON(400, gin.H{"error": "invalid response mode for the response type"})
+			return
+		}
+
+		// prompt none can't be combined with the prompts that need user interaction
+		if isPromptNone && len(prompt) > 1 {
+			log.Debug("Invalid prompt: ", prompt)
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_request",
+				"error_description": "The prompt none can't be combined with other values",
+			})
+			return
+		}
+
+		var maxAge *int64
+		if maxAgeString != "" {
+			value, err := strconv.ParseInt(maxAgeString, 10, 64)
+			if err != nil || value < 0 {
+				log.Debug("Invalid max_age: ", maxAgeString)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_request",
+					"error_description": "The max_age must be a non-negative number of seconds",
+				})
+				return
+			}
+			maxAge = &value
 		}
 
 		if redirectURI == "" {
 			redirectURI = "/app"
 		}
 
-		isQuery := responseMode == "query"
+		isWebMessage := responseMode == "web_message"
 
-		loginURL := "/app?state=" + state + "&scope=" + strings.Join(scope, " ") + "&redirect_uri=" + redirectURI
+		// login hint is passed to the login page
+		loginHintParam := ""
+		if loginHint != "" {
+			loginHintParam = "&login_hint=" + url.QueryEscape(loginHint)
+		}
+		loginURL := "/app?state=" + state + "&scope=" + strings.Join(scope, " ") + "&redirect_uri=" + redirectURI + loginHintParam
 
 		if clientID == "" {
-			if isQuery {
+			if !isWebMessage {
 				gc.Redirect(http.StatusFound, loginURL)
 			} else {
 				log.Debug("Failed to get client_id: ", clientID)
@@ -79,8 +151,9 @@ func AuthorizeHandler() gin.HandlerFunc {
 			return
 		}
 
-		if client, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID); client != clientID || err != nil {
-			if isQuery {
+		client, err := getOAuthClient(gc, clientID)
+		if err != nil {
+			if !isWebMessage {
 				gc.Redirect(http.StatusFound, loginURL)
 			} else {
 				log.Debug("Invalid client_id: ", clientID)
@@ -97,8 +170,24 @@ func AuthorizeHandler() gin.HandlerFunc {
 			return
 		}
 
+		// redirect_uri must be registered for the client, first registered uri is used by default
+		if client != nil {
+			if strings.TrimSpace(gc.Query("redirect_uri")) == "" {
+				redirectURI = strings.Split(client.RedirectURIs, ",")[0]
+			}
+			if !client.IsRedirectURIAllowed(redirectURI) {
+				log.Debug("Invalid redirect_uri: ", redirectURI)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_redirect_uri",
+					"error_description": "The redirect_uri is not registered for the client",
+				})
+				return
+			}
+			loginURL = "/app?state=" + state + "&scope=" + strings.Join(scope, " ") + "&redirect_uri=" + redirectURI + loginHintParam
+		}
+
 		if state == "" {
-			if isQuery {
+			if !isWebMessage {
 				gc.Redirect(http.StatusFound, loginURL)
 			} else {
 				log.Debug("Failed to get state: ", state)
@@ -115,15 +204,8 @@ func AuthorizeHandler() gin.HandlerFunc {
 			return
 		}
 
-		if responseType == "" {
-			responseType = "token"
-		}
-
-		isResponseTypeCode := responseType == "code"
-		isResponseTypeToken := responseType == "token"
-
-		if !isResponseTypeCode && !isResponseTypeToken {
-			if isQuery {
+		if !isValidResponseType {
+			if !isWebMessage {
 				gc.Redirect(http.StatusFound, loginURL)
 			} else {
 				log.Debug("Invalid response_type: ", responseType)
@@ -140,9 +222,39 @@ func AuthorizeHandler() gin.HandlerFunc {
 			return
 		}
 
+		if client != nil {
+			clientError := ""
+			if isResponseTypeCode && !client.IsGrantTypeAllowed(constants.GrantTypeAuthorizationCode) {
+				clientError = "unauthorized_client"
+			} else if (isResponseTypeToken || isResponseTypeIDToken) && !client.IsGrantTypeAllowed(constants.GrantTypeImplicit) {
+				clientError = "unauthorized_client"
+			} else if !client.IsScopeAllowed(scope) {
+				clientError = "invalid_scope"
+			}
+			if clientError != "" {
+				log.Debug("Invalid authorization request for client: ", clientError)
+				if !isWebMessage {
+					gc.JSON(http.StatusBadRequest, gin.H{
+						"error": clientError,
+					})
+				} else {
+					gc.HTML(http.StatusOK, template, gin.H{
+						"target_origin": redirectURI,
+						"authorization_response": map[string]interface{}{
+							"type": "authorization_response",
+							"response": map[string]string{
+								"error": clientError,
+							},
+						},
+					})
+				}
+				return
+			}
+		}
+
 		if isResponseTypeCode {
 			if codeChallenge == "" {
-				if isQuery {
+				if !isWebMessage {
 					gc.Redirect(http.StatusFound, loginURL)
 				} else {
 					log.Debug("Failed to get code_challenge: ", codeChallenge)
@@ -160,18 +272,22 @@ func AuthorizeHandler() gin.HandlerFunc {
 			}
 		}
 
-		sessionToken, err := cookie.GetSession(gc)
-		if err != nil {
-			if isQuery {
-				gc.Redirect(http.StatusFound, loginURL)
+		// nonce binds the id token to the client session to prevent replay attacks
+		if isResponseTypeIDToken && nonce == "" {
+			log.Debug("Failed to get nonce: ", nonce)
+			if !isWebMessage {
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_request",
+					"error_description": "The nonce is required for the id_token response type",
+				})
 			} else {
 				gc.HTML(http.StatusOK, template, gin.H{
 					"target_origin": redirectURI,
 					"authorization_response": map[string]interface{}{
 						"type": "authorization_response",
 						"response": map[string]string{
-							"error":             "login_required",
-							"error_description": "Login is required",
+							"error":             "invalid_request",
+							"error_description": "The nonce is required for the id_token response type",
 						},
 					},
 				})
@@ -179,10 +295,18 @@ func AuthorizeHandler() gin.HandlerFunc {
 			return
 		}
 
-		// get session from cookie
-		claims, err := token.ValidateBrowserSession(gc, sessionToken)
-		if err != nil {
-			if isQuery {
+		// loginRequired redirects to the login page,
+		// login_required error is returned if login page can't be shown
+		loginRequired := func() {
+			if isPromptNone {
+				writeAuthorizeResponse(gc, responseMode, redirectURI, map[string]interface{}{
+					"error":             "login_required",
+					"error_description": "Login is required",
+					"state":             state,
+				})
+				return
+			}
+			if !isWebMessage {
 				gc.Redirect(http.StatusFound, loginURL)
 			} else {
 				gc.HTML(http.StatusOK, template, gin.H{
@@ -196,12 +320,24 @@ func AuthorizeHandler() gin.HandlerFunc {
 					},
 				})
 			}
+		}
+
+		sessionToken, err := cookie.GetSession(gc)
+		if err != nil {
+			loginRequired()
+			return
+		}
+
+		// get session from cookie
+		claims, err := token.ValidateBrowserSession(gc, sessionToken)
+		if err != nil {
+			loginRequired()
 			return
 		}
 		userID := claims.Subject
 		user, err := db.Provider.GetUserByID(gc, userID)
 		if err != nil {
-			if isQuery {
+			if !isWebMessage {
 				gc.Redirect(http.StatusFound, loginURL)
 			} else {
 				gc.HTML(http.StatusOK, template, gin.H{
@@ -223,129 +359,216 @@ func AuthorizeHandler() gin.HandlerFunc {
 			sessionKey = claims.LoginMethod + ":" + user.ID
 		}
 
-		// if user is logged in
-		// based on the response type code, generate the response
-		if isResponseTypeCode {
-			// rollover the session for security
-			go memorystore.Provider.DeleteUserSession(sessionKey, claims.Nonce)
-			nonce := uuid.New().String()
-			newSessionTokenData, newSessionToken, err := token.CreateSessionToken(user, nonce, claims.Roles, scope, claims.LoginMethod)
-			if err != nil {
-				if isQuery {
-					gc.Redirect(http.StatusFound, loginURL)
+		// user has to authenticate again for prompt login, max_age & login hint of another user
+		isReauthenticationRequired := utils.StringSliceContains(prompt, constants.PromptLogin) ||
+			(maxAge != nil && claims.IsOlderThan(*maxAge)) ||
+			(loginHint != "" && !isLoginHintOf(user, loginHint))
+		if isReauthenticationRequired {
+			// existing session is removed so that the login page does not reuse it
+			if !isPromptNone {
+				memorystore.Provider.DeleteUserSession(sessionKey, claims.Nonce)
+				cookie.DeleteSession(gc)
+			}
+			loginRequired()
+			return
+		}
+
+		// registered clients need the consent of user for the requested scopes
+		if client != nil {
+			isConsentGiven := false
+			if consentChallenge := strings.TrimSpace(gc.Query(constants.ConsentChallengeParam)); consentChallenge != "" {
+				consentRequest, err := consent.Get(consentChallenge)
+				if err != nil || consentRequest.UserID != user.ID || consentRequest.ClientID != client.GetClientID() || consentRequest.IsExpired() {
+					log.Debug("Invalid consent_challenge: ", consentChallenge)
 				} else {
-					gc.HTML(http.StatusOK, template, gin.H{
-						"target_origin": redirectURI,
-						"authorization_response": map[string]interface{}{
-							"type": "authorization_response",
-							"response": map[string]string{
-								"error":             "login_required",
-								"error_description": "Login is required",
-							},
-						},
+					consent.Remove(consentRequest)
+					if consentRequest.Status == constants.ConsentRequestStatusDenied {
+						writeAuthorizeResponse(gc, responseMode, redirectURI, map[string]interface{}{
+							"error":             "access_denied",
+							"error_description": "The user denied the consent",
+							"state":             state,
+						})
+						return
+					}
+					isConsentGiven = consentRequest.Status == constants.ConsentRequestStatusApproved
+				}
+			}
+
+			if !isConsentGiven && (utils.StringSliceContains(prompt, constants.PromptConsent) || !consent.IsGranted(gc, user.ID, client.GetClientID(), scope)) {
+				// consent page can't be shown without user interaction
+				if isPromptNone || isWebMessage {
+					writeAuthorizeResponse(gc, responseMode, redirectURI, map[string]interface{}{
+						"error":             "consent_required",
+						"error_description": "Consent of user is required",
+						"state":             state,
 					})
+					return
 				}
+
+				// prompt & max_age are already honored by the login checked above
+				authorizeQuery := gc.Request.URL.Query()
+				authorizeQuery.Del(constants.ConsentChallengeParam)
+				authorizeQuery.Del("prompt")
+				authorizeQuery.Del("max_age")
+				consentRequest, err := consent.NewRequest(user.ID, client.GetClientID(), scope, authorizeQuery.Encode())
+				if err != nil {
+					log.Debug("Failed to create consent request: ", err)
+					gc.Redirect(http.StatusFound, loginURL)
+					return
+				}
+				gc.Redirect(http.StatusFound, "/app/consent?"+constants.ConsentChallengeParam+"="+consentRequest.Challenge)
 				return
 			}
+		}
 
-			memorystore.Provider.SetUserSession(user.ID, constants.TokenTypeSessionToken+"_"+newSessionTokenData.Nonce, newSessionToken)
-			cookie.SetSession(gc, newSessionToken)
-			code := uuid.New().String()
-			memorystore.Provider.SetState(codeChallenge, code+"@"+newSessionToken)
-			gc.HTML(http.StatusOK, template, gin.H{
-				"target_origin": redirectURI,
-				"authorization_response": map[string]interface{}{
-					"type": "authorization_response",
-					"response": map[string]string{
-						"code":  code,
-						"state": state,
+		// if user is logged in
+		// based on the response type, generate the response
+		res := map[string]interface{}{
+			"state": state,
+		}
+
+		sessionError := func() {
+			if !isWebMessage {
+				gc.Redirect(http.StatusFound, loginURL)
+			} else {
+				gc.HTML(http.StatusOK, template, gin.H{
+					"target_origin": redirectURI,
+					"authorization_response": map[string]interface{}{
+						"type": "authorization_response",
+						"response": map[string]string{
+							"error":             "login_required",
+							"error_description": "Login is required",
+						},
 					},
-				},
-			})
-			return
+				})
+			}
 		}
 
-		if isResponseTypeToken {
-			// rollover the session for security
-			authToken, err := token.CreateAuthToken(gc, user, claims.Roles, scope, claims.LoginMethod)
+		code := ""
+		if isResponseTypeCode {
+			code = uuid.New().String()
+		}
+
+		// rollover the session for security,
+		// session is created once & the code is bound to it for all the response types
+		newSessionToken := ""
+		if isResponseTypeToken || isResponseTypeIDToken {
+			authToken, err := token.CreateAuthToken(gc, user, claims.Roles, scope, claims.LoginMethod, token.AuthTokenOptions{
+				AuthInfo: &claims.AuthenticationInfo,
+				Client:   client,
+				Authorization: &token.AuthorizationParams{
+					Nonce:                 nonce,
+					Code:                  code,
+					IsAccessTokenReturned: isResponseTypeToken,
+				},
+			})
 			if err != nil {
-				if isQuery {
-					gc.Redirect(http.StatusFound, loginURL)
-				} else {
-					gc.HTML(http.StatusOK, template, gin.H{
-						"target_origin": redirectURI,
-						"authorization_response": map[string]interface{}{
-							"type": "authorization_response",
-							"response": map[string]string{
-								"error":             "login_required",
-								"error_description": "Login is required",
-							},
-						},
-					})
-				}
+				sessionError()
 				return
 			}
 
 			go memorystore.Provider.DeleteUserSession(sessionKey, claims.Nonce)
 			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash)
-			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token)
 			cookie.SetSession(gc, authToken.FingerPrintHash)
+			newSessionToken = authToken.FingerPrintHash
 
-			expiresIn := authToken.AccessToken.ExpiresAt - time.Now().Unix()
-			if expiresIn <= 0 {
-				expiresIn = 1
-			}
+			// id token is returned with access token as well for the existing clients
+			res["id_token"] = authToken.IDToken.Token
 
-			// used of query mode
-			params := "access_token=" + authToken.AccessToken.Token + "&token_type=bearer&expires_in=" + strconv.FormatInt(expiresIn, 10) + "&state=" + state + "&id_token=" + authToken.IDToken.Token
+			if isResponseTypeToken {
+				memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token)
 
-			res := map[string]interface{}{
-				"access_token": authToken.AccessToken.Token,
-				"id_token":     authToken.IDToken.Token,
-				"state":        state,
-				"scope":        scope,
-				"token_type":   "Bearer",
-				"expires_in":   expiresIn,
-			}
+				expiresIn := authToken.AccessToken.ExpiresAt - time.Now().Unix()
+				if expiresIn <= 0 {
+					expiresIn = 1
+				}
 
-			if authToken.RefreshToken != nil {
-				res["refresh_token"] = authToken.RefreshToken.Token
-				params += "&refresh_token=" + authToken.RefreshToken.Token
-				memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeRefreshToken+"_"+authToken.FingerPrint, authToken.RefreshToken.Token)
-			}
+				res["access_token"] = authToken.AccessToken.Token
+				res["scope"] = scope
+				res["token_type"] = "Bearer"
+				res["expires_in"] = expiresIn
 
-			if isQuery {
-				if strings.Contains(redirectURI, "?") {
-					gc.Redirect(http.StatusFound, redirectURI+"&"+params)
-				} else {
-					gc.Redirect(http.StatusFound, redirectURI+"?"+params)
+				if authToken.RefreshToken != nil {
+					res["refresh_token"] = authToken.RefreshToken.Token
+					memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeRefreshToken+"_"+authToken.FingerPrint, authToken.RefreshToken.Token)
 				}
-			} else {
-				gc.HTML(http.StatusOK, template, gin.H{
-					"target_origin": redirectURI,
-					"authorization_response": map[string]interface{}{
-						"type":     "authorization_response",
-						"response": res,
-					},
-				})
 			}
-			return
+		} else {
+			go memorystore.Provider.DeleteUserSession(sessionKey, claims.Nonce)
+			newSessionTokenData, sessionToken, err := token.CreateSessionToken(user, uuid.New().String(), claims.Roles, scope, claims.LoginMethod, claims.AuthenticationInfo)
+			if err != nil {
+				sessionError()
+				return
+			}
+
+			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+newSessionTokenData.Nonce, sessionToken)
+			cookie.SetSession(gc, sessionToken)
+			newSessionToken = sessionToken
+		}
+
+		if isResponseTypeCode {
+			// code is bound to the client that requested it
+			memorystore.Provider.SetState(codeChallenge, code+"@"+newSessionToken+"@"+clientID)
+			res["code"] = code
 		}
 
-		if isQuery {
-			gc.Redirect(http.StatusFound, loginURL)
+		writeAuthorizeResponse(gc, responseMode, redirectURI, res)
+	}
+}
+
+// isLoginHintOf returns true if login hint is the email, phone number or id of user
+func isLoginHintOf(user models.User, loginHint string) bool {
+	if strings.EqualFold(user.Email, loginHint) || user.ID == loginHint {
+		return true
+	}
+	return user.PhoneNumber != nil && *user.PhoneNumber == loginHint
+}
+
+// writeAuthorizeResponse returns the authorization response to redirect uri as per response mode.
+// web_message posts the response to parent window, form_post auto submits the response as html form
+// & query or fragment redirects with the response in the url
+func writeAuthorizeResponse(gc *gin.Context, responseMode, redirectURI string, res map[string]interface{}) {
+	if responseMode == "web_message" {
+		gc.HTML(http.StatusOK, "authorize.tmpl", gin.H{
+			"target_origin": redirectURI,
+			"authorization_response": map[string]interface{}{
+				"type":     "authorization_response",
+				"response": res,
+			},
+		})
+		return
+	}
+
+	params := map[string]string{}
+	for key, value := range res {
+		if values, ok := value.([]string); ok {
+			params[key] = strings.Join(values, " ")
 		} else {
-			// by default return with error
-			gc.HTML(http.StatusOK, template, gin.H{
-				"target_origin": redirectURI,
-				"authorization_response": map[string]interface{}{
-					"type": "authorization_response",
-					"response": map[string]string{
-						"error":             "login_required",
-						"error_description": "Login is required",
-					},
-				},
-			})
+			params[key] = fmt.Sprint(value)
 		}
 	}
+
+	if responseMode == "form_post" {
+		gc.HTML(http.StatusOK, "authorize_form_post.tmpl", gin.H{
+			"target": redirectURI,
+			"params": params,
+		})
+		return
+	}
+
+	values := url.Values{}
+	for key, value := range params {
+		values.Set(key, value)
+	}
+
+	if responseMode == "fragment" {
+		gc.Redirect(http.StatusFound, redirectURI+"#"+values.Encode())
+		return
+	}
+
+	if strings.Contains(redirectURI, "?") {
+		gc.Redirect(http.StatusFound, redirectURI+"&"+values.Encode())
+	} else {
+		gc.Redirect(http.StatusFound, redirectURI+"?"+values.Encode())
+	}
 }
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// getOAuthClient returns the registered oauth client for client_id.
+// nil client is returned for the instance client configured with CLIENT_ID,
+// it is not restricted to redirect uris, scopes or grant types
+func getOAuthClient(ctx context.Context, clientID string) (*models.Client, error) {
+	instanceClientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+	if err == nil && instanceClientID == clientID {
+		return nil, nil
+	}
+
+	client, err := db.Provider.GetClientByID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+// bindOAuthRequest returns the parameters of oauth request, body can be json or form encoded
+func bindOAuthRequest(gc *gin.Context) (map[string]string, error) {
+	reqBody := map[string]string{}
+	if gc.ContentType() == "application/x-www-form-urlencoded" {
+		if err := gc.Request.ParseForm(); err != nil {
+			return nil, err
+		}
+		for key := range gc.Request.PostForm {
+			reqBody[key] = gc.Request.PostForm.Get(key)
+		}
+		return reqBody, nil
+	}
+
+	if err := gc.BindJSON(&reqBody); err != nil {
+		return nil, err
+	}
+	return reqBody, nil
+}
+
+// getClientCredentials returns the client_id & client_secret of oauth request,
+// client can authenticate with client_secret_basic or client_secret_post
+func getClientCredentials(gc *gin.Context, reqBody map[string]string) (string, string) {
+	// client_secret_basic, credentials are form encoded before base64 encoding
+	if clientID, clientSecret, ok := gc.Request.BasicAuth(); ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+		return strings.TrimSpace(clientID), strings.TrimSpace(clientSecret)
+	}
+
+	return strings.TrimSpace(reqBody["client_id"]), strings.TrimSpace(reqBody["client_secret"])
+}
+
+// isValidClientSecret validates the secret of registered client or
+// CLIENT_SECRET of the instance client if client is nil
+func isValidClientSecret(client *models.Client, clientSecret string) bool {
+	if clientSecret == "" {
+		return false
+	}
+
+	if client == nil {
+		instanceClientSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientSecret)
+		if err != nil || instanceClientSecret == "" {
+			return false
+		}
+		return subtle.ConstantTimeCompare([]byte(instanceClientSecret), []byte(clientSecret)) == 1
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(client.ClientSecret), []byte(clientSecret)) == nil
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/device"
+	"github.com/authorizerdev/authorizer/server/parsers"
+)
+
+// DeviceAuthorizationHandler to handle /oauth/device_authorization requests as per RFC 8628.
+// It issues the device_code that is polled by device at token endpoint
+// & the user_code that is approved by user at /app/device
+func DeviceAuthorizationHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		reqBody, err := bindOAuthRequest(gc)
+		if err != nil {
+			log.Debug("Error binding request: ", err)
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_request",
+				"error_description": err.Error(),
+			})
+			return
+		}
+
+		clientID, clientSecret := getClientCredentials(gc, reqBody)
+		if clientID == "" {
+			log.Debug("Client ID is empty")
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             "client_id_required",
+				"error_description": "The client id is required",
+			})
+			return
+		}
+
+		client, err := getOAuthClient(gc, clientID)
+		if err != nil {
+			log.Debug("Client ID is invalid: ", clientID)
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_client_id",
+				"error_description": "The client id is invalid",
+			})
+			return
+		}
+
+		scope := strings.Fields(reqBody["scope"])
+		if len(scope) == 0 {
+			scope = []string{"openid", "profile", "email"}
+		}
+
+		if client != nil {
+			// devices are mostly public clients, but secret must be valid if sent
+			if clientSecret != "" && !isValidClientSecret(client, clientSecret) {
+				log.Debug("Client secret is invalid: ", clientID)
+				gc.JSON(http.StatusUnauthorized, gin.H{
+					"error":             "invalid_client",
+					"error_description": "The client secret is invalid",
+				})
+				return
+			}
+
+			if !client.IsGrantTypeAllowed(constants.GrantTypeDeviceCode) {
+				log.Debug("Device code grant is not allowed for client: ", clientID)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "unauthorized_client",
+					"error_description": "The device code grant is not allowed for the client",
+				})
+				return
+			}
+
+			if !client.IsScopeAllowed(scope) {
+				log.Debug("Scope is not allowed for client: ", scope)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_scope",
+					"error_description": "The scope is not allowed for the client",
+				})
+				return
+			}
+		}
+
+		authorization, err := device.NewAuthorization(clientID, scope)
+		if err != nil {
+			log.Debug("Error creating device authorization: ", err)
+			gc.JSON(http.StatusInternalServerError, gin.H{
+				"error":             "server_error",
+				"error_description": err.Error(),
+			})
+			return
+		}
+
+		verificationURI := parsers.GetHost(gc) + "/app/device"
+		gc.JSON(http.StatusOK, gin.H{
+			"device_code":               authorization.DeviceCode,
+			"user_code":                 authorization.UserCode,
+			"verification_uri":          verificationURI,
+			"verification_uri_complete": verificationURI + "?user_code=" + url.QueryEscape(authorization.UserCode),
+			"expires_in":                int64(constants.DeviceCodeExpiry / time.Second),
+			"interval":                  authorization.Interval,
+		})
+	}
+}
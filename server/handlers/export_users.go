@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+)
+
+// exportUsersPageSize is the number of users read from database at a time
+const exportUsersPageSize = 100
+
+// ExportUsersHandler streams the users in csv or json format of users import for admin.
+// Users are read page by page with the cursor, so that large user base can be exported
+func ExportUsersHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		if !token.IsSuperAdmin(gc) {
+			log.Debug("Not logged in as super admin")
+			gc.JSON(http.StatusUnauthorized, gin.H{
+				"error": "unauthorized",
+			})
+			return
+		}
+
+		format := gc.DefaultQuery("format", constants.UserRecordsFormatJSON)
+		var csvWriter *csv.Writer
+		switch format {
+		case constants.UserRecordsFormatCSV:
+			gc.Header("Content-Type", "text/csv")
+			csvWriter = csv.NewWriter(gc.Writer)
+		case constants.UserRecordsFormatJSON:
+			gc.Header("Content-Type", "application/json")
+		default:
+			log.Debug("Invalid users export format: ", format)
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("invalid format, supported formats are %s & %s", constants.UserRecordsFormatCSV, constants.UserRecordsFormatJSON),
+			})
+			return
+		}
+		gc.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="users.%s"`, format))
+		gc.Status(http.StatusOK)
+
+		if csvWriter != nil {
+			csvWriter.Write(models.UserRecordFields)
+		} else {
+			gc.Writer.WriteString("[")
+		}
+
+		exported := 0
+		pagination := model.Pagination{
+			Limit: exportUsersPageSize,
+			Page:  1,
+		}
+		// oldest users first, so that users added during export are listed at the end
+		filter := models.UserFilter{
+			SortOrder: constants.SortOrderAsc,
+		}
+		for {
+			res, err := db.Provider.ListUsers(gc, pagination, filter)
+			if err != nil {
+				// response is left incomplete, so that failed export is not mistaken for complete one
+				log.Debug("Failed to list users: ", err)
+				return
+			}
+
+			for _, apiUser := range res.Users {
+				// password hash is not part of the listed users
+				user, err := db.Provider.GetUserByID(gc, apiUser.ID)
+				if err != nil {
+					log.Debug("Failed to get user for export: ", err)
+					continue
+				}
+				record := models.NewUserRecord(user)
+				if csvWriter != nil {
+					csvWriter.Write(record.CSVRow())
+					continue
+				}
+
+				data, err := json.Marshal(record)
+				if err != nil {
+					log.Debug("Failed to marshal user for export: ", err)
+					continue
+				}
+				if exported > 0 {
+					gc.Writer.WriteString(",")
+				}
+				gc.Writer.Write(data)
+				exported++
+			}
+
+			if csvWriter != nil {
+				csvWriter.Flush()
+			}
+			gc.Writer.Flush()
+
+			if !res.Pagination.HasNextPage || res.Pagination.EndCursor == nil {
+				break
+			}
+			pagination.After = res.Pagination.EndCursor
+		}
+
+		if csvWriter == nil {
+			gc.Writer.WriteString("]")
+		}
+	}
+}
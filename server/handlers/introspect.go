@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// IntrospectHandler to handle /oauth/introspect requests as per RFC 7662.
+// calling client must authenticate with client_secret_basic or client_secret_post,
+// token is active only if it is valid & its session is not revoked
+func IntrospectHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		reqBody, err := bindOAuthRequest(gc)
+		if err != nil {
+			log.Debug("Error binding request: ", err)
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_request",
+				"error_description": err.Error(),
+			})
+			return
+		}
+
+		clientID, clientSecret := getClientCredentials(gc, reqBody)
+		if clientID == "" {
+			log.Debug("Client ID is empty")
+			gc.JSON(http.StatusUnauthorized, gin.H{
+				"error":             "invalid_client",
+				"error_description": "The client credentials are required",
+			})
+			return
+		}
+
+		client, err := getOAuthClient(gc, clientID)
+		if err != nil || !isValidClientSecret(client, clientSecret) {
+			log.Debug("Invalid client credentials: ", clientID)
+			gc.JSON(http.StatusUnauthorized, gin.H{
+				"error":             "invalid_client",
+				"error_description": "The client credentials are invalid",
+			})
+			return
+		}
+
+		tokenString := strings.TrimSpace(reqBody["token"])
+		if tokenString == "" {
+			log.Debug("Token is empty")
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_request",
+				"error_description": "The token is required",
+			})
+			return
+		}
+
+		claims := introspectToken(gc, tokenString, strings.TrimSpace(reqBody["token_type_hint"]))
+		if claims == nil {
+			gc.JSON(http.StatusOK, gin.H{
+				"active": false,
+			})
+			return
+		}
+
+		scope := []string{}
+		for _, s := range utils.ConvertInterfaceToSlice(claims["scope"]) {
+			scope = append(scope, s.(string))
+		}
+		roles := []string{}
+		for _, r := range utils.ConvertInterfaceToSlice(claims["roles"]) {
+			roles = append(roles, r.(string))
+		}
+
+		// token_type of access token is the type defined in RFC 6749 section 5.1
+		tokenType := "Bearer"
+		if claims["token_type"] == constants.TokenTypeRefreshToken {
+			tokenType = constants.TokenTypeRefreshToken
+		}
+
+		gc.JSON(http.StatusOK, gin.H{
+			"active":     true,
+			"sub":        claims["sub"],
+			"scope":      strings.Join(scope, " "),
+			"exp":        claims["exp"],
+			"iat":        claims["iat"],
+			"iss":        claims["iss"],
+			"aud":        claims["aud"],
+			"client_id":  claims["aud"],
+			"roles":      roles,
+			"token_type": tokenType,
+		})
+	}
+}
+
+// introspectToken returns the claims of token if it is active, otherwise nil.
+// token_type_hint is used to validate the token as that type first
+func introspectToken(gc *gin.Context, tokenString, tokenTypeHint string) jwt.MapClaims {
+	validateFuncs := []func() (jwt.MapClaims, error){
+		func() (jwt.MapClaims, error) {
+			claims, err := token.ParseJWTToken(tokenString)
+			if err != nil {
+				return nil, err
+			}
+			// access token of client_credentials grant is not persisted in session store
+			if token.IsClientAccessToken(claims) {
+				// sub of client access token is the client id
+				clientID, _ := claims["sub"].(string)
+				if ok, err := token.ValidateJWTTokenWithoutNonce(claims, parsers.GetHost(gc), clientID); !ok || err != nil {
+					return nil, err
+				}
+				return claims, nil
+			}
+			return token.ValidateAccessTokenWithoutProof(gc, tokenString)
+		},
+		func() (jwt.MapClaims, error) {
+			return token.ValidateRefreshToken(gc, tokenString)
+		},
+	}
+	if tokenTypeHint == constants.TokenTypeRefreshToken {
+		validateFuncs[0], validateFuncs[1] = validateFuncs[1], validateFuncs[0]
+	}
+
+	for _, validate := range validateFuncs {
+		claims, err := validate()
+		if err == nil && claims != nil {
+			return claims
+		}
+	}
+	log.Debug("Token is not active")
+	return nil
+}
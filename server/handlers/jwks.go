@@ -1,40 +1,27 @@
 package handlers
 
 import (
-	"encoding/json"
-
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 
-	"github.com/authorizerdev/authorizer/server/constants"
-	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
 )
 
+// JWKsHandler returns the JWK of active, next & previous signing keys,
+// so that tokens signed before & after key rotation can be verified
 func JWKsHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var data map[string]string
-		jwk, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJWK)
-		if err != nil {
-			log.Debug("Error getting JWK from memorystore: ", err)
-			c.JSON(500, gin.H{
-				"error": err.Error(),
-			})
-			return
-		}
-		err = json.Unmarshal([]byte(jwk), &data)
+		jwks, err := token.GetJWKS()
 		if err != nil {
-			log.Debug("Failed to parse JWK: ", err)
+			log.Debug("Failed to get JWKS: ", err)
 			c.JSON(500, gin.H{
 				"error": err.Error(),
 			})
-
 			return
 		}
 
 		c.JSON(200, gin.H{
-			"keys": []map[string]string{
-				data,
-			},
+			"keys": jwks,
 		})
 	}
 }
@@ -1,55 +1,132 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/authorizerdev/authorizer/server/cookie"
-	"github.com/authorizerdev/authorizer/server/crypto"
-	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/logout"
+	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/validators"
 )
 
-// Handler to logout user
+// LogoutHandler is the end_session_endpoint as per OpenID Connect RP-Initiated Logout.
+// User is identified with session cookie or id_token_hint, all the sessions of user for the login method are deleted
+// only if the session cookie is valid & the clients are notified with back-channel logout token & front-channel logout iframes.
+// post_logout_redirect_uri (or redirect_uri) must be registered for the client
 func LogoutHandler() gin.HandlerFunc {
 	return func(gc *gin.Context) {
-		redirectURL := strings.TrimSpace(gc.Query("redirect_uri"))
-		// get fingerprint hash
-		fingerprintHash, err := cookie.GetSession(gc)
+		idTokenHint := strings.TrimSpace(gc.Request.FormValue("id_token_hint"))
+		clientID := strings.TrimSpace(gc.Request.FormValue("client_id"))
+		state := strings.TrimSpace(gc.Request.FormValue("state"))
+		redirectURL := strings.TrimSpace(gc.Request.FormValue("post_logout_redirect_uri"))
+		if redirectURL == "" {
+			redirectURL = strings.TrimSpace(gc.Request.FormValue("redirect_uri"))
+		}
+
+		userID := ""
+		if idTokenHint != "" {
+			claims, err := token.ParseIDTokenHint(idTokenHint)
+			if err != nil {
+				log.Debug("Failed to parse id token hint: ", err)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_request",
+					"error_description": "The id_token_hint is invalid",
+				})
+				return
+			}
+			hintClientID, _ := claims["aud"].(string)
+			if clientID != "" && clientID != hintClientID {
+				log.Debug("Client ID does not match id token hint: ", clientID)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_request",
+					"error_description": "The client_id does not match the id_token_hint",
+				})
+				return
+			}
+			clientID = hintClientID
+			userID, _ = claims["sub"].(string)
+		}
+
+		// sessions are ended only for the valid browser session, id_token_hint alone does not end the sessions
+		// as it can be an expired token of the user
+		var sessionData *token.SessionData
+		sessionToken, err := cookie.GetSession(gc)
+		if err == nil {
+			sessionData, err = token.ValidateBrowserSession(gc, sessionToken)
+		}
 		if err != nil {
-			log.Debug("Failed to get session: ", err)
-			gc.JSON(http.StatusUnauthorized, gin.H{
-				"error": err.Error(),
+			if userID == "" {
+				log.Debug("Failed to get session: ", err)
+				gc.JSON(http.StatusUnauthorized, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			log.Debug("No valid session to end for id token hint: ", err)
+		} else if userID != "" && sessionData.Subject != userID {
+			log.Debug("Session does not belong to the user of id token hint")
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_request",
+				"error_description": "The id_token_hint does not match the session",
 			})
 			return
 		}
 
-		decryptedFingerPrint, err := crypto.DecryptAES(fingerprintHash)
-		if err != nil {
-			log.Debug("Failed to decrypt fingerprint: ", err)
-			gc.JSON(http.StatusUnauthorized, gin.H{
-				"error": err.Error(),
+		if redirectURL != "" && !isValidPostLogoutRedirectURI(gc, clientID, redirectURL) {
+			log.Debug("Invalid post logout redirect uri: ", redirectURL)
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_request",
+				"error_description": "The post_logout_redirect_uri is not registered for the client",
 			})
 			return
 		}
 
-		var sessionData token.SessionData
-		err = json.Unmarshal([]byte(decryptedFingerPrint), &sessionData)
-		if err != nil {
-			log.Debug("Failed to decrypt fingerprint: ", err)
-			gc.JSON(http.StatusUnauthorized, gin.H{
-				"error": err.Error(),
+		hostname := parsers.GetHost(gc)
+		clients := []models.Client{}
+		if sessionData != nil {
+			sessionKey := sessionData.Subject
+			if sessionData.LoginMethod != "" {
+				sessionKey = sessionData.LoginMethod + ":" + sessionData.Subject
+			}
+			clients = logout.EndSession(gc, sessionKey, sessionData.Subject, hostname)
+			cookie.DeleteSession(gc)
+		}
+
+		if redirectURL != "" && state != "" {
+			if strings.Contains(redirectURL, "?") {
+				redirectURL = redirectURL + "&state=" + url.QueryEscape(state)
+			} else {
+				redirectURL = redirectURL + "?state=" + url.QueryEscape(state)
+			}
+		}
+
+		frontchannelLogoutURIs := []string{}
+		for _, client := range clients {
+			if client.FrontchannelLogoutURI == "" {
+				continue
+			}
+			if strings.Contains(client.FrontchannelLogoutURI, "?") {
+				frontchannelLogoutURIs = append(frontchannelLogoutURIs, client.FrontchannelLogoutURI+"&iss="+url.QueryEscape(hostname))
+			} else {
+				frontchannelLogoutURIs = append(frontchannelLogoutURIs, client.FrontchannelLogoutURI+"?iss="+url.QueryEscape(hostname))
+			}
+		}
+
+		if len(frontchannelLogoutURIs) > 0 {
+			gc.HTML(http.StatusOK, "logout.tmpl", gin.H{
+				"frontchannel_logout_uris": frontchannelLogoutURIs,
+				"redirect_uri":             redirectURL,
 			})
 			return
 		}
 
-		memorystore.Provider.DeleteUserSession(sessionData.Subject, sessionData.Nonce)
-		cookie.DeleteSession(gc)
-
 		if redirectURL != "" {
 			gc.Redirect(http.StatusFound, redirectURL)
 		} else {
@@ -59,3 +136,20 @@ func LogoutHandler() gin.HandlerFunc {
 		}
 	}
 }
+
+// isValidPostLogoutRedirectURI validates the redirect uri against post logout redirect uris of registered client,
+// ALLOWED_ORIGINS is used for the instance client
+func isValidPostLogoutRedirectURI(gc *gin.Context, clientID, redirectURI string) bool {
+	if clientID == "" {
+		return validators.IsValidOrigin(redirectURI)
+	}
+
+	client, err := getOAuthClient(gc, clientID)
+	if err != nil {
+		return false
+	}
+	if client == nil {
+		return validators.IsValidOrigin(redirectURI)
+	}
+	return client.IsPostLogoutRedirectURIAllowed(redirectURI)
+}
@@ -56,6 +56,7 @@ func OAuthCallbackHandler() gin.HandlerFunc {
 
 		user := models.User{}
 		code := ctx.Request.FormValue("code")
+		loginMethod := provider
 		switch provider {
 		case constants.AuthRecipeMethodGoogle:
 			user, err = processGoogleUserInfo(code)
@@ -68,8 +69,15 @@ func OAuthCallbackHandler() gin.HandlerFunc {
 		case constants.AuthRecipeMethodApple:
 			user, err = processAppleUserInfo(code)
 		default:
-			log.Info("Invalid oauth provider")
-			err = fmt.Errorf(`invalid oauth provider`)
+			oidcProvider := oauth.GetGenericOIDCProvider(provider)
+			if oidcProvider == nil {
+				log.Info("Invalid oauth provider")
+				err = fmt.Errorf(`invalid oauth provider`)
+				break
+			}
+			user, err = processGenericOIDCUserInfo(oidcProvider, code)
+			// generic oidc providers share the session namespace as their names are configurable
+			loginMethod = constants.AuthRecipeMethodOIDC
 		}
 
 		if err != nil {
@@ -194,7 +202,7 @@ func OAuthCallbackHandler() gin.HandlerFunc {
 			}
 		}
 
-		authToken, err := token.CreateAuthToken(ctx, user, inputRoles, scopes, provider)
+		authToken, err := token.CreateAuthToken(ctx, user, inputRoles, scopes, loginMethod, token.AuthTokenOptions{})
 		if err != nil {
 			log.Debug("Failed to create auth token: ", err)
 			ctx.JSON(500, gin.H{"error": err.Error()})
@@ -207,7 +215,7 @@ func OAuthCallbackHandler() gin.HandlerFunc {
 
 		params := "access_token=" + authToken.AccessToken.Token + "&token_type=bearer&expires_in=" + strconv.FormatInt(expiresIn, 10) + "&state=" + stateValue + "&id_token=" + authToken.IDToken.Token
 
-		sessionKey := provider + ":" + user.ID
+		sessionKey := loginMethod + ":" + user.ID
 		cookie.SetSession(ctx, authToken.FingerPrintHash)
 		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash)
 		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token)
@@ -219,11 +227,12 @@ func OAuthCallbackHandler() gin.HandlerFunc {
 
 		go func() {
 			if isSignUp {
-				utils.RegisterEvent(ctx, constants.UserSignUpWebhookEvent, provider, user)
+				utils.RegisterEvent(ctx, constants.UserSignUpWebhookEvent, loginMethod, user)
 			} else {
-				utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, provider, user)
+				utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, loginMethod, user)
 			}
 			db.Provider.AddSession(ctx, models.Session{
+				ID:        authToken.SessionID,
 				UserID:    user.ID,
 				UserAgent: utils.GetUserAgent(ctx.Request),
 				IP:        utils.GetIP(ctx.Request),
@@ -564,3 +573,81 @@ func processAppleUserInfo(code string) (models.User, error) {
 
 	return user, err
 }
+
+func processGenericOIDCUserInfo(oidcProvider *oauth.GenericOIDCProvider, code string) (models.User, error) {
+	user := models.User{}
+	ctx := context.Background()
+	name := oidcProvider.Config.Name
+	oauth2Token, err := oidcProvider.OAuthConfig.Exchange(ctx, code)
+	if err != nil {
+		log.Debug("Failed to exchange code for token: ", err)
+		return user, fmt.Errorf("invalid %s exchange code: %s", name, err.Error())
+	}
+
+	verifier := oidcProvider.Provider.Verifier(&oidc.Config{ClientID: oidcProvider.OAuthConfig.ClientID})
+
+	// Extract the ID Token from OAuth2 token.
+	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
+	if !ok {
+		log.Debug("Failed to extract ID Token from OAuth2 token")
+		return user, fmt.Errorf("unable to extract id_token")
+	}
+
+	// Parse and verify ID Token payload.
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		log.Debug("Failed to verify ID Token: ", err)
+		return user, fmt.Errorf("unable to verify id_token: %s", err.Error())
+	}
+
+	claims := map[string]interface{}{}
+	if err := idToken.Claims(&claims); err != nil {
+		log.Debug("Failed to parse ID Token claims: ", err)
+		return user, fmt.Errorf("unable to extract claims")
+	}
+
+	// some providers return only the subject in id token, rest of the claims are fetched from userinfo endpoint
+	userInfo, err := oidcProvider.Provider.UserInfo(ctx, oauth2.StaticTokenSource(oauth2Token))
+	if err != nil {
+		log.Debug("Failed to get user info: ", err)
+	} else if userInfo.Subject == idToken.Subject {
+		userInfoClaims := map[string]interface{}{}
+		if err := userInfo.Claims(&userInfoClaims); err == nil {
+			for key, value := range userInfoClaims {
+				if _, ok := claims[key]; !ok {
+					claims[key] = value
+				}
+			}
+		}
+	}
+
+	// email is used to link accounts, hence it should not be used if provider says that it is not verified
+	if emailVerified, ok := claims["email_verified"].(bool); ok && !emailVerified {
+		log.Debug("Email is not verified by oidc provider")
+		return user, fmt.Errorf("email not verified by %s", name)
+	}
+
+	getClaim := func(field string) *string {
+		value, ok := claims[oidcProvider.GetClaimName(field)].(string)
+		if !ok || value == "" {
+			return nil
+		}
+		return &value
+	}
+
+	email := getClaim("email")
+	if email == nil {
+		log.Debug("Email claim not found in oidc claims")
+		return user, fmt.Errorf("unable to extract email from %s claims", name)
+	}
+	user.Email = strings.ToLower(*email)
+	user.GivenName = getClaim("given_name")
+	user.FamilyName = getClaim("family_name")
+	user.MiddleName = getClaim("middle_name")
+	user.Nickname = getClaim("nickname")
+	user.Picture = getClaim("picture")
+	user.Gender = getClaim("gender")
+	user.Birthdate = getClaim("birthdate")
+
+	return user, nil
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/oauth"
 	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/saml"
 	"github.com/authorizerdev/authorizer/server/validators"
 )
 
@@ -188,11 +190,53 @@ func OAuthLoginHandler() gin.HandlerFunc {
 			// check: https://github.com/golang/oauth2/issues/449
 			url := oauth.OAuthProviders.AppleConfig.AuthCodeURL(oauthStateString, oauth2.SetAuthURLParam("response_mode", "form_post")) + "&scope=name email"
 			c.Redirect(http.StatusTemporaryRedirect, url)
+		case constants.AuthRecipeMethodSAML:
+			idp, err := saml.GetIdentityProvider()
+			if err != nil {
+				log.Debug("SAML identity provider is not configured: ", err)
+				isProviderConfigured = false
+				break
+			}
+			// relay state should not exceed 80 bytes, hence id of authn request is used as relay state
+			// and it is mapped to the oauth state that is used by /saml/acs
+			requestID := "id-" + uuid.New().String()
+			err = memorystore.Provider.SetState(requestID, oauthStateString)
+			if err != nil {
+				log.Debug("Error setting state: ", err)
+				c.JSON(500, gin.H{
+					"error": "internal server error",
+				})
+				return
+			}
+			url, err := saml.GetServiceProvider(hostname).AuthnRequestURL(idp, requestID, requestID)
+			if err != nil {
+				log.Debug("Failed to create saml authn request: ", err)
+				c.JSON(500, gin.H{
+					"error": "internal server error",
+				})
+				return
+			}
+			c.Redirect(http.StatusTemporaryRedirect, url)
 		default:
-			log.Debug("Invalid oauth provider: ", provider)
-			c.JSON(422, gin.H{
-				"message": "Invalid oauth provider",
-			})
+			oidcProvider := oauth.GetGenericOIDCProvider(provider)
+			if oidcProvider == nil {
+				log.Debug("Invalid oauth provider: ", provider)
+				c.JSON(422, gin.H{
+					"message": "Invalid oauth provider",
+				})
+				break
+			}
+			err := memorystore.Provider.SetState(oauthStateString, provider)
+			if err != nil {
+				log.Debug("Error setting state: ", err)
+				c.JSON(500, gin.H{
+					"error": "internal server error",
+				})
+				return
+			}
+			oidcProvider.OAuthConfig.RedirectURL = hostname + "/oauth_callback/" + provider
+			url := oidcProvider.OAuthConfig.AuthCodeURL(oauthStateString)
+			c.Redirect(http.StatusTemporaryRedirect, url)
 		}
 
 		if !isProviderConfigured {
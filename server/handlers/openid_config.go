@@ -15,16 +15,26 @@ func OpenIDConfigurationHandler() gin.HandlerFunc {
 		jwtType, _ := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtType)
 
 		c.JSON(200, gin.H{
-			"issuer":                                issuer,
-			"authorization_endpoint":                issuer + "/authorize",
-			"token_endpoint":                        issuer + "/token",
-			"userinfo_endpoint":                     issuer + "/userinfo",
-			"jwks_uri":                              issuer + "/.well-known/jwks.json",
-			"response_types_supported":              []string{"code", "token", "id_token", "code token", "code id_token", "token id_token", "code token id_token"},
-			"scopes_supported":                      []string{"openid", "email", "profile", "email_verified", "given_name", "family_name", "nick_name", "picture"},
-			"response_modes_supported":              []string{"query", "fragment", "form_post"},
-			"id_token_signing_alg_values_supported": []string{jwtType},
-			"claims_supported":                      []string{"aud", "exp", "iss", "iat", "sub", "given_name", "family_name", "middle_name", "nickname", "preferred_username", "picture", "email", "email_verified", "roles", "gender", "birthdate", "phone_number", "phone_number_verified"},
+			"issuer":                                   issuer,
+			"authorization_endpoint":                   issuer + "/authorize",
+			"token_endpoint":                           issuer + "/token",
+			"userinfo_endpoint":                        issuer + "/userinfo",
+			"introspection_endpoint":                   issuer + "/oauth/introspect",
+			"device_authorization_endpoint":            issuer + "/oauth/device_authorization",
+			"end_session_endpoint":                     issuer + "/logout",
+			"backchannel_logout_supported":             true,
+			"frontchannel_logout_supported":            true,
+			"jwks_uri":                                 issuer + "/.well-known/jwks.json",
+			"response_types_supported":                 []string{"code", "token", "id_token", "code token", "code id_token", "token id_token", "code token id_token"},
+			"scopes_supported":                         []string{"openid", "email", "profile", "email_verified", "given_name", "family_name", "nick_name", "picture"},
+			"response_modes_supported":                 []string{"query", "fragment", "form_post"},
+			"id_token_signing_alg_values_supported":    []string{jwtType},
+			"id_token_encryption_alg_values_supported": constants.IDTokenEncryptionAlgs,
+			"id_token_encryption_enc_values_supported": constants.IDTokenEncryptionEncs,
+			"dpop_signing_alg_values_supported":        constants.DPoPSigningAlgs,
+			"prompt_values_supported":                  []string{constants.PromptNone, constants.PromptLogin, constants.PromptConsent},
+			"acr_values_supported":                     []string{constants.ACRSingleFactor, constants.ACRMultiFactor},
+			"claims_supported":                         []string{"aud", "exp", "iss", "iat", "sub", "auth_time", "amr", "acr", "given_name", "family_name", "middle_name", "nickname", "preferred_username", "picture", "email", "email_verified", "roles", "gender", "birthdate", "phone_number", "phone_number_verified"},
 		})
 	}
 }
@@ -7,7 +7,6 @@ import (
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 
-	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/token"
 )
@@ -37,7 +36,7 @@ func RevokeRefreshTokenHandler() gin.HandlerFunc {
 			return
 		}
 
-		if client, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID); client != clientID || err != nil {
+		if _, err := getOAuthClient(gc, clientID); err != nil {
 			log.Debug("Client ID is invalid: ", clientID)
 			gc.JSON(http.StatusBadRequest, gin.H{
 				"error":             "invalid_client_id",
@@ -56,6 +55,15 @@ func RevokeRefreshTokenHandler() gin.HandlerFunc {
 			return
 		}
 
+		if claims["aud"] != clientID {
+			log.Debug("Refresh token is not issued for the client: ", clientID)
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_client_id",
+				"error_description": "The refresh token is not issued for the client",
+			})
+			return
+		}
+
 		userID := claims["sub"].(string)
 		loginMethod := claims["login_method"]
 		sessionToken := userID
@@ -64,6 +72,11 @@ func RevokeRefreshTokenHandler() gin.HandlerFunc {
 		}
 
 		memorystore.Provider.DeleteUserSession(sessionToken, claims["nonce"].(string))
+		// tokens rotated from the refresh token are revoked as well
+		familyID, _ := claims["family_id"].(string)
+		if family, err := token.GetRefreshTokenFamily(familyID); err == nil {
+			token.RevokeRefreshTokenFamily(family)
+		}
 
 		gc.JSON(http.StatusOK, gin.H{
 			"message": "Token revoked successfully",
@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/cookie"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/saml"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/validators"
+)
+
+// SamlAcsHandler is the assertion consumer service of saml login.
+// It verifies the response posted by identity provider and logs in the user same as oauth_callback
+func SamlAcsHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// relay state is the id of authn request that is mapped to the oauth state in /oauth_login/saml
+		requestID := ctx.Request.FormValue("RelayState")
+		samlResponse := ctx.Request.FormValue("SAMLResponse")
+		if requestID == "" || samlResponse == "" {
+			log.Debug("Invalid saml response")
+			ctx.JSON(400, gin.H{"error": "invalid saml response"})
+			return
+		}
+
+		sessionState, err := memorystore.Provider.GetState(requestID)
+		if sessionState == "" || err != nil {
+			log.Debug("Invalid saml relay state: ", requestID)
+			ctx.JSON(400, gin.H{"error": "invalid saml relay state"})
+			return
+		}
+		// response can be used only once
+		memorystore.Provider.RemoveState(requestID)
+
+		// contains random token, redirect url, role & scope
+		sessionSplit := strings.Split(sessionState, "___")
+		if len(sessionSplit) < 4 {
+			log.Debug("Unable to get redirect url from state: ", sessionState)
+			ctx.JSON(400, gin.H{"error": "invalid redirect url"})
+			return
+		}
+
+		stateValue := sessionSplit[0]
+		redirectURL := sessionSplit[1]
+		inputRoles := strings.Split(sessionSplit[2], ",")
+		scopes := strings.Split(sessionSplit[3], ",")
+
+		idp, err := saml.GetIdentityProvider()
+		if err != nil {
+			log.Debug("Failed to get saml identity provider: ", err)
+			ctx.JSON(400, gin.H{"error": "saml login is not configured"})
+			return
+		}
+
+		hostname := parsers.GetHost(ctx)
+		assertion, err := saml.GetServiceProvider(hostname).ParseResponse(idp, samlResponse, requestID)
+		if err != nil {
+			log.Debug("Failed to verify saml response: ", err)
+			ctx.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+
+		user, samlRoles, err := processSamlUserInfo(assertion)
+		if err != nil {
+			log.Debug("Failed to process saml user info: ", err)
+			ctx.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+
+		protectedRolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyProtectedRoles)
+		protectedRoles := []string{}
+		if err != nil {
+			log.Debug("Failed to get protected roles: ", err)
+			protectedRolesString = ""
+		} else {
+			protectedRoles = strings.Split(protectedRolesString, ",")
+		}
+
+		existingUser, err := db.Provider.GetUserByEmail(ctx, user.Email)
+		log := log.WithField("user", user.Email)
+		isSignUp := false
+
+		if err != nil {
+			isSignupDisabled, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyDisableSignUp)
+			if err != nil {
+				log.Debug("Failed to get signup disabled env variable: ", err)
+				ctx.JSON(400, gin.H{"error": err.Error()})
+				return
+			}
+			if isSignupDisabled {
+				log.Debug("Failed to signup as disabled")
+				ctx.JSON(400, gin.H{"error": "signup is disabled for this instance"})
+				return
+			}
+
+			// user not registered, register user and generate session token
+			user.SignupMethods = constants.AuthRecipeMethodSAML
+			if len(samlRoles) > 0 {
+				user.Roles = strings.Join(samlRoles, ",")
+			} else {
+				// make sure inputRoles don't include protected roles
+				for _, ir := range inputRoles {
+					if utils.StringSliceContains(protectedRoles, ir) {
+						log.Debug("Signup is not allowed with protected roles:", inputRoles)
+						ctx.JSON(400, gin.H{"error": "invalid role"})
+						return
+					}
+				}
+				user.Roles = strings.Join(inputRoles, ",")
+			}
+			now := time.Now().Unix()
+			user.EmailVerifiedAt = &now
+			user, err = db.Provider.AddUser(ctx, user)
+			if err != nil {
+				log.Debug("Failed to add user: ", err)
+				ctx.JSON(500, gin.H{"error": err.Error()})
+				return
+			}
+			isSignUp = true
+		} else {
+			if existingUser.RevokedTimestamp != nil {
+				log.Debug("User access revoked at: ", existingUser.RevokedTimestamp)
+				ctx.JSON(400, gin.H{"error": "user access has been revoked"})
+				return
+			}
+
+			// identity provider is the source of truth for the mapped attributes
+			if user.GivenName != nil {
+				existingUser.GivenName = user.GivenName
+			}
+			if user.FamilyName != nil {
+				existingUser.FamilyName = user.FamilyName
+			}
+			if user.MiddleName != nil {
+				existingUser.MiddleName = user.MiddleName
+			}
+			if user.Nickname != nil {
+				existingUser.Nickname = user.Nickname
+			}
+			if user.Picture != nil {
+				existingUser.Picture = user.Picture
+			}
+			if user.Gender != nil {
+				existingUser.Gender = user.Gender
+			}
+			if user.Birthdate != nil {
+				existingUser.Birthdate = user.Birthdate
+			}
+			user = existingUser
+
+			if !strings.Contains(user.SignupMethods, constants.AuthRecipeMethodSAML) {
+				user.SignupMethods = user.SignupMethods + "," + constants.AuthRecipeMethodSAML
+			}
+
+			if user.EmailVerifiedAt == nil {
+				now := time.Now().Unix()
+				user.EmailVerifiedAt = &now
+			}
+
+			if len(samlRoles) > 0 {
+				user.Roles = strings.Join(samlRoles, ",")
+			} else {
+				// find the unassigned roles, protected roles can only be assigned by admin or identity provider
+				existingRoles := strings.Split(existingUser.Roles, ",")
+				unasignedRoles := []string{}
+				for _, ir := range inputRoles {
+					if !utils.StringSliceContains(existingRoles, ir) {
+						if utils.StringSliceContains(protectedRoles, ir) {
+							log.Debug("Invalid role. User is using protected unassigned role")
+							ctx.JSON(400, gin.H{"error": "invalid role"})
+							return
+						}
+						unasignedRoles = append(unasignedRoles, ir)
+					}
+				}
+				if len(unasignedRoles) > 0 {
+					user.Roles = existingUser.Roles + "," + strings.Join(unasignedRoles, ",")
+				}
+			}
+
+			user, err = db.Provider.UpdateUser(ctx, user)
+			if err != nil {
+				log.Debug("Failed to update user: ", err)
+				ctx.JSON(500, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		// roles asserted by identity provider are used for the session
+		if len(samlRoles) > 0 {
+			inputRoles = samlRoles
+		}
+
+		authToken, err := token.CreateAuthToken(ctx, user, inputRoles, scopes, constants.AuthRecipeMethodSAML, token.AuthTokenOptions{})
+		if err != nil {
+			log.Debug("Failed to create auth token: ", err)
+			ctx.JSON(500, gin.H{"error": err.Error()})
+			return
+		}
+
+		expiresIn := authToken.AccessToken.ExpiresAt - time.Now().Unix()
+		if expiresIn <= 0 {
+			expiresIn = 1
+		}
+
+		params := "access_token=" + authToken.AccessToken.Token + "&token_type=bearer&expires_in=" + strconv.FormatInt(expiresIn, 10) + "&state=" + stateValue + "&id_token=" + authToken.IDToken.Token
+
+		sessionKey := constants.AuthRecipeMethodSAML + ":" + user.ID
+		cookie.SetSession(ctx, authToken.FingerPrintHash)
+		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash)
+		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token)
+
+		if authToken.RefreshToken != nil {
+			params = params + `&refresh_token=` + authToken.RefreshToken.Token
+			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeRefreshToken+"_"+authToken.FingerPrint, authToken.RefreshToken.Token)
+		}
+
+		go func() {
+			if isSignUp {
+				utils.RegisterEvent(ctx, constants.UserSignUpWebhookEvent, constants.AuthRecipeMethodSAML, user)
+			} else {
+				utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, constants.AuthRecipeMethodSAML, user)
+			}
+			db.Provider.AddSession(ctx, models.Session{
+				ID:        authToken.SessionID,
+				UserID:    user.ID,
+				UserAgent: utils.GetUserAgent(ctx.Request),
+				IP:        utils.GetIP(ctx.Request),
+			})
+		}()
+		if strings.Contains(redirectURL, "?") {
+			redirectURL = redirectURL + "&" + params
+		} else {
+			redirectURL = redirectURL + "?" + strings.TrimPrefix(params, "&")
+		}
+
+		ctx.Redirect(http.StatusFound, redirectURL)
+	}
+}
+
+// processSamlUserInfo maps the attributes of saml assertion to user fields using SAML_ATTRIBUTE_MAPPING
+// it returns the user & the roles asserted by identity provider that are configured in authorizer
+func processSamlUserInfo(assertion *saml.Assertion) (models.User, []string, error) {
+	user := models.User{}
+
+	attributeMappingString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeySamlAttributeMapping)
+	if err != nil {
+		attributeMappingString = ""
+	}
+	attributeMapping, err := saml.ParseAttributeMapping(attributeMappingString)
+	if err != nil {
+		return user, nil, err
+	}
+
+	email := strings.TrimSpace(assertion.GetAttribute(attributeMapping["email"]))
+	// name id is mostly the email of user
+	if email == "" {
+		email = assertion.NameID
+	}
+	if !validators.IsValidEmail(email) {
+		return user, nil, fmt.Errorf("email not found in saml assertion")
+	}
+	user.Email = strings.ToLower(email)
+
+	stringFields := map[string]**string{
+		"given_name":  &user.GivenName,
+		"family_name": &user.FamilyName,
+		"middle_name": &user.MiddleName,
+		"nickname":    &user.Nickname,
+		"picture":     &user.Picture,
+		"gender":      &user.Gender,
+		"birthdate":   &user.Birthdate,
+	}
+	for field, value := range stringFields {
+		if attribute := strings.TrimSpace(assertion.GetAttribute(attributeMapping[field])); attribute != "" {
+			*value = refs.NewStringRef(attribute)
+		}
+	}
+
+	rolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyRoles)
+	if err != nil {
+		rolesString = ""
+	}
+	protectedRolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyProtectedRoles)
+	if err != nil {
+		protectedRolesString = ""
+	}
+	allowedRoles := append(strings.Split(rolesString, ","), strings.Split(protectedRolesString, ",")...)
+
+	// roles can be asserted as multiple values or comma separated value,
+	// roles that are not configured in authorizer are ignored
+	roles := []string{}
+	for _, value := range assertion.Attributes[attributeMapping["roles"]] {
+		for _, role := range strings.Split(value, ",") {
+			role = strings.TrimSpace(role)
+			if role != "" && utils.StringSliceContains(allowedRoles, role) && !utils.StringSliceContains(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+	}
+
+	return user, roles, nil
+}
@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/saml"
+)
+
+// SamlMetadataHandler returns the saml service provider metadata,
+// that is used to configure authorizer in the identity provider
+func SamlMetadataHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		hostname := parsers.GetHost(c)
+		c.Data(200, "application/samlmetadata+xml", saml.GetServiceProvider(hostname).Metadata())
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/scim"
+)
+
+// scimPageSize is the number of users read from database at a time by the scim requests
+const scimPageSize = 100
+
+// scimBaseURL returns the url of scim endpoints
+func scimBaseURL(gc *gin.Context) string {
+	return parsers.GetHost(gc) + "/scim/v2"
+}
+
+// scimResponse writes the scim response with scim content type
+func scimResponse(gc *gin.Context, status int, res interface{}) {
+	data, err := json.Marshal(res)
+	if err != nil {
+		log.Debug("Failed to marshal scim response: ", err)
+		scimErrorResponse(gc, err)
+		return
+	}
+	gc.Data(status, scim.ContentType, data)
+}
+
+// scimErrorResponse writes the scim error response of err
+func scimErrorResponse(gc *gin.Context, err error) {
+	scimErr := scim.AsError(err)
+	data, _ := json.Marshal(scimErr)
+	gc.Data(scimErr.StatusCode(), scim.ContentType, data)
+}
+
+// bindScimBody decodes the json body of scim request
+func bindScimBody(gc *gin.Context, body interface{}) error {
+	if err := json.NewDecoder(gc.Request.Body).Decode(body); err != nil {
+		log.Debug("Failed to decode scim request: ", err)
+		return scim.NewError(http.StatusBadRequest, scim.ErrorTypeInvalidSyntax, "invalid request body: "+err.Error())
+	}
+	return nil
+}
+
+// scimPage returns the range of items on the page with 1-based startIndex & count
+func scimPage(total, startIndex, count int) (int, int) {
+	from := startIndex - 1
+	if from > total {
+		from = total
+	}
+	to := from + count
+	if to > total {
+		to = total
+	}
+	return from, to
+}
+
+// forEachUser calls fn for all the users matching filter, users are read page by page with the cursor
+func forEachUser(ctx context.Context, filter models.UserFilter, fn func(user *model.User)) error {
+	pagination := model.Pagination{
+		Limit: scimPageSize,
+		Page:  1,
+	}
+	filter.SortOrder = constants.SortOrderAsc
+	for {
+		res, err := db.Provider.ListUsers(ctx, pagination, filter)
+		if err != nil {
+			return err
+		}
+		for _, user := range res.Users {
+			fn(user)
+		}
+		if !res.Pagination.HasNextPage || res.Pagination.EndCursor == nil {
+			return nil
+		}
+		pagination.After = res.Pagination.EndCursor
+	}
+}
+
+// ScimServiceProviderConfigHandler returns the features supported by scim endpoints
+func ScimServiceProviderConfigHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		scimResponse(gc, http.StatusOK, scim.ServiceProviderConfig(scimBaseURL(gc)))
+	}
+}
+
+// ScimSchemasHandler returns the schemas of user & group resources,
+// schema with the id is returned if id param is set
+func ScimSchemasHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		scimDiscoveryResponse(gc, scim.Schemas(scimBaseURL(gc)))
+	}
+}
+
+// ScimResourceTypesHandler returns the user & group resource types,
+// resource type with the id is returned if id param is set
+func ScimResourceTypesHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		scimDiscoveryResponse(gc, scim.ResourceTypes(scimBaseURL(gc)))
+	}
+}
+
+// scimDiscoveryResponse writes the list response of discovery resources or the resource with id param
+func scimDiscoveryResponse(gc *gin.Context, resources []map[string]interface{}) {
+	id := gc.Param("id")
+	if id == "" {
+		scimResponse(gc, http.StatusOK, scim.NewListResponse(resources, len(resources), 1, len(resources)))
+		return
+	}
+	for _, resource := range resources {
+		if resource["id"] == id {
+			scimResponse(gc, http.StatusOK, resource)
+			return
+		}
+	}
+	scimErrorResponse(gc, scim.NewError(http.StatusNotFound, "", "resource not found"))
+}
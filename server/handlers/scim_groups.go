@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/scim"
+)
+
+// scimRoles returns the roles that are exposed as groups, the roles configured with ROLES & PROTECTED_ROLES
+func scimRoles() []string {
+	res := []string{}
+	seen := map[string]bool{}
+	for _, key := range []string{constants.EnvKeyRoles, constants.EnvKeyProtectedRoles} {
+		rolesString, err := memorystore.Provider.GetStringStoreEnvVariable(key)
+		if err != nil {
+			log.Debug("Error getting roles: ", err)
+			continue
+		}
+		for _, role := range strings.Split(rolesString, ",") {
+			role = strings.TrimSpace(role)
+			if role != "" && !seen[role] {
+				seen[role] = true
+				res = append(res, role)
+			}
+		}
+	}
+	return res
+}
+
+// isScimRole returns true if role is one of the roles exposed as groups
+func isScimRole(role string) bool {
+	for _, r := range scimRoles() {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// scimGroupMembers returns the users having the role
+func scimGroupMembers(gc *gin.Context, role string) ([]scim.GroupMember, error) {
+	var members []scim.GroupMember
+	err := forEachUser(gc, models.UserFilter{Role: role}, func(user *model.User) {
+		members = append(members, scim.GroupMember{
+			ID:    user.ID,
+			Email: user.Email,
+		})
+	})
+	return members, err
+}
+
+// isMembersExcluded returns true if members are excluded from the groups with excludedAttributes param,
+// identity providers exclude members while looking up the groups as groups can have many members
+func isMembersExcluded(gc *gin.Context) bool {
+	for _, attr := range strings.Split(gc.Query("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attr), "members") {
+			return true
+		}
+	}
+	return false
+}
+
+// ScimListGroupsHandler lists the groups matching the filter
+func ScimListGroupsHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		filter, err := scim.ParseFilter(gc.Query("filter"))
+		if err != nil {
+			log.Debug("Invalid scim filter: ", err)
+			scimErrorResponse(gc, err)
+			return
+		}
+		startIndex, count := scim.ParsePage(gc.Query("startIndex"), gc.Query("count"))
+		baseURL := scimBaseURL(gc)
+		isExcluded := isMembersExcluded(gc)
+
+		roles := scimRoles()
+		if role, ok := filter.EqualValue("displayName"); ok {
+			roles = []string{}
+			if isScimRole(role) {
+				roles = append(roles, role)
+			}
+		}
+
+		matches := []scim.Group{}
+		for _, role := range roles {
+			var members []scim.GroupMember
+			// members are required to evaluate the filter even if they are excluded
+			if !isExcluded || filter != nil {
+				members, err = scimGroupMembers(gc, role)
+				if err != nil {
+					log.Debug("Failed to list group members: ", err)
+					scimErrorResponse(gc, err)
+					return
+				}
+			}
+			group := scim.NewGroup(role, members, baseURL)
+			if !filter.Matches(group) {
+				continue
+			}
+			if isExcluded {
+				group.Members = nil
+			}
+			matches = append(matches, group)
+		}
+
+		from, to := scimPage(len(matches), startIndex, count)
+		scimResponse(gc, http.StatusOK, scim.NewListResponse(matches[from:to], len(matches), startIndex, to-from))
+	}
+}
+
+// ScimGetGroupHandler returns the group with id
+func ScimGetGroupHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		role := gc.Param("id")
+		if !isScimRole(role) {
+			log.Debug("Invalid scim group: ", role)
+			scimErrorResponse(gc, scim.NewError(http.StatusNotFound, "", "group not found"))
+			return
+		}
+
+		var members []scim.GroupMember
+		if !isMembersExcluded(gc) {
+			var err error
+			members, err = scimGroupMembers(gc, role)
+			if err != nil {
+				log.Debug("Failed to list group members: ", err)
+				scimErrorResponse(gc, err)
+				return
+			}
+		}
+		scimResponse(gc, http.StatusOK, scim.NewGroup(role, members, scimBaseURL(gc)))
+	}
+}
+
+// ScimReplaceGroupHandler replaces the members of group with id
+func ScimReplaceGroupHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		role := gc.Param("id")
+		members, ok := getScimGroupMembers(gc, role)
+		if !ok {
+			return
+		}
+
+		var group scim.Group
+		if err := bindScimBody(gc, &group); err != nil {
+			scimErrorResponse(gc, err)
+			return
+		}
+		updateScimGroup(gc, role, members, group)
+	}
+}
+
+// ScimPatchGroupHandler applies the patch operations on members of group with id
+func ScimPatchGroupHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		role := gc.Param("id")
+		members, ok := getScimGroupMembers(gc, role)
+		if !ok {
+			return
+		}
+
+		var patch scim.PatchRequest
+		if err := bindScimBody(gc, &patch); err != nil {
+			scimErrorResponse(gc, err)
+			return
+		}
+		group := scim.NewGroup(role, members, scimBaseURL(gc))
+		if err := patch.Apply(&group); err != nil {
+			log.Debug("Failed to apply scim patch: ", err)
+			scimErrorResponse(gc, err)
+			return
+		}
+		updateScimGroup(gc, role, members, group)
+	}
+}
+
+// ScimUnsupportedGroupHandler rejects creating & deleting the groups, as groups are the configured roles
+func ScimUnsupportedGroupHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		scimErrorResponse(gc, scim.NewError(http.StatusNotImplemented, "", "groups are the roles configured with ROLES & PROTECTED_ROLES env variables, they can't be created or deleted"))
+	}
+}
+
+// getScimGroupMembers returns the members of group, error response is written if group is not found
+func getScimGroupMembers(gc *gin.Context, role string) ([]scim.GroupMember, bool) {
+	if !isScimRole(role) {
+		log.Debug("Invalid scim group: ", role)
+		scimErrorResponse(gc, scim.NewError(http.StatusNotFound, "", "group not found"))
+		return nil, false
+	}
+	members, err := scimGroupMembers(gc, role)
+	if err != nil {
+		log.Debug("Failed to list group members: ", err)
+		scimErrorResponse(gc, err)
+		return nil, false
+	}
+	return members, true
+}
+
+// updateScimGroup adds the role to the users added to members & removes it from the users removed from members
+func updateScimGroup(gc *gin.Context, role string, members []scim.GroupMember, group scim.Group) {
+	if group.DisplayName != "" && group.DisplayName != role {
+		log.Debug("Invalid scim group display name: ", group.DisplayName)
+		scimErrorResponse(gc, scim.NewError(http.StatusBadRequest, scim.ErrorTypeMutability, "displayName of group can't be changed"))
+		return
+	}
+
+	memberIDs := group.MemberIDs()
+	currentIDs := map[string]bool{}
+	for _, member := range members {
+		currentIDs[member.ID] = true
+	}
+
+	updateRoles := func(userID string, isMember bool) error {
+		user, err := db.Provider.GetUserByID(gc, userID)
+		if err != nil {
+			log.Debug("Failed to get user by ID: ", err)
+			return scim.NewError(http.StatusBadRequest, scim.ErrorTypeInvalidValue, fmt.Sprintf("user %s not found", userID))
+		}
+		roles := []string{}
+		for _, r := range strings.Split(user.Roles, ",") {
+			if r != "" && r != role {
+				roles = append(roles, r)
+			}
+		}
+		if isMember {
+			roles = append(roles, role)
+		}
+		user.Roles = strings.Join(roles, ",")
+		_, err = db.Provider.UpdateUser(gc, user)
+		return err
+	}
+
+	for _, member := range members {
+		if memberIDs[member.ID] {
+			continue
+		}
+		if err := updateRoles(member.ID, false); err != nil {
+			log.Debug("Failed to remove group member: ", err)
+			scimErrorResponse(gc, err)
+			return
+		}
+	}
+	for id := range memberIDs {
+		if currentIDs[id] {
+			continue
+		}
+		if err := updateRoles(id, true); err != nil {
+			log.Debug("Failed to add group member: ", err)
+			scimErrorResponse(gc, err)
+			return
+		}
+	}
+
+	members, err := scimGroupMembers(gc, role)
+	if err != nil {
+		log.Debug("Failed to list group members: ", err)
+		scimErrorResponse(gc, err)
+		return
+	}
+	scimResponse(gc, http.StatusOK, scim.NewGroup(role, members, scimBaseURL(gc)))
+}
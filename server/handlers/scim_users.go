@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/scim"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// ScimListUsersHandler lists the users matching the filter,
+// users are looked up by email for `userName eq` filter & filter is evaluated on all the users otherwise
+func ScimListUsersHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		filter, err := scim.ParseFilter(gc.Query("filter"))
+		if err != nil {
+			log.Debug("Invalid scim filter: ", err)
+			scimErrorResponse(gc, err)
+			return
+		}
+		startIndex, count := scim.ParsePage(gc.Query("startIndex"), gc.Query("count"))
+		baseURL := scimBaseURL(gc)
+
+		resources := []scim.User{}
+		if filter == nil {
+			// page is listed from database directly, oldest users first so that the pages are stable
+			pagination := model.Pagination{
+				Limit:  int64(count),
+				Offset: int64(startIndex - 1),
+				Page:   1,
+			}
+			if count == 0 {
+				pagination.Limit = 1
+			}
+			res, err := db.Provider.ListUsers(gc, pagination, models.UserFilter{
+				SortOrder: constants.SortOrderAsc,
+			})
+			if err != nil {
+				log.Debug("Failed to list users: ", err)
+				scimErrorResponse(gc, err)
+				return
+			}
+			for _, user := range res.Users {
+				if len(resources) < count {
+					resources = append(resources, scim.NewUser(user, baseURL))
+				}
+			}
+			scimResponse(gc, http.StatusOK, scim.NewListResponse(resources, int(res.Pagination.Total), startIndex, len(resources)))
+			return
+		}
+
+		var matches []scim.User
+		if email, ok := filter.EqualValue("userName"); ok {
+			user, err := db.Provider.GetUserByEmail(gc, strings.ToLower(email))
+			if err == nil {
+				if resource := scim.NewUser(user.AsAPIUser(), baseURL); filter.Matches(resource) {
+					matches = append(matches, resource)
+				}
+			}
+		} else {
+			err = forEachUser(gc, models.UserFilter{}, func(user *model.User) {
+				if resource := scim.NewUser(user, baseURL); filter.Matches(resource) {
+					matches = append(matches, resource)
+				}
+			})
+			if err != nil {
+				log.Debug("Failed to list users: ", err)
+				scimErrorResponse(gc, err)
+				return
+			}
+		}
+
+		from, to := scimPage(len(matches), startIndex, count)
+		resources = append(resources, matches[from:to]...)
+		scimResponse(gc, http.StatusOK, scim.NewListResponse(resources, len(matches), startIndex, len(resources)))
+	}
+}
+
+// ScimGetUserHandler returns the user with id
+func ScimGetUserHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		user, err := db.Provider.GetUserByID(gc, gc.Param("id"))
+		if err != nil {
+			log.Debug("Failed to get user by ID: ", err)
+			scimErrorResponse(gc, scim.NewError(http.StatusNotFound, "", "user not found"))
+			return
+		}
+		scimResponse(gc, http.StatusOK, scim.NewUser(user.AsAPIUser(), scimBaseURL(gc)))
+	}
+}
+
+// ScimCreateUserHandler creates the user, email of user is marked as verified
+// as it is verified by the identity provider of organization
+func ScimCreateUserHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		var resource scim.User
+		if err := bindScimBody(gc, &resource); err != nil {
+			scimErrorResponse(gc, err)
+			return
+		}
+
+		if _, err := db.Provider.GetUserByEmail(gc, resource.Email()); err == nil {
+			log.Debug("User already exists with email: ", resource.Email())
+			scimErrorResponse(gc, scim.NewError(http.StatusConflict, scim.ErrorTypeUniqueness, "user with this userName already exists"))
+			return
+		}
+
+		var user models.User
+		if err := resource.ApplyTo(&user); err != nil {
+			log.Debug("Invalid scim user: ", err)
+			scimErrorResponse(gc, err)
+			return
+		}
+		if err := validateScimUserPhoneNumber(gc, user); err != nil {
+			scimErrorResponse(gc, err)
+			return
+		}
+
+		user, err := db.Provider.AddUser(gc, user)
+		if err != nil {
+			log.Debug("Failed to add user: ", err)
+			scimErrorResponse(gc, err)
+			return
+		}
+		go utils.RegisterEvent(gc, constants.UserCreatedWebhookEvent, constants.AuthRecipeMethodBasicAuth, user)
+
+		scimResponse(gc, http.StatusCreated, scim.NewUser(user.AsAPIUser(), scimBaseURL(gc)))
+	}
+}
+
+// ScimReplaceUserHandler replaces the attributes of user with id
+func ScimReplaceUserHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		user, err := db.Provider.GetUserByID(gc, gc.Param("id"))
+		if err != nil {
+			log.Debug("Failed to get user by ID: ", err)
+			scimErrorResponse(gc, scim.NewError(http.StatusNotFound, "", "user not found"))
+			return
+		}
+
+		var resource scim.User
+		if err := bindScimBody(gc, &resource); err != nil {
+			scimErrorResponse(gc, err)
+			return
+		}
+		updateScimUser(gc, user, resource)
+	}
+}
+
+// ScimPatchUserHandler applies the patch operations on user with id
+func ScimPatchUserHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		user, err := db.Provider.GetUserByID(gc, gc.Param("id"))
+		if err != nil {
+			log.Debug("Failed to get user by ID: ", err)
+			scimErrorResponse(gc, scim.NewError(http.StatusNotFound, "", "user not found"))
+			return
+		}
+
+		var patch scim.PatchRequest
+		if err := bindScimBody(gc, &patch); err != nil {
+			scimErrorResponse(gc, err)
+			return
+		}
+		resource := scim.NewUser(user.AsAPIUser(), scimBaseURL(gc))
+		if err := patch.Apply(&resource); err != nil {
+			log.Debug("Failed to apply scim patch: ", err)
+			scimErrorResponse(gc, err)
+			return
+		}
+		updateScimUser(gc, user, resource)
+	}
+}
+
+// ScimDeleteUserHandler deletes the user with id & its sessions
+func ScimDeleteUserHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		user, err := db.Provider.GetUserByID(gc, gc.Param("id"))
+		if err != nil {
+			log.Debug("Failed to get user by ID: ", err)
+			scimErrorResponse(gc, scim.NewError(http.StatusNotFound, "", "user not found"))
+			return
+		}
+
+		if err := db.Provider.DeleteUser(gc, user); err != nil {
+			log.Debug("Failed to delete user: ", err)
+			scimErrorResponse(gc, err)
+			return
+		}
+
+		go func() {
+			memorystore.Provider.DeleteAllUserSessions(user.ID)
+			utils.RegisterEvent(gc, constants.UserDeletedWebhookEvent, "", user)
+		}()
+
+		gc.Status(http.StatusNoContent)
+	}
+}
+
+// updateScimUser updates the user with the attributes of resource, sessions of user are deleted if user is deactivated
+func updateScimUser(gc *gin.Context, user models.User, resource scim.User) {
+	log := log.WithFields(log.Fields{
+		"user_id": user.ID,
+	})
+	email := user.Email
+	wasRevoked := user.RevokedTimestamp != nil
+	if err := resource.ApplyTo(&user); err != nil {
+		log.Debug("Invalid scim user: ", err)
+		scimErrorResponse(gc, err)
+		return
+	}
+
+	if user.Email != email {
+		if _, err := db.Provider.GetUserByEmail(gc, user.Email); err == nil {
+			log.Debug("User already exists with email: ", user.Email)
+			scimErrorResponse(gc, scim.NewError(http.StatusConflict, scim.ErrorTypeUniqueness, "user with this userName already exists"))
+			return
+		}
+	}
+	if err := validateScimUserPhoneNumber(gc, user); err != nil {
+		scimErrorResponse(gc, err)
+		return
+	}
+
+	user, err := db.Provider.UpdateUser(gc, user)
+	if err != nil {
+		log.Debug("Failed to update user: ", err)
+		scimErrorResponse(gc, err)
+		return
+	}
+
+	isRevoked := user.RevokedTimestamp != nil
+	if !wasRevoked && isRevoked {
+		go func() {
+			memorystore.Provider.DeleteAllUserSessions(user.ID)
+			utils.RegisterEvent(gc, constants.UserAccessRevokedWebhookEvent, "", user)
+		}()
+	}
+	if wasRevoked && !isRevoked {
+		go utils.RegisterEvent(gc, constants.UserAccessEnabledWebhookEvent, "", user)
+	}
+
+	scimResponse(gc, http.StatusOK, scim.NewUser(user.AsAPIUser(), scimBaseURL(gc)))
+}
+
+// validateScimUserPhoneNumber validates that phone number of user is not used by other user
+func validateScimUserPhoneNumber(gc *gin.Context, user models.User) error {
+	phoneNumber := refs.StringValue(user.PhoneNumber)
+	if phoneNumber == "" {
+		return nil
+	}
+	existingUser, err := db.Provider.GetUserByPhoneNumber(gc, phoneNumber)
+	if err == nil && existingUser.ID != user.ID {
+		log.Debug("User already exists with phone number: ", phoneNumber)
+		return scim.NewError(http.StatusConflict, scim.ErrorTypeUniqueness, "user with this phone number already exists")
+	}
+	return nil
+}
@@ -13,17 +13,23 @@ import (
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/cookie"
 	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/device"
+	"github.com/authorizerdev/authorizer/server/dpop"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/token"
 )
 
 // TokenHandler to handle /oauth/token requests
 // grant type required
+// request body can be json or form encoded,
+// client can authenticate with client_secret_basic or client_secret_post
 func TokenHandler() gin.HandlerFunc {
 	return func(gc *gin.Context) {
-		var reqBody map[string]string
-		if err := gc.BindJSON(&reqBody); err != nil {
-			log.Debug("Error binding JSON: ", err)
+		reqBody, err := bindOAuthRequest(gc)
+		if err != nil {
+			log.Debug("Error binding request: ", err)
 			gc.JSON(http.StatusBadRequest, gin.H{
 				"error":             "error_binding_json",
 				"error_description": err.Error(),
@@ -33,23 +39,26 @@ func TokenHandler() gin.HandlerFunc {
 
 		codeVerifier := strings.TrimSpace(reqBody["code_verifier"])
 		code := strings.TrimSpace(reqBody["code"])
-		clientID := strings.TrimSpace(reqBody["client_id"])
 		grantType := strings.TrimSpace(reqBody["grant_type"])
 		refreshToken := strings.TrimSpace(reqBody["refresh_token"])
+		clientID, clientSecret := getClientCredentials(gc, reqBody)
 
 		if grantType == "" {
-			grantType = "authorization_code"
+			grantType = constants.GrantTypeAuthorizationCode
 		}
 
-		isRefreshTokenGrant := grantType == "refresh_token"
-		isAuthorizationCodeGrant := grantType == "authorization_code"
+		isRefreshTokenGrant := grantType == constants.GrantTypeRefreshToken
+		isAuthorizationCodeGrant := grantType == constants.GrantTypeAuthorizationCode
+		isClientCredentialsGrant := grantType == constants.GrantTypeClientCredentials
+		isDeviceCodeGrant := grantType == constants.GrantTypeDeviceCode
 
-		if !isRefreshTokenGrant && !isAuthorizationCodeGrant {
+		if !isRefreshTokenGrant && !isAuthorizationCodeGrant && !isClientCredentialsGrant && !isDeviceCodeGrant {
 			log.Debug("Invalid grant type: ", grantType)
 			gc.JSON(http.StatusBadRequest, gin.H{
 				"error":             "invalid_grant_type",
 				"error_description": "grant_type is invalid",
 			})
+			return
 		}
 
 		if clientID == "" {
@@ -61,7 +70,8 @@ func TokenHandler() gin.HandlerFunc {
 			return
 		}
 
-		if client, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID); clientID != client || err != nil {
+		client, err := getOAuthClient(gc, clientID)
+		if err != nil {
 			log.Debug("Client ID is invalid: ", clientID)
 			gc.JSON(http.StatusBadRequest, gin.H{
 				"error":             "invalid_client_id",
@@ -70,10 +80,61 @@ func TokenHandler() gin.HandlerFunc {
 			return
 		}
 
+		if isClientCredentialsGrant && (client == nil || clientSecret == "") {
+			log.Debug("Client credentials grant requires registered client with secret: ", clientID)
+			gc.JSON(http.StatusUnauthorized, gin.H{
+				"error":             "invalid_client",
+				"error_description": "The client credentials are required",
+			})
+			return
+		}
+
+		if client != nil {
+			// client secret is optional for public clients using pkce, but it must be valid if sent
+			if clientSecret != "" && !isValidClientSecret(client, clientSecret) {
+				log.Debug("Client secret is invalid: ", clientID)
+				gc.JSON(http.StatusUnauthorized, gin.H{
+					"error":             "invalid_client",
+					"error_description": "The client secret is invalid",
+				})
+				return
+			}
+
+			if !client.IsGrantTypeAllowed(grantType) {
+				log.Debug("Grant type is not allowed for client: ", grantType)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "unauthorized_client",
+					"error_description": "The grant_type is not allowed for the client",
+				})
+				return
+			}
+		}
+
+		if isClientCredentialsGrant {
+			clientCredentialsGrant(gc, *client, reqBody["scope"])
+			return
+		}
+
+		// tokens are bound to the key of DPoP proof if it is sent
+		dpopJKT := ""
+		if dpop.HasProof(gc) {
+			dpopJKT, err = dpop.ValidateProof(gc, "")
+			if err != nil {
+				log.Debug("Invalid DPoP proof: ", err)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_dpop_proof",
+					"error_description": err.Error(),
+				})
+				return
+			}
+		}
+
 		var userID string
 		var roles, scope []string
 		loginMethod := ""
 		sessionKey := ""
+		refreshTokenFamilyID := ""
+		var authInfo token.AuthenticationInfo
 
 		if isAuthorizationCodeGrant {
 
@@ -112,10 +173,10 @@ func TokenHandler() gin.HandlerFunc {
 
 			go memorystore.Provider.RemoveState(encryptedCode)
 			// split session data
-			// it contains code@sessiontoken
+			// it contains code@sessiontoken@clientid
 			sessionDataSplit := strings.Split(sessionData, "@")
 
-			if sessionDataSplit[0] != code {
+			if len(sessionDataSplit) < 3 || sessionDataSplit[0] != code {
 				log.Debug("Invalid code verifier. Unable to split session data")
 				gc.JSON(http.StatusBadRequest, gin.H{
 					"error":             "invalid_code_verifier",
@@ -124,6 +185,15 @@ func TokenHandler() gin.HandlerFunc {
 				return
 			}
 
+			if sessionDataSplit[2] != clientID {
+				log.Debug("Code is not issued for the client: ", clientID)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_grant",
+					"error_description": "The code is not issued for the client",
+				})
+				return
+			}
+
 			// validate session
 			claims, err := token.ValidateBrowserSession(gc, sessionDataSplit[1])
 			if err != nil {
@@ -138,12 +208,28 @@ func TokenHandler() gin.HandlerFunc {
 			roles = claims.Roles
 			scope = claims.Scope
 			loginMethod = claims.LoginMethod
+			authInfo = claims.AuthenticationInfo
 			// rollover the session for security
 			sessionKey = userID
 			if loginMethod != "" {
 				sessionKey = loginMethod + ":" + userID
 			}
 			go memorystore.Provider.DeleteUserSession(sessionKey, claims.Nonce)
+		} else if isDeviceCodeGrant {
+			authorization, ok := deviceCodeGrant(gc, strings.TrimSpace(reqBody["device_code"]), clientID)
+			if !ok {
+				return
+			}
+			userID = authorization.UserID
+			roles = authorization.Roles
+			scope = authorization.Scope
+			loginMethod = authorization.LoginMethod
+			// user authenticates the device by approving it
+			authInfo = token.NewAuthenticationInfo(loginMethod, false)
+			sessionKey = userID
+			if loginMethod != "" {
+				sessionKey = loginMethod + ":" + userID
+			}
 		} else {
 			// validate refresh token
 			if refreshToken == "" {
@@ -152,18 +238,47 @@ func TokenHandler() gin.HandlerFunc {
 					"error":             "invalid_refresh_token",
 					"error_description": "The refresh token is invalid",
 				})
+				return
 			}
 
 			claims, err := token.ValidateRefreshToken(gc, refreshToken)
 			if err != nil {
 				log.Debug("Error validating refresh token: ", err)
+				// rotated refresh token is presented again, it might be stolen
+				if token.RevokeReusedRefreshToken(gc, refreshToken) {
+					gc.JSON(http.StatusBadRequest, gin.H{
+						"error":             "invalid_grant",
+						"error_description": "The refresh token is already used",
+					})
+					return
+				}
 				gc.JSON(http.StatusUnauthorized, gin.H{
 					"error":             "unauthorized",
 					"error_description": err.Error(),
 				})
+				return
+			}
+			if claims["aud"] != clientID {
+				log.Debug("Refresh token is not issued for the client: ", clientID)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_grant",
+					"error_description": "The refresh token is not issued for the client",
+				})
+				return
+			}
+			// refresh token bound to DPoP key can only be used with the proof of same key
+			if jkt := dpop.GetJKT(claims); jkt != "" && jkt != dpopJKT {
+				log.Debug("Refresh token is bound to another DPoP key")
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_dpop_proof",
+					"error_description": "The refresh token is bound to another DPoP key",
+				})
+				return
 			}
 			userID = claims["sub"].(string)
-			loginMethod := claims["login_method"]
+			loginMethod, _ = claims["login_method"].(string)
+			refreshTokenFamilyID, _ = claims["family_id"].(string)
+			authInfo = token.GetAuthenticationInfo(claims)
 			rolesInterface := claims["roles"].([]interface{})
 			scopeInterface := claims["scope"].([]interface{})
 			for _, v := range rolesInterface {
@@ -174,11 +289,22 @@ func TokenHandler() gin.HandlerFunc {
 			}
 
 			sessionKey = userID
-			if loginMethod != nil && loginMethod != "" {
-				sessionKey = loginMethod.(string) + ":" + sessionKey
+			if loginMethod != "" {
+				sessionKey = loginMethod + ":" + sessionKey
+			}
+			// remove older refresh token and rotate it for security,
+			// it is consumed before the tokens are issued so that it can not be used by concurrent requests
+			nonce, _ := claims["nonce"].(string)
+			isConsumed, err := memorystore.Provider.ConsumeUserSession(sessionKey, nonce, constants.TokenTypeRefreshToken+"_"+nonce, refreshToken)
+			if err != nil || !isConsumed {
+				log.Debug("Refresh token is already used: ", err)
+				token.RevokeReusedRefreshToken(gc, refreshToken)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_grant",
+					"error_description": "The refresh token is already used",
+				})
+				return
 			}
-			// remove older refresh token and rotate it for security
-			go memorystore.Provider.DeleteUserSession(sessionKey, claims["nonce"].(string))
 		}
 
 		if sessionKey == "" {
@@ -200,7 +326,13 @@ func TokenHandler() gin.HandlerFunc {
 			return
 		}
 
-		authToken, err := token.CreateAuthToken(gc, user, roles, scope, loginMethod)
+		// refresh token family of rotated refresh token is continued
+		authToken, err := token.CreateAuthToken(gc, user, roles, scope, loginMethod, token.AuthTokenOptions{
+			AuthInfo:             &authInfo,
+			Client:               client,
+			RefreshTokenFamilyID: refreshTokenFamilyID,
+			DPoPJKT:              dpopJKT,
+		})
 		if err != nil {
 			log.Debug("Error creating auth token: ", err)
 			gc.JSON(http.StatusUnauthorized, gin.H{
@@ -218,7 +350,12 @@ func TokenHandler() gin.HandlerFunc {
 			expiresIn = 1
 		}
 
+		tokenType := "Bearer"
+		if dpopJKT != "" {
+			tokenType = constants.DPoPHeader
+		}
 		res := map[string]interface{}{
+			"token_type":   tokenType,
 			"access_token": authToken.AccessToken.Token,
 			"id_token":     authToken.IDToken.Token,
 			"scope":        scope,
@@ -234,3 +371,113 @@ func TokenHandler() gin.HandlerFunc {
 		gc.JSON(http.StatusOK, res)
 	}
 }
+
+// clientCredentialsGrant issues the access token for the authenticated client itself,
+// requested scopes must be allowed for the client & refresh token or id token is not issued
+func clientCredentialsGrant(gc *gin.Context, client models.Client, scopeString string) {
+	scope := strings.Fields(scopeString)
+	if len(scope) == 0 {
+		scope = client.AsAPIClient().AllowedScopes
+	}
+	if !client.IsScopeAllowed(scope) {
+		log.Debug("Scope is not allowed for client: ", scope)
+		gc.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_scope",
+			"error_description": "The scope is not allowed for the client",
+		})
+		return
+	}
+
+	accessToken, expiresAt, err := token.CreateClientAccessToken(client, scope, parsers.GetHost(gc))
+	if err != nil {
+		log.Debug("Error creating client access token: ", err)
+		gc.JSON(http.StatusInternalServerError, gin.H{
+			"error":             "server_error",
+			"error_description": err.Error(),
+		})
+		return
+	}
+
+	expiresIn := expiresAt - time.Now().Unix()
+	if expiresIn <= 0 {
+		expiresIn = 1
+	}
+
+	gc.JSON(http.StatusOK, gin.H{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"scope":        scope,
+		"expires_in":   expiresIn,
+	})
+}
+
+// deviceCodeGrant validates the device_code polled by device & returns the device authorization once user has approved it.
+// polling before the user decision is answered with authorization_pending
+// & polling faster than the interval is answered with slow_down, which increases the interval by 5 seconds
+func deviceCodeGrant(gc *gin.Context, deviceCode, clientID string) (*device.Authorization, bool) {
+	if deviceCode == "" {
+		log.Debug("Device code is empty")
+		gc.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_request",
+			"error_description": "The device code is required",
+		})
+		return nil, false
+	}
+
+	authorization, err := device.GetByDeviceCode(deviceCode)
+	if err != nil || authorization.ClientID != clientID {
+		log.Debug("Invalid device code: ", err)
+		gc.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_grant",
+			"error_description": "The device code is invalid",
+		})
+		return nil, false
+	}
+
+	if authorization.IsExpired() {
+		log.Debug("Device code is expired")
+		device.Remove(authorization)
+		gc.JSON(http.StatusBadRequest, gin.H{
+			"error":             "expired_token",
+			"error_description": "The device code is expired",
+		})
+		return nil, false
+	}
+
+	switch authorization.Status {
+	case constants.DeviceAuthorizationStatusApproved:
+		// device code can be exchanged only once
+		device.Remove(authorization)
+		return authorization, true
+	case constants.DeviceAuthorizationStatusDenied:
+		log.Debug("Device authorization is denied by user")
+		device.Remove(authorization)
+		gc.JSON(http.StatusBadRequest, gin.H{
+			"error":             "access_denied",
+			"error_description": "The device authorization is denied",
+		})
+		return nil, false
+	}
+
+	now := time.Now().Unix()
+	if authorization.LastPolledAt > 0 && now-authorization.LastPolledAt < authorization.Interval {
+		log.Debug("Device is polling too fast")
+		authorization.Interval += constants.DevicePollingInterval
+		authorization.LastPolledAt = now
+		device.Save(authorization)
+		gc.JSON(http.StatusBadRequest, gin.H{
+			"error":             "slow_down",
+			"error_description": "The device is polling too fast",
+			"interval":          authorization.Interval,
+		})
+		return nil, false
+	}
+
+	authorization.LastPolledAt = now
+	device.Save(authorization)
+	gc.JSON(http.StatusBadRequest, gin.H{
+		"error":             "authorization_pending",
+		"error_description": "The device authorization is pending",
+	})
+	return nil, false
+}
@@ -98,7 +98,7 @@ func VerifyEmailHandler() gin.HandlerFunc {
 		if verificationRequest.Identifier == constants.VerificationTypeMagicLinkLogin {
 			loginMethod = constants.AuthRecipeMethodMagicLinkLogin
 		}
-		authToken, err := token.CreateAuthToken(c, user, roles, scope, loginMethod)
+		authToken, err := token.CreateAuthToken(c, user, roles, scope, loginMethod, token.AuthTokenOptions{})
 		if err != nil {
 			log.Debug("Error creating auth token: ", err)
 			errorRes["error_description"] = err.Error()
@@ -141,6 +141,7 @@ func VerifyEmailHandler() gin.HandlerFunc {
 			}
 
 			db.Provider.AddSession(c, models.Session{
+				ID:        authToken.SessionID,
 				UserID:    user.ID,
 				UserAgent: utils.GetUserAgent(c.Request),
 				IP:        utils.GetIP(c.Request),
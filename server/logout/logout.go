@@ -0,0 +1,112 @@
+// Package logout ends the user sessions & notifies the oauth clients about the logout
+// as per OpenID Connect Back-Channel Logout
+package logout
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+)
+
+// EndSession deletes all the sessions of user saved with session key (login_method:user_id)
+// with the refresh token families of the sessions & returns the registered clients that were issued tokens in these sessions.
+// Logout token is sent to back-channel logout uri of the clients in background
+func EndSession(ctx context.Context, sessionKey, userID, hostname string) []models.Client {
+	sessions, err := memorystore.Provider.GetAllUserSessions(sessionKey)
+	if err != nil {
+		log.Debug("Failed to get user sessions: ", err)
+		sessions = map[string]string{}
+	}
+
+	nonces := []string{}
+	clientIDs := []string{}
+	familyIDs := []string{}
+	for key, value := range sessions {
+		for _, tokenType := range []string{constants.TokenTypeSessionToken, constants.TokenTypeAccessToken, constants.TokenTypeRefreshToken} {
+			if !strings.HasPrefix(key, tokenType+"_") {
+				continue
+			}
+			if nonce := strings.TrimPrefix(key, tokenType+"_"); !contains(nonces, nonce) {
+				nonces = append(nonces, nonce)
+			}
+			// tokens are read from the session store, so audience can be read without verifying them again
+			if tokenType != constants.TokenTypeSessionToken {
+				claims := jwt.MapClaims{}
+				if _, _, err := new(jwt.Parser).ParseUnverified(value, claims); err == nil {
+					if aud, ok := claims["aud"].(string); ok && aud != "" && !contains(clientIDs, aud) {
+						clientIDs = append(clientIDs, aud)
+					}
+					if familyID, ok := claims["family_id"].(string); ok && familyID != "" && !contains(familyIDs, familyID) {
+						familyIDs = append(familyIDs, familyID)
+					}
+				}
+			}
+		}
+	}
+
+	for _, nonce := range nonces {
+		memorystore.Provider.DeleteUserSession(sessionKey, nonce)
+	}
+	for _, familyID := range familyIDs {
+		token.DeleteRefreshTokenFamily(familyID)
+	}
+
+	clients := []models.Client{}
+	for _, clientID := range clientIDs {
+		// instance client & deleted clients are not registered for logout
+		client, err := db.Provider.GetClientByID(ctx, clientID)
+		if err != nil {
+			continue
+		}
+		clients = append(clients, client)
+		if client.BackchannelLogoutURI != "" {
+			go sendLogoutToken(client, userID, hostname)
+		}
+	}
+
+	return clients
+}
+
+// sendLogoutToken posts the logout token to back-channel logout uri of client
+func sendLogoutToken(client models.Client, userID, hostname string) {
+	log := log.WithField("client_id", client.GetClientID())
+	logoutToken, err := token.CreateLogoutToken(client.GetClientID(), userID, hostname)
+	if err != nil {
+		log.Debug("Failed to create logout token: ", err)
+		return
+	}
+
+	httpClient := &http.Client{Timeout: time.Second * 30}
+	res, err := httpClient.PostForm(client.BackchannelLogoutURI, url.Values{
+		"logout_token": {logoutToken},
+	})
+	if err != nil {
+		log.Debug("Failed to send logout token: ", err)
+		return
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		log.Debug("Back-channel logout failed with status: ", res.StatusCode)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -3,13 +3,18 @@ package inmemory
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/memorystore/providers"
 )
 
 // SetUserSession sets the user session
 func (c *provider) SetUserSession(userId, key, token string) error {
 	c.sessionStore.Set(userId, key, token)
+	// expires the same way as the redis hash of user sessions
+	c.sessionStore.Expire(userId, providers.GetSessionTTL(c))
 	return nil
 }
 
@@ -26,28 +31,54 @@ func (c *provider) GetUserSession(userId, sessionToken string) (string, error) {
 
 // DeleteAllUserSessions deletes all the user sessions from in-memory store.
 func (c *provider) DeleteAllUserSessions(userId string) error {
-	namespaces := []string{
-		constants.AuthRecipeMethodBasicAuth,
-		constants.AuthRecipeMethodMagicLinkLogin,
-		constants.AuthRecipeMethodApple,
-		constants.AuthRecipeMethodFacebook,
-		constants.AuthRecipeMethodGithub,
-		constants.AuthRecipeMethodGoogle,
-		constants.AuthRecipeMethodLinkedIn,
+	for _, namespace := range constants.SessionNamespaces {
+		c.sessionStore.RemoveAll(namespace + ":" + userId)
 	}
+	return nil
+}
 
-	for _, namespace := range namespaces {
-		c.sessionStore.RemoveAll(namespace + ":" + userId)
+// ListUserSessions returns the tokens of all the user sessions from in-memory store
+// keyed by the session store key i.e. loginMethod:userId
+func (c *provider) ListUserSessions(userId string) (map[string]map[string]string, error) {
+	res := make(map[string]map[string]string)
+	for _, namespace := range constants.SessionNamespaces {
+		sessionKey := namespace + ":" + userId
+		if data := c.sessionStore.GetAll(sessionKey); len(data) > 0 {
+			res[sessionKey] = data
+		}
+	}
+	return res, nil
+}
+
+// DeleteOtherUserSessions deletes all the user sessions from in-memory store except the session with given nonce
+func (c *provider) DeleteOtherUserSessions(userId, nonce string) error {
+	for _, namespace := range constants.SessionNamespaces {
+		sessionKey := namespace + ":" + userId
+		for key := range c.sessionStore.GetAll(sessionKey) {
+			if !strings.HasSuffix(key, "_"+nonce) {
+				c.sessionStore.Remove(sessionKey, key)
+			}
+		}
 	}
 	return nil
 }
 
-// DeleteUserSession deletes the user session from the in-memory store.
-func (c *provider) DeleteUserSession(userId, sessionToken string) error {
-	c.sessionStore.Remove(userId, sessionToken)
+// DeleteUserSession deletes the session, access & refresh token of the user session from the in-memory store.
+func (c *provider) DeleteUserSession(userId, key string) error {
+	c.sessionStore.Remove(userId, constants.TokenTypeSessionToken+"_"+key)
+	c.sessionStore.Remove(userId, constants.TokenTypeAccessToken+"_"+key)
+	c.sessionStore.Remove(userId, constants.TokenTypeRefreshToken+"_"+key)
 	return nil
 }
 
+// ConsumeUserSession deletes the user session only if the token saved with tokenKey matches
+func (c *provider) ConsumeUserSession(userId, key, tokenKey, token string) (bool, error) {
+	if !c.sessionStore.RemoveIfEquals(userId, tokenKey, token) {
+		return false, nil
+	}
+	return true, c.DeleteUserSession(userId, key)
+}
+
 // DeleteSessionForNamespace to delete session for a given namespace example google,github
 func (c *provider) DeleteSessionForNamespace(namespace string) error {
 	c.sessionStore.RemoveByNamespace(namespace)
@@ -65,6 +96,17 @@ func (c *provider) SetState(key, state string) error {
 	return nil
 }
 
+// SetStateWithExpiry sets the state in the in-memory store that is removed after expiry.
+func (c *provider) SetStateWithExpiry(key, state string, expiresIn time.Duration) error {
+	if os.Getenv("ENV") != constants.TestEnv {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+	}
+	c.stateStore.SetWithExpiry(key, state, time.Now().Add(expiresIn))
+
+	return nil
+}
+
 // GetState gets the state from the in-memory store.
 func (c *provider) GetState(key string) (string, error) {
 	return c.stateStore.Get(key), nil
@@ -3,24 +3,40 @@ package stores
 import (
 	"strings"
 	"sync"
+	"time"
 )
 
 // SessionStore struct to store the env variables
 type SessionStore struct {
 	mutex sync.Mutex
 	store map[string]map[string]string
+	// expiry of the keys, keys without expiry are not present
+	expiresAt map[string]time.Time
 }
 
 // NewSessionStore create a new session store
 func NewSessionStore() *SessionStore {
 	return &SessionStore{
-		mutex: sync.Mutex{},
-		store: make(map[string]map[string]string),
+		mutex:     sync.Mutex{},
+		store:     make(map[string]map[string]string),
+		expiresAt: make(map[string]time.Time),
+	}
+}
+
+// removeIfExpired removes all the values of key if it is expired,
+// mutex must be locked by the caller
+func (s *SessionStore) removeIfExpired(key string) {
+	if expiresAt, ok := s.expiresAt[key]; ok && time.Now().After(expiresAt) {
+		delete(s.store, key)
+		delete(s.expiresAt, key)
 	}
 }
 
 // Get returns the value of the key in state store
 func (s *SessionStore) Get(key, subKey string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.removeIfExpired(key)
 	return s.store[key][subKey]
 }
 
@@ -29,17 +45,30 @@ func (s *SessionStore) Set(key string, subKey, value string) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	s.removeIfExpired(key)
 	if _, ok := s.store[key]; !ok {
 		s.store[key] = make(map[string]string)
 	}
 	s.store[key][subKey] = value
 }
 
+// Expire sets the expiry of all the values for given key, 0 ttl removes the expiry
+func (s *SessionStore) Expire(key string, ttl time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if ttl <= 0 {
+		delete(s.expiresAt, key)
+		return
+	}
+	s.expiresAt[key] = time.Now().Add(ttl)
+}
+
 // RemoveAll all values for given key
 func (s *SessionStore) RemoveAll(key string) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 	delete(s.store, key)
+	delete(s.expiresAt, key)
 }
 
 // Remove value for given key and subkey
@@ -51,12 +80,30 @@ func (s *SessionStore) Remove(key, subKey string) {
 	}
 }
 
-// Get all the values for given key
+// RemoveIfEquals removes the value for given key and subkey only if it equals the value,
+// it returns true if value is removed
+func (s *SessionStore) RemoveIfEquals(key, subKey, value string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.removeIfExpired(key)
+	if current, ok := s.store[key][subKey]; !ok || current != value {
+		return false
+	}
+	delete(s.store[key], subKey)
+	return true
+}
+
+// Get all the values for given key, copy of the values is returned
+// so that it can be iterated while the sessions are updated
 func (s *SessionStore) GetAll(key string) map[string]string {
-	if _, ok := s.store[key]; !ok {
-		s.store[key] = make(map[string]string)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.removeIfExpired(key)
+	res := make(map[string]string, len(s.store[key]))
+	for subKey, value := range s.store[key] {
+		res[subKey] = value
 	}
-	return s.store[key]
+	return res
 }
 
 // RemoveByNamespace to delete session for a given namespace example google,github
@@ -66,6 +113,7 @@ func (s *SessionStore) RemoveByNamespace(namespace string) error {
 	for key := range s.store {
 		if strings.Contains(key, namespace+":") {
 			delete(s.store, key)
+			delete(s.expiresAt, key)
 		}
 	}
 	return nil
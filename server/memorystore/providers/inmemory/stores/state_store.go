@@ -2,24 +2,41 @@ package stores
 
 import (
 	"sync"
+	"time"
 )
 
+// stateExpirySweepInterval is the interval at which expired states are removed from state store,
+// so the states that are not read again after expiry do not grow the store
+const stateExpirySweepInterval = time.Minute
+
 // StateStore struct to store the env variables
 type StateStore struct {
-	mutex sync.Mutex
-	store map[string]string
+	mutex     sync.Mutex
+	store     map[string]string
+	expiresAt map[string]time.Time
+	sweptAt   time.Time
 }
 
 // NewStateStore create a new state store
 func NewStateStore() *StateStore {
 	return &StateStore{
-		mutex: sync.Mutex{},
-		store: make(map[string]string),
+		mutex:     sync.Mutex{},
+		store:     make(map[string]string),
+		expiresAt: make(map[string]time.Time),
+		sweptAt:   time.Now(),
 	}
 }
 
 // Get returns the value of the key in state store
 func (s *StateStore) Get(key string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if expiresAt, ok := s.expiresAt[key]; ok && time.Now().After(expiresAt) {
+		delete(s.store, key)
+		delete(s.expiresAt, key)
+		return ""
+	}
 	return s.store[key]
 }
 
@@ -29,6 +46,26 @@ func (s *StateStore) Set(key string, value string) {
 	defer s.mutex.Unlock()
 
 	s.store[key] = value
+	delete(s.expiresAt, key)
+}
+
+// SetWithExpiry sets the value of the key in state store that is removed after expiresAt
+func (s *StateStore) SetWithExpiry(key string, value string, expiresAt time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.sweptAt) > stateExpirySweepInterval {
+		for k, e := range s.expiresAt {
+			if now.After(e) {
+				delete(s.store, k)
+				delete(s.expiresAt, k)
+			}
+		}
+		s.sweptAt = now
+	}
+	s.store[key] = value
+	s.expiresAt[key] = expiresAt
 }
 
 // Remove removes the key from state store
@@ -37,4 +74,5 @@ func (s *StateStore) Remove(key string) {
 	defer s.mutex.Unlock()
 
 	delete(s.store, key)
+	delete(s.expiresAt, key)
 }
@@ -1,5 +1,7 @@
 package providers
 
+import "time"
+
 // Provider defines current memory store provider
 type Provider interface {
 	// SetUserSession sets the user session
@@ -10,13 +12,23 @@ type Provider interface {
 	GetUserSession(userId, key string) (string, error)
 	// DeleteUserSession deletes the user session
 	DeleteUserSession(userId, key string) error
+	// ConsumeUserSession deletes the user session only if the token saved with tokenKey matches,
+	// false is returned if it is already deleted so that the token e.g. refresh token is used only once
+	ConsumeUserSession(userId, key, tokenKey, token string) (bool, error)
 	// DeleteAllSessions deletes all the sessions from the session store
 	DeleteAllUserSessions(userId string) error
+	// ListUserSessions returns the tokens of all the user sessions across the login methods,
+	// keyed by the session store key i.e. loginMethod:userId
+	ListUserSessions(userId string) (map[string]map[string]string, error)
+	// DeleteOtherUserSessions deletes all the user sessions except the session with given nonce
+	DeleteOtherUserSessions(userId, nonce string) error
 	// DeleteSessionForNamespace deletes the session for a given namespace
 	DeleteSessionForNamespace(namespace string) error
 
 	// SetState sets the login state (key, value form) in the session store
 	SetState(key, state string) error
+	// SetStateWithExpiry sets the state in the session store that is removed after expiry
+	SetStateWithExpiry(key, state string, expiresIn time.Duration) error
 	// GetState returns the state from the session store
 	GetState(key string) (string, error)
 	// RemoveState removes the social login state from the session store
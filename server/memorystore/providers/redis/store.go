@@ -2,8 +2,11 @@ package redis
 
 import (
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/memorystore/providers"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -21,6 +24,12 @@ func (c *provider) SetUserSession(userId, key, token string) error {
 		log.Debug("Error saving to redis: ", err)
 		return err
 	}
+	if ttl := providers.GetSessionTTL(c); ttl > 0 {
+		if err := c.store.Expire(c.ctx, userId, ttl).Err(); err != nil {
+			log.Debug("Error setting expiry of user session in redis: ", err)
+			return err
+		}
+	}
 	return nil
 }
 
@@ -61,18 +70,25 @@ func (c *provider) DeleteUserSession(userId, key string) error {
 	return nil
 }
 
+// consumeUserSessionScript deletes the hash field only if its value matches, so that it is checked & deleted atomically
+const consumeUserSessionScript = `if redis.call("HGET", KEYS[1], ARGV[1]) == ARGV[2] then return redis.call("HDEL", KEYS[1], ARGV[1]) end return 0`
+
+// ConsumeUserSession deletes the user session from redis store only if the token saved with tokenKey matches
+func (c *provider) ConsumeUserSession(userId, key, tokenKey, token string) (bool, error) {
+	deleted, err := c.store.Eval(c.ctx, consumeUserSessionScript, []string{userId}, tokenKey, token).Int()
+	if err != nil {
+		log.Debug("Error consuming user session from redis: ", err)
+		return false, err
+	}
+	if deleted == 0 {
+		return false, nil
+	}
+	return true, c.DeleteUserSession(userId, key)
+}
+
 // DeleteAllUserSessions deletes all the user session from redis
 func (c *provider) DeleteAllUserSessions(userID string) error {
-	namespaces := []string{
-		constants.AuthRecipeMethodBasicAuth,
-		constants.AuthRecipeMethodMagicLinkLogin,
-		constants.AuthRecipeMethodApple,
-		constants.AuthRecipeMethodFacebook,
-		constants.AuthRecipeMethodGithub,
-		constants.AuthRecipeMethodGoogle,
-		constants.AuthRecipeMethodLinkedIn,
-	}
-	for _, namespace := range namespaces {
+	for _, namespace := range constants.SessionNamespaces {
 		err := c.store.Del(c.ctx, namespace+":"+userID).Err()
 		if err != nil {
 			log.Debug("Error deleting all user sessions from redis: ", err)
@@ -82,6 +98,44 @@ func (c *provider) DeleteAllUserSessions(userID string) error {
 	return nil
 }
 
+// ListUserSessions returns the tokens of all the user sessions from redis
+// keyed by the session store key i.e. loginMethod:userId
+func (c *provider) ListUserSessions(userID string) (map[string]map[string]string, error) {
+	res := make(map[string]map[string]string)
+	for _, namespace := range constants.SessionNamespaces {
+		sessionKey := namespace + ":" + userID
+		data, err := c.store.HGetAll(c.ctx, sessionKey).Result()
+		if err != nil {
+			log.Debug("Error getting user sessions from redis: ", err)
+			return nil, err
+		}
+		if len(data) > 0 {
+			res[sessionKey] = data
+		}
+	}
+	return res, nil
+}
+
+// DeleteOtherUserSessions deletes all the user sessions from redis except the session with given nonce
+func (c *provider) DeleteOtherUserSessions(userID, nonce string) error {
+	sessions, err := c.ListUserSessions(userID)
+	if err != nil {
+		return err
+	}
+	for sessionKey, data := range sessions {
+		for key := range data {
+			if strings.HasSuffix(key, "_"+nonce) {
+				continue
+			}
+			if err := c.store.HDel(c.ctx, sessionKey, key).Err(); err != nil {
+				log.Debug("Error deleting user session from redis: ", err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // DeleteSessionForNamespace to delete session for a given namespace example google,github
 func (c *provider) DeleteSessionForNamespace(namespace string) error {
 	var cursor uint64
@@ -119,6 +173,17 @@ func (c *provider) SetState(key, value string) error {
 	return nil
 }
 
+// SetStateWithExpiry sets the state in redis store that is removed after expiry.
+func (c *provider) SetStateWithExpiry(key, value string, expiresIn time.Duration) error {
+	err := c.store.Set(c.ctx, stateStorePrefix+key, value, expiresIn).Err()
+	if err != nil {
+		log.Debug("Error saving redis token: ", err)
+		return err
+	}
+
+	return nil
+}
+
 // GetState gets the state from redis store.
 func (c *provider) GetState(key string) (string, error) {
 	data, err := c.store.Get(c.ctx, stateStorePrefix+key).Result()
@@ -0,0 +1,46 @@
+package providers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+)
+
+// ParseDurationInSeconds parses input s, removes ms/us/ns and returns result duration.
+// It lives here as utils depends on memorystore, use utils.ParseDurationInSeconds outside of memorystore
+func ParseDurationInSeconds(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+
+	d = d.Truncate(time.Second)
+	if d <= 0 {
+		return 0, errors.New(`duration must be greater than 0s`)
+	}
+
+	return d, nil
+}
+
+// GetSessionTTL returns the expiry of the session store entry of user (loginMethod:userId).
+// Entry is extended on every session update, so it expires only when all of its sessions have
+// passed SESSION_IDLE_TIMEOUT or SESSION_MAX_LIFETIME, whichever is shorter.
+// 0 is returned when neither is configured & session store entry does not expire
+func GetSessionTTL(p Provider) time.Duration {
+	var ttl time.Duration
+	for _, key := range []string{constants.EnvKeySessionIdleTimeout, constants.EnvKeySessionMaxLifetime} {
+		value, err := p.GetStringStoreEnvVariable(key)
+		if err != nil || value == "" {
+			continue
+		}
+		duration, err := ParseDurationInSeconds(value)
+		if err != nil {
+			continue
+		}
+		if ttl == 0 || duration < ttl {
+			ttl = duration
+		}
+	}
+	return ttl
+}
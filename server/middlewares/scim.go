@@ -0,0 +1,48 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/scim"
+)
+
+// ScimAuthMiddleware is a middleware to authenticate scim requests with the bearer token configured with SCIM_TOKEN,
+// scim endpoints are disabled if the token is not configured
+func ScimAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		abort := func(scimErr *scim.Error) {
+			data, _ := json.Marshal(scimErr)
+			c.Data(scimErr.StatusCode(), scim.ContentType, data)
+			c.Abort()
+		}
+
+		scimToken, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyScimToken)
+		if err != nil || scimToken == "" {
+			log.Debug("SCIM token is not configured: ", err)
+			abort(scim.NewError(http.StatusForbidden, "", "scim provisioning is not enabled"))
+			return
+		}
+
+		authorization := c.GetHeader("Authorization")
+		bearerToken := ""
+		if len(authorization) > len("Bearer ") && strings.EqualFold(authorization[:len("Bearer ")], "Bearer ") {
+			bearerToken = strings.TrimSpace(authorization[len("Bearer "):])
+		}
+		if subtle.ConstantTimeCompare([]byte(bearerToken), []byte(scimToken)) != 1 {
+			log.Debug("Invalid SCIM bearer token")
+			c.Header("WWW-Authenticate", "Bearer")
+			abort(scim.NewError(http.StatusUnauthorized, "", "invalid bearer token"))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -133,5 +133,5 @@ func InitOAuth() error {
 		}
 	}
 
-	return nil
+	return initGenericOIDCProviders(ctx)
 }
@@ -0,0 +1,166 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// oidcProviderNameRegex is used to validate the name of generic OpenID Connect provider,
+// name is used in /oauth_login/:oauth_provider & /oauth_callback/:oauth_provider
+var oidcProviderNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// reservedOIDCProviderNames are the auth methods that can not be used as name of generic OpenID Connect provider
+var reservedOIDCProviderNames = []string{
+	constants.AuthRecipeMethodBasicAuth,
+	constants.AuthRecipeMethodMagicLinkLogin,
+	constants.AuthRecipeMethodGoogle,
+	constants.AuthRecipeMethodGithub,
+	constants.AuthRecipeMethodFacebook,
+	constants.AuthRecipeMethodLinkedIn,
+	constants.AuthRecipeMethodApple,
+	constants.AuthRecipeMethodWebauthn,
+	constants.AuthRecipeMethodMobileOTP,
+	constants.AuthRecipeMethodOIDC,
+	constants.AuthRecipeMethodSAML,
+}
+
+// DefaultOIDCClaimMapping is the mapping of user fields to the claims of OpenID Connect provider
+// it is used for the fields that are not present in claim_mapping of provider config
+var DefaultOIDCClaimMapping = map[string]string{
+	"email":       "email",
+	"given_name":  "given_name",
+	"family_name": "family_name",
+	"middle_name": "middle_name",
+	"nickname":    "nickname",
+	"picture":     "picture",
+	"gender":      "gender",
+	"birthdate":   "birthdate",
+}
+
+// OIDCProviderConfig is the config of generic OpenID Connect provider like keycloak, okta, azure ad
+type OIDCProviderConfig struct {
+	Name         string            `json:"name"`
+	DisplayName  string            `json:"display_name"`
+	IssuerURL    string            `json:"issuer_url"`
+	ClientID     string            `json:"client_id"`
+	ClientSecret string            `json:"client_secret"`
+	Scopes       []string          `json:"scopes"`
+	ClaimMapping map[string]string `json:"claim_mapping"`
+}
+
+// GenericOIDCProvider contains the instance of configured generic OpenID Connect provider
+type GenericOIDCProvider struct {
+	Config      OIDCProviderConfig
+	OAuthConfig *oauth2.Config
+	Provider    *oidc.Provider
+}
+
+// GetClaimName returns the name of the claim for given user field
+func (p *GenericOIDCProvider) GetClaimName(field string) string {
+	if claim, ok := p.Config.ClaimMapping[field]; ok && claim != "" {
+		return claim
+	}
+	return DefaultOIDCClaimMapping[field]
+}
+
+// GenericOIDCProviders is a global variable that contains instance of all the enabled generic OpenID Connect providers
+var GenericOIDCProviders []*GenericOIDCProvider
+
+// GetGenericOIDCProvider returns the enabled generic OpenID Connect provider for given name
+func GetGenericOIDCProvider(name string) *GenericOIDCProvider {
+	for _, p := range GenericOIDCProviders {
+		if p.Config.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// ParseOIDCProviderConfigs parses & validates the generic OpenID Connect provider configs
+func ParseOIDCProviderConfigs(data string) ([]OIDCProviderConfig, error) {
+	configs := []OIDCProviderConfig{}
+	if strings.TrimSpace(data) == "" {
+		return configs, nil
+	}
+
+	err := json.Unmarshal([]byte(data), &configs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid oidc providers config: %s", err.Error())
+	}
+
+	names := map[string]bool{}
+	for i, config := range configs {
+		if !oidcProviderNameRegex.MatchString(config.Name) {
+			return nil, fmt.Errorf("invalid oidc provider name %s, it should contain lowercase letters, digits, _ or -", config.Name)
+		}
+		for _, reservedName := range reservedOIDCProviderNames {
+			if config.Name == reservedName {
+				return nil, fmt.Errorf("oidc provider name %s is reserved", config.Name)
+			}
+		}
+		if names[config.Name] {
+			return nil, fmt.Errorf("duplicate oidc provider name %s", config.Name)
+		}
+		names[config.Name] = true
+
+		if config.IssuerURL == "" || config.ClientID == "" || config.ClientSecret == "" {
+			return nil, fmt.Errorf("issuer_url, client_id & client_secret are required for oidc provider %s", config.Name)
+		}
+
+		if config.DisplayName == "" {
+			configs[i].DisplayName = config.Name
+		}
+		if len(config.Scopes) == 0 {
+			configs[i].Scopes = []string{oidc.ScopeOpenID, "profile", "email"}
+		}
+	}
+
+	return configs, nil
+}
+
+// initGenericOIDCProviders initializes the generic OpenID Connect providers configured with OIDC_PROVIDERS env.
+// Provider for which discovery fails is skipped, so that unreachable issuer does not block the other login methods.
+func initGenericOIDCProviders(ctx context.Context) error {
+	data, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyOIDCProviders)
+	if err != nil {
+		data = ""
+	}
+
+	configs, err := ParseOIDCProviderConfigs(data)
+	if err != nil {
+		return err
+	}
+
+	providers := []*GenericOIDCProvider{}
+	for _, config := range configs {
+		p, err := oidc.NewProvider(ctx, config.IssuerURL)
+		if err != nil {
+			log.Errorf("Failed to discover oidc provider %s: %s", config.Name, err.Error())
+			continue
+		}
+		providers = append(providers, &GenericOIDCProvider{
+			Config:   config,
+			Provider: p,
+			OAuthConfig: &oauth2.Config{
+				ClientID:     config.ClientID,
+				ClientSecret: config.ClientSecret,
+				RedirectURL:  "/oauth_callback/" + config.Name,
+				Endpoint:     p.Endpoint(),
+				Scopes:       config.Scopes,
+			},
+		})
+	}
+	GenericOIDCProviders = providers
+
+	return nil
+}
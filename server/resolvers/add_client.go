@@ -0,0 +1,147 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/validators"
+)
+
+// AddClientResolver resolver for add client mutation
+// client secret is returned only once in the response, only its hash is stored
+func AddClientResolver(ctx context.Context, params model.AddClientRequest) (*model.ClientResponse, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	if strings.TrimSpace(params.Name) == "" {
+		log.Debug("empty client name not allowed")
+		return nil, fmt.Errorf("empty client name not allowed")
+	}
+
+	allowedScopes := params.AllowedScopes
+	if len(allowedScopes) == 0 {
+		allowedScopes = constants.DefaultClientScopes
+	}
+	grantTypes := params.GrantTypes
+	if len(grantTypes) == 0 {
+		grantTypes = constants.DefaultClientGrantTypes
+	}
+
+	client := models.Client{
+		Name:                        strings.TrimSpace(params.Name),
+		RedirectURIs:                strings.Join(params.RedirectUris, ","),
+		AllowedScopes:               strings.Join(allowedScopes, ","),
+		GrantTypes:                  strings.Join(grantTypes, ","),
+		AccessTokenExpiryTime:       refs.StringValue(params.AccessTokenExpiryTime),
+		RefreshTokenExpiryTime:      refs.StringValue(params.RefreshTokenExpiryTime),
+		PostLogoutRedirectURIs:      strings.Join(params.PostLogoutRedirectUris, ","),
+		BackchannelLogoutURI:        strings.TrimSpace(refs.StringValue(params.BackchannelLogoutURI)),
+		FrontchannelLogoutURI:       strings.TrimSpace(refs.StringValue(params.FrontchannelLogoutURI)),
+		IDTokenEncryptedResponseAlg: refs.StringValue(params.IDTokenEncryptedResponseAlg),
+		IDTokenEncryptedResponseEnc: refs.StringValue(params.IDTokenEncryptedResponseEnc),
+		IDTokenEncryptionKey:        strings.TrimSpace(refs.StringValue(params.IDTokenEncryptionKey)),
+	}
+	if client.IsIDTokenEncrypted() && client.IDTokenEncryptedResponseEnc == "" {
+		client.IDTokenEncryptedResponseEnc = constants.IDTokenEncryptionEncA256GCM
+	}
+	if err := validateClient(client); err != nil {
+		log.Debug("Invalid client: ", err)
+		return nil, err
+	}
+
+	clientSecret := uuid.New().String()
+	client.ClientSecret, err = crypto.EncryptPassword(clientSecret)
+	if err != nil {
+		log.Debug("Failed to hash client secret: ", err)
+		return nil, err
+	}
+
+	client, err = db.Provider.AddClient(ctx, client)
+	if err != nil {
+		log.Debug("Failed to add client: ", err)
+		return nil, err
+	}
+
+	return &model.ClientResponse{
+		Message:      `Client added successfully`,
+		Client:       client.AsAPIClient(),
+		ClientSecret: refs.NewStringRef(clientSecret),
+	}, nil
+}
+
+// validateClient validates the redirect uris, logout uris, grant types, token lifetimes & id token encryption of client
+func validateClient(client models.Client) error {
+	redirectURIs := client.AsAPIClient().RedirectUris
+	if len(redirectURIs) == 0 {
+		return fmt.Errorf("at least one redirect uri is required")
+	}
+	for _, redirectURI := range redirectURIs {
+		if !validators.IsValidRedirectURI(redirectURI) {
+			return fmt.Errorf("invalid redirect uri %s", redirectURI)
+		}
+	}
+
+	for _, redirectURI := range client.AsAPIClient().PostLogoutRedirectUris {
+		if !validators.IsValidRedirectURI(redirectURI) {
+			return fmt.Errorf("invalid post logout redirect uri %s", redirectURI)
+		}
+	}
+	// logout uris are called by authorizer & browser, so they must be http(s) urls
+	if client.BackchannelLogoutURI != "" && !validators.IsValidLogoutURI(client.BackchannelLogoutURI) {
+		return fmt.Errorf("invalid backchannel logout uri %s", client.BackchannelLogoutURI)
+	}
+	if client.FrontchannelLogoutURI != "" && !validators.IsValidLogoutURI(client.FrontchannelLogoutURI) {
+		return fmt.Errorf("invalid frontchannel logout uri %s", client.FrontchannelLogoutURI)
+	}
+
+	for _, grantType := range client.AsAPIClient().GrantTypes {
+		if !validators.IsValidGrantType(grantType) {
+			return fmt.Errorf("invalid grant type %s", grantType)
+		}
+	}
+
+	if client.AccessTokenExpiryTime != "" {
+		if _, err := utils.ParseDurationInSeconds(client.AccessTokenExpiryTime); err != nil {
+			return fmt.Errorf("invalid access token expiry time: %s", err.Error())
+		}
+	}
+	if client.RefreshTokenExpiryTime != "" {
+		if _, err := utils.ParseDurationInSeconds(client.RefreshTokenExpiryTime); err != nil {
+			return fmt.Errorf("invalid refresh token expiry time: %s", err.Error())
+		}
+	}
+
+	if client.IsIDTokenEncrypted() {
+		if !utils.StringSliceContains(constants.IDTokenEncryptionAlgs, client.IDTokenEncryptedResponseAlg) {
+			return fmt.Errorf("invalid id token encryption algorithm %s", client.IDTokenEncryptedResponseAlg)
+		}
+		if !utils.StringSliceContains(constants.IDTokenEncryptionEncs, client.IDTokenEncryptedResponseEnc) {
+			return fmt.Errorf("invalid id token encryption encoding %s", client.IDTokenEncryptedResponseEnc)
+		}
+		if _, err := crypto.ParseEncryptionJWK(client.IDTokenEncryptionKey, client.IDTokenEncryptedResponseAlg); err != nil {
+			return fmt.Errorf("invalid id token encryption key: %s", err.Error())
+		}
+	}
+
+	return nil
+}
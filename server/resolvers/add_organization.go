@@ -0,0 +1,83 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// AddOrganizationResolver resolver for add organization mutation
+func AddOrganizationResolver(ctx context.Context, params model.AddOrganizationRequest) (*model.Organization, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	organization := models.Organization{
+		Name:         strings.TrimSpace(params.Name),
+		DisplayName:  strings.TrimSpace(refs.StringValue(params.DisplayName)),
+		Roles:        joinRoles(params.Roles),
+		DefaultRoles: joinRoles(params.DefaultRoles),
+	}
+	if err := validateOrganization(organization); err != nil {
+		log.Debug("Invalid organization: ", err)
+		return nil, err
+	}
+
+	if _, err := db.Provider.GetOrganizationByName(ctx, organization.Name); err == nil {
+		log.Debug("Organization already exists with name: ", organization.Name)
+		return nil, fmt.Errorf("organization with %s name already exists", organization.Name)
+	}
+
+	organization, err = db.Provider.AddOrganization(ctx, organization)
+	if err != nil {
+		log.Debug("Failed to add organization: ", err)
+		return nil, err
+	}
+
+	return organization.AsAPIOrganization(), nil
+}
+
+// validateOrganization validates the name & roles of organization,
+// default roles must be the roles of organization
+func validateOrganization(organization models.Organization) error {
+	if organization.Name == "" {
+		return fmt.Errorf("empty organization name not allowed")
+	}
+	if len(organization.GetRoles()) == 0 {
+		return fmt.Errorf("at least one role is required")
+	}
+	for _, role := range organization.GetDefaultRoles() {
+		if !organization.HasRoles([]string{role}) {
+			return fmt.Errorf("invalid default role %s", role)
+		}
+	}
+	return nil
+}
+
+// joinRoles returns the comma separated roles, roles are trimmed & empty roles are ignored
+func joinRoles(roles []string) string {
+	res := []string{}
+	for _, role := range roles {
+		role = strings.TrimSpace(role)
+		if role != "" && !utils.StringSliceContains(res, role) {
+			res = append(res, role)
+		}
+	}
+	return strings.Join(res, ",")
+}
@@ -0,0 +1,97 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// AddOrganizationMemberResolver resolver for add organization member mutation,
+// default roles of organization are assigned if roles are not set
+func AddOrganizationMemberResolver(ctx context.Context, params model.AddOrganizationMemberRequest) (*model.OrganizationMember, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	log := log.WithFields(log.Fields{
+		"organization_id": params.OrganizationID,
+		"user_id":         params.UserID,
+	})
+
+	organization, err := db.Provider.GetOrganizationByID(ctx, params.OrganizationID)
+	if err != nil {
+		log.Debug("Failed to get organization: ", err)
+		return nil, err
+	}
+
+	user, err := db.Provider.GetUserByID(ctx, params.UserID)
+	if err != nil {
+		log.Debug("Failed to get user: ", err)
+		return nil, err
+	}
+
+	if _, err := db.Provider.GetOrganizationMember(ctx, params.OrganizationID, user.ID); err == nil {
+		log.Debug("User is already a member of organization")
+		return nil, fmt.Errorf("user is already a member of organization")
+	}
+
+	roles := joinRoles(params.Roles)
+	if roles == "" {
+		roles = strings.Join(organization.GetDefaultRoles(), ",")
+	}
+	member := models.OrganizationMember{
+		OrganizationID: params.OrganizationID,
+		UserID:         user.ID,
+		Roles:          roles,
+	}
+	if err := validateOrganizationMember(organization, member); err != nil {
+		log.Debug("Invalid organization member: ", err)
+		return nil, err
+	}
+
+	member, err = db.Provider.AddOrganizationMember(ctx, member)
+	if err != nil {
+		log.Debug("Failed to add organization member: ", err)
+		return nil, err
+	}
+
+	// pending invitation is not required once user is the member
+	if invitation, err := db.Provider.GetOrganizationInvitation(ctx, params.OrganizationID, user.Email); err == nil {
+		if err := db.Provider.DeleteOrganizationInvitation(ctx, invitation); err != nil {
+			log.Debug("Failed to delete organization invitation: ", err)
+		}
+	}
+
+	res := member.AsAPIOrganizationMember()
+	res.User = user.AsAPIUser()
+	res.Organization = organization.AsAPIOrganization()
+	return res, nil
+}
+
+// validateOrganizationMember validates that member has at least one role & the roles are of organization
+func validateOrganizationMember(organization models.Organization, member models.OrganizationMember) error {
+	if len(member.GetRoles()) == 0 {
+		return fmt.Errorf("at least one role is required")
+	}
+	for _, role := range member.GetRoles() {
+		if !organization.HasRoles([]string{role}) {
+			return fmt.Errorf("invalid role %s", role)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// ConfirmTotpResolver is a resolver for confirm totp mutation
+// it enables multi factor authentication for the user once valid otp is entered
+func ConfirmTotpResolver(ctx context.Context, params model.ConfirmTOTPInput) (*model.Response, error) {
+	var res *model.Response
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	accessToken, err := token.GetAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed to get access token: ", err)
+		return res, err
+	}
+
+	claims, err := token.ValidateAccessToken(gc, accessToken)
+	if err != nil {
+		log.Debug("Failed to validate access token: ", err)
+		return res, err
+	}
+
+	userID := claims["sub"].(string)
+	log := log.WithFields(log.Fields{
+		"user_id": userID,
+	})
+	user, err := db.Provider.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Debug("Failed to get user: ", err)
+		return res, err
+	}
+
+	if user.TOTPVerifiedAt != nil {
+		log.Debug("Multi factor authentication is already enabled")
+		return res, fmt.Errorf(`multi factor authentication is already enabled`)
+	}
+
+	if user.TOTPSecret == nil || *user.TOTPSecret == "" {
+		log.Debug("TOTP enrollment not found")
+		return res, fmt.Errorf(`totp enrollment not found`)
+	}
+
+	secret, err := crypto.DecryptAES(*user.TOTPSecret)
+	if err != nil {
+		log.Debug("Failed to decrypt totp secret: ", err)
+		return res, err
+	}
+
+	if !validateTOTPCode(user.ID, secret, params.Otp) {
+		log.Debug("Invalid otp")
+		return res, fmt.Errorf(`invalid otp`)
+	}
+
+	now := time.Now().Unix()
+	user.TOTPVerifiedAt = &now
+	_, err = db.Provider.UpdateUser(ctx, user)
+	if err != nil {
+		log.Debug("Failed to update user: ", err)
+		return res, err
+	}
+
+	res = &model.Response{
+		Message: `Multi factor authentication enabled successfully`,
+	}
+
+	return res, nil
+}
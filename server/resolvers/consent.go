@@ -0,0 +1,77 @@
+package resolvers
+
+import (
+	"context"
+	"net/url"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/consent"
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// ConsentResolver is a resolver for consent mutation.
+// logged in user approves or denies the scopes requested by the client,
+// approved scopes are saved as grant so that consent is not asked again.
+// It returns the authorize url to resume the authorization request
+func ConsentResolver(ctx context.Context, params model.ConsentInput) (*model.ConsentResponse, error) {
+	var res *model.ConsentResponse
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	accessToken, err := token.GetAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed to get access token: ", err)
+		return res, err
+	}
+
+	claims, err := token.ValidateAccessToken(gc, accessToken)
+	if err != nil {
+		log.Debug("Failed to validate access token: ", err)
+		return res, err
+	}
+
+	userID := claims["sub"].(string)
+	log := log.WithFields(log.Fields{
+		"user_id": userID,
+	})
+
+	consentRequest, err := getPendingConsentRequest(params.ConsentChallenge, userID)
+	if err != nil {
+		log.Debug("Failed to get consent request: ", err)
+		return res, err
+	}
+
+	if params.Approve {
+		if _, err := consent.SaveGrant(ctx, userID, consentRequest.ClientID, consentRequest.Scope); err != nil {
+			log.Debug("Failed to save grant: ", err)
+			return res, err
+		}
+		consentRequest.Status = constants.ConsentRequestStatusApproved
+	} else {
+		consentRequest.Status = constants.ConsentRequestStatusDenied
+	}
+	if err := consent.Save(consentRequest); err != nil {
+		log.Debug("Failed to save consent request: ", err)
+		return res, err
+	}
+
+	query, err := url.ParseQuery(consentRequest.AuthorizeQuery)
+	if err != nil {
+		log.Debug("Failed to parse authorize query: ", err)
+		return res, err
+	}
+	query.Set(constants.ConsentChallengeParam, consentRequest.Challenge)
+
+	return &model.ConsentResponse{
+		RedirectURI: parsers.GetHost(gc) + "/authorize?" + query.Encode(),
+	}, nil
+}
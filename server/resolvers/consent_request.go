@@ -0,0 +1,78 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/consent"
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// ConsentRequestResolver is a resolver for consent request query.
+// It returns the client & scopes for which the consent of logged in user is requested
+func ConsentRequestResolver(ctx context.Context, params model.ConsentRequestInput) (*model.ConsentRequest, error) {
+	var res *model.ConsentRequest
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	accessToken, err := token.GetAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed to get access token: ", err)
+		return res, err
+	}
+
+	claims, err := token.ValidateAccessToken(gc, accessToken)
+	if err != nil {
+		log.Debug("Failed to validate access token: ", err)
+		return res, err
+	}
+
+	userID := claims["sub"].(string)
+	log := log.WithFields(log.Fields{
+		"user_id": userID,
+	})
+
+	consentRequest, err := getPendingConsentRequest(params.ConsentChallenge, userID)
+	if err != nil {
+		log.Debug("Failed to get consent request: ", err)
+		return res, err
+	}
+
+	client, err := db.Provider.GetClientByID(ctx, consentRequest.ClientID)
+	if err != nil {
+		log.Debug("Failed to get client: ", err)
+		return res, fmt.Errorf(`invalid consent challenge`)
+	}
+
+	return &model.ConsentRequest{
+		ClientID:   consentRequest.ClientID,
+		ClientName: client.Name,
+		Scope:      consentRequest.Scope,
+	}, nil
+}
+
+// getPendingConsentRequest returns the consent request waiting for the response of user
+func getPendingConsentRequest(challenge, userID string) (*consent.Request, error) {
+	consentRequest, err := consent.Get(challenge)
+	if err != nil || consentRequest.UserID != userID {
+		return nil, fmt.Errorf(`invalid consent challenge`)
+	}
+	if consentRequest.IsExpired() {
+		consent.Remove(consentRequest)
+		return nil, fmt.Errorf(`consent challenge is expired`)
+	}
+	if consentRequest.Status != constants.ConsentRequestStatusPending {
+		return nil, fmt.Errorf(`invalid consent challenge`)
+	}
+	return consentRequest, nil
+}
@@ -0,0 +1,50 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// DeleteClientResolver resolver to delete oauth client
+func DeleteClientResolver(ctx context.Context, params model.ClientRequest) (*model.Response, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	if params.ID == "" {
+		log.Debug("client ID is required")
+		return nil, fmt.Errorf("client ID required")
+	}
+
+	log := log.WithField("client_id", params.ID)
+
+	client, err := db.Provider.GetClientByID(ctx, params.ID)
+	if err != nil {
+		log.Debug("failed to get client: ", err)
+		return nil, err
+	}
+
+	err = db.Provider.DeleteClient(ctx, client)
+	if err != nil {
+		log.Debug("failed to delete client: ", err)
+		return nil, err
+	}
+	token.InvalidateAudience(client.GetClientID())
+
+	return &model.Response{
+		Message: "Client deleted successfully",
+	}, nil
+}
@@ -0,0 +1,50 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// DeleteOrganizationResolver resolver to delete organization along with its members & invitations
+func DeleteOrganizationResolver(ctx context.Context, params model.OrganizationRequest) (*model.Response, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	if params.ID == "" {
+		log.Debug("organization ID is required")
+		return nil, fmt.Errorf("organization ID required")
+	}
+
+	log := log.WithField("organization_id", params.ID)
+
+	organization, err := db.Provider.GetOrganizationByID(ctx, params.ID)
+	if err != nil {
+		log.Debug("Failed to get organization: ", err)
+		return nil, err
+	}
+
+	err = db.Provider.DeleteOrganization(ctx, organization)
+	if err != nil {
+		log.Debug("Failed to delete organization: ", err)
+		return nil, err
+	}
+
+	return &model.Response{
+		Message: "Organization deleted successfully",
+	}, nil
+}
@@ -0,0 +1,103 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// EnrollTotpResolver is a resolver for enroll totp mutation
+// it returns the secret & otpauth uri that should be added to authenticator app
+// enrollment is completed once the otp is confirmed via confirm_totp mutation
+func EnrollTotpResolver(ctx context.Context) (*model.TOTPEnrollment, error) {
+	var res *model.TOTPEnrollment
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	accessToken, err := token.GetAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed to get access token: ", err)
+		return res, err
+	}
+
+	claims, err := token.ValidateAccessToken(gc, accessToken)
+	if err != nil {
+		log.Debug("Failed to validate access token: ", err)
+		return res, err
+	}
+
+	userID := claims["sub"].(string)
+	log := log.WithFields(log.Fields{
+		"user_id": userID,
+	})
+	user, err := db.Provider.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Debug("Failed to get user: ", err)
+		return res, err
+	}
+
+	if user.TOTPVerifiedAt != nil {
+		log.Debug("Multi factor authentication is already enabled")
+		return res, fmt.Errorf(`multi factor authentication is already enabled`)
+	}
+
+	res, err = getTOTPEnrollment(ctx, &user)
+	if err != nil {
+		log.Debug("Failed to get totp enrollment: ", err)
+		return res, err
+	}
+
+	return res, nil
+}
+
+// getTOTPEnrollment returns the totp enrollment for the user
+// pending enrollment is re-used, else new secret is generated and saved encrypted in db
+func getTOTPEnrollment(ctx context.Context, user *models.User) (*model.TOTPEnrollment, error) {
+	var secret string
+	var err error
+	if user.TOTPSecret != nil && *user.TOTPSecret != "" {
+		secret, err = crypto.DecryptAES(*user.TOTPSecret)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		secret, err = crypto.NewTOTPSecret()
+		if err != nil {
+			return nil, err
+		}
+		encryptedSecret, err := crypto.EncryptAES(secret)
+		if err != nil {
+			return nil, err
+		}
+		user.TOTPSecret = &encryptedSecret
+		user.TOTPVerifiedAt = nil
+		*user, err = db.Provider.UpdateUser(ctx, *user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	issuer, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyOrganizationName)
+	if err != nil || issuer == "" {
+		issuer = "Authorizer"
+	}
+
+	return &model.TOTPEnrollment{
+		Secret:     secret,
+		OtpauthURI: crypto.GetTOTPURI(secret, issuer, user.Email),
+	}, nil
+}
@@ -41,6 +41,21 @@ func EnvResolver(ctx context.Context) (*model.Env, error) {
 	if val, ok := store[constants.EnvKeyAccessTokenExpiryTime]; ok {
 		res.AccessTokenExpiryTime = refs.NewStringRef(val.(string))
 	}
+	if val, ok := store[constants.EnvKeyRefreshTokenExpiryTime]; ok {
+		res.RefreshTokenExpiryTime = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeyRefreshTokenMaxLifetime]; ok {
+		res.RefreshTokenMaxLifetime = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeyMaxActiveSessions]; ok {
+		res.MaxActiveSessions = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeySessionIdleTimeout]; ok {
+		res.SessionIDLeTimeout = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeySessionMaxLifetime]; ok {
+		res.SessionMaxLifetime = refs.NewStringRef(val.(string))
+	}
 	if val, ok := store[constants.EnvKeyAdminSecret]; ok {
 		res.AdminSecret = refs.NewStringRef(val.(string))
 	}
@@ -50,6 +65,9 @@ func EnvResolver(ctx context.Context) (*model.Env, error) {
 	if val, ok := store[constants.EnvKeyClientSecret]; ok {
 		res.ClientSecret = val.(string)
 	}
+	if val, ok := store[constants.EnvKeyScimToken]; ok {
+		res.ScimToken = refs.NewStringRef(val.(string))
+	}
 	if val, ok := store[constants.EnvKeyDatabaseURL]; ok {
 		res.DatabaseURL = refs.NewStringRef(val.(string))
 	}
@@ -143,6 +161,21 @@ func EnvResolver(ctx context.Context) (*model.Env, error) {
 	if val, ok := store[constants.EnvKeyAppleClientSecret]; ok {
 		res.AppleClientSecret = refs.NewStringRef(val.(string))
 	}
+	if val, ok := store[constants.EnvKeyOIDCProviders]; ok {
+		res.OidcProviders = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeySamlIdpEntityID]; ok {
+		res.SamlIDPEntityID = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeySamlIdpSsoURL]; ok {
+		res.SamlIDPSsoURL = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeySamlIdpCertificate]; ok {
+		res.SamlIDPCertificate = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeySamlAttributeMapping]; ok {
+		res.SamlAttributeMapping = refs.NewStringRef(val.(string))
+	}
 	if val, ok := store[constants.EnvKeyOrganizationName]; ok {
 		res.OrganizationName = refs.NewStringRef(val.(string))
 	}
@@ -162,6 +195,13 @@ func EnvResolver(ctx context.Context) (*model.Env, error) {
 			res.ProtectedRoles = append(res.ProtectedRoles, strings.Trim(role, " "))
 		}
 	}
+	mfaRequiredRoles := strings.Split(store[constants.EnvKeyMFARequiredRoles].(string), ",")
+	res.MfaRequiredRoles = []string{}
+	for _, role := range mfaRequiredRoles {
+		if strings.Trim(role, " ") != "" {
+			res.MfaRequiredRoles = append(res.MfaRequiredRoles, strings.Trim(role, " "))
+		}
+	}
 
 	// bool vars
 	res.DisableEmailVerification = store[constants.EnvKeyDisableEmailVerification].(bool)
@@ -66,6 +66,18 @@ func GenerateJWTKeysResolver(ctx context.Context, params model.GenerateJWTKeysIn
 		}, nil
 	}
 
+	if crypto.IsEdDSA(params.Type) {
+		_, privateKey, publicKey, _, err := crypto.NewEdDSAKey(params.Type, clientID)
+		if err != nil {
+			log.Debug("Failed to generate new EdDSA key: ", err)
+			return nil, err
+		}
+		return &model.GenerateJWTKeysResponse{
+			PrivateKey: &privateKey,
+			PublicKey:  &publicKey,
+		}, nil
+	}
+
 	log.Debug("Invalid algorithm: ", params.Type)
 	return nil, fmt.Errorf("invalid algorithm")
 }
@@ -0,0 +1,56 @@
+package resolvers
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// GrantsResolver is a resolver for grants query.
+// It returns the oauth clients granted access by the logged in user
+func GrantsResolver(ctx context.Context) ([]*model.Grant, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	accessToken, err := token.GetAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed to get access token: ", err)
+		return nil, err
+	}
+
+	claims, err := token.ValidateAccessToken(gc, accessToken)
+	if err != nil {
+		log.Debug("Failed to validate access token: ", err)
+		return nil, err
+	}
+
+	userID := claims["sub"].(string)
+	log := log.WithFields(log.Fields{
+		"user_id": userID,
+	})
+
+	grants, err := db.Provider.ListGrantsByUserID(ctx, userID)
+	if err != nil {
+		log.Debug("Failed to list grants: ", err)
+		return nil, err
+	}
+
+	res := []*model.Grant{}
+	for _, grant := range grants {
+		clientName := ""
+		if client, err := db.Provider.GetClientByID(ctx, grant.ClientID); err == nil {
+			clientName = client.Name
+		}
+		res = append(res, grant.AsAPIGrant(clientName))
+	}
+
+	return res, nil
+}
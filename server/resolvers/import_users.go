@@ -0,0 +1,154 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/validators"
+)
+
+// ImportUsersResolver is a resolver for importing users from csv or json by admin.
+// Users are imported one by one, the records that can't be imported are returned with the error
+func ImportUsersResolver(ctx context.Context, params model.ImportUsersInput) (*model.ImportUsersResponse, error) {
+	var res *model.ImportUsersResponse
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return res, fmt.Errorf("unauthorized")
+	}
+
+	res = &model.ImportUsersResponse{
+		Errors: []*model.ImportUserError{},
+	}
+	addError := func(row int64, email string, err error) {
+		importError := &model.ImportUserError{
+			Row:     row,
+			Message: err.Error(),
+		}
+		if email != "" {
+			importError.Email = refs.NewStringRef(email)
+		}
+		res.Errors = append(res.Errors, importError)
+	}
+	importRecord := func(row int64, record models.UserRecord) {
+		if err := importUser(ctx, record); err != nil {
+			log.Debug("Failed to import user: ", err)
+			addError(row, record.Email, err)
+			return
+		}
+		res.Imported++
+	}
+
+	switch params.Format {
+	case constants.UserRecordsFormatJSON:
+		var records []models.UserRecord
+		if err := json.Unmarshal([]byte(params.Data), &records); err != nil {
+			log.Debug("Failed to parse users json: ", err)
+			return nil, fmt.Errorf("invalid json, array of users is expected")
+		}
+		for i, record := range records {
+			importRecord(int64(i+1), record)
+		}
+	case constants.UserRecordsFormatCSV:
+		reader := csv.NewReader(strings.NewReader(params.Data))
+		// number of columns is validated for each row
+		reader.FieldsPerRecord = -1
+		header, err := reader.Read()
+		if err != nil {
+			log.Debug("Failed to read users csv header: ", err)
+			return nil, fmt.Errorf("invalid csv, header is required")
+		}
+		if err := models.ValidateUserRecordsCSVHeader(header); err != nil {
+			log.Debug("Invalid users csv header: ", err)
+			return nil, err
+		}
+		for row := int64(1); ; row++ {
+			columns, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				addError(row, "", err)
+				continue
+			}
+			record, err := models.NewUserRecordFromCSV(header, columns)
+			if err != nil {
+				addError(row, record.Email, err)
+				continue
+			}
+			importRecord(row, record)
+		}
+	default:
+		log.Debug("Invalid users import format: ", params.Format)
+		return nil, fmt.Errorf("invalid format, supported formats are %s & %s", constants.UserRecordsFormatCSV, constants.UserRecordsFormatJSON)
+	}
+
+	res.Message = fmt.Sprintf("%d users imported, %d failed", res.Imported, len(res.Errors))
+	return res, nil
+}
+
+// importUser validates the user record & adds the user,
+// id & password hash of record are preserved
+func importUser(ctx context.Context, record models.UserRecord) error {
+	record.Email = strings.ToLower(strings.TrimSpace(record.Email))
+	if !validators.IsValidEmail(record.Email) {
+		return errors.New("invalid email address")
+	}
+	if record.ID != "" {
+		if _, err := uuid.Parse(record.ID); err != nil {
+			return errors.New("invalid id, uuid is expected")
+		}
+		if _, err := db.Provider.GetUserByID(ctx, record.ID); err == nil {
+			return errors.New("user with this id already exists")
+		}
+	}
+	if _, err := db.Provider.GetUserByEmail(ctx, record.Email); err == nil {
+		return errors.New("user with this email already exists")
+	}
+	if record.PasswordHash != nil && !crypto.IsSupportedPasswordHash(*record.PasswordHash) {
+		return errors.New("unsupported password hash")
+	}
+	if record.PhoneNumber != nil && !validators.IsValidPhoneNumber(*record.PhoneNumber) {
+		return errors.New("invalid phone number")
+	}
+
+	// default roles are assigned while adding user if roles are not set
+	if record.Roles != "" {
+		rolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyRoles)
+		if err != nil {
+			return err
+		}
+		if !validators.IsValidRoles(strings.Split(record.Roles, ","), strings.Split(rolesString, ",")) {
+			return errors.New("invalid roles")
+		}
+	}
+	if record.SignupMethods == "" {
+		record.SignupMethods = constants.AuthRecipeMethodBasicAuth
+	}
+
+	_, err := db.Provider.AddUser(ctx, record.AsUser())
+	return err
+}
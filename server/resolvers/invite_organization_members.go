@@ -0,0 +1,130 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	emailservice "github.com/authorizerdev/authorizer/server/email"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/validators"
+)
+
+// InviteOrganizationMembersResolver resolver to invite users to the organization.
+// Invited users become the members of organization when they login with organization_id,
+// invitation email is sent if email sending is enabled
+func InviteOrganizationMembersResolver(ctx context.Context, params model.InviteOrganizationMembersRequest) (*model.Response, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	log := log.WithField("organization_id", params.OrganizationID)
+
+	organization, err := db.Provider.GetOrganizationByID(ctx, params.OrganizationID)
+	if err != nil {
+		log.Debug("Failed to get organization: ", err)
+		return nil, err
+	}
+
+	roles := joinRoles(params.Roles)
+	if roles == "" {
+		roles = strings.Join(organization.GetDefaultRoles(), ",")
+	}
+	if err := validateOrganizationMember(organization, models.OrganizationMember{Roles: roles}); err != nil {
+		log.Debug("Invalid organization invitation roles: ", err)
+		return nil, err
+	}
+
+	// filter valid emails
+	emails := []string{}
+	for _, email := range params.Emails {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if validators.IsValidEmail(email) && !utils.StringSliceContains(emails, email) {
+			emails = append(emails, email)
+		}
+	}
+	if len(emails) == 0 {
+		log.Debug("No valid email addresses")
+		return nil, fmt.Errorf("no valid emails found")
+	}
+
+	isEmailVerificationDisabled, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyDisableEmailVerification)
+	if err != nil {
+		log.Debug("Error getting email verification disabled: ", err)
+		isEmailVerificationDisabled = true
+	}
+
+	redirectURL := parsers.GetAppURL(gc)
+	if params.RedirectURI != nil && *params.RedirectURI != "" {
+		redirectURL = *params.RedirectURI
+	}
+	loginURL := redirectURL
+	if strings.Contains(loginURL, "?") {
+		loginURL += "&"
+	} else {
+		loginURL += "?"
+	}
+	loginURL += "organization_id=" + url.QueryEscape(organization.GetOrganizationID())
+
+	invited := 0
+	for _, email := range emails {
+		if user, err := db.Provider.GetUserByEmail(ctx, email); err == nil {
+			if _, err := db.Provider.GetOrganizationMember(ctx, params.OrganizationID, user.ID); err == nil {
+				log.Debugf("User with %s email is already a member, so not inviting user", email)
+				continue
+			}
+		}
+
+		expiresAt := time.Now().Add(constants.OrganizationInvitationExpiry).Unix()
+		// invitation is renewed if user is already invited
+		invitation, err := db.Provider.GetOrganizationInvitation(ctx, params.OrganizationID, email)
+		if err == nil {
+			invitation.Roles = roles
+			invitation.ExpiresAt = expiresAt
+			_, err = db.Provider.UpdateOrganizationInvitation(ctx, invitation)
+		} else {
+			_, err = db.Provider.AddOrganizationInvitation(ctx, models.OrganizationInvitation{
+				OrganizationID: params.OrganizationID,
+				Email:          email,
+				Roles:          roles,
+				ExpiresAt:      expiresAt,
+			})
+		}
+		if err != nil {
+			log.Debugf("Failed to add organization invitation for %s: %v", email, err)
+			return nil, err
+		}
+		invited++
+
+		if !isEmailVerificationDisabled {
+			go emailservice.SendOrganizationInvitationMail(email, organization.Name, loginURL)
+		}
+	}
+
+	if invited == 0 {
+		log.Debug("No new members to invite")
+		return nil, fmt.Errorf("all users are already members of organization")
+	}
+
+	return &model.Response{
+		Message: fmt.Sprintf("%d user(s) invited to organization", invited),
+	}, nil
+}
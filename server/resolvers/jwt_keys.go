@@ -0,0 +1,43 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// JWTKeysResolver resolver for getting the keys of signing keyset,
+// private keys & secrets are never returned
+func JWTKeysResolver(ctx context.Context) (*model.JWTKeys, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	keys, err := token.GetJWTKeys()
+	if err != nil {
+		log.Debug("Failed to get jwt keys: ", err)
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	res := &model.JWTKeys{
+		Keys: []*model.JWTKey{},
+	}
+	for _, key := range keys {
+		res.Keys = append(res.Keys, key.AsAPIJWTKey(now))
+	}
+	return res, nil
+}
@@ -6,15 +6,18 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
-	"golang.org/x/crypto/bcrypt"
 
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/cookie"
+	"github.com/authorizerdev/authorizer/server/crypto"
 	"github.com/authorizerdev/authorizer/server/db"
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/authorizerdev/authorizer/server/token"
 	"github.com/authorizerdev/authorizer/server/utils"
 	"github.com/authorizerdev/authorizer/server/validators"
@@ -66,13 +69,26 @@ func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthRes
 		return res, fmt.Errorf(`email not verified`)
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(*user.Password), []byte(params.Password))
+	shouldRehashPassword, err := crypto.ComparePassword(*user.Password, params.Password)
 
 	if err != nil {
 		log.Debug("Failed to compare password: ", err)
 		return res, fmt.Errorf(`invalid password`)
 	}
 
+	// password hashes of imported users are replaced with bcrypt hash
+	if shouldRehashPassword {
+		password, err := crypto.EncryptPassword(params.Password)
+		if err != nil {
+			log.Debug("Failed to encrypt password: ", err)
+		} else {
+			user.Password = &password
+			if _, err := db.Provider.UpdateUser(ctx, user); err != nil {
+				log.Debug("Failed to update password hash: ", err)
+			}
+		}
+	}
+
 	defaultRolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyDefaultRoles)
 	roles := []string{}
 	if err != nil {
@@ -83,6 +99,19 @@ func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthRes
 	}
 
 	currentRoles := strings.Split(user.Roles, ",")
+	// roles of membership are used when user logs in to organization
+	organizationID := strings.TrimSpace(refs.StringValue(params.OrganizationID))
+	mfaRoles := currentRoles
+	if organizationID != "" {
+		member, err := getOrganizationMember(ctx, organizationID, user)
+		if err != nil {
+			log.Debug("Failed to get organization member: ", err)
+			return res, err
+		}
+		roles = member.GetRoles()
+		currentRoles = member.GetRoles()
+		mfaRoles = append(strings.Split(user.Roles, ","), currentRoles...)
+	}
 	if len(params.Roles) > 0 {
 		if !validators.IsValidRoles(params.Roles, currentRoles) {
 			log.Debug("Invalid roles: ", params.Roles)
@@ -97,7 +126,13 @@ func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthRes
 		scope = params.Scope
 	}
 
-	authToken, err := token.CreateAuthToken(gc, user, roles, scope, constants.AuthRecipeMethodBasicAuth)
+	if isMFARequired(user, mfaRoles) {
+		return createMfaSession(ctx, gc, &user, roles, scope, constants.AuthRecipeMethodBasicAuth, organizationID)
+	}
+
+	authInfo := token.NewAuthenticationInfo(constants.AuthRecipeMethodBasicAuth, false)
+	authInfo.OrganizationID = organizationID
+	authToken, err := token.CreateAuthToken(gc, user, roles, scope, constants.AuthRecipeMethodBasicAuth, token.AuthTokenOptions{AuthInfo: &authInfo})
 	if err != nil {
 		log.Debug("Failed to create auth token", err)
 		return res, err
@@ -129,6 +164,7 @@ func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthRes
 	go func() {
 		utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, constants.AuthRecipeMethodBasicAuth, user)
 		db.Provider.AddSession(ctx, models.Session{
+			ID:        authToken.SessionID,
 			UserID:    user.ID,
 			UserAgent: utils.GetUserAgent(gc.Request),
 			IP:        utils.GetIP(gc.Request),
@@ -137,3 +173,106 @@ func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthRes
 
 	return res, nil
 }
+
+// isMFARequired returns true if user has enabled mfa or any of the roles requires it
+func isMFARequired(user models.User, roles []string) bool {
+	if user.TOTPVerifiedAt != nil {
+		return true
+	}
+	mfaRequiredRolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyMFARequiredRoles)
+	if err != nil {
+		log.Debug("Error getting mfa required roles: ", err)
+		mfaRequiredRolesString = ""
+	}
+	mfaRequiredRoles := strings.Split(mfaRequiredRolesString, ",")
+	for _, role := range roles {
+		if role != "" && utils.StringSliceContains(mfaRequiredRoles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// createMfaSession creates the mfa session for the login that is completed by verify otp mutation,
+// if user has not enrolled the authenticator app yet, otp is sent to the email of user that should be verified
+// before the totp enrollment is returned, so that the totp secret is not handed out with the first factor alone
+func createMfaSession(ctx context.Context, gc *gin.Context, user *models.User, roles, scope []string, loginMethod, organizationID string) (*model.AuthResponse, error) {
+	res := &model.AuthResponse{
+		Message:              `Please enter the otp from your authenticator app`,
+		ShouldShowTotpScreen: refs.NewBoolRef(true),
+	}
+
+	if user.TOTPVerifiedAt == nil {
+		isEmailVerificationDisabled, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyDisableEmailVerification)
+		if err != nil {
+			log.Debug("Error getting email verification disabled: ", err)
+			isEmailVerificationDisabled = true
+		}
+		if isEmailVerificationDisabled || user.EmailVerifiedAt == nil {
+			log.Debug("Email otp can not be sent to set up multi factor authentication")
+			return nil, fmt.Errorf(`verified email is required to set up multi factor authentication`)
+		}
+		err = sendEmailOtp(ctx, user.Email, constants.VerificationTypeMfaEnrollment)
+		if err != nil {
+			log.Debug("Failed to send email otp: ", err)
+			return nil, err
+		}
+		res.Message = `Please enter the otp sent to your email to set up the authenticator app`
+		res.ShouldShowTotpScreen = refs.NewBoolRef(false)
+		res.ShouldShowEmailOtpScreen = refs.NewBoolRef(true)
+	}
+
+	mfaSession := uuid.New().String()
+	expiresAt := time.Now().Add(constants.MfaSessionExpiry).Unix()
+	err := memorystore.Provider.SetStateWithExpiry(constants.MfaSessionStatePrefix+mfaSession, fmt.Sprintf("%s___%s___%s___%d___%s___%s", user.ID, strings.Join(roles, ","), strings.Join(scope, ","), expiresAt, organizationID, loginMethod), constants.MfaSessionExpiry)
+	if err != nil {
+		log.Debug("Failed to set mfa session: ", err)
+		return nil, err
+	}
+	cookie.SetMfaSession(gc, mfaSession)
+
+	return res, nil
+}
+
+// getOrganizationMember returns the membership of user in the organization,
+// pending invitation of user email is accepted if user is not a member yet
+func getOrganizationMember(ctx context.Context, organizationID string, user models.User) (models.OrganizationMember, error) {
+	log := log.WithFields(log.Fields{
+		"organization_id": organizationID,
+		"user_id":         user.ID,
+	})
+	member, err := db.Provider.GetOrganizationMember(ctx, organizationID, user.ID)
+	if err == nil {
+		return member, nil
+	}
+
+	invitation, err := db.Provider.GetOrganizationInvitation(ctx, organizationID, user.Email)
+	if err != nil || invitation.ExpiresAt < time.Now().Unix() {
+		log.Debug("User is not a member of organization: ", err)
+		return member, fmt.Errorf(`user is not a member of organization`)
+	}
+	organization, err := db.Provider.GetOrganizationByID(ctx, organizationID)
+	if err != nil {
+		log.Debug("Failed to get organization: ", err)
+		return member, fmt.Errorf(`user is not a member of organization`)
+	}
+
+	roles := invitation.GetRoles()
+	if len(roles) == 0 {
+		roles = organization.GetDefaultRoles()
+	}
+	member, err = db.Provider.AddOrganizationMember(ctx, models.OrganizationMember{
+		OrganizationID: organizationID,
+		UserID:         user.ID,
+		Roles:          strings.Join(roles, ","),
+	})
+	if err != nil {
+		log.Debug("Failed to add organization member: ", err)
+		return member, err
+	}
+	if err := db.Provider.DeleteOrganizationInvitation(ctx, invitation); err != nil {
+		log.Debug("Failed to delete organization invitation: ", err)
+	}
+
+	return member, nil
+}
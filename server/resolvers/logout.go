@@ -9,7 +9,8 @@ import (
 	"github.com/authorizerdev/authorizer/server/cookie"
 	"github.com/authorizerdev/authorizer/server/crypto"
 	"github.com/authorizerdev/authorizer/server/graph/model"
-	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/logout"
+	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/token"
 	"github.com/authorizerdev/authorizer/server/utils"
 )
@@ -46,7 +47,8 @@ func LogoutResolver(ctx context.Context) (*model.Response, error) {
 		sessionKey = sessionData.LoginMethod + ":" + sessionData.Subject
 	}
 
-	memorystore.Provider.DeleteUserSession(sessionKey, sessionData.Nonce)
+	// all the sessions of user for the login method are ended & clients are notified
+	logout.EndSession(ctx, sessionKey, sessionData.Subject, parsers.GetHost(gc))
 	cookie.DeleteSession(gc)
 
 	res := &model.Response{
@@ -8,6 +8,8 @@ import (
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/oauth"
+	"github.com/authorizerdev/authorizer/server/saml"
 )
 
 // MetaResolver is a resolver for meta query
@@ -107,6 +109,14 @@ func MetaResolver(ctx context.Context) (*model.Meta, error) {
 		isSignUpDisabled = true
 	}
 
+	oidcProviders := []*model.OIDCProvider{}
+	for _, p := range oauth.GenericOIDCProviders {
+		oidcProviders = append(oidcProviders, &model.OIDCProvider{
+			Name:        p.Config.Name,
+			DisplayName: p.Config.DisplayName,
+		})
+	}
+
 	metaInfo := model.Meta{
 		Version:                      constants.VERSION,
 		ClientID:                     clientID,
@@ -120,6 +130,8 @@ func MetaResolver(ctx context.Context) (*model.Meta, error) {
 		IsMagicLinkLoginEnabled:      !isMagicLinkLoginDisabled,
 		IsSignUpEnabled:              !isSignUpDisabled,
 		IsStrongPasswordEnabled:      !isStrongPasswordDisabled,
+		OidcProviders:                oidcProviders,
+		IsSamlLoginEnabled:           saml.IsSamlLoginEnabled(),
 	}
 	return &metaInfo, nil
 }
@@ -0,0 +1,41 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// OrganizationInvitationsResolver resolver for getting the pending invitations of organization
+func OrganizationInvitationsResolver(ctx context.Context, params model.OrganizationRequest) ([]*model.OrganizationInvitation, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	log := log.WithField("organization_id", params.ID)
+
+	invitations, err := db.Provider.ListOrganizationInvitations(ctx, params.ID)
+	if err != nil {
+		log.Debug("Failed to get organization invitations: ", err)
+		return nil, err
+	}
+
+	res := []*model.OrganizationInvitation{}
+	for _, invitation := range invitations {
+		res = append(res, invitation.AsAPIOrganizationInvitation())
+	}
+	return res, nil
+}
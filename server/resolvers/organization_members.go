@@ -0,0 +1,54 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// OrganizationMembersResolver resolver for getting the list of organization members based on pagination
+func OrganizationMembersResolver(ctx context.Context, params model.ListOrganizationMembersRequest) (*model.OrganizationMembers, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	log := log.WithField("organization_id", params.OrganizationID)
+
+	organization, err := db.Provider.GetOrganizationByID(ctx, params.OrganizationID)
+	if err != nil {
+		log.Debug("Failed to get organization: ", err)
+		return nil, err
+	}
+
+	pagination := utils.GetPagination(&model.PaginatedInput{
+		Pagination: params.Pagination,
+	})
+
+	members, err := db.Provider.ListOrganizationMembers(ctx, pagination, params.OrganizationID)
+	if err != nil {
+		log.Debug("Failed to get organization members: ", err)
+		return nil, err
+	}
+
+	apiOrganization := organization.AsAPIOrganization()
+	for _, member := range members.Members {
+		member.Organization = apiOrganization
+		if user, err := db.Provider.GetUserByID(ctx, member.UserID); err == nil {
+			member.User = user.AsAPIUser()
+		}
+	}
+	return members, nil
+}
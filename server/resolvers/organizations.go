@@ -0,0 +1,36 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// OrganizationsResolver resolver for getting the list of organizations based on pagination
+func OrganizationsResolver(ctx context.Context, params *model.PaginatedInput) (*model.Organizations, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	pagination := utils.GetPagination(params)
+
+	organizations, err := db.Provider.ListOrganizations(ctx, pagination)
+	if err != nil {
+		log.Debug("Failed to get organizations: ", err)
+		return nil, err
+	}
+	return organizations, nil
+}
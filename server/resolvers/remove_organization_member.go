@@ -0,0 +1,49 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// RemoveOrganizationMemberResolver resolver to remove user from organization,
+// tokens of the organization can not be refreshed by the removed member
+func RemoveOrganizationMemberResolver(ctx context.Context, params model.OrganizationMemberRequest) (*model.Response, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	log := log.WithFields(log.Fields{
+		"organization_id": params.OrganizationID,
+		"user_id":         params.UserID,
+	})
+
+	member, err := db.Provider.GetOrganizationMember(ctx, params.OrganizationID, params.UserID)
+	if err != nil {
+		log.Debug("Failed to get organization member: ", err)
+		return nil, fmt.Errorf("user is not a member of organization")
+	}
+
+	err = db.Provider.DeleteOrganizationMember(ctx, member)
+	if err != nil {
+		log.Debug("Failed to delete organization member: ", err)
+		return nil, err
+	}
+
+	return &model.Response{
+		Message: "Organization member removed successfully",
+	}, nil
+}
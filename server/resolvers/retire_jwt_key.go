@@ -0,0 +1,42 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// RetireJWTKeyResolver mutation to retire the previous signing key.
+// Key is retired once the longest lived token signed with it is expired,
+// after that it is removed from JWKS & tokens signed with it are rejected
+func RetireJWTKeyResolver(ctx context.Context, params model.RetireJWTKeyInput) (*model.JWTKey, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	if params.Kid == "" {
+		log.Debug("Key id is required")
+		return nil, fmt.Errorf("kid is required")
+	}
+
+	key, err := token.RetireJWTKey(ctx, params.Kid)
+	if err != nil {
+		log.Debug("Failed to retire jwt key: ", err)
+		return nil, err
+	}
+
+	return key.AsAPIJWTKey(time.Now().Unix()), nil
+}
@@ -3,13 +3,22 @@ package resolvers
 import (
 	"context"
 
+	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
 )
 
-// RevokeResolver resolver to revoke refresh token
+// RevokeResolver resolver to revoke refresh token,
+// tokens rotated from the refresh token are revoked with its refresh token family
 func RevokeResolver(ctx context.Context, params model.OAuthRevokeInput) (*model.Response, error) {
 	memorystore.Provider.RemoveState(params.RefreshToken)
+	if claims, err := token.ParseJWTToken(params.RefreshToken); err == nil && claims["token_type"] == constants.TokenTypeRefreshToken {
+		familyID, _ := claims["family_id"].(string)
+		if family, err := token.GetRefreshTokenFamily(familyID); err == nil {
+			token.RevokeRefreshTokenFamily(family)
+		}
+	}
 	return &model.Response{
 		Message: "Token revoked",
 	}, nil
@@ -0,0 +1,59 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// RevokeGrantResolver is a resolver for revoke grant mutation.
+// It removes the access granted by the logged in user to the client,
+// so that the consent is requested again on next authorization
+func RevokeGrantResolver(ctx context.Context, params model.RevokeGrantInput) (*model.Response, error) {
+	var res *model.Response
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	accessToken, err := token.GetAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed to get access token: ", err)
+		return res, err
+	}
+
+	claims, err := token.ValidateAccessToken(gc, accessToken)
+	if err != nil {
+		log.Debug("Failed to validate access token: ", err)
+		return res, err
+	}
+
+	userID := claims["sub"].(string)
+	log := log.WithFields(log.Fields{
+		"user_id":   userID,
+		"client_id": params.ClientID,
+	})
+
+	grant, err := db.Provider.GetGrantByUserIDAndClientID(ctx, userID, params.ClientID)
+	if err != nil || grant.ID == "" {
+		log.Debug("Failed to get grant: ", err)
+		return res, fmt.Errorf(`grant not found`)
+	}
+
+	if err := db.Provider.DeleteGrant(ctx, grant); err != nil {
+		log.Debug("Failed to delete grant: ", err)
+		return res, err
+	}
+
+	return &model.Response{
+		Message: `Access revoked successfully`,
+	}, nil
+}
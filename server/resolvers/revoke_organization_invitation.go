@@ -0,0 +1,50 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// RevokeOrganizationInvitationResolver resolver to revoke the pending invitation of organization
+func RevokeOrganizationInvitationResolver(ctx context.Context, params model.OrganizationInvitationRequest) (*model.Response, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	email := strings.ToLower(strings.TrimSpace(params.Email))
+	log := log.WithFields(log.Fields{
+		"organization_id": params.OrganizationID,
+		"email":           email,
+	})
+
+	invitation, err := db.Provider.GetOrganizationInvitation(ctx, params.OrganizationID, email)
+	if err != nil {
+		log.Debug("Failed to get organization invitation: ", err)
+		return nil, fmt.Errorf("invitation not found")
+	}
+
+	err = db.Provider.DeleteOrganizationInvitation(ctx, invitation)
+	if err != nil {
+		log.Debug("Failed to delete organization invitation: ", err)
+		return nil, err
+	}
+
+	return &model.Response{
+		Message: "Organization invitation revoked successfully",
+	}, nil
+}
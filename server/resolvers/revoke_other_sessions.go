@@ -0,0 +1,74 @@
+package resolvers
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// RevokeOtherSessionsResolver is a resolver for revoke other sessions mutation.
+// It signs out the logged in user from all the sessions except the current one
+func RevokeOtherSessionsResolver(ctx context.Context) (*model.Response, error) {
+	var res *model.Response
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	accessToken, err := token.GetAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed to get access token: ", err)
+		return res, err
+	}
+
+	claims, err := token.ValidateAccessToken(gc, accessToken)
+	if err != nil {
+		log.Debug("Failed to validate access token: ", err)
+		return res, err
+	}
+
+	userID := claims["sub"].(string)
+	nonce, _ := claims["nonce"].(string)
+	log := log.WithFields(log.Fields{
+		"user_id": userID,
+	})
+
+	sessions, err := getUserSessions(userID)
+	if err != nil {
+		log.Debug("Failed to get user sessions: ", err)
+		return res, err
+	}
+	currentSessionID := ""
+	for _, session := range sessions {
+		if session.data.Nonce == nonce {
+			currentSessionID = session.data.SessionID
+		}
+	}
+
+	if err := memorystore.Provider.DeleteOtherUserSessions(userID, nonce); err != nil {
+		log.Debug("Failed to delete other user sessions: ", err)
+		return res, err
+	}
+
+	for _, session := range sessions {
+		if session.data.SessionID == "" || session.data.SessionID == currentSessionID {
+			continue
+		}
+		if err := db.Provider.DeleteSession(ctx, models.Session{ID: session.data.SessionID}); err != nil {
+			log.Debug("Failed to delete session: ", err)
+		}
+	}
+
+	return &model.Response{
+		Message: `Other sessions revoked successfully`,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package resolvers
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// RevokeSessionResolver is a resolver for revoke session mutation.
+// It signs out the session of logged in user on other device
+func RevokeSessionResolver(ctx context.Context, params model.RevokeSessionInput) (*model.Response, error) {
+	var res *model.Response
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	accessToken, err := token.GetAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed to get access token: ", err)
+		return res, err
+	}
+
+	claims, err := token.ValidateAccessToken(gc, accessToken)
+	if err != nil {
+		log.Debug("Failed to validate access token: ", err)
+		return res, err
+	}
+
+	userID := claims["sub"].(string)
+	if err := revokeUserSession(ctx, userID, params.Nonce); err != nil {
+		return res, err
+	}
+
+	return &model.Response{
+		Message: `Session revoked successfully`,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// RevokeUserSessionResolver is a resolver for revoking the session of user by admin
+func RevokeUserSessionResolver(ctx context.Context, params model.RevokeUserSessionInput) (*model.Response, error) {
+	var res *model.Response
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return res, fmt.Errorf("unauthorized")
+	}
+
+	if err := revokeUserSession(ctx, params.UserID, params.Nonce); err != nil {
+		return res, err
+	}
+
+	return &model.Response{
+		Message: `Session revoked successfully`,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// RotateJWTKeyResolver mutation to schedule the rotation of signing key.
+// New key is published in JWKS right away & signs the tokens from activates_at,
+// current key keeps verifying the tokens until it is retired
+func RotateJWTKeyResolver(ctx context.Context, params model.RotateJWTKeyInput) (*model.JWTKey, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	jwtType := ""
+	if params.Type != nil {
+		jwtType = *params.Type
+	} else {
+		jwtType, err = memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtType)
+		if err != nil {
+			log.Debug("Failed to get jwt type: ", err)
+			return nil, err
+		}
+	}
+	if !crypto.IsHMACA(jwtType) && !crypto.IsRSA(jwtType) && !crypto.IsECDSA(jwtType) && !crypto.IsEdDSA(jwtType) {
+		log.Debug("Invalid JWT type: ", jwtType)
+		return nil, fmt.Errorf("invalid jwt type")
+	}
+
+	activatesAt := time.Now().Unix()
+	if params.ActivatesAt != nil {
+		activatesAt = *params.ActivatesAt
+	}
+
+	key, err := token.RotateJWTKey(ctx, jwtType, activatesAt)
+	if err != nil {
+		log.Debug("Failed to rotate jwt key: ", err)
+		return nil, err
+	}
+
+	return key.AsAPIJWTKey(time.Now().Unix()), nil
+}
@@ -0,0 +1,122 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	emailservice "github.com/authorizerdev/authorizer/server/email"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/sms"
+	"github.com/authorizerdev/authorizer/server/validators"
+)
+
+// SendOtpResolver is a resolver for send otp mutation
+// it sends otp via sms that can be used to login with verify_otp mutation
+func SendOtpResolver(ctx context.Context, params model.SendOTPInput) (*model.Response, error) {
+	var res *model.Response
+
+	if !sms.IsSmsServiceEnabled() {
+		log.Debug("SMS service is not enabled")
+		return res, fmt.Errorf(`sms service is not enabled`)
+	}
+
+	phoneNumber := strings.TrimSpace(params.PhoneNumber)
+	if !validators.IsValidPhoneNumber(phoneNumber) {
+		log.Debug("Invalid phone number: ", phoneNumber)
+		return res, fmt.Errorf(`invalid phone number, it should be in E.164 format`)
+	}
+
+	log := log.WithFields(log.Fields{
+		"phone_number": phoneNumber,
+	})
+	user, err := db.Provider.GetUserByPhoneNumber(ctx, phoneNumber)
+	if err != nil {
+		log.Debug("Failed to get user by phone number: ", err)
+		return res, fmt.Errorf(`user with this phone number not found`)
+	}
+
+	if user.RevokedTimestamp != nil {
+		log.Debug("User access is revoked")
+		return res, fmt.Errorf(`user access has been revoked`)
+	}
+
+	// otp should not be sent to a phone number that is not verified by the user
+	if user.PhoneNumberVerifiedAt == nil {
+		log.Debug("User phone number is not verified")
+		return res, fmt.Errorf(`phone number not verified`)
+	}
+
+	err = sendPhoneOtp(ctx, phoneNumber, constants.VerificationTypeMobileOTPLogin)
+	if err != nil {
+		log.Debug("Failed to send otp: ", err)
+		return res, err
+	}
+
+	res = &model.Response{
+		Message: `OTP sent successfully`,
+	}
+
+	return res, nil
+}
+
+// sendPhoneOtp generates otp, saves it as verification request and sends it via sms
+func sendPhoneOtp(ctx context.Context, phoneNumber, verificationType string) error {
+	otp, err := saveOtp(ctx, phoneNumber, verificationType)
+	if err != nil {
+		return err
+	}
+
+	return sms.SendOtpSMS(phoneNumber, otp)
+}
+
+// sendEmailOtp generates otp, saves it as verification request and sends it via email
+func sendEmailOtp(ctx context.Context, email, verificationType string) error {
+	otp, err := saveOtp(ctx, email, verificationType)
+	if err != nil {
+		return err
+	}
+
+	return emailservice.SendOtpMail(email, otp)
+}
+
+// saveOtp generates otp and saves its hash as verification request of the phone number or email
+func saveOtp(ctx context.Context, identifier, verificationType string) (string, error) {
+	otp, err := crypto.GenerateOTP()
+	if err != nil {
+		return "", err
+	}
+
+	// otp is saved as hash as it can be brute forced if leaked unlike signed verification tokens
+	otpHash, err := crypto.EncryptPassword(otp)
+	if err != nil {
+		return "", err
+	}
+
+	// remove the previous otp, if any, so that only the latest otp is valid
+	verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, identifier, verificationType)
+	if err == nil && verificationRequest.ID != "" {
+		db.Provider.DeleteVerificationRequest(ctx, verificationRequest)
+	}
+	memorystore.Provider.RemoveState(constants.OtpAttemptsStatePrefix + verificationType + "_" + identifier)
+
+	_, err = db.Provider.AddVerificationRequest(ctx, models.VerificationRequest{
+		Token:      otpHash,
+		Identifier: verificationType,
+		ExpiresAt:  time.Now().Add(constants.OtpExpiry).Unix(),
+		Email:      identifier,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return otp, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -11,10 +12,12 @@ import (
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/cookie"
 	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/token"
 	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/validators"
 )
 
 // SessionResolver is a resolver for session query
@@ -56,7 +59,16 @@ func SessionResolver(ctx context.Context, params *model.SessionQueryInput) (*mod
 	claimRoles := []string{}
 	claimRoles = append(claimRoles, claimRoleInterface...)
 
-	if params != nil && params.Roles != nil && len(params.Roles) > 0 {
+	authInfo := claims.AuthenticationInfo
+	isOrganizationSwitched := params != nil && params.OrganizationID != nil && strings.TrimSpace(*params.OrganizationID) != authInfo.OrganizationID
+	if isOrganizationSwitched {
+		authInfo.OrganizationID = strings.TrimSpace(*params.OrganizationID)
+		claimRoles, err = getOrganizationRoles(ctx, user, authInfo, params.Roles)
+		if err != nil {
+			log.Debug("Failed to switch organization: ", err)
+			return res, err
+		}
+	} else if params != nil && params.Roles != nil && len(params.Roles) > 0 {
 		for _, v := range params.Roles {
 			if !utils.StringSliceContains(claimRoles, v) {
 				log.Debug("User does not have required role: ", claimRoles, v)
@@ -70,7 +82,8 @@ func SessionResolver(ctx context.Context, params *model.SessionQueryInput) (*mod
 		scope = params.Scope
 	}
 
-	authToken, err := token.CreateAuthToken(gc, user, claimRoles, scope, claims.LoginMethod)
+	// authentication info of the existing session is carried over
+	authToken, err := token.CreateAuthToken(gc, user, claimRoles, scope, claims.LoginMethod, token.AuthTokenOptions{AuthInfo: &authInfo})
 	if err != nil {
 		log.Debug("Failed to create auth token: ", err)
 		return res, err
@@ -106,3 +119,49 @@ func SessionResolver(ctx context.Context, params *model.SessionQueryInput) (*mod
 	}
 	return res, nil
 }
+
+// getOrganizationRoles returns the roles of session switched to the organization of authInfo,
+// roles of user outside organizations are used if organization is empty.
+// Roles default to the roles of membership or DEFAULT_ROLES if roles are not requested
+func getOrganizationRoles(ctx context.Context, user models.User, authInfo token.AuthenticationInfo, requestedRoles []string) ([]string, error) {
+	var roles, allowedRoles []string
+	if authInfo.OrganizationID != "" {
+		member, err := getOrganizationMember(ctx, authInfo.OrganizationID, user)
+		if err != nil {
+			return nil, err
+		}
+		roles = member.GetRoles()
+		allowedRoles = member.GetRoles()
+	} else {
+		defaultRolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyDefaultRoles)
+		if err != nil {
+			log.Debug("Error getting default roles: ", err)
+			defaultRolesString = ""
+		}
+		roles = strings.Split(defaultRolesString, ",")
+		allowedRoles = strings.Split(user.Roles, ",")
+	}
+
+	if len(requestedRoles) > 0 {
+		if !validators.IsValidRoles(requestedRoles, allowedRoles) {
+			return nil, fmt.Errorf(`invalid roles`)
+		}
+		roles = requestedRoles
+	}
+
+	// session without multi factor authentication can not be switched to the roles requiring it
+	if authInfo.ACR() != constants.ACRMultiFactor {
+		mfaRequiredRolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyMFARequiredRoles)
+		if err != nil {
+			log.Debug("Error getting mfa required roles: ", err)
+			mfaRequiredRolesString = ""
+		}
+		for _, role := range strings.Split(mfaRequiredRolesString, ",") {
+			if role != "" && utils.StringSliceContains(allowedRoles, role) {
+				return nil, fmt.Errorf(`multi factor authentication is required, please login again`)
+			}
+		}
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,160 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// SessionsResolver is a resolver for sessions query.
+// It returns the active sessions of the logged in user across the devices
+func SessionsResolver(ctx context.Context) ([]*model.UserSession, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	accessToken, err := token.GetAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed to get access token: ", err)
+		return nil, err
+	}
+
+	claims, err := token.ValidateAccessToken(gc, accessToken)
+	if err != nil {
+		log.Debug("Failed to validate access token: ", err)
+		return nil, err
+	}
+
+	userID := claims["sub"].(string)
+	nonce, _ := claims["nonce"].(string)
+	return listUserSessions(ctx, userID, nonce)
+}
+
+// userSession is the session saved in the session store
+type userSession struct {
+	sessionKey string
+	data       *token.SessionData
+}
+
+// getUserSessions returns the sessions of user from the session store
+func getUserSessions(userID string) ([]userSession, error) {
+	sessions, err := memorystore.Provider.ListUserSessions(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []userSession{}
+	for sessionKey, tokens := range sessions {
+		for key, value := range tokens {
+			if !strings.HasPrefix(key, constants.TokenTypeSessionToken+"_") {
+				continue
+			}
+			sessionData, err := token.ParseSessionToken(value)
+			if err != nil {
+				log.Debug("Failed to parse session token: ", err)
+				continue
+			}
+			if sessionData.IsExpired() {
+				continue
+			}
+			if sessionData.LoginMethod == "" {
+				sessionData.LoginMethod = strings.TrimSuffix(sessionKey, ":"+userID)
+			}
+			res = append(res, userSession{
+				sessionKey: sessionKey,
+				data:       sessionData,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// listUserSessions returns the sessions of user with the device information saved in db,
+// session with currentNonce is marked as the current session
+func listUserSessions(ctx context.Context, userID, currentNonce string) ([]*model.UserSession, error) {
+	log := log.WithFields(log.Fields{
+		"user_id": userID,
+	})
+	sessions, err := getUserSessions(userID)
+	if err != nil {
+		log.Debug("Failed to get user sessions: ", err)
+		return nil, err
+	}
+
+	dbSessions, err := db.Provider.ListSessionsByUserID(ctx, userID)
+	if err != nil {
+		log.Debug("Failed to list sessions: ", err)
+		return nil, err
+	}
+	dbSessionsByID := map[string]models.Session{}
+	for _, session := range dbSessions {
+		dbSessionsByID[session.ID] = session
+	}
+
+	res := []*model.UserSession{}
+	for _, session := range sessions {
+		userSession := &model.UserSession{
+			Nonce:       session.data.Nonce,
+			LoginMethod: session.data.LoginMethod,
+			CreatedAt:   refs.NewInt64Ref(session.data.AuthTime),
+			LastUsedAt:  refs.NewInt64Ref(session.data.IssuedAt),
+			IsCurrent:   session.data.Nonce == currentNonce,
+		}
+		if dbSession, ok := dbSessionsByID[session.data.SessionID]; ok {
+			userSession.UserAgent = refs.NewStringRef(dbSession.UserAgent)
+			userSession.IP = refs.NewStringRef(dbSession.IP)
+		}
+		res = append(res, userSession)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return refs.Int64Value(res[i].LastUsedAt) > refs.Int64Value(res[j].LastUsedAt)
+	})
+
+	return res, nil
+}
+
+// revokeUserSession deletes the tokens of user session with nonce & its device information
+func revokeUserSession(ctx context.Context, userID, nonce string) error {
+	log := log.WithFields(log.Fields{
+		"user_id": userID,
+	})
+	sessions, err := getUserSessions(userID)
+	if err != nil {
+		log.Debug("Failed to get user sessions: ", err)
+		return err
+	}
+
+	for _, session := range sessions {
+		if session.data.Nonce != nonce {
+			continue
+		}
+		if err := memorystore.Provider.DeleteUserSession(session.sessionKey, nonce); err != nil {
+			log.Debug("Failed to delete user session: ", err)
+			return err
+		}
+		if session.data.SessionID != "" {
+			if err := db.Provider.DeleteSession(ctx, models.Session{ID: session.data.SessionID}); err != nil {
+				log.Debug("Failed to delete session: ", err)
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf(`session not found`)
+}
@@ -222,7 +222,7 @@ func SignupResolver(ctx context.Context, params model.SignUpInput) (*model.AuthR
 			scope = params.Scope
 		}
 
-		authToken, err := token.CreateAuthToken(gc, user, roles, scope, constants.AuthRecipeMethodBasicAuth)
+		authToken, err := token.CreateAuthToken(gc, user, roles, scope, constants.AuthRecipeMethodBasicAuth, token.AuthTokenOptions{})
 		if err != nil {
 			log.Debug("Failed to create auth token: ", err)
 			return res, err
@@ -253,6 +253,7 @@ func SignupResolver(ctx context.Context, params model.SignUpInput) (*model.AuthR
 		go func() {
 			utils.RegisterEvent(ctx, constants.UserSignUpWebhookEvent, constants.AuthRecipeMethodBasicAuth, user)
 			db.Provider.AddSession(ctx, models.Session{
+				ID:        authToken.SessionID,
 				UserID:    user.ID,
 				UserAgent: utils.GetUserAgent(gc.Request),
 				IP:        utils.GetIP(gc.Request),
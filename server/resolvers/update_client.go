@@ -0,0 +1,110 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// UpdateClientResolver resolver for update client mutation
+// new client secret is returned in the response if regenerate_client_secret is set
+func UpdateClientResolver(ctx context.Context, params model.UpdateClientRequest) (*model.ClientResponse, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	log := log.WithField("client_id", params.ID)
+	client, err := db.Provider.GetClientByID(ctx, params.ID)
+	if err != nil {
+		log.Debug("Failed to get client: ", err)
+		return nil, err
+	}
+
+	if params.Name != nil {
+		if strings.TrimSpace(refs.StringValue(params.Name)) == "" {
+			log.Debug("empty client name not allowed")
+			return nil, fmt.Errorf("empty client name not allowed")
+		}
+		client.Name = strings.TrimSpace(refs.StringValue(params.Name))
+	}
+	if params.RedirectUris != nil {
+		client.RedirectURIs = strings.Join(params.RedirectUris, ",")
+	}
+	if params.AllowedScopes != nil {
+		client.AllowedScopes = strings.Join(params.AllowedScopes, ",")
+	}
+	if params.GrantTypes != nil {
+		client.GrantTypes = strings.Join(params.GrantTypes, ",")
+	}
+	if params.PostLogoutRedirectUris != nil {
+		client.PostLogoutRedirectURIs = strings.Join(params.PostLogoutRedirectUris, ",")
+	}
+	if params.BackchannelLogoutURI != nil {
+		client.BackchannelLogoutURI = strings.TrimSpace(refs.StringValue(params.BackchannelLogoutURI))
+	}
+	if params.FrontchannelLogoutURI != nil {
+		client.FrontchannelLogoutURI = strings.TrimSpace(refs.StringValue(params.FrontchannelLogoutURI))
+	}
+	if params.IDTokenEncryptedResponseAlg != nil {
+		client.IDTokenEncryptedResponseAlg = refs.StringValue(params.IDTokenEncryptedResponseAlg)
+	}
+	if params.IDTokenEncryptedResponseEnc != nil {
+		client.IDTokenEncryptedResponseEnc = refs.StringValue(params.IDTokenEncryptedResponseEnc)
+	}
+	if params.IDTokenEncryptionKey != nil {
+		client.IDTokenEncryptionKey = strings.TrimSpace(refs.StringValue(params.IDTokenEncryptionKey))
+	}
+	if client.IsIDTokenEncrypted() && client.IDTokenEncryptedResponseEnc == "" {
+		client.IDTokenEncryptedResponseEnc = constants.IDTokenEncryptionEncA256GCM
+	}
+	if params.AccessTokenExpiryTime != nil {
+		client.AccessTokenExpiryTime = refs.StringValue(params.AccessTokenExpiryTime)
+	}
+	if params.RefreshTokenExpiryTime != nil {
+		client.RefreshTokenExpiryTime = refs.StringValue(params.RefreshTokenExpiryTime)
+	}
+	if err := validateClient(client); err != nil {
+		log.Debug("Invalid client: ", err)
+		return nil, err
+	}
+
+	res := &model.ClientResponse{
+		Message: `Client updated successfully`,
+	}
+	if refs.BoolValue(params.RegenerateClientSecret) {
+		clientSecret := uuid.New().String()
+		client.ClientSecret, err = crypto.EncryptPassword(clientSecret)
+		if err != nil {
+			log.Debug("Failed to hash client secret: ", err)
+			return nil, err
+		}
+		res.ClientSecret = refs.NewStringRef(clientSecret)
+	}
+
+	client, err = db.Provider.UpdateClient(ctx, client)
+	if err != nil {
+		log.Debug("Failed to update client: ", err)
+		return nil, err
+	}
+	res.Client = client.AsAPIClient()
+
+	return res, nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -17,6 +18,7 @@ import (
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/oauth"
+	"github.com/authorizerdev/authorizer/server/saml"
 	"github.com/authorizerdev/authorizer/server/token"
 	"github.com/authorizerdev/authorizer/server/utils"
 )
@@ -30,6 +32,7 @@ func clearSessionIfRequired(currentData, updatedData map[string]interface{}) {
 	isCurrentFacebookLoginEnabled := currentData[constants.EnvKeyFacebookClientID] != nil && currentData[constants.EnvKeyFacebookClientSecret] != nil && currentData[constants.EnvKeyFacebookClientID].(string) != "" && currentData[constants.EnvKeyFacebookClientSecret].(string) != ""
 	isCurrentGoogleLoginEnabled := currentData[constants.EnvKeyGoogleClientID] != nil && currentData[constants.EnvKeyGoogleClientSecret] != nil && currentData[constants.EnvKeyGoogleClientID].(string) != "" && currentData[constants.EnvKeyGoogleClientSecret].(string) != ""
 	isCurrentGithubLoginEnabled := currentData[constants.EnvKeyGithubClientID] != nil && currentData[constants.EnvKeyGithubClientSecret] != nil && currentData[constants.EnvKeyGithubClientID].(string) != "" && currentData[constants.EnvKeyGithubClientSecret].(string) != ""
+	isCurrentSamlLoginEnabled := currentData[constants.EnvKeySamlIdpSsoURL] != nil && currentData[constants.EnvKeySamlIdpCertificate] != nil && currentData[constants.EnvKeySamlIdpSsoURL].(string) != "" && currentData[constants.EnvKeySamlIdpCertificate].(string) != ""
 	isCurrentLinkedInLoginEnabled := currentData[constants.EnvKeyLinkedInClientID] != nil && currentData[constants.EnvKeyLinkedInClientSecret] != nil && currentData[constants.EnvKeyLinkedInClientID].(string) != "" && currentData[constants.EnvKeyLinkedInClientSecret].(string) != ""
 
 	isUpdatedBasicAuthEnabled := !updatedData[constants.EnvKeyDisableBasicAuthentication].(bool)
@@ -38,6 +41,7 @@ func clearSessionIfRequired(currentData, updatedData map[string]interface{}) {
 	isUpdatedFacebookLoginEnabled := updatedData[constants.EnvKeyFacebookClientID] != nil && updatedData[constants.EnvKeyFacebookClientSecret] != nil && updatedData[constants.EnvKeyFacebookClientID].(string) != "" && updatedData[constants.EnvKeyFacebookClientSecret].(string) != ""
 	isUpdatedGoogleLoginEnabled := updatedData[constants.EnvKeyGoogleClientID] != nil && updatedData[constants.EnvKeyGoogleClientSecret] != nil && updatedData[constants.EnvKeyGoogleClientID].(string) != "" && updatedData[constants.EnvKeyGoogleClientSecret].(string) != ""
 	isUpdatedGithubLoginEnabled := updatedData[constants.EnvKeyGithubClientID] != nil && updatedData[constants.EnvKeyGithubClientSecret] != nil && updatedData[constants.EnvKeyGithubClientID].(string) != "" && updatedData[constants.EnvKeyGithubClientSecret].(string) != ""
+	isUpdatedSamlLoginEnabled := updatedData[constants.EnvKeySamlIdpSsoURL] != nil && updatedData[constants.EnvKeySamlIdpCertificate] != nil && updatedData[constants.EnvKeySamlIdpSsoURL].(string) != "" && updatedData[constants.EnvKeySamlIdpCertificate].(string) != ""
 	isUpdatedLinkedInLoginEnabled := updatedData[constants.EnvKeyLinkedInClientID] != nil && updatedData[constants.EnvKeyLinkedInClientSecret] != nil && updatedData[constants.EnvKeyLinkedInClientID].(string) != "" && updatedData[constants.EnvKeyLinkedInClientSecret].(string) != ""
 
 	if isCurrentBasicAuthEnabled && !isUpdatedBasicAuthEnabled {
@@ -67,6 +71,34 @@ func clearSessionIfRequired(currentData, updatedData map[string]interface{}) {
 	if isCurrentLinkedInLoginEnabled && !isUpdatedLinkedInLoginEnabled {
 		memorystore.Provider.DeleteSessionForNamespace(constants.AuthRecipeMethodLinkedIn)
 	}
+
+	if isCurrentSamlLoginEnabled && !isUpdatedSamlLoginEnabled {
+		memorystore.Provider.DeleteSessionForNamespace(constants.AuthRecipeMethodSAML)
+	}
+
+	// generic oidc providers share the session namespace,
+	// hence sessions are removed if any of the provider is removed
+	currentOIDCProviders, _ := currentData[constants.EnvKeyOIDCProviders].(string)
+	updatedOIDCProviders, _ := updatedData[constants.EnvKeyOIDCProviders].(string)
+	currentOIDCConfigs, err := oauth.ParseOIDCProviderConfigs(currentOIDCProviders)
+	if err == nil {
+		updatedOIDCConfigs, err := oauth.ParseOIDCProviderConfigs(updatedOIDCProviders)
+		if err == nil {
+			for _, currentConfig := range currentOIDCConfigs {
+				isRemoved := true
+				for _, updatedConfig := range updatedOIDCConfigs {
+					if updatedConfig.Name == currentConfig.Name {
+						isRemoved = false
+						break
+					}
+				}
+				if isRemoved {
+					memorystore.Provider.DeleteSessionForNamespace(constants.AuthRecipeMethodOIDC)
+					break
+				}
+			}
+		}
+	}
 }
 
 // UpdateEnvResolver is a resolver for update config mutation
@@ -102,7 +134,7 @@ func UpdateEnvResolver(ctx context.Context, params model.UpdateEnvInput) (*model
 	algo := updatedData[constants.EnvKeyJwtType].(string)
 	if params.JwtType != nil {
 		algo = *params.JwtType
-		if !crypto.IsHMACA(algo) && !crypto.IsECDSA(algo) && !crypto.IsRSA(algo) {
+		if !crypto.IsHMACA(algo) && !crypto.IsECDSA(algo) && !crypto.IsRSA(algo) && !crypto.IsEdDSA(algo) {
 			log.Debug("Invalid JWT type: ", algo)
 			return res, fmt.Errorf("invalid jwt type")
 		}
@@ -174,6 +206,27 @@ func UpdateEnvResolver(ctx context.Context, params model.UpdateEnvInput) (*model
 			}
 		}
 
+		if crypto.IsEdDSA(algo) {
+			if params.JwtPrivateKey == nil || params.JwtPublicKey == nil {
+				log.Debug("JWT private key and public key are required for EdDSA")
+				return res, fmt.Errorf("jwt private and public key is required for EdDSA algorithm")
+			}
+
+			// reset the jwt secret
+			params.JwtSecret = &defaultSecret
+			_, err = crypto.ParseEdDSAPrivateKeyFromPemStr(*params.JwtPrivateKey)
+			if err != nil {
+				log.Debug("Invalid JWT private key: ", err)
+				return res, err
+			}
+
+			_, err := crypto.ParseEdDSAPublicKeyFromPemStr(*params.JwtPublicKey)
+			if err != nil {
+				log.Debug("Invalid JWT public key: ", err)
+				return res, err
+			}
+		}
+
 	}
 
 	var data map[string]interface{}
@@ -213,6 +266,12 @@ func UpdateEnvResolver(ctx context.Context, params model.UpdateEnvInput) (*model
 
 	}
 
+	// scim token is the bearer credential of provisioning endpoints, empty token disables them
+	if params.ScimToken != nil && *params.ScimToken != "" && len(*params.ScimToken) < 32 {
+		log.Debug("SCIM token is too short")
+		return res, fmt.Errorf("scim token must be at least 32 characters")
+	}
+
 	for key, value := range data {
 		if value != nil {
 			fieldType := reflect.TypeOf(value).String()
@@ -243,6 +302,65 @@ func UpdateEnvResolver(ctx context.Context, params model.UpdateEnvInput) (*model
 		}
 	}
 
+	if params.RefreshTokenExpiryTime != nil && strings.TrimSpace(*params.RefreshTokenExpiryTime) != "" {
+		if _, err = utils.ParseDurationInSeconds(*params.RefreshTokenExpiryTime); err != nil {
+			log.Debug("Invalid refresh token expiry time: ", err)
+			return res, fmt.Errorf("invalid refresh token expiry time: %s", err.Error())
+		}
+	}
+
+	if params.RefreshTokenMaxLifetime != nil && strings.TrimSpace(*params.RefreshTokenMaxLifetime) != "" {
+		if _, err = utils.ParseDurationInSeconds(*params.RefreshTokenMaxLifetime); err != nil {
+			log.Debug("Invalid refresh token max lifetime: ", err)
+			return res, fmt.Errorf("invalid refresh token max lifetime: %s", err.Error())
+		}
+	}
+
+	if params.MaxActiveSessions != nil && strings.TrimSpace(*params.MaxActiveSessions) != "" {
+		if maxSessions, err := strconv.Atoi(*params.MaxActiveSessions); err != nil || maxSessions < 0 {
+			log.Debug("Invalid max active sessions: ", *params.MaxActiveSessions)
+			return res, fmt.Errorf("invalid max active sessions: must be a non-negative number")
+		}
+	}
+
+	if params.SessionIDLeTimeout != nil && strings.TrimSpace(*params.SessionIDLeTimeout) != "" {
+		if _, err = utils.ParseDurationInSeconds(*params.SessionIDLeTimeout); err != nil {
+			log.Debug("Invalid session idle timeout: ", err)
+			return res, fmt.Errorf("invalid session idle timeout: %s", err.Error())
+		}
+	}
+
+	if params.SessionMaxLifetime != nil && strings.TrimSpace(*params.SessionMaxLifetime) != "" {
+		if _, err = utils.ParseDurationInSeconds(*params.SessionMaxLifetime); err != nil {
+			log.Debug("Invalid session max lifetime: ", err)
+			return res, fmt.Errorf("invalid session max lifetime: %s", err.Error())
+		}
+	}
+
+	if params.OidcProviders != nil {
+		_, err = oauth.ParseOIDCProviderConfigs(*params.OidcProviders)
+		if err != nil {
+			log.Debug("Invalid oidc providers: ", err)
+			return res, err
+		}
+	}
+
+	if params.SamlIDPCertificate != nil && strings.TrimSpace(*params.SamlIDPCertificate) != "" {
+		_, err = saml.ParseCertificate(*params.SamlIDPCertificate)
+		if err != nil {
+			log.Debug("Invalid saml idp certificate: ", err)
+			return res, fmt.Errorf("invalid saml idp certificate: %s", err.Error())
+		}
+	}
+
+	if params.SamlAttributeMapping != nil {
+		_, err = saml.ParseAttributeMapping(*params.SamlAttributeMapping)
+		if err != nil {
+			log.Debug("Invalid saml attribute mapping: ", err)
+			return res, err
+		}
+	}
+
 	// check the roles change
 	if len(params.Roles) > 0 {
 		if len(params.DefaultRoles) > 0 {
@@ -0,0 +1,66 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// UpdateOrganizationResolver resolver for update organization mutation,
+// roles removed from organization are not removed from the members & are ignored in their tokens
+func UpdateOrganizationResolver(ctx context.Context, params model.UpdateOrganizationRequest) (*model.Organization, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	log := log.WithField("organization_id", params.ID)
+
+	organization, err := db.Provider.GetOrganizationByID(ctx, params.ID)
+	if err != nil {
+		log.Debug("Failed to get organization: ", err)
+		return nil, err
+	}
+
+	if params.Name != nil && strings.TrimSpace(*params.Name) != organization.Name {
+		organization.Name = strings.TrimSpace(*params.Name)
+		if _, err := db.Provider.GetOrganizationByName(ctx, organization.Name); err == nil {
+			log.Debug("Organization already exists with name: ", organization.Name)
+			return nil, fmt.Errorf("organization with %s name already exists", organization.Name)
+		}
+	}
+	if params.DisplayName != nil {
+		organization.DisplayName = strings.TrimSpace(*params.DisplayName)
+	}
+	if params.Roles != nil {
+		organization.Roles = joinRoles(params.Roles)
+	}
+	if params.DefaultRoles != nil {
+		organization.DefaultRoles = joinRoles(params.DefaultRoles)
+	}
+	if err := validateOrganization(organization); err != nil {
+		log.Debug("Invalid organization: ", err)
+		return nil, err
+	}
+
+	organization, err = db.Provider.UpdateOrganization(ctx, organization)
+	if err != nil {
+		log.Debug("Failed to update organization: ", err)
+		return nil, err
+	}
+
+	return organization.AsAPIOrganization(), nil
+}
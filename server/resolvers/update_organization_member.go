@@ -0,0 +1,64 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// UpdateOrganizationMemberResolver resolver for update organization member mutation,
+// updated roles are applied to the tokens of member when they are refreshed
+func UpdateOrganizationMemberResolver(ctx context.Context, params model.UpdateOrganizationMemberRequest) (*model.OrganizationMember, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	log := log.WithFields(log.Fields{
+		"organization_id": params.OrganizationID,
+		"user_id":         params.UserID,
+	})
+
+	organization, err := db.Provider.GetOrganizationByID(ctx, params.OrganizationID)
+	if err != nil {
+		log.Debug("Failed to get organization: ", err)
+		return nil, err
+	}
+
+	member, err := db.Provider.GetOrganizationMember(ctx, params.OrganizationID, params.UserID)
+	if err != nil {
+		log.Debug("Failed to get organization member: ", err)
+		return nil, fmt.Errorf("user is not a member of organization")
+	}
+
+	member.Roles = joinRoles(params.Roles)
+	if err := validateOrganizationMember(organization, member); err != nil {
+		log.Debug("Invalid organization member: ", err)
+		return nil, err
+	}
+
+	member, err = db.Provider.UpdateOrganizationMember(ctx, member)
+	if err != nil {
+		log.Debug("Failed to update organization member: ", err)
+		return nil, err
+	}
+
+	res := member.AsAPIOrganizationMember()
+	res.Organization = organization.AsAPIOrganization()
+	if user, err := db.Provider.GetUserByID(ctx, member.UserID); err == nil {
+		res.User = user.AsAPIUser()
+	}
+	return res, nil
+}
@@ -18,10 +18,10 @@ import (
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/sms"
 	"github.com/authorizerdev/authorizer/server/token"
 	"github.com/authorizerdev/authorizer/server/utils"
 	"github.com/authorizerdev/authorizer/server/validators"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // UpdateProfileResolver is resolver for update profile mutation
@@ -86,8 +86,24 @@ func UpdateProfileResolver(ctx context.Context, params model.UpdateProfileInput)
 		user.Gender = params.Gender
 	}
 
+	hasPhoneNumberChanged := false
 	if params.PhoneNumber != nil && refs.StringValue(user.PhoneNumber) != refs.StringValue(params.PhoneNumber) {
+		if refs.StringValue(params.PhoneNumber) != "" && sms.IsSmsServiceEnabled() {
+			if !validators.IsValidPhoneNumber(refs.StringValue(params.PhoneNumber)) {
+				log.Debug("Invalid phone number: ", refs.StringValue(params.PhoneNumber))
+				return res, fmt.Errorf("invalid phone number, it should be in E.164 format")
+			}
+			// check if user with new phone number exists
+			_, err := db.Provider.GetUserByPhoneNumber(ctx, refs.StringValue(params.PhoneNumber))
+			// err = nil means user exists
+			if err == nil {
+				log.Debug("Failed to get user by phone number: ", refs.StringValue(params.PhoneNumber))
+				return res, fmt.Errorf("user with this phone number already exists")
+			}
+			hasPhoneNumberChanged = true
+		}
 		user.PhoneNumber = params.PhoneNumber
+		user.PhoneNumberVerifiedAt = nil
 	}
 
 	if params.Picture != nil && refs.StringValue(user.Picture) != refs.StringValue(params.Picture) {
@@ -117,7 +133,7 @@ func UpdateProfileResolver(ctx context.Context, params model.UpdateProfileInput)
 	}
 
 	if isPasswordChanging && user.Password != nil && params.OldPassword != nil {
-		if err = bcrypt.CompareHashAndPassword([]byte(refs.StringValue(user.Password)), []byte(refs.StringValue(params.OldPassword))); err != nil {
+		if _, err = crypto.ComparePassword(refs.StringValue(user.Password), refs.StringValue(params.OldPassword)); err != nil {
 			log.Debug("Failed to compare hash and old password: ", err)
 			return res, fmt.Errorf("incorrect old password")
 		}
@@ -234,6 +250,14 @@ func UpdateProfileResolver(ctx context.Context, params model.UpdateProfileInput)
 	if hasEmailChanged {
 		message += `For the email change we have sent new verification email, please verify and continue`
 	}
+	if hasPhoneNumberChanged {
+		err = sendPhoneOtp(ctx, refs.StringValue(user.PhoneNumber), constants.VerificationTypeVerifyPhoneNumber)
+		if err != nil {
+			log.Debug("Failed to send phone number verification otp: ", err)
+		} else {
+			message += `For the phone number change we have sent otp, please verify and continue`
+		}
+	}
 	res = &model.Response{
 		Message: message,
 	}
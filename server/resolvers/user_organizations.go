@@ -0,0 +1,59 @@
+package resolvers
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// UserOrganizationsResolver is a resolver for user_organizations query.
+// It returns the organizations memberships of the logged in user, used to switch the organization of session
+func UserOrganizationsResolver(ctx context.Context) ([]*model.OrganizationMember, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	accessToken, err := token.GetAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed to get access token: ", err)
+		return nil, err
+	}
+
+	claims, err := token.ValidateAccessToken(gc, accessToken)
+	if err != nil {
+		log.Debug("Failed to validate access token: ", err)
+		return nil, err
+	}
+
+	userID := claims["sub"].(string)
+	log := log.WithFields(log.Fields{
+		"user_id": userID,
+	})
+
+	members, err := db.Provider.ListOrganizationMembersByUserID(ctx, userID)
+	if err != nil {
+		log.Debug("Failed to list organization members: ", err)
+		return nil, err
+	}
+
+	res := []*model.OrganizationMember{}
+	for _, member := range members {
+		organization, err := db.Provider.GetOrganizationByID(ctx, member.OrganizationID)
+		if err != nil {
+			log.Debug("Failed to get organization: ", err)
+			continue
+		}
+		apiMember := member.AsAPIOrganizationMember()
+		apiMember.Organization = organization.AsAPIOrganization()
+		res = append(res, apiMember)
+	}
+
+	return res, nil
+}
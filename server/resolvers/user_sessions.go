@@ -0,0 +1,36 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// UserSessionsResolver is a resolver for user sessions query.
+// It returns the active sessions of user & is used by the admin
+func UserSessionsResolver(ctx context.Context, params model.UserSessionsInput) ([]*model.UserSession, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	user, err := db.Provider.GetUserByID(ctx, params.UserID)
+	if err != nil {
+		log.Debug("Failed to get user by ID: ", err)
+		return nil, err
+	}
+
+	return listUserSessions(ctx, user.ID, "")
+}
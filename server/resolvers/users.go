@@ -3,18 +3,23 @@ package resolvers
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/authorizerdev/authorizer/server/token"
 	"github.com/authorizerdev/authorizer/server/utils"
 )
 
-// UsersResolver is a resolver for users query
+// UsersResolver is a resolver for users query,
+// users matching the filter are listed in the order of sort.
 // This is admin only query
-func UsersResolver(ctx context.Context, params *model.PaginatedInput) (*model.Users, error) {
+func UsersResolver(ctx context.Context, params *model.PaginatedInput, filterInput *model.UsersFilterInput, sort *model.UsersSortInput) (*model.Users, error) {
 	gc, err := utils.GinContextFromContext(ctx)
 	if err != nil {
 		log.Debug("Failed to get GinContext: ", err)
@@ -28,7 +33,34 @@ func UsersResolver(ctx context.Context, params *model.PaginatedInput) (*model.Us
 
 	pagination := utils.GetPagination(params)
 
-	res, err := db.Provider.ListUsers(ctx, pagination)
+	var filter models.UserFilter
+	if filterInput != nil {
+		filter = models.UserFilter{
+			Email:           strings.TrimSpace(refs.StringValue(filterInput.Email)),
+			Role:            strings.TrimSpace(refs.StringValue(filterInput.Role)),
+			SignupMethod:    strings.TrimSpace(refs.StringValue(filterInput.SignupMethod)),
+			IsEmailVerified: filterInput.IsEmailVerified,
+			IsPhoneVerified: filterInput.IsPhoneVerified,
+			IsRevoked:       filterInput.IsRevoked,
+			CreatedAfter:    filterInput.CreatedAfter,
+			CreatedBefore:   filterInput.CreatedBefore,
+		}
+	}
+	if sort != nil {
+		filter.SortBy = refs.StringValue(sort.SortBy)
+		filter.SortOrder = strings.ToLower(refs.StringValue(sort.SortOrder))
+	}
+
+	if filter.SortBy != "" && !utils.StringSliceContains(models.UserSortFields, filter.SortBy) {
+		log.Debug("Invalid sort by: ", filter.SortBy)
+		return nil, fmt.Errorf("invalid sort_by, must be one of %s", strings.Join(models.UserSortFields, ", "))
+	}
+	if filter.SortOrder != "" && filter.SortOrder != constants.SortOrderAsc && filter.SortOrder != constants.SortOrderDesc {
+		log.Debug("Invalid sort order: ", filter.SortOrder)
+		return nil, fmt.Errorf("invalid sort_order, must be asc or desc")
+	}
+
+	res, err := db.Provider.ListUsers(ctx, pagination, filter)
 	if err != nil {
 		log.Debug("Failed to get users: ", err)
 		return nil, err
@@ -9,6 +9,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/parsers"
@@ -47,8 +48,18 @@ func ValidateJwtTokenResolver(ctx context.Context, params model.ValidateJWTToken
 	}
 	userID = claims["sub"].(string)
 
+	// access_token issued with client_credentials grant is not persisted in session store,
+	// it is validated with its claims & the client must still be registered
+	isClientAccessToken := tokenType == constants.TokenTypeAccessToken && token.IsClientAccessToken(claims)
+	if isClientAccessToken {
+		if _, err := db.Provider.GetClientByID(ctx, claims["client_id"].(string)); err != nil {
+			log.Debug("Failed to get client: ", err)
+			return nil, errors.New("invalid token")
+		}
+	}
+
 	// access_token and refresh_token should be validated from session store as well
-	if tokenType == constants.TokenTypeAccessToken || tokenType == constants.TokenTypeRefreshToken {
+	if !isClientAccessToken && (tokenType == constants.TokenTypeAccessToken || tokenType == constants.TokenTypeRefreshToken) {
 		nonce = claims["nonce"].(string)
 		loginMethod := claims["login_method"]
 		sessionKey := userID
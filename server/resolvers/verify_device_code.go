@@ -0,0 +1,88 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/device"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// VerifyDeviceCodeResolver is a resolver for verify device code mutation.
+// logged in user approves or denies the device authorization with user_code shown by the device
+func VerifyDeviceCodeResolver(ctx context.Context, params model.VerifyDeviceCodeInput) (*model.Response, error) {
+	var res *model.Response
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	accessToken, err := token.GetAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed to get access token: ", err)
+		return res, err
+	}
+
+	claims, err := token.ValidateAccessToken(gc, accessToken)
+	if err != nil {
+		log.Debug("Failed to validate access token: ", err)
+		return res, err
+	}
+
+	userID := claims["sub"].(string)
+	log := log.WithFields(log.Fields{
+		"user_id": userID,
+	})
+
+	authorization, err := device.GetByUserCode(params.UserCode)
+	if err != nil {
+		log.Debug("Failed to get device authorization: ", err)
+		return res, fmt.Errorf(`invalid user code`)
+	}
+	if authorization.IsExpired() {
+		log.Debug("Device authorization is expired")
+		device.Remove(authorization)
+		return res, fmt.Errorf(`user code is expired`)
+	}
+	if authorization.Status != constants.DeviceAuthorizationStatusPending {
+		log.Debug("Device authorization is already verified")
+		return res, fmt.Errorf(`invalid user code`)
+	}
+
+	if !params.Approve {
+		authorization.Status = constants.DeviceAuthorizationStatusDenied
+		if err := device.Save(authorization); err != nil {
+			log.Debug("Failed to save device authorization: ", err)
+			return res, err
+		}
+		return &model.Response{
+			Message: `Device denied successfully`,
+		}, nil
+	}
+
+	roles := []string{}
+	for _, role := range utils.ConvertInterfaceToSlice(claims["roles"]) {
+		roles = append(roles, role.(string))
+	}
+	loginMethod, _ := claims["login_method"].(string)
+
+	authorization.Status = constants.DeviceAuthorizationStatusApproved
+	authorization.UserID = userID
+	authorization.Roles = roles
+	authorization.LoginMethod = loginMethod
+	if err := device.Save(authorization); err != nil {
+		log.Debug("Failed to save device authorization: ", err)
+		return res, err
+	}
+
+	return &model.Response{
+		Message: `Device approved successfully`,
+	}, nil
+}
@@ -84,7 +84,7 @@ func VerifyEmailResolver(ctx context.Context, params model.VerifyEmailInput) (*m
 
 	roles := strings.Split(user.Roles, ",")
 	scope := []string{"openid", "email", "profile"}
-	authToken, err := token.CreateAuthToken(gc, user, roles, scope, loginMethod)
+	authToken, err := token.CreateAuthToken(gc, user, roles, scope, loginMethod, token.AuthTokenOptions{})
 	if err != nil {
 		log.Debug("Failed to create auth token: ", err)
 		return res, err
@@ -98,6 +98,7 @@ func VerifyEmailResolver(ctx context.Context, params model.VerifyEmailInput) (*m
 		}
 
 		db.Provider.AddSession(ctx, models.Session{
+			ID:        authToken.SessionID,
 			UserID:    user.ID,
 			UserAgent: utils.GetUserAgent(gc.Request),
 			IP:        utils.GetIP(gc.Request),
@@ -0,0 +1,391 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/cookie"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// VerifyOtpResolver is a resolver for verify otp mutation
+// it completes the login that is pending for multi factor authentication
+func VerifyOtpResolver(ctx context.Context, params model.VerifyOTPRequest) (*model.AuthResponse, error) {
+	var res *model.AuthResponse
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	if params.PhoneNumber != nil && strings.TrimSpace(*params.PhoneNumber) != "" {
+		return verifyPhoneOtp(ctx, gc, params)
+	}
+
+	mfaSession, err := cookie.GetMfaSession(gc)
+	if err != nil {
+		log.Debug("Failed to get mfa session: ", err)
+		return res, fmt.Errorf(`invalid mfa session`)
+	}
+
+	mfaSessionKey := constants.MfaSessionStatePrefix + mfaSession
+	mfaSessionState, err := memorystore.Provider.GetState(mfaSessionKey)
+	if err != nil || mfaSessionState == "" {
+		log.Debug("Failed to get mfa session state: ", err)
+		return res, fmt.Errorf(`invalid mfa session`)
+	}
+
+	// mfa session state is in format userID___roles___scope___expiresAt___organizationID___loginMethod,
+	// organizationID & loginMethod are not present in the sessions created before their support
+	sessionSplit := strings.Split(mfaSessionState, "___")
+	if len(sessionSplit) < 4 || len(sessionSplit) > 6 {
+		log.Debug("Invalid mfa session state: ", mfaSessionState)
+		return res, fmt.Errorf(`invalid mfa session`)
+	}
+
+	expiresAt, err := strconv.ParseInt(sessionSplit[3], 10, 64)
+	if err != nil || expiresAt < time.Now().Unix() {
+		log.Debug("MFA session expired")
+		memorystore.Provider.RemoveState(mfaSessionKey)
+		cookie.DeleteMfaSession(gc)
+		return res, fmt.Errorf(`mfa session expired, please login again`)
+	}
+
+	userID := sessionSplit[0]
+	log := log.WithFields(log.Fields{
+		"user_id": userID,
+	})
+	user, err := db.Provider.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Debug("Failed to get user: ", err)
+		return res, err
+	}
+
+	if params.Email != nil && strings.ToLower(strings.TrimSpace(*params.Email)) != user.Email {
+		log.Debug("Email does not match mfa session")
+		return res, fmt.Errorf(`invalid email`)
+	}
+
+	if user.RevokedTimestamp != nil {
+		log.Debug("User access is revoked")
+		return res, fmt.Errorf(`user access has been revoked`)
+	}
+
+	// user enrolling the authenticator app during login should first verify the otp sent to email,
+	// totp enrollment is returned only after it
+	enrollmentKey := constants.MfaEnrollmentStatePrefix + mfaSession
+	isVerifyingEmailOtp := false
+	if user.TOTPVerifiedAt == nil {
+		enrollmentState, _ := memorystore.Provider.GetState(enrollmentKey)
+		isVerifyingEmailOtp = enrollmentState == ""
+	}
+
+	isValidOtp := false
+	var emailOtpRequest models.VerificationRequest
+	if isVerifyingEmailOtp {
+		emailOtpRequest, err = db.Provider.GetVerificationRequestByEmail(ctx, user.Email, constants.VerificationTypeMfaEnrollment)
+		if err != nil || emailOtpRequest.ExpiresAt < time.Now().Unix() {
+			log.Debug("Email otp not found or expired: ", err)
+			return res, fmt.Errorf(`otp expired, please login again`)
+		}
+		isValidOtp = bcrypt.CompareHashAndPassword([]byte(emailOtpRequest.Token), []byte(strings.TrimSpace(params.Otp))) == nil
+	} else {
+		if user.TOTPSecret == nil || *user.TOTPSecret == "" {
+			log.Debug("TOTP enrollment not found")
+			return res, fmt.Errorf(`totp enrollment not found`)
+		}
+
+		secret, err := crypto.DecryptAES(*user.TOTPSecret)
+		if err != nil {
+			log.Debug("Failed to decrypt totp secret: ", err)
+			return res, err
+		}
+		isValidOtp = validateTOTPCode(user.ID, secret, params.Otp)
+	}
+
+	attemptsKey := constants.MfaAttemptsStatePrefix + mfaSession
+	if !isValidOtp {
+		log.Debug("Invalid otp")
+		attemptsState, _ := memorystore.Provider.GetState(attemptsKey)
+		attempts, _ := strconv.Atoi(attemptsState)
+		attempts++
+		if attempts >= constants.OtpMaxAttempts {
+			// mfa session is invalidated to prevent brute force, user should login again
+			memorystore.Provider.RemoveState(mfaSessionKey)
+			memorystore.Provider.RemoveState(attemptsKey)
+			memorystore.Provider.RemoveState(enrollmentKey)
+			cookie.DeleteMfaSession(gc)
+			return res, fmt.Errorf(`too many invalid attempts, please login again`)
+		}
+		memorystore.Provider.SetStateWithExpiry(attemptsKey, strconv.Itoa(attempts), constants.MfaSessionExpiry)
+		return res, fmt.Errorf(`invalid otp`)
+	}
+
+	if isVerifyingEmailOtp {
+		db.Provider.DeleteVerificationRequest(ctx, emailOtpRequest)
+		memorystore.Provider.RemoveState(attemptsKey)
+		err = memorystore.Provider.SetStateWithExpiry(enrollmentKey, "verified", time.Until(time.Unix(expiresAt, 0)))
+		if err != nil {
+			log.Debug("Failed to set mfa enrollment state: ", err)
+			return res, err
+		}
+
+		enrollment, err := getTOTPEnrollment(ctx, &user)
+		if err != nil {
+			log.Debug("Failed to get totp enrollment: ", err)
+			return res, err
+		}
+		res = &model.AuthResponse{
+			Message:              `Please scan the qr code using your authenticator app and enter the otp`,
+			ShouldShowTotpScreen: refs.NewBoolRef(true),
+			TotpEnrollment:       enrollment,
+		}
+		return res, nil
+	}
+
+	// login was used to enroll the authenticator app
+	if user.TOTPVerifiedAt == nil {
+		now := time.Now().Unix()
+		user.TOTPVerifiedAt = &now
+		user, err = db.Provider.UpdateUser(ctx, user)
+		if err != nil {
+			log.Debug("Failed to update user: ", err)
+			return res, err
+		}
+	}
+
+	memorystore.Provider.RemoveState(mfaSessionKey)
+	memorystore.Provider.RemoveState(attemptsKey)
+	memorystore.Provider.RemoveState(enrollmentKey)
+	cookie.DeleteMfaSession(gc)
+
+	roles := strings.Split(sessionSplit[1], ",")
+	scope := strings.Split(sessionSplit[2], ",")
+	loginMethod := constants.AuthRecipeMethodBasicAuth
+	if len(sessionSplit) == 6 && sessionSplit[5] != "" {
+		loginMethod = sessionSplit[5]
+	}
+	authInfo := token.NewAuthenticationInfo(loginMethod, true)
+	if len(sessionSplit) >= 5 {
+		authInfo.OrganizationID = sessionSplit[4]
+	}
+	authToken, err := token.CreateAuthToken(gc, user, roles, scope, loginMethod, token.AuthTokenOptions{AuthInfo: &authInfo})
+	if err != nil {
+		log.Debug("Failed to create auth token", err)
+		return res, err
+	}
+
+	expiresIn := authToken.AccessToken.ExpiresAt - time.Now().Unix()
+	if expiresIn <= 0 {
+		expiresIn = 1
+	}
+
+	res = &model.AuthResponse{
+		Message:              `Logged in successfully`,
+		AccessToken:          &authToken.AccessToken.Token,
+		IDToken:              &authToken.IDToken.Token,
+		ExpiresIn:            &expiresIn,
+		User:                 user.AsAPIUser(),
+		ShouldShowTotpScreen: refs.NewBoolRef(false),
+	}
+
+	cookie.SetSession(gc, authToken.FingerPrintHash)
+	sessionStoreKey := loginMethod + ":" + user.ID
+	memorystore.Provider.SetUserSession(sessionStoreKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash)
+	memorystore.Provider.SetUserSession(sessionStoreKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token)
+
+	if authToken.RefreshToken != nil {
+		res.RefreshToken = &authToken.RefreshToken.Token
+		memorystore.Provider.SetUserSession(sessionStoreKey, constants.TokenTypeRefreshToken+"_"+authToken.FingerPrint, authToken.RefreshToken.Token)
+	}
+
+	go func() {
+		utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, loginMethod, user)
+		db.Provider.AddSession(ctx, models.Session{
+			ID:        authToken.SessionID,
+			UserID:    user.ID,
+			UserAgent: utils.GetUserAgent(gc.Request),
+			IP:        utils.GetIP(gc.Request),
+		})
+	}()
+
+	return res, nil
+}
+
+// validateTOTPCode validates the totp code of user & remembers its time step,
+// so the code can not be replayed within the time it is valid
+func validateTOTPCode(userID, secret, code string) bool {
+	timeStepKey := constants.TOTPTimeStepStatePrefix + userID
+	timeStepState, _ := memorystore.Provider.GetState(timeStepKey)
+	lastTimeStep, _ := strconv.ParseInt(timeStepState, 10, 64)
+	timeStep, ok := crypto.ValidateTOTPCode(secret, code, lastTimeStep)
+	if !ok {
+		return false
+	}
+	err := memorystore.Provider.SetStateWithExpiry(timeStepKey, strconv.FormatInt(timeStep, 10), crypto.TOTPValidity)
+	if err != nil {
+		log.Debug("Failed to set totp time step: ", err)
+	}
+	return true
+}
+
+// verifyPhoneOtp verifies the otp sent via sms.
+// If phone number is already verified otp is used to login,
+// else it completes the phone number verification for the logged in user.
+func verifyPhoneOtp(ctx context.Context, gc *gin.Context, params model.VerifyOTPRequest) (*model.AuthResponse, error) {
+	var res *model.AuthResponse
+
+	phoneNumber := strings.TrimSpace(*params.PhoneNumber)
+	log := log.WithFields(log.Fields{
+		"phone_number": phoneNumber,
+	})
+	user, err := db.Provider.GetUserByPhoneNumber(ctx, phoneNumber)
+	if err != nil {
+		log.Debug("Failed to get user by phone number: ", err)
+		return res, fmt.Errorf(`user with this phone number not found`)
+	}
+
+	if user.RevokedTimestamp != nil {
+		log.Debug("User access is revoked")
+		return res, fmt.Errorf(`user access has been revoked`)
+	}
+
+	isVerifyingPhoneNumber := user.PhoneNumberVerifiedAt == nil
+	verificationType := constants.VerificationTypeMobileOTPLogin
+	if isVerifyingPhoneNumber {
+		// phone number can only be verified by the user who added it
+		accessToken, err := token.GetAccessToken(gc)
+		if err != nil {
+			log.Debug("Failed to get access token: ", err)
+			return res, err
+		}
+		claims, err := token.ValidateAccessToken(gc, accessToken)
+		if err != nil {
+			log.Debug("Failed to validate access token: ", err)
+			return res, err
+		}
+		if claims["sub"].(string) != user.ID {
+			log.Debug("Phone number does not belong to user")
+			return res, fmt.Errorf(`unauthorized`)
+		}
+		verificationType = constants.VerificationTypeVerifyPhoneNumber
+	}
+
+	verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, phoneNumber, verificationType)
+	if err != nil {
+		log.Debug("Failed to get verification request: ", err)
+		return res, fmt.Errorf(`invalid otp`)
+	}
+
+	if verificationRequest.ExpiresAt < time.Now().Unix() {
+		log.Debug("OTP expired")
+		db.Provider.DeleteVerificationRequest(ctx, verificationRequest)
+		return res, fmt.Errorf(`otp expired`)
+	}
+
+	attemptsKey := constants.OtpAttemptsStatePrefix + verificationType + "_" + phoneNumber
+	err = bcrypt.CompareHashAndPassword([]byte(verificationRequest.Token), []byte(strings.TrimSpace(params.Otp)))
+	if err != nil {
+		log.Debug("Invalid otp: ", err)
+		attemptsState, _ := memorystore.Provider.GetState(attemptsKey)
+		attempts, _ := strconv.Atoi(attemptsState)
+		attempts++
+		if attempts >= constants.OtpMaxAttempts {
+			// otp is invalidated to prevent brute force, new otp should be requested
+			db.Provider.DeleteVerificationRequest(ctx, verificationRequest)
+			memorystore.Provider.RemoveState(attemptsKey)
+		} else {
+			memorystore.Provider.SetStateWithExpiry(attemptsKey, strconv.Itoa(attempts), constants.OtpExpiry)
+		}
+		return res, fmt.Errorf(`invalid otp`)
+	}
+
+	db.Provider.DeleteVerificationRequest(ctx, verificationRequest)
+	memorystore.Provider.RemoveState(attemptsKey)
+
+	if isVerifyingPhoneNumber {
+		now := time.Now().Unix()
+		user.PhoneNumberVerifiedAt = &now
+	}
+	if !isVerifyingPhoneNumber && !strings.Contains(user.SignupMethods, constants.AuthRecipeMethodMobileOTP) {
+		user.SignupMethods = user.SignupMethods + "," + constants.AuthRecipeMethodMobileOTP
+	}
+	user, err = db.Provider.UpdateUser(ctx, user)
+	if err != nil {
+		log.Debug("Failed to update user: ", err)
+		return res, err
+	}
+
+	if isVerifyingPhoneNumber {
+		res = &model.AuthResponse{
+			Message: `Phone number verified successfully`,
+			User:    user.AsAPIUser(),
+		}
+		return res, nil
+	}
+
+	roles := strings.Split(user.Roles, ",")
+	scope := []string{"openid", "email", "profile"}
+
+	// otp sent via sms is not the second factor, so mfa policy is applied same as password login
+	if isMFARequired(user, roles) {
+		return createMfaSession(ctx, gc, &user, roles, scope, constants.AuthRecipeMethodMobileOTP, "")
+	}
+
+	authToken, err := token.CreateAuthToken(gc, user, roles, scope, constants.AuthRecipeMethodMobileOTP, token.AuthTokenOptions{})
+	if err != nil {
+		log.Debug("Failed to create auth token", err)
+		return res, err
+	}
+
+	expiresIn := authToken.AccessToken.ExpiresAt - time.Now().Unix()
+	if expiresIn <= 0 {
+		expiresIn = 1
+	}
+
+	res = &model.AuthResponse{
+		Message:     `Logged in successfully`,
+		AccessToken: &authToken.AccessToken.Token,
+		IDToken:     &authToken.IDToken.Token,
+		ExpiresIn:   &expiresIn,
+		User:        user.AsAPIUser(),
+	}
+
+	cookie.SetSession(gc, authToken.FingerPrintHash)
+	sessionStoreKey := constants.AuthRecipeMethodMobileOTP + ":" + user.ID
+	memorystore.Provider.SetUserSession(sessionStoreKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash)
+	memorystore.Provider.SetUserSession(sessionStoreKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token)
+
+	if authToken.RefreshToken != nil {
+		res.RefreshToken = &authToken.RefreshToken.Token
+		memorystore.Provider.SetUserSession(sessionStoreKey, constants.TokenTypeRefreshToken+"_"+authToken.FingerPrint, authToken.RefreshToken.Token)
+	}
+
+	go func() {
+		utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, constants.AuthRecipeMethodMobileOTP, user)
+		db.Provider.AddSession(ctx, models.Session{
+			ID:        authToken.SessionID,
+			UserID:    user.ID,
+			UserAgent: utils.GetUserAgent(gc.Request),
+			IP:        utils.GetIP(gc.Request),
+		})
+	}()
+
+	return res, nil
+}
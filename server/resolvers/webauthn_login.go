@@ -0,0 +1,203 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/cookie"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/validators"
+	"github.com/authorizerdev/authorizer/server/webauthn"
+)
+
+// WebauthnLoginResolver is a resolver for webauthn login mutation
+// it verifies the assertion created by authenticator and logs in the credential owner
+func WebauthnLoginResolver(ctx context.Context, params model.WebauthnLoginInput) (*model.AuthResponse, error) {
+	var res *model.AuthResponse
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	rpID := getWebauthnRPID(gc)
+	clientDataJSON, err := webauthn.DecodeBase64(params.ClientDataJSON)
+	if err != nil {
+		log.Debug("Failed to decode client data: ", err)
+		return res, fmt.Errorf(`invalid client data`)
+	}
+
+	clientData, err := webauthn.ParseClientData(clientDataJSON, webauthn.CeremonyTypeGet, rpID, getWebauthnOrigins(gc))
+	if err != nil {
+		log.Debug("Failed to parse client data: ", err)
+		return res, fmt.Errorf(`invalid client data: %s`, err.Error())
+	}
+
+	stateKey := constants.WebauthnLoginStatePrefix + clientData.Challenge
+	state, err := memorystore.Provider.GetState(stateKey)
+	if err != nil || state == "" {
+		log.Debug("Failed to get webauthn login state: ", err)
+		return res, fmt.Errorf(`invalid challenge`)
+	}
+	// challenge can be used only once
+	memorystore.Provider.RemoveState(stateKey)
+
+	// state is in format userID___expiresAt
+	stateSplit := strings.Split(state, "___")
+	if len(stateSplit) != 2 {
+		log.Debug("Invalid webauthn login state: ", state)
+		return res, fmt.Errorf(`invalid challenge`)
+	}
+	expiresAt, err := strconv.ParseInt(stateSplit[1], 10, 64)
+	if err != nil || expiresAt < time.Now().Unix() {
+		log.Debug("Webauthn login challenge expired")
+		return res, fmt.Errorf(`challenge expired`)
+	}
+
+	credentialID, err := webauthn.DecodeBase64(params.ID)
+	if err != nil {
+		log.Debug("Failed to decode credential id: ", err)
+		return res, fmt.Errorf(`invalid credential`)
+	}
+
+	credential, err := db.Provider.GetWebauthnCredentialByCredentialID(ctx, webauthn.EncodeBase64(credentialID))
+	if err != nil {
+		log.Debug("Failed to get webauthn credential: ", err)
+		return res, fmt.Errorf(`invalid credential`)
+	}
+
+	if stateSplit[0] != "" && stateSplit[0] != credential.UserID {
+		log.Debug("Webauthn credential does not belong to user")
+		return res, fmt.Errorf(`invalid credential`)
+	}
+
+	log := log.WithFields(log.Fields{
+		"user_id": credential.UserID,
+	})
+	user, err := db.Provider.GetUserByID(ctx, credential.UserID)
+	if err != nil {
+		log.Debug("Failed to get user: ", err)
+		return res, err
+	}
+
+	if user.RevokedTimestamp != nil {
+		log.Debug("User access is revoked")
+		return res, fmt.Errorf(`user access has been revoked`)
+	}
+
+	publicKey, err := webauthn.DecodeBase64(credential.PublicKey)
+	if err != nil {
+		log.Debug("Failed to decode credential public key: ", err)
+		return res, err
+	}
+
+	authenticatorData, err := webauthn.DecodeBase64(params.AuthenticatorData)
+	if err != nil {
+		log.Debug("Failed to decode authenticator data: ", err)
+		return res, fmt.Errorf(`invalid authenticator data`)
+	}
+
+	signature, err := webauthn.DecodeBase64(params.Signature)
+	if err != nil {
+		log.Debug("Failed to decode signature: ", err)
+		return res, fmt.Errorf(`invalid signature`)
+	}
+
+	authData, err := webauthn.VerifyAssertion(publicKey, clientDataJSON, authenticatorData, signature, rpID)
+	if err != nil {
+		log.Debug("Failed to verify webauthn assertion: ", err)
+		return res, fmt.Errorf(`invalid assertion`)
+	}
+
+	// sign count that does not increase indicates a cloned authenticator
+	// authenticators that do not support counters always send 0
+	signCount := int64(authData.SignCount)
+	if (signCount > 0 || credential.SignCount > 0) && signCount <= credential.SignCount {
+		log.Debug("Webauthn sign count did not increase")
+		return res, fmt.Errorf(`invalid assertion`)
+	}
+
+	credential.SignCount = signCount
+	_, err = db.Provider.UpdateWebauthnCredential(ctx, credential)
+	if err != nil {
+		log.Debug("Failed to update webauthn credential: ", err)
+		return res, err
+	}
+
+	defaultRolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyDefaultRoles)
+	roles := []string{}
+	if err != nil {
+		log.Debug("Error getting default roles: ", err)
+		defaultRolesString = ""
+	} else {
+		roles = strings.Split(defaultRolesString, ",")
+	}
+
+	currentRoles := strings.Split(user.Roles, ",")
+	if len(params.Roles) > 0 {
+		if !validators.IsValidRoles(params.Roles, currentRoles) {
+			log.Debug("Invalid roles: ", params.Roles)
+			return res, fmt.Errorf(`invalid roles`)
+		}
+
+		roles = params.Roles
+	}
+
+	scope := []string{"openid", "email", "profile"}
+	if params.Scope != nil && len(params.Scope) > 0 {
+		scope = params.Scope
+	}
+
+	authToken, err := token.CreateAuthToken(gc, user, roles, scope, constants.AuthRecipeMethodWebauthn, token.AuthTokenOptions{})
+	if err != nil {
+		log.Debug("Failed to create auth token", err)
+		return res, err
+	}
+
+	expiresIn := authToken.AccessToken.ExpiresAt - time.Now().Unix()
+	if expiresIn <= 0 {
+		expiresIn = 1
+	}
+
+	res = &model.AuthResponse{
+		Message:     `Logged in successfully`,
+		AccessToken: &authToken.AccessToken.Token,
+		IDToken:     &authToken.IDToken.Token,
+		ExpiresIn:   &expiresIn,
+		User:        user.AsAPIUser(),
+	}
+
+	cookie.SetSession(gc, authToken.FingerPrintHash)
+	sessionStoreKey := constants.AuthRecipeMethodWebauthn + ":" + user.ID
+	memorystore.Provider.SetUserSession(sessionStoreKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash)
+	memorystore.Provider.SetUserSession(sessionStoreKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token)
+
+	if authToken.RefreshToken != nil {
+		res.RefreshToken = &authToken.RefreshToken.Token
+		memorystore.Provider.SetUserSession(sessionStoreKey, constants.TokenTypeRefreshToken+"_"+authToken.FingerPrint, authToken.RefreshToken.Token)
+	}
+
+	go func() {
+		utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, constants.AuthRecipeMethodWebauthn, user)
+		db.Provider.AddSession(ctx, models.Session{
+			ID:        authToken.SessionID,
+			UserID:    user.ID,
+			UserAgent: utils.GetUserAgent(gc.Request),
+			IP:        utils.GetIP(gc.Request),
+		})
+	}()
+
+	return res, nil
+}
@@ -0,0 +1,79 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/webauthn"
+)
+
+// WebauthnLoginOptionsResolver is a resolver for webauthn login options mutation
+// it returns the options for navigator.credentials.get
+// if email is not passed, discoverable credentials (passkeys) can be used for login
+func WebauthnLoginOptionsResolver(ctx context.Context, params *model.WebauthnLoginOptionsInput) (*model.WebauthnOptionsResponse, error) {
+	var res *model.WebauthnOptionsResponse
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	userID := ""
+	credentialIDs := []string{}
+	if params != nil && params.Email != nil && strings.TrimSpace(*params.Email) != "" {
+		email := strings.ToLower(strings.TrimSpace(*params.Email))
+		log := log.WithFields(log.Fields{
+			"email": email,
+		})
+		user, err := db.Provider.GetUserByEmail(ctx, email)
+		if err != nil {
+			log.Debug("Failed to get user by email: ", err)
+			return res, fmt.Errorf(`user with this email not found`)
+		}
+
+		credentials, err := db.Provider.ListWebauthnCredentialsByUserID(ctx, user.ID)
+		if err != nil {
+			log.Debug("Failed to list webauthn credentials: ", err)
+			return res, err
+		}
+		if len(credentials) == 0 {
+			log.Debug("User has not registered any webauthn credential")
+			return res, fmt.Errorf(`user has not registered any webauthn credential`)
+		}
+
+		userID = user.ID
+		for _, credential := range credentials {
+			credentialIDs = append(credentialIDs, credential.CredentialID)
+		}
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		log.Debug("Failed to generate challenge: ", err)
+		return res, err
+	}
+
+	// state is in format userID___expiresAt, userID is empty for discoverable credentials
+	expiresAt := time.Now().Add(constants.WebauthnChallengeExpiry).Unix()
+	err = memorystore.Provider.SetStateWithExpiry(constants.WebauthnLoginStatePrefix+challenge, fmt.Sprintf("%s___%d", userID, expiresAt), constants.WebauthnChallengeExpiry)
+	if err != nil {
+		log.Debug("Failed to set webauthn login state: ", err)
+		return res, err
+	}
+
+	res = &model.WebauthnOptionsResponse{
+		Options: webauthn.RequestOptions(challenge, getWebauthnRPID(gc), credentialIDs),
+	}
+
+	return res, nil
+}
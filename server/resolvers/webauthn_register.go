@@ -0,0 +1,134 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/webauthn"
+)
+
+// WebauthnRegisterResolver is a resolver for webauthn register mutation
+// it verifies the attestation created by authenticator and saves the credential for logged in user
+func WebauthnRegisterResolver(ctx context.Context, params model.WebauthnRegisterInput) (*model.Response, error) {
+	var res *model.Response
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	accessToken, err := token.GetAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed to get access token: ", err)
+		return res, err
+	}
+
+	claims, err := token.ValidateAccessToken(gc, accessToken)
+	if err != nil {
+		log.Debug("Failed to validate access token: ", err)
+		return res, err
+	}
+
+	userID := claims["sub"].(string)
+	log := log.WithFields(log.Fields{
+		"user_id": userID,
+	})
+
+	rpID := getWebauthnRPID(gc)
+	clientDataJSON, err := webauthn.DecodeBase64(params.ClientDataJSON)
+	if err != nil {
+		log.Debug("Failed to decode client data: ", err)
+		return res, fmt.Errorf(`invalid client data`)
+	}
+
+	clientData, err := webauthn.ParseClientData(clientDataJSON, webauthn.CeremonyTypeCreate, rpID, getWebauthnOrigins(gc))
+	if err != nil {
+		log.Debug("Failed to parse client data: ", err)
+		return res, fmt.Errorf(`invalid client data: %s`, err.Error())
+	}
+
+	stateKey := constants.WebauthnRegistrationStatePrefix + clientData.Challenge
+	state, err := memorystore.Provider.GetState(stateKey)
+	if err != nil || state == "" {
+		log.Debug("Failed to get webauthn registration state: ", err)
+		return res, fmt.Errorf(`invalid challenge`)
+	}
+	// challenge can be used only once
+	memorystore.Provider.RemoveState(stateKey)
+
+	// state is in format userID___expiresAt
+	stateSplit := strings.Split(state, "___")
+	if len(stateSplit) != 2 || stateSplit[0] != userID {
+		log.Debug("Invalid webauthn registration state: ", state)
+		return res, fmt.Errorf(`invalid challenge`)
+	}
+	expiresAt, err := strconv.ParseInt(stateSplit[1], 10, 64)
+	if err != nil || expiresAt < time.Now().Unix() {
+		log.Debug("Webauthn registration challenge expired")
+		return res, fmt.Errorf(`challenge expired`)
+	}
+
+	attestationObject, err := webauthn.DecodeBase64(params.AttestationObject)
+	if err != nil {
+		log.Debug("Failed to decode attestation object: ", err)
+		return res, fmt.Errorf(`invalid attestation object`)
+	}
+
+	credential, err := webauthn.VerifyRegistration(attestationObject, rpID)
+	if err != nil {
+		log.Debug("Failed to verify webauthn registration: ", err)
+		return res, fmt.Errorf(`invalid attestation: %s`, err.Error())
+	}
+
+	existingCredential, err := db.Provider.GetWebauthnCredentialByCredentialID(ctx, credential.ID)
+	if err == nil && existingCredential.ID != "" {
+		log.Debug("Webauthn credential already registered")
+		return res, fmt.Errorf(`credential already registered`)
+	}
+
+	user, err := db.Provider.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Debug("Failed to get user: ", err)
+		return res, err
+	}
+
+	_, err = db.Provider.AddWebauthnCredential(ctx, models.WebauthnCredential{
+		UserID:       user.ID,
+		CredentialID: credential.ID,
+		PublicKey:    webauthn.EncodeBase64(credential.PublicKey),
+		SignCount:    int64(credential.SignCount),
+		AAGUID:       credential.AAGUID,
+	})
+	if err != nil {
+		log.Debug("Failed to add webauthn credential: ", err)
+		return res, err
+	}
+
+	if !strings.Contains(user.SignupMethods, constants.AuthRecipeMethodWebauthn) {
+		user.SignupMethods = user.SignupMethods + "," + constants.AuthRecipeMethodWebauthn
+		_, err = db.Provider.UpdateUser(ctx, user)
+		if err != nil {
+			log.Debug("Failed to update user: ", err)
+			return res, err
+		}
+	}
+
+	res = &model.Response{
+		Message: `Webauthn credential registered successfully`,
+	}
+
+	return res, nil
+}
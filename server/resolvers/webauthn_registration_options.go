@@ -0,0 +1,113 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/webauthn"
+)
+
+// WebauthnRegistrationOptionsResolver is a resolver for webauthn registration options mutation
+// it returns the options for navigator.credentials.create for logged in user
+func WebauthnRegistrationOptionsResolver(ctx context.Context) (*model.WebauthnOptionsResponse, error) {
+	var res *model.WebauthnOptionsResponse
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	accessToken, err := token.GetAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed to get access token: ", err)
+		return res, err
+	}
+
+	claims, err := token.ValidateAccessToken(gc, accessToken)
+	if err != nil {
+		log.Debug("Failed to validate access token: ", err)
+		return res, err
+	}
+
+	userID := claims["sub"].(string)
+	log := log.WithFields(log.Fields{
+		"user_id": userID,
+	})
+	user, err := db.Provider.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Debug("Failed to get user: ", err)
+		return res, err
+	}
+
+	credentials, err := db.Provider.ListWebauthnCredentialsByUserID(ctx, user.ID)
+	if err != nil {
+		log.Debug("Failed to list webauthn credentials: ", err)
+		return res, err
+	}
+	credentialIDs := []string{}
+	for _, credential := range credentials {
+		credentialIDs = append(credentialIDs, credential.CredentialID)
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		log.Debug("Failed to generate challenge: ", err)
+		return res, err
+	}
+
+	expiresAt := time.Now().Add(constants.WebauthnChallengeExpiry).Unix()
+	err = memorystore.Provider.SetStateWithExpiry(constants.WebauthnRegistrationStatePrefix+challenge, fmt.Sprintf("%s___%d", user.ID, expiresAt), constants.WebauthnChallengeExpiry)
+	if err != nil {
+		log.Debug("Failed to set webauthn registration state: ", err)
+		return res, err
+	}
+
+	rpName, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyOrganizationName)
+	if err != nil || rpName == "" {
+		rpName = "Authorizer"
+	}
+
+	res = &model.WebauthnOptionsResponse{
+		Options: webauthn.CreationOptions(challenge, getWebauthnRPID(gc), rpName, user.ID, user.Email, credentialIDs),
+	}
+
+	return res, nil
+}
+
+// getWebauthnRPID returns the relying party id for webauthn ceremonies
+// root domain is used so that credentials work for app & authorizer on different sub domains
+func getWebauthnRPID(gc *gin.Context) string {
+	return parsers.GetDomainName(parsers.GetHost(gc))
+}
+
+// getWebauthnOrigins returns the origins from which webauthn ceremonies are allowed,
+// i.e. authorizer itself & the ALLOWED_ORIGINS that are not wildcard patterns.
+// Origins are compared exactly, so that any sub domain of relying party is not trusted
+func getWebauthnOrigins(gc *gin.Context) []string {
+	origins := []string{parsers.GetHost(gc)}
+	allowedOriginsString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAllowedOrigins)
+	if err != nil {
+		log.Debug("Error getting allowed origins: ", err)
+		return origins
+	}
+	for _, origin := range strings.Split(allowedOriginsString, ",") {
+		origin = strings.TrimSpace(origin)
+		if origin != "" && !strings.Contains(origin, "*") {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
@@ -25,14 +25,44 @@ func InitRouter(log *logrus.Logger) *gin.Engine {
 	router.GET("/oauth_callback/:oauth_provider", handlers.OAuthCallbackHandler())
 	router.POST("/oauth_callback/:oauth_provider", handlers.OAuthCallbackHandler())
 	router.GET("/verify_email", handlers.VerifyEmailHandler())
+	// SAML service provider routes
+	router.GET("/saml/metadata", handlers.SamlMetadataHandler())
+	router.POST("/saml/acs", handlers.SamlAcsHandler())
 	// OPEN ID routes
 	router.GET("/.well-known/openid-configuration", handlers.OpenIDConfigurationHandler())
 	router.GET("/.well-known/jwks.json", handlers.JWKsHandler())
 	router.GET("/authorize", handlers.AuthorizeHandler())
 	router.GET("/userinfo", handlers.UserInfoHandler())
 	router.GET("/logout", handlers.LogoutHandler())
+	router.POST("/logout", handlers.LogoutHandler())
 	router.POST("/oauth/token", handlers.TokenHandler())
 	router.POST("/oauth/revoke", handlers.RevokeRefreshTokenHandler())
+	router.POST("/oauth/introspect", handlers.IntrospectHandler())
+	router.POST("/oauth/device_authorization", handlers.DeviceAuthorizationHandler())
+	// admin routes
+	router.GET("/admin/users/export", handlers.ExportUsersHandler())
+
+	// scim provisioning routes
+	scim := router.Group("/scim/v2", middlewares.ScimAuthMiddleware())
+	{
+		scim.GET("/ServiceProviderConfig", handlers.ScimServiceProviderConfigHandler())
+		scim.GET("/Schemas", handlers.ScimSchemasHandler())
+		scim.GET("/Schemas/:id", handlers.ScimSchemasHandler())
+		scim.GET("/ResourceTypes", handlers.ScimResourceTypesHandler())
+		scim.GET("/ResourceTypes/:id", handlers.ScimResourceTypesHandler())
+		scim.GET("/Users", handlers.ScimListUsersHandler())
+		scim.POST("/Users", handlers.ScimCreateUserHandler())
+		scim.GET("/Users/:id", handlers.ScimGetUserHandler())
+		scim.PUT("/Users/:id", handlers.ScimReplaceUserHandler())
+		scim.PATCH("/Users/:id", handlers.ScimPatchUserHandler())
+		scim.DELETE("/Users/:id", handlers.ScimDeleteUserHandler())
+		scim.GET("/Groups", handlers.ScimListGroupsHandler())
+		scim.POST("/Groups", handlers.ScimUnsupportedGroupHandler())
+		scim.GET("/Groups/:id", handlers.ScimGetGroupHandler())
+		scim.PUT("/Groups/:id", handlers.ScimReplaceGroupHandler())
+		scim.PATCH("/Groups/:id", handlers.ScimPatchGroupHandler())
+		scim.DELETE("/Groups/:id", handlers.ScimUnsupportedGroupHandler())
+	}
 
 	router.LoadHTMLGlob("templates/*")
 	// login page app related routes.
@@ -0,0 +1,94 @@
+package saml
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// DefaultAttributeMapping is the mapping of user fields to the attributes of saml assertion
+// it is used for the fields that are not present in SAML_ATTRIBUTE_MAPPING
+var DefaultAttributeMapping = map[string]string{
+	"email":       "email",
+	"given_name":  "given_name",
+	"family_name": "family_name",
+	"middle_name": "middle_name",
+	"nickname":    "nickname",
+	"picture":     "picture",
+	"gender":      "gender",
+	"birthdate":   "birthdate",
+	"roles":       "roles",
+}
+
+// ParseAttributeMapping parses & validates the attribute mapping configured with SAML_ATTRIBUTE_MAPPING
+func ParseAttributeMapping(data string) (map[string]string, error) {
+	mapping := map[string]string{}
+	for field, attribute := range DefaultAttributeMapping {
+		mapping[field] = attribute
+	}
+	if strings.TrimSpace(data) == "" {
+		return mapping, nil
+	}
+
+	configuredMapping := map[string]string{}
+	err := json.Unmarshal([]byte(data), &configuredMapping)
+	if err != nil {
+		return nil, fmt.Errorf("invalid saml attribute mapping: %s", err.Error())
+	}
+	for field, attribute := range configuredMapping {
+		if _, ok := DefaultAttributeMapping[field]; !ok {
+			return nil, fmt.Errorf("invalid saml attribute mapping field %s", field)
+		}
+		if attribute != "" {
+			mapping[field] = attribute
+		}
+	}
+
+	return mapping, nil
+}
+
+// IsSamlLoginEnabled returns true if saml identity provider is configured
+func IsSamlLoginEnabled() bool {
+	_, err := GetIdentityProvider()
+	return err == nil
+}
+
+// GetIdentityProvider returns the saml identity provider configured with SAML_IDP_* env variables
+func GetIdentityProvider() (IdentityProvider, error) {
+	ssoURL, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeySamlIdpSsoURL)
+	if err != nil || ssoURL == "" {
+		return IdentityProvider{}, errors.New("saml identity provider is not configured")
+	}
+
+	certificateString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeySamlIdpCertificate)
+	if err != nil || certificateString == "" {
+		return IdentityProvider{}, errors.New("saml identity provider is not configured")
+	}
+	certificate, err := ParseCertificate(certificateString)
+	if err != nil {
+		return IdentityProvider{}, err
+	}
+
+	entityID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeySamlIdpEntityID)
+	if err != nil {
+		entityID = ""
+	}
+
+	return IdentityProvider{
+		EntityID:    entityID,
+		SsoURL:      ssoURL,
+		Certificate: certificate,
+	}, nil
+}
+
+// GetServiceProvider returns the service provider for authorizer host
+func GetServiceProvider(hostname string) ServiceProvider {
+	return ServiceProvider{
+		EntityID: hostname + "/saml/metadata",
+		AcsURL:   hostname + "/saml/acs",
+	}
+}
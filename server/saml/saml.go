@@ -0,0 +1,296 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/beevik/etree"
+)
+
+const (
+	namespaceMetadata  = "urn:oasis:names:tc:SAML:2.0:metadata"
+	namespaceProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	namespaceAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+
+	// BindingHTTPPost is the binding used by identity provider to post response to assertion consumer service
+	BindingHTTPPost = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	// BindingHTTPRedirect is the binding used to send authn request to identity provider
+	BindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+
+	nameIDFormatUnspecified = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+	nameIDFormatEmail       = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	statusSuccess           = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	confirmationBearer      = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+
+	// maxClockSkew is the allowed clock difference between service provider & identity provider
+	maxClockSkew = 3 * time.Minute
+)
+
+// ServiceProvider contains the urls of authorizer as saml service provider
+type ServiceProvider struct {
+	EntityID string
+	AcsURL   string
+}
+
+// IdentityProvider contains the config of saml identity provider
+type IdentityProvider struct {
+	// EntityID is optional, if set issuer of assertion is validated
+	EntityID    string
+	SsoURL      string
+	Certificate *x509.Certificate
+}
+
+// Assertion contains the verified subject & attributes of saml assertion
+type Assertion struct {
+	NameID       string
+	NameIDFormat string
+	// Attributes are keyed by name & friendly name of attribute
+	Attributes map[string][]string
+}
+
+// GetAttribute returns the first value of attribute
+func (a *Assertion) GetAttribute(name string) string {
+	if values := a.Attributes[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Metadata returns the xml metadata of service provider that is configured in identity provider
+func (sp ServiceProvider) Metadata() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<md:EntityDescriptor xmlns:md="` + namespaceMetadata + `" entityID="` + escapeXML(sp.EntityID) + `">`)
+	buf.WriteString(`<md:SPSSODescriptor AuthnRequestsSigned="false" WantAssertionsSigned="true" protocolSupportEnumeration="` + namespaceProtocol + `">`)
+	buf.WriteString(`<md:NameIDFormat>` + nameIDFormatEmail + `</md:NameIDFormat>`)
+	buf.WriteString(`<md:AssertionConsumerService Binding="` + BindingHTTPPost + `" Location="` + escapeXML(sp.AcsURL) + `" index="1" isDefault="true"/>`)
+	buf.WriteString(`</md:SPSSODescriptor>`)
+	buf.WriteString(`</md:EntityDescriptor>`)
+	return buf.Bytes()
+}
+
+// AuthnRequestURL returns the url of identity provider with authn request using HTTP-Redirect binding
+// requestID is saved by caller & validated against InResponseTo of response
+func (sp ServiceProvider) AuthnRequestURL(idp IdentityProvider, requestID, relayState string) (string, error) {
+	var request bytes.Buffer
+	request.WriteString(`<samlp:AuthnRequest xmlns:samlp="` + namespaceProtocol + `" xmlns:saml="` + namespaceAssertion + `"`)
+	request.WriteString(` ID="` + escapeXML(requestID) + `" Version="2.0" IssueInstant="` + time.Now().UTC().Format(time.RFC3339) + `"`)
+	request.WriteString(` Destination="` + escapeXML(idp.SsoURL) + `" ProtocolBinding="` + BindingHTTPPost + `" AssertionConsumerServiceURL="` + escapeXML(sp.AcsURL) + `">`)
+	request.WriteString(`<saml:Issuer>` + escapeXML(sp.EntityID) + `</saml:Issuer>`)
+	request.WriteString(`<samlp:NameIDPolicy Format="` + nameIDFormatUnspecified + `" AllowCreate="true"/>`)
+	request.WriteString(`</samlp:AuthnRequest>`)
+
+	var deflated bytes.Buffer
+	writer, err := flate.NewWriter(&deflated, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write(request.Bytes()); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	ssoURL, err := url.Parse(idp.SsoURL)
+	if err != nil {
+		return "", err
+	}
+	query := ssoURL.Query()
+	query.Set("SAMLRequest", base64.StdEncoding.EncodeToString(deflated.Bytes()))
+	if relayState != "" {
+		query.Set("RelayState", relayState)
+	}
+	ssoURL.RawQuery = query.Encode()
+
+	return ssoURL.String(), nil
+}
+
+// ParseResponse parses the base64 encoded SAMLResponse posted to assertion consumer service.
+// It verifies the signature with certificate of identity provider, validates the response
+// is issued for requestID & service provider, and returns the assertion.
+// Encrypted assertions & identity provider initiated login are not supported.
+func (sp ServiceProvider) ParseResponse(idp IdentityProvider, samlResponse, requestID string) (*Assertion, error) {
+	data, err := decodeBase64(samlResponse)
+	if err != nil {
+		return nil, errors.New("invalid saml response encoding")
+	}
+
+	response, err := parseDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid saml response: %s", err.Error())
+	}
+	if !isElement(response, namespaceProtocol, "Response") {
+		return nil, errors.New("invalid saml response")
+	}
+
+	if destination := attr(response, "Destination"); destination != "" && destination != sp.AcsURL {
+		return nil, errors.New("invalid response destination")
+	}
+	if requestID == "" || attr(response, "InResponseTo") != requestID {
+		return nil, errors.New("response is not issued for the request")
+	}
+
+	status := childElement(response, namespaceProtocol, "Status")
+	if status == nil {
+		return nil, errors.New("response status not found")
+	}
+	statusCode := childElement(status, namespaceProtocol, "StatusCode")
+	if statusCode == nil || attr(statusCode, "Value") != statusSuccess {
+		return nil, errors.New("authentication failed at identity provider")
+	}
+
+	if len(childElements(response, namespaceAssertion, "EncryptedAssertion")) > 0 {
+		return nil, errors.New("encrypted assertions are not supported")
+	}
+
+	// either the response or the assertion must be signed,
+	// assertion is read from the verified elements returned by signature verification
+	isSigned := false
+	if hasSignature(response) {
+		response, err = verifySignature(response, idp.Certificate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid response signature: %s", err.Error())
+		}
+		isSigned = true
+	}
+	assertions := childElements(response, namespaceAssertion, "Assertion")
+	if len(assertions) != 1 {
+		return nil, errors.New("expected exactly one assertion")
+	}
+	assertion := assertions[0]
+	if hasSignature(assertion) {
+		assertion, err = verifySignature(assertion, idp.Certificate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid assertion signature: %s", err.Error())
+		}
+		isSigned = true
+	}
+	if !isSigned {
+		return nil, errors.New("assertion is not signed")
+	}
+
+	if idp.EntityID != "" {
+		issuer := childElement(assertion, namespaceAssertion, "Issuer")
+		if issuer == nil || text(issuer) != idp.EntityID {
+			return nil, errors.New("invalid assertion issuer")
+		}
+	}
+
+	now := time.Now()
+	if err := sp.validateConditions(assertion, now); err != nil {
+		return nil, err
+	}
+
+	subject := childElement(assertion, namespaceAssertion, "Subject")
+	if subject == nil {
+		return nil, errors.New("assertion subject not found")
+	}
+	if err := sp.validateSubjectConfirmation(subject, requestID, now); err != nil {
+		return nil, err
+	}
+
+	res := &Assertion{
+		Attributes: map[string][]string{},
+	}
+	if nameID := childElement(subject, namespaceAssertion, "NameID"); nameID != nil {
+		res.NameID = text(nameID)
+		res.NameIDFormat = attr(nameID, "Format")
+	}
+
+	for _, attributeStatement := range childElements(assertion, namespaceAssertion, "AttributeStatement") {
+		for _, attribute := range childElements(attributeStatement, namespaceAssertion, "Attribute") {
+			values := []string{}
+			for _, value := range childElements(attribute, namespaceAssertion, "AttributeValue") {
+				values = append(values, text(value))
+			}
+			for _, name := range []string{attr(attribute, "Name"), attr(attribute, "FriendlyName")} {
+				if name != "" {
+					res.Attributes[name] = append(res.Attributes[name], values...)
+				}
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// validateConditions validates the validity period & audience of assertion, both are required
+func (sp ServiceProvider) validateConditions(assertion *etree.Element, now time.Time) error {
+	conditions := childElement(assertion, namespaceAssertion, "Conditions")
+	if conditions == nil {
+		return errors.New("assertion conditions not found")
+	}
+
+	if notBefore := attr(conditions, "NotBefore"); notBefore != "" {
+		t, err := time.Parse(time.RFC3339, notBefore)
+		if err != nil || now.Add(maxClockSkew).Before(t) {
+			return errors.New("assertion is not yet valid")
+		}
+	}
+	if notOnOrAfter := attr(conditions, "NotOnOrAfter"); notOnOrAfter != "" {
+		t, err := time.Parse(time.RFC3339, notOnOrAfter)
+		if err != nil || !now.Add(-maxClockSkew).Before(t) {
+			return errors.New("assertion is expired")
+		}
+	}
+
+	// assertion must be restricted to service provider & it must be the audience of all the audience restrictions
+	audienceRestrictions := childElements(conditions, namespaceAssertion, "AudienceRestriction")
+	if len(audienceRestrictions) == 0 {
+		return errors.New("assertion audience restriction not found")
+	}
+	for _, audienceRestriction := range audienceRestrictions {
+		isAudience := false
+		for _, audience := range childElements(audienceRestriction, namespaceAssertion, "Audience") {
+			if text(audience) == sp.EntityID {
+				isAudience = true
+				break
+			}
+		}
+		if !isAudience {
+			return errors.New("invalid assertion audience")
+		}
+	}
+
+	return nil
+}
+
+// validateSubjectConfirmation validates that the assertion can be used by service provider as bearer
+func (sp ServiceProvider) validateSubjectConfirmation(subject *etree.Element, requestID string, now time.Time) error {
+	for _, subjectConfirmation := range childElements(subject, namespaceAssertion, "SubjectConfirmation") {
+		if attr(subjectConfirmation, "Method") != confirmationBearer {
+			continue
+		}
+		data := childElement(subjectConfirmation, namespaceAssertion, "SubjectConfirmationData")
+		if data == nil {
+			continue
+		}
+		if attr(data, "Recipient") != sp.AcsURL {
+			continue
+		}
+		if inResponseTo := attr(data, "InResponseTo"); inResponseTo != "" && inResponseTo != requestID {
+			continue
+		}
+		notOnOrAfter, err := time.Parse(time.RFC3339, attr(data, "NotOnOrAfter"))
+		if err != nil || !now.Add(-maxClockSkew).Before(notOnOrAfter) {
+			continue
+		}
+		return nil
+	}
+
+	return errors.New("invalid subject confirmation")
+}
+
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
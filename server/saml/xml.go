@@ -0,0 +1,74 @@
+package saml
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+// parseDocument parses xml document and returns its root element,
+// directives are rejected to prevent entity expansion attacks.
+func parseDocument(data []byte) (*etree.Element, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, err
+	}
+	for _, token := range doc.Child {
+		if _, ok := token.(*etree.Directive); ok {
+			return nil, errors.New("xml directives are not allowed")
+		}
+	}
+
+	root := doc.Root()
+	if root == nil {
+		return nil, errors.New("root element not found")
+	}
+	return root, nil
+}
+
+// isElement returns true if element has given namespace & local name
+func isElement(el *etree.Element, space, local string) bool {
+	return el.Tag == local && el.NamespaceURI() == space
+}
+
+// childElements returns the direct children with given namespace & local name
+func childElements(el *etree.Element, space, local string) []*etree.Element {
+	res := []*etree.Element{}
+	for _, child := range el.ChildElements() {
+		if isElement(child, space, local) {
+			res = append(res, child)
+		}
+	}
+	return res
+}
+
+// childElement returns the first direct child with given namespace & local name
+func childElement(el *etree.Element, space, local string) *etree.Element {
+	children := childElements(el, space, local)
+	if len(children) == 0 {
+		return nil
+	}
+	return children[0]
+}
+
+// attr returns the value of attribute without namespace
+func attr(el *etree.Element, local string) string {
+	for _, attribute := range el.Attr {
+		if attribute.Space == "" && attribute.Key == local {
+			return attribute.Value
+		}
+	}
+	return ""
+}
+
+// text returns the trimmed character data of element
+func text(el *etree.Element) string {
+	var buf strings.Builder
+	for _, child := range el.Child {
+		if charData, ok := child.(*etree.CharData); ok {
+			buf.WriteString(charData.Data)
+		}
+	}
+	return strings.TrimSpace(buf.String())
+}
@@ -0,0 +1,123 @@
+package saml
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"strings"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+)
+
+const (
+	namespaceXMLDSig = "http://www.w3.org/2000/09/xmldsig#"
+
+	algorithmRSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	algorithmRSASHA512 = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512"
+	algorithmSHA256    = "http://www.w3.org/2001/04/xmlenc#sha256"
+	algorithmSHA512    = "http://www.w3.org/2001/04/xmlenc#sha512"
+)
+
+var (
+	// sha1 based methods are not allowed even though they are supported by xmldsig library
+	supportedSignatureMethods = map[string]bool{
+		algorithmRSASHA256: true,
+		algorithmRSASHA512: true,
+	}
+	supportedDigestMethods = map[string]bool{
+		algorithmSHA256: true,
+		algorithmSHA512: true,
+	}
+)
+
+// ParseCertificate parses the certificate of identity provider,
+// it can be either PEM encoded or base64 encoded DER as present in the identity provider metadata
+func ParseCertificate(data string) (*x509.Certificate, error) {
+	data = strings.TrimSpace(data)
+	if data == "" {
+		return nil, errors.New("certificate is empty")
+	}
+
+	var der []byte
+	if block, _ := pem.Decode([]byte(data)); block != nil {
+		der = block.Bytes
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(data), ""))
+		if err != nil {
+			return nil, errors.New("invalid certificate encoding")
+		}
+		der = decoded
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := certificate.PublicKey.(*rsa.PublicKey); !ok {
+		return nil, errors.New("only rsa certificates are supported")
+	}
+
+	return certificate, nil
+}
+
+// hasSignature returns true if element contains an enveloped signature
+func hasSignature(el *etree.Element) bool {
+	return len(childElements(el, namespaceXMLDSig, "Signature")) > 0
+}
+
+// verifySignature verifies the enveloped signature of the element using the certificate of identity provider
+// and returns the verified copy of the element that should be used instead of the passed element.
+// Signature must reference the element that contains it, so that only the verified element is trusted
+// and signature wrapping attacks are not possible.
+func verifySignature(el *etree.Element, certificate *x509.Certificate) (*etree.Element, error) {
+	signatures := childElements(el, namespaceXMLDSig, "Signature")
+	if len(signatures) != 1 {
+		return nil, errors.New("expected exactly one signature")
+	}
+	signedInfo := childElement(signatures[0], namespaceXMLDSig, "SignedInfo")
+	if signedInfo == nil {
+		return nil, errors.New("signed info not found")
+	}
+
+	references := childElements(signedInfo, namespaceXMLDSig, "Reference")
+	if len(references) != 1 {
+		return nil, errors.New("expected exactly one reference")
+	}
+	id := attr(el, "ID")
+	if id == "" || attr(references[0], "URI") != "#"+id {
+		return nil, errors.New("signature does not reference the signed element")
+	}
+
+	signatureMethod := childElement(signedInfo, namespaceXMLDSig, "SignatureMethod")
+	if signatureMethod == nil || !supportedSignatureMethods[attr(signatureMethod, "Algorithm")] {
+		return nil, errors.New("unsupported signature method")
+	}
+	digestMethod := childElement(references[0], namespaceXMLDSig, "DigestMethod")
+	if digestMethod == nil || !supportedDigestMethods[attr(digestMethod, "Algorithm")] {
+		return nil, errors.New("unsupported digest method")
+	}
+
+	// namespaces declared on the ancestors are copied to the element, so that it can be canonicalized on its own
+	nsContext, err := etreeutils.NSBuildParentContext(el)
+	if err != nil {
+		return nil, err
+	}
+	detached, err := etreeutils.NSDetatch(nsContext, el)
+	if err != nil {
+		return nil, err
+	}
+
+	validationContext := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{
+		Roots: []*x509.Certificate{certificate},
+	})
+	return validationContext.Validate(detached)
+}
+
+// decodeBase64 decodes the base64 value that can contain white spaces
+func decodeBase64(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
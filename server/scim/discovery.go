@@ -0,0 +1,147 @@
+package scim
+
+import (
+	"fmt"
+)
+
+// ServiceProviderConfig returns the features supported by the scim endpoints,
+// https://datatracker.ietf.org/doc/html/rfc7643#section-5
+func ServiceProviderConfig(baseURL string) map[string]interface{} {
+	unsupported := map[string]interface{}{
+		"supported": false,
+	}
+	return map[string]interface{}{
+		"schemas": []string{schemaServiceProviderConfig},
+		"patch": map[string]interface{}{
+			"supported": true,
+		},
+		"bulk": map[string]interface{}{
+			"supported":      false,
+			"maxOperations":  0,
+			"maxPayloadSize": 0,
+		},
+		"filter": map[string]interface{}{
+			"supported":  true,
+			"maxResults": MaxCount,
+		},
+		"changePassword": map[string]interface{}{
+			"supported": true,
+		},
+		"sort": unsupported,
+		"etag": unsupported,
+		"authenticationSchemes": []map[string]interface{}{
+			{
+				"type":        "oauthbearertoken",
+				"name":        "Bearer Token",
+				"description": "Authentication with the bearer token configured with SCIM_TOKEN",
+				"primary":     true,
+			},
+		},
+		"meta": map[string]interface{}{
+			"resourceType": "ServiceProviderConfig",
+			"location":     baseURL + "/ServiceProviderConfig",
+		},
+	}
+}
+
+// ResourceTypes returns the user & group resource types
+func ResourceTypes(baseURL string) []map[string]interface{} {
+	resourceType := func(name, endpoint, schema string) map[string]interface{} {
+		return map[string]interface{}{
+			"schemas":     []string{schemaResourceType},
+			"id":          name,
+			"name":        name,
+			"endpoint":    endpoint,
+			"description": name + " account",
+			"schema":      schema,
+			"meta": map[string]interface{}{
+				"resourceType": "ResourceType",
+				"location":     fmt.Sprintf("%s/ResourceTypes/%s", baseURL, name),
+			},
+		}
+	}
+	return []map[string]interface{}{
+		resourceType("User", "/Users", SchemaUser),
+		resourceType("Group", "/Groups", SchemaGroup),
+	}
+}
+
+// attribute is the attribute definition of schema
+type attribute struct {
+	Name          string      `json:"name"`
+	Type          string      `json:"type"`
+	MultiValued   bool        `json:"multiValued"`
+	Required      bool        `json:"required"`
+	CaseExact     bool        `json:"caseExact"`
+	Mutability    string      `json:"mutability"`
+	Returned      string      `json:"returned"`
+	Uniqueness    string      `json:"uniqueness"`
+	SubAttributes []attribute `json:"subAttributes,omitempty"`
+}
+
+// newAttribute returns the optional, read write, single valued attribute
+func newAttribute(name, attributeType string, subAttributes ...attribute) attribute {
+	return attribute{
+		Name:          name,
+		Type:          attributeType,
+		Mutability:    "readWrite",
+		Returned:      "default",
+		Uniqueness:    "none",
+		SubAttributes: subAttributes,
+	}
+}
+
+// multiValued returns the multi-valued attribute with value, type & primary sub-attributes
+func multiValued(name string, mutability string) attribute {
+	res := newAttribute(name, "complex", newAttribute("value", "string"), newAttribute("display", "string"), newAttribute("type", "string"), newAttribute("primary", "boolean"))
+	res.MultiValued = true
+	res.Mutability = mutability
+	return res
+}
+
+// Schemas returns the schemas of user & group resources with the attributes supported by authorizer
+func Schemas(baseURL string) []map[string]interface{} {
+	userName := newAttribute("userName", "string")
+	userName.Required = true
+	userName.Uniqueness = "server"
+	password := newAttribute("password", "string")
+	password.Mutability = "writeOnly"
+	password.Returned = "never"
+	displayName := newAttribute("displayName", "string")
+	displayName.Mutability = "readOnly"
+	groupDisplayName := newAttribute("displayName", "string")
+	groupDisplayName.Required = true
+	groupDisplayName.Mutability = "readOnly"
+	groupDisplayName.Uniqueness = "server"
+
+	schema := func(id, name string, attributes []attribute) map[string]interface{} {
+		return map[string]interface{}{
+			"schemas":     []string{schemaSchema},
+			"id":          id,
+			"name":        name,
+			"description": name + " account",
+			"attributes":  attributes,
+			"meta": map[string]interface{}{
+				"resourceType": "Schema",
+				"location":     fmt.Sprintf("%s/Schemas/%s", baseURL, id),
+			},
+		}
+	}
+	return []map[string]interface{}{
+		schema(SchemaUser, "User", []attribute{
+			userName,
+			newAttribute("name", "complex", newAttribute("formatted", "string"), newAttribute("givenName", "string"), newAttribute("familyName", "string"), newAttribute("middleName", "string")),
+			displayName,
+			newAttribute("nickName", "string"),
+			password,
+			newAttribute("active", "boolean"),
+			multiValued("emails", "readWrite"),
+			multiValued("phoneNumbers", "readWrite"),
+			multiValued("groups", "readOnly"),
+		}),
+		schema(SchemaGroup, "Group", []attribute{
+			groupDisplayName,
+			multiValued("members", "readWrite"),
+		}),
+	}
+}
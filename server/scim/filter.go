@@ -0,0 +1,509 @@
+package scim
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter is the parsed filter of list requests, https://datatracker.ietf.org/doc/html/rfc7644#section-3.4.2.2
+// Filters are evaluated on the json representation of resources, so any attribute of resource can be filtered
+type Filter struct {
+	expr expression
+}
+
+// ParseFilter parses the filter, nil filter is returned for empty filter
+func ParseFilter(filter string) (*Filter, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, nil
+	}
+	expr, err := parseExpression(filter)
+	if err != nil {
+		return nil, err
+	}
+	return &Filter{
+		expr: expr,
+	}, nil
+}
+
+// Matches returns true if resource matches the filter, nil filter matches all the resources
+func (f *Filter) Matches(resource interface{}) bool {
+	if f == nil {
+		return true
+	}
+	data, err := toMap(resource)
+	if err != nil {
+		return false
+	}
+	return f.expr.matches(data)
+}
+
+// EqualValue returns the value of filter if it is `attr eq "value"` filter,
+// it is used to lookup the resource directly instead of evaluating filter on all the resources
+func (f *Filter) EqualValue(attr string) (string, bool) {
+	if f == nil {
+		return "", false
+	}
+	comparison, ok := f.expr.(*comparisonExpression)
+	if !ok || comparison.op != "eq" || comparison.path.sub != "" || !strings.EqualFold(comparison.path.attr, attr) {
+		return "", false
+	}
+	value, ok := comparison.value.(string)
+	return value, ok
+}
+
+// expression is the node of parsed filter
+type expression interface {
+	matches(resource map[string]interface{}) bool
+}
+
+type logicalExpression struct {
+	op          string
+	left, right expression
+}
+
+func (e *logicalExpression) matches(resource map[string]interface{}) bool {
+	if e.op == "and" {
+		return e.left.matches(resource) && e.right.matches(resource)
+	}
+	return e.left.matches(resource) || e.right.matches(resource)
+}
+
+type notExpression struct {
+	expr expression
+}
+
+func (e *notExpression) matches(resource map[string]interface{}) bool {
+	return !e.expr.matches(resource)
+}
+
+// valuePathExpression matches the resources having a value of multi-valued attribute matching the filter,
+// for example emails[type eq "work" and value co "@example.com"]
+type valuePathExpression struct {
+	path attributePath
+	expr expression
+}
+
+func (e *valuePathExpression) matches(resource map[string]interface{}) bool {
+	for _, value := range e.path.values(resource) {
+		if element, ok := value.(map[string]interface{}); ok && e.expr.matches(element) {
+			return true
+		}
+	}
+	return false
+}
+
+type comparisonExpression struct {
+	path attributePath
+	op   string
+	// value is string, bool, float64 or nil
+	value interface{}
+}
+
+func (e *comparisonExpression) matches(resource map[string]interface{}) bool {
+	values := e.path.values(resource)
+	switch e.op {
+	case "pr":
+		return len(values) > 0
+	case "ne":
+		if e.value == nil {
+			return len(values) > 0
+		}
+		for _, value := range values {
+			if compareValue(value, "eq", e.value) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if e.value == nil {
+		return e.op == "eq" && len(values) == 0
+	}
+	for _, value := range values {
+		if compareValue(value, e.op, e.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareValue compares the attribute value with the value of filter, strings are compared case insensitive
+func compareValue(actual interface{}, op string, expected interface{}) bool {
+	switch expectedValue := expected.(type) {
+	case string:
+		actualValue, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		a, b := strings.ToLower(actualValue), strings.ToLower(expectedValue)
+		switch op {
+		case "eq":
+			return a == b
+		case "co":
+			return strings.Contains(a, b)
+		case "sw":
+			return strings.HasPrefix(a, b)
+		case "ew":
+			return strings.HasSuffix(a, b)
+		case "gt":
+			return a > b
+		case "ge":
+			return a >= b
+		case "lt":
+			return a < b
+		case "le":
+			return a <= b
+		}
+	case bool:
+		actualValue, ok := actual.(bool)
+		return ok && op == "eq" && actualValue == expectedValue
+	case float64:
+		actualValue, ok := actual.(float64)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "eq":
+			return actualValue == expectedValue
+		case "gt":
+			return actualValue > expectedValue
+		case "ge":
+			return actualValue >= expectedValue
+		case "lt":
+			return actualValue < expectedValue
+		case "le":
+			return actualValue <= expectedValue
+		}
+	}
+	return false
+}
+
+// attributePath is the attribute with optional sub-attribute, for example name.givenName
+type attributePath struct {
+	attr string
+	sub  string
+}
+
+// parseAttributePath parses the attribute path, the schema urn prefix of core schemas is optional.
+// Attributes of other schemas are kept as is, they are not part of the resources
+func parseAttributePath(path string) attributePath {
+	for _, schema := range []string{SchemaUser, SchemaGroup} {
+		if len(path) > len(schema) && strings.EqualFold(path[:len(schema)+1], schema+":") {
+			path = path[len(schema)+1:]
+		}
+	}
+	if strings.HasPrefix(strings.ToLower(path), "urn:") {
+		return attributePath{
+			attr: path,
+		}
+	}
+
+	parts := strings.SplitN(path, ".", 2)
+	res := attributePath{
+		attr: parts[0],
+	}
+	if len(parts) == 2 {
+		res.sub = parts[1]
+	}
+	return res
+}
+
+// values returns the values of attribute path in resource, values of multi-valued attributes are flattened.
+// The value sub-attribute is used for multi-valued attributes without sub-attribute
+func (p attributePath) values(resource map[string]interface{}) []interface{} {
+	value, _, ok := lookup(resource, p.attr)
+	if !ok || value == nil {
+		return nil
+	}
+
+	var res []interface{}
+	appendValue := func(value interface{}, sub string) {
+		if sub == "" {
+			if value != nil {
+				res = append(res, value)
+			}
+			return
+		}
+		if object, ok := value.(map[string]interface{}); ok {
+			if subValue, _, ok := lookup(object, sub); ok && subValue != nil {
+				res = append(res, subValue)
+			}
+		}
+	}
+
+	if elements, ok := value.([]interface{}); ok {
+		for _, element := range elements {
+			if _, ok := element.(map[string]interface{}); ok && p.sub == "" {
+				appendValue(element, "value")
+				continue
+			}
+			appendValue(element, p.sub)
+		}
+		return res
+	}
+	appendValue(value, p.sub)
+	return res
+}
+
+// lookup returns the value & key of attribute in object, attribute names are case insensitive
+func lookup(object map[string]interface{}, attr string) (interface{}, string, bool) {
+	if value, ok := object[attr]; ok {
+		return value, attr, true
+	}
+	for key, value := range object {
+		if strings.EqualFold(key, attr) {
+			return value, key, true
+		}
+	}
+	return nil, attr, false
+}
+
+// filter tokens
+const (
+	tokenWord = iota
+	tokenString
+	tokenLeftParen
+	tokenRightParen
+	tokenLeftBracket
+	tokenRightBracket
+)
+
+type token struct {
+	kind  int
+	value string
+}
+
+var comparisonOperators = map[string]bool{
+	"eq": true,
+	"ne": true,
+	"co": true,
+	"sw": true,
+	"ew": true,
+	"gt": true,
+	"ge": true,
+	"lt": true,
+	"le": true,
+}
+
+func invalidFilterError(format string, args ...interface{}) *Error {
+	return NewError(http.StatusBadRequest, ErrorTypeInvalidFilter, fmt.Sprintf(format, args...))
+}
+
+// tokenize splits the filter into words, quoted strings, parentheses & brackets
+func tokenize(filter string) ([]token, error) {
+	var tokens []token
+	runes := []rune(filter)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen})
+			i++
+		case r == '[':
+			tokens = append(tokens, token{kind: tokenLeftBracket})
+			i++
+		case r == ']':
+			tokens = append(tokens, token{kind: tokenRightBracket})
+			i++
+		case r == '"':
+			// strings are json strings, escaped quotes are skipped while finding the end
+			end := i + 1
+			for ; end < len(runes) && runes[end] != '"'; end++ {
+				if runes[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(runes) {
+				return nil, invalidFilterError("unterminated string in filter")
+			}
+			value, err := strconv.Unquote(string(runes[i : end+1]))
+			if err != nil {
+				return nil, invalidFilterError("invalid string %s in filter", string(runes[i:end+1]))
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value})
+			i = end + 1
+		default:
+			end := i
+			for ; end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`()[]"`, runes[end]); end++ {
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: string(runes[i:end])})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// parser is the recursive descent parser of filters
+type parser struct {
+	tokens   []token
+	position int
+}
+
+func parseExpression(filter string) (expression, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{
+		tokens: tokens,
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.position < len(p.tokens) {
+		return nil, invalidFilterError("unexpected token at position %d in filter", p.position+1)
+	}
+	return expr, nil
+}
+
+func (p *parser) peek() *token {
+	if p.position < len(p.tokens) {
+		return &p.tokens[p.position]
+	}
+	return nil
+}
+
+func (p *parser) next() *token {
+	t := p.peek()
+	if t != nil {
+		p.position++
+	}
+	return t
+}
+
+// peekKeyword returns true if the next token is the keyword, keywords are case insensitive
+func (p *parser) peekKeyword(keyword string) bool {
+	t := p.peek()
+	return t != nil && t.kind == tokenWord && strings.EqualFold(t.value, keyword)
+}
+
+func (p *parser) expect(kind int, name string) error {
+	t := p.next()
+	if t == nil || t.kind != kind {
+		return invalidFilterError("%s is expected in filter", name)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpression{op: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpression{op: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (expression, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, invalidFilterError("unexpected end of filter")
+	}
+
+	isNot := p.peekKeyword("not") && p.position+1 < len(p.tokens) && p.tokens[p.position+1].kind == tokenLeftParen
+	if isNot {
+		p.next()
+	}
+	if isNot || t.kind == tokenLeftParen {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRightParen, ")"); err != nil {
+			return nil, err
+		}
+		if isNot {
+			return &notExpression{expr: expr}, nil
+		}
+		return expr, nil
+	}
+	return p.parseAttributeExpression()
+}
+
+func (p *parser) parseAttributeExpression() (expression, error) {
+	t := p.next()
+	if t.kind != tokenWord {
+		return nil, invalidFilterError("attribute is expected in filter")
+	}
+	path := parseAttributePath(t.value)
+
+	if next := p.peek(); next != nil && next.kind == tokenLeftBracket {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRightBracket, "]"); err != nil {
+			return nil, err
+		}
+		return &valuePathExpression{path: path, expr: expr}, nil
+	}
+
+	op := p.next()
+	if op == nil || op.kind != tokenWord {
+		return nil, invalidFilterError("operator is expected after %s in filter", t.value)
+	}
+	operator := strings.ToLower(op.value)
+	if operator == "pr" {
+		return &comparisonExpression{path: path, op: operator}, nil
+	}
+	if !comparisonOperators[operator] {
+		return nil, invalidFilterError("invalid operator %s in filter", op.value)
+	}
+
+	value := p.next()
+	if value == nil {
+		return nil, invalidFilterError("value is expected after %s in filter", op.value)
+	}
+	res := &comparisonExpression{path: path, op: operator}
+	switch {
+	case value.kind == tokenString:
+		res.value = value.value
+	case value.kind != tokenWord:
+		return nil, invalidFilterError("value is expected after %s in filter", op.value)
+	case strings.EqualFold(value.value, "true"), strings.EqualFold(value.value, "false"):
+		res.value = strings.EqualFold(value.value, "true")
+	case strings.EqualFold(value.value, "null"):
+		res.value = nil
+	default:
+		number, err := strconv.ParseFloat(value.value, 64)
+		if err != nil {
+			return nil, invalidFilterError("invalid value %s in filter", value.value)
+		}
+		res.value = number
+	}
+	return res, nil
+}
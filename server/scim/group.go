@@ -0,0 +1,54 @@
+package scim
+
+import (
+	"fmt"
+)
+
+// Group is the scim group resource. Groups are the roles configured with ROLES & PROTECTED_ROLES,
+// id & displayName of group are the role & members are the users having the role
+type Group struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []MultiValue `json:"members,omitempty"`
+	Meta        *Meta        `json:"meta,omitempty"`
+}
+
+// GroupMember is the user having the role of group
+type GroupMember struct {
+	ID    string
+	Email string
+}
+
+// NewGroup returns the group resource of role with its members, baseURL is the url of scim endpoints
+func NewGroup(role string, members []GroupMember, baseURL string) Group {
+	res := Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          role,
+		DisplayName: role,
+		Meta: &Meta{
+			ResourceType: "Group",
+			Location:     fmt.Sprintf("%s/Groups/%s", baseURL, role),
+		},
+	}
+	for _, member := range members {
+		res.Members = append(res.Members, MultiValue{
+			Value:   member.ID,
+			Display: member.Email,
+			Type:    "User",
+			Ref:     fmt.Sprintf("%s/Users/%s", baseURL, member.ID),
+		})
+	}
+	return res
+}
+
+// MemberIDs returns the ids of users in members of group
+func (g *Group) MemberIDs() map[string]bool {
+	res := map[string]bool{}
+	for _, member := range g.Members {
+		if member.Value != "" {
+			res[member.Value] = true
+		}
+	}
+	return res
+}
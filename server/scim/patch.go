@@ -0,0 +1,266 @@
+package scim
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// PatchRequest is the body of patch requests, https://datatracker.ietf.org/doc/html/rfc7644#section-3.5.2
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is the add, replace or remove operation of patch request
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Validate validates the schema & operations of patch request
+func (r *PatchRequest) Validate() error {
+	hasSchema := false
+	for _, schema := range r.Schemas {
+		hasSchema = hasSchema || schema == SchemaPatchOp
+	}
+	if !hasSchema {
+		return NewError(http.StatusBadRequest, ErrorTypeInvalidSyntax, fmt.Sprintf("schemas must contain %s", SchemaPatchOp))
+	}
+	if len(r.Operations) == 0 {
+		return NewError(http.StatusBadRequest, ErrorTypeInvalidSyntax, "operations are required")
+	}
+	for _, operation := range r.Operations {
+		switch strings.ToLower(operation.Op) {
+		case "add", "replace":
+		case "remove":
+			if operation.Path == "" {
+				return NewError(http.StatusBadRequest, ErrorTypeNoTarget, "path is required for remove operation")
+			}
+		default:
+			return NewError(http.StatusBadRequest, ErrorTypeInvalidSyntax, fmt.Sprintf("invalid operation %s", operation.Op))
+		}
+	}
+	return nil
+}
+
+// Apply applies the operations on resource, resource must be pointer to User or Group.
+// Operations are applied on the json representation of resource, so read only attributes
+// & attributes that are not part of the resource are ignored while decoding it back
+func (r *PatchRequest) Apply(resource interface{}) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	data, err := toMap(resource)
+	if err != nil {
+		return err
+	}
+
+	for _, operation := range r.Operations {
+		op := strings.ToLower(operation.Op)
+		if operation.Path != "" {
+			if err := applyOperation(data, op, operation.Path, operation.Value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// attributes of value are the paths of operation, azure ad uses paths like name.givenName as the keys
+		values, ok := operation.Value.(map[string]interface{})
+		if !ok {
+			return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, "value must be an object if path is not set")
+		}
+		for path, value := range values {
+			if err := applyOperation(data, op, path, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	// attributes removed by the operations must not be kept while decoding
+	value := reflect.ValueOf(resource).Elem()
+	value.Set(reflect.Zero(value.Type()))
+	return fromMap(data, resource)
+}
+
+// applyOperation applies the operation on the attribute path with optional value filter,
+// for example emails[type eq "work"].value
+func applyOperation(data map[string]interface{}, op, path string, value interface{}) error {
+	var valueFilter expression
+	var attrPath attributePath
+	if start := strings.Index(path, "["); start != -1 {
+		end := strings.LastIndex(path, "]")
+		if end < start {
+			return NewError(http.StatusBadRequest, ErrorTypeInvalidPath, fmt.Sprintf("invalid path %s", path))
+		}
+		expr, err := parseExpression(path[start+1 : end])
+		if err != nil {
+			return NewError(http.StatusBadRequest, ErrorTypeInvalidPath, fmt.Sprintf("invalid path %s", path))
+		}
+		valueFilter = expr
+		attrPath = parseAttributePath(path[:start])
+		if rest := path[end+1:]; rest != "" {
+			if !strings.HasPrefix(rest, ".") || attrPath.sub != "" {
+				return NewError(http.StatusBadRequest, ErrorTypeInvalidPath, fmt.Sprintf("invalid path %s", path))
+			}
+			attrPath.sub = rest[1:]
+		}
+	} else {
+		attrPath = parseAttributePath(path)
+	}
+
+	existing, key, _ := lookup(data, attrPath.attr)
+	if valueFilter != nil {
+		return applyFilteredOperation(data, key, existing, op, valueFilter, attrPath.sub, value)
+	}
+
+	if attrPath.sub == "" {
+		data[key] = operationResult(existing, op, value)
+		if data[key] == nil {
+			delete(data, key)
+		}
+		return nil
+	}
+
+	// sub-attribute of complex attribute or of all the values of multi-valued attribute
+	setSub := func(object map[string]interface{}) {
+		subValue, subKey, _ := lookup(object, attrPath.sub)
+		object[subKey] = operationResult(subValue, op, value)
+		if object[subKey] == nil {
+			delete(object, subKey)
+		}
+	}
+	switch target := existing.(type) {
+	case []interface{}:
+		for _, element := range target {
+			if object, ok := element.(map[string]interface{}); ok {
+				setSub(object)
+			}
+		}
+	case map[string]interface{}:
+		setSub(target)
+	default:
+		if op != "remove" {
+			object := map[string]interface{}{}
+			setSub(object)
+			data[key] = object
+		}
+	}
+	return nil
+}
+
+// operationResult returns the new value of attribute after the operation, nil value removes the attribute.
+// Values are appended to multi-valued attributes by add & sub-attributes of complex attributes are merged
+func operationResult(existing interface{}, op string, value interface{}) interface{} {
+	switch op {
+	case "remove":
+		// values of multi-valued attribute are removed if value is set, azure ad removes members this way
+		elements, isArray := existing.([]interface{})
+		removed, hasValues := value.([]interface{})
+		if !isArray || !hasValues {
+			return nil
+		}
+		var res []interface{}
+		for _, element := range elements {
+			if !containsValue(removed, element) {
+				res = append(res, element)
+			}
+		}
+		return res
+	case "add":
+		if elements, ok := existing.([]interface{}); ok {
+			if values, ok := value.([]interface{}); ok {
+				return append(elements, values...)
+			}
+			return append(elements, value)
+		}
+	}
+
+	existingObject, isObject := existing.(map[string]interface{})
+	valueObject, isValueObject := value.(map[string]interface{})
+	if isObject && isValueObject {
+		for k, v := range valueObject {
+			_, key, _ := lookup(existingObject, k)
+			existingObject[key] = v
+		}
+		return existingObject
+	}
+	return value
+}
+
+// containsValue returns true if values contain an element with the same value sub-attribute as element
+func containsValue(values []interface{}, element interface{}) bool {
+	elementObject, ok := element.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	elementValue, _, _ := lookup(elementObject, "value")
+	for _, value := range values {
+		if object, ok := value.(map[string]interface{}); ok {
+			if v, _, _ := lookup(object, "value"); v != nil && fmt.Sprint(v) == fmt.Sprint(elementValue) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyFilteredOperation applies the operation on the values of multi-valued attribute matching the filter.
+// If no value matches `attr eq "value"` filter for add & replace, the value is added with the attribute of filter
+func applyFilteredOperation(data map[string]interface{}, key string, existing interface{}, op string, valueFilter expression, sub string, value interface{}) error {
+	elements, _ := existing.([]interface{})
+	var res []interface{}
+	matched := false
+	for _, element := range elements {
+		object, ok := element.(map[string]interface{})
+		if !ok || !valueFilter.matches(object) {
+			res = append(res, element)
+			continue
+		}
+		matched = true
+
+		if sub == "" {
+			if op != "remove" {
+				res = append(res, operationResult(object, op, value))
+			}
+			continue
+		}
+		subValue, subKey, _ := lookup(object, sub)
+		object[subKey] = operationResult(subValue, op, value)
+		if object[subKey] == nil {
+			delete(object, subKey)
+		}
+		res = append(res, object)
+	}
+
+	if !matched && op != "remove" {
+		comparison, ok := valueFilter.(*comparisonExpression)
+		if !ok || comparison.op != "eq" || comparison.path.sub != "" {
+			return NewError(http.StatusBadRequest, ErrorTypeNoTarget, "no value matches the path filter")
+		}
+		object := map[string]interface{}{
+			comparison.path.attr: comparison.value,
+		}
+		if sub == "" {
+			valueObject, ok := value.(map[string]interface{})
+			if !ok {
+				return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, "value must be an object")
+			}
+			for k, v := range valueObject {
+				object[k] = v
+			}
+		} else {
+			object[sub] = value
+		}
+		res = append(res, object)
+	}
+
+	if len(res) == 0 {
+		delete(data, key)
+		return nil
+	}
+	data[key] = res
+	return nil
+}
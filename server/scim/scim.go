@@ -0,0 +1,204 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ContentType is the media type of scim requests & responses
+	ContentType = "application/scim+json"
+
+	// SchemaUser is the core schema of user resource
+	SchemaUser = "urn:ietf:params:scim:schemas:core:2.0:User"
+	// SchemaGroup is the core schema of group resource
+	SchemaGroup = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	// SchemaListResponse is the schema of list & query responses
+	SchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	// SchemaPatchOp is the schema of patch requests
+	SchemaPatchOp = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	// SchemaError is the schema of error responses
+	SchemaError = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	schemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+
+	// DefaultCount is the number of resources returned by list requests without count
+	DefaultCount = 100
+	// MaxCount is the maximum number of resources returned by list requests
+	MaxCount = 200
+)
+
+// scimType values of errors, https://datatracker.ietf.org/doc/html/rfc7644#section-3.12
+const (
+	ErrorTypeInvalidFilter = "invalidFilter"
+	ErrorTypeUniqueness    = "uniqueness"
+	ErrorTypeMutability    = "mutability"
+	ErrorTypeInvalidSyntax = "invalidSyntax"
+	ErrorTypeInvalidPath   = "invalidPath"
+	ErrorTypeNoTarget      = "noTarget"
+	ErrorTypeInvalidValue  = "invalidValue"
+)
+
+// Error is the scim error response, it is also used as the error returned by the functions of package
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+// StatusCode returns the http status code of error
+func (e *Error) StatusCode() int {
+	status, err := strconv.Atoi(e.Status)
+	if err != nil {
+		return http.StatusInternalServerError
+	}
+	return status
+}
+
+// NewError returns the scim error with status code, scimType is optional
+func NewError(status int, scimType string, detail string) *Error {
+	return &Error{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	}
+}
+
+// AsError returns the scim error of err, errors that are not scim errors are internal server errors
+func AsError(err error) *Error {
+	if scimErr, ok := err.(*Error); ok {
+		return scimErr
+	}
+	return NewError(http.StatusInternalServerError, "", err.Error())
+}
+
+// ListResponse is the response of list & query requests
+type ListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// NewListResponse returns the list response of resources on the page starting at 1-based startIndex
+func NewListResponse(resources interface{}, totalResults, startIndex, itemsPerPage int) ListResponse {
+	return ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: totalResults,
+		StartIndex:   startIndex,
+		ItemsPerPage: itemsPerPage,
+		Resources:    resources,
+	}
+}
+
+// ParsePage parses the 1-based startIndex & count query params of list requests,
+// invalid values are replaced with the defaults as recommended by rfc
+func ParsePage(startIndex, count string) (int, int) {
+	start, err := strconv.Atoi(startIndex)
+	if err != nil || start < 1 {
+		start = 1
+	}
+	size, err := strconv.Atoi(count)
+	if err != nil {
+		size = DefaultCount
+	}
+	if size < 0 {
+		size = 0
+	}
+	if size > MaxCount {
+		size = MaxCount
+	}
+	return start, size
+}
+
+// Boolean is the boolean attribute of resources, some identity providers like azure ad
+// send boolean values as "True" & "False" strings
+type Boolean bool
+
+// UnmarshalJSON decodes boolean from json boolean or string
+func (b *Boolean) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case bool:
+		*b = Boolean(v)
+	case string:
+		parsed, err := strconv.ParseBool(strings.ToLower(v))
+		if err != nil {
+			return fmt.Errorf("invalid boolean %s", v)
+		}
+		*b = Boolean(parsed)
+	case nil:
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", string(data))
+	}
+	return nil
+}
+
+// MultiValue is the value of multi-valued attributes like emails, phoneNumbers, groups & members
+type MultiValue struct {
+	Value   string  `json:"value"`
+	Display string  `json:"display,omitempty"`
+	Type    string  `json:"type,omitempty"`
+	Primary Boolean `json:"primary,omitempty"`
+	Ref     string  `json:"$ref,omitempty"`
+}
+
+// primaryValue returns the value marked as primary or the first value
+func primaryValue(values []MultiValue) string {
+	for _, value := range values {
+		if value.Primary {
+			return value.Value
+		}
+	}
+	if len(values) > 0 {
+		return values[0].Value
+	}
+	return ""
+}
+
+// Meta is the metadata of resources
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+// toMap returns the json object of resource, it is used to evaluate filters & patch operations
+func toMap(resource interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]interface{}{}
+	err = json.Unmarshal(data, &res)
+	return res, err
+}
+
+// fromMap decodes the json object of resource into resource
+func fromMap(data map[string]interface{}, resource interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(encoded, resource); err != nil {
+		return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package scim
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/validators"
+)
+
+// User is the scim user resource. userName is the email of user & groups are the roles of user
+type User struct {
+	Schemas      []string     `json:"schemas"`
+	ID           string       `json:"id,omitempty"`
+	UserName     string       `json:"userName"`
+	Name         *Name        `json:"name,omitempty"`
+	DisplayName  string       `json:"displayName,omitempty"`
+	NickName     string       `json:"nickName,omitempty"`
+	Password     string       `json:"password,omitempty"`
+	Active       *Boolean     `json:"active,omitempty"`
+	Emails       []MultiValue `json:"emails,omitempty"`
+	PhoneNumbers []MultiValue `json:"phoneNumbers,omitempty"`
+	Groups       []MultiValue `json:"groups,omitempty"`
+	Meta         *Meta        `json:"meta,omitempty"`
+}
+
+// Name is the name of user resource
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	MiddleName string `json:"middleName,omitempty"`
+}
+
+// NewUser returns the user resource of user, baseURL is the url of scim endpoints
+func NewUser(user *model.User, baseURL string) User {
+	active := Boolean(user.RevokedTimestamp == nil)
+	res := User{
+		Schemas:  []string{SchemaUser},
+		ID:       user.ID,
+		UserName: user.Email,
+		NickName: refs.StringValue(user.Nickname),
+		Active:   &active,
+		Emails: []MultiValue{
+			{
+				Value:   user.Email,
+				Type:    "work",
+				Primary: true,
+			},
+		},
+		Meta: &Meta{
+			ResourceType: "User",
+			Created:      formatTime(user.CreatedAt),
+			LastModified: formatTime(user.UpdatedAt),
+			Location:     fmt.Sprintf("%s/Users/%s", baseURL, user.ID),
+		},
+	}
+
+	name := Name{
+		GivenName:  refs.StringValue(user.GivenName),
+		FamilyName: refs.StringValue(user.FamilyName),
+		MiddleName: refs.StringValue(user.MiddleName),
+	}
+	name.Formatted = strings.Join(strings.Fields(strings.Join([]string{name.GivenName, name.MiddleName, name.FamilyName}, " ")), " ")
+	if name.Formatted != "" {
+		res.Name = &name
+		res.DisplayName = name.Formatted
+	}
+
+	if phoneNumber := refs.StringValue(user.PhoneNumber); phoneNumber != "" {
+		res.PhoneNumbers = []MultiValue{
+			{
+				Value:   phoneNumber,
+				Type:    "mobile",
+				Primary: true,
+			},
+		}
+	}
+
+	for _, role := range user.Roles {
+		if role == "" {
+			continue
+		}
+		res.Groups = append(res.Groups, MultiValue{
+			Value:   role,
+			Display: role,
+			Ref:     fmt.Sprintf("%s/Groups/%s", baseURL, role),
+		})
+	}
+	return res
+}
+
+// formatTime formats the unix timestamp of meta in RFC 3339 format
+func formatTime(timestamp *int64) string {
+	if timestamp == nil || *timestamp == 0 {
+		return ""
+	}
+	return time.Unix(*timestamp, 0).UTC().Format(time.RFC3339)
+}
+
+// Email returns the email of user resource, userName is used if it is an email else the primary email
+func (u *User) Email() string {
+	email := strings.ToLower(strings.TrimSpace(u.UserName))
+	if !validators.IsValidEmail(email) {
+		email = strings.ToLower(strings.TrimSpace(primaryValue(u.Emails)))
+	}
+	return email
+}
+
+// ApplyTo replaces the attributes of user with the attributes of resource.
+// Groups are read only, roles are updated by the group requests
+func (u *User) ApplyTo(user *models.User) error {
+	email := u.Email()
+	if !validators.IsValidEmail(email) {
+		return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, "userName or emails must contain a valid email address")
+	}
+	// email is verified by the identity provider of organization
+	if user.Email != email || user.EmailVerifiedAt == nil {
+		user.Email = email
+		user.EmailVerifiedAt = refs.NewInt64Ref(time.Now().Unix())
+	}
+
+	name := Name{}
+	if u.Name != nil {
+		name = *u.Name
+	}
+	user.GivenName = optionalString(name.GivenName)
+	user.FamilyName = optionalString(name.FamilyName)
+	user.MiddleName = optionalString(name.MiddleName)
+	user.Nickname = optionalString(u.NickName)
+
+	phoneNumber := optionalString(strings.TrimSpace(primaryValue(u.PhoneNumbers)))
+	if phoneNumber != nil && !validators.IsValidPhoneNumber(*phoneNumber) {
+		return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, "invalid phone number")
+	}
+	if refs.StringValue(phoneNumber) != refs.StringValue(user.PhoneNumber) {
+		user.PhoneNumber = phoneNumber
+		user.PhoneNumberVerifiedAt = nil
+	}
+
+	if u.Password != "" {
+		if err := validators.IsValidPassword(u.Password); err != nil {
+			return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, err.Error())
+		}
+		password, err := crypto.EncryptPassword(u.Password)
+		if err != nil {
+			return err
+		}
+		user.Password = &password
+		if !strings.Contains(user.SignupMethods, constants.AuthRecipeMethodBasicAuth) {
+			user.SignupMethods = strings.Trim(user.SignupMethods+","+constants.AuthRecipeMethodBasicAuth, ",")
+		}
+	}
+	if user.SignupMethods == "" {
+		user.SignupMethods = constants.AuthRecipeMethodBasicAuth
+	}
+
+	// users are active unless active is set to false
+	isActive := u.Active == nil || bool(*u.Active)
+	if !isActive && user.RevokedTimestamp == nil {
+		user.RevokedTimestamp = refs.NewInt64Ref(time.Now().Unix())
+	}
+	if isActive {
+		user.RevokedTimestamp = nil
+	}
+	return nil
+}
+
+// optionalString returns nil for empty string
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return refs.NewStringRef(value)
+}
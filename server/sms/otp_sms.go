@@ -0,0 +1,19 @@
+package sms
+
+import (
+	"fmt"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// SendOtpSMS sends the otp to given phone number
+func SendOtpSMS(phoneNumber, otp string) error {
+	organizationName, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyOrganizationName)
+	if err != nil || organizationName == "" {
+		organizationName = "Authorizer"
+	}
+
+	message := fmt.Sprintf("%s is your %s verification code. It expires in %d minutes.", otp, organizationName, int(constants.OtpExpiry.Minutes()))
+	return SendSMS(phoneNumber, message)
+}
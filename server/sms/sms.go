@@ -0,0 +1,98 @@
+package sms
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// Sender is the interface that should be implemented by sms providers
+type Sender interface {
+	// SendSMS sends the message to given phone number
+	SendSMS(to, message string) error
+}
+
+var (
+	sendersMutex sync.RWMutex
+	senders      = map[string]Sender{
+		constants.SmsProviderLog:  &logSender{},
+		constants.SmsProviderFile: &fileSender{},
+	}
+)
+
+// RegisterSender registers sms sender for the provider name,
+// provider is selected using SMS_PROVIDER env variable
+func RegisterSender(provider string, sender Sender) {
+	sendersMutex.Lock()
+	defer sendersMutex.Unlock()
+	senders[provider] = sender
+}
+
+// IsSmsServiceEnabled returns true if sender is configured for SMS_PROVIDER
+func IsSmsServiceEnabled() bool {
+	_, err := getSender()
+	return err == nil
+}
+
+// SendSMS sends sms using the sender configured with SMS_PROVIDER env variable
+func SendSMS(to, message string) error {
+	sender, err := getSender()
+	if err != nil {
+		return err
+	}
+
+	return sender.SendSMS(to, message)
+}
+
+func getSender() (Sender, error) {
+	provider, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeySmsProvider)
+	if err != nil || provider == "" {
+		return nil, errors.New("sms service is not enabled")
+	}
+
+	sendersMutex.RLock()
+	defer sendersMutex.RUnlock()
+	sender, ok := senders[provider]
+	if !ok {
+		return nil, fmt.Errorf("sms provider %s is not supported", provider)
+	}
+	return sender, nil
+}
+
+// logSender writes sms to the logs
+type logSender struct{}
+
+func (s *logSender) SendSMS(to, message string) error {
+	log.WithField("to", to).Info("SMS: ", message)
+	return nil
+}
+
+// fileSender appends sms to the file set in SMS_LOG_FILE env variable
+type fileSender struct {
+	mutex sync.Mutex
+}
+
+func (s *fileSender) SendSMS(to, message string) error {
+	filePath, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeySmsLogFile)
+	if err != nil || filePath == "" {
+		return errors.New("sms log file is not set")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "%s\t%s\t%s\n", time.Now().UTC().Format(time.RFC3339), to, message)
+	return err
+}
@@ -0,0 +1,116 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+)
+
+func authorizePromptTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should honor prompt, max_age and login_hint`, func(t *testing.T) {
+		clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+		assert.NoError(t, err)
+
+		_, ctx := createContext(s)
+		email := "authorize_prompt." + s.TestInfo.Email
+		user, err := db.Provider.AddUser(ctx, models.User{
+			Email:         email,
+			SignupMethods: constants.AuthRecipeMethodBasicAuth,
+			Roles:         "user",
+		})
+		assert.NoError(t, err)
+		defer db.Provider.DeleteUser(ctx, user)
+		defer memorystore.Provider.DeleteAllUserSessions(user.ID)
+
+		// user authenticated an hour ago
+		authTime := time.Now().Add(-time.Hour).Unix()
+		nonce := uuid.New().String()
+		_, sessionToken, err := token.CreateSessionToken(user, nonce, []string{"user"}, []string{"openid", "email"}, constants.AuthRecipeMethodBasicAuth, token.AuthenticationInfo{
+			AuthTime: authTime,
+			AMR:      []string{constants.AMRPassword},
+		})
+		assert.NoError(t, err)
+		sessionKey := constants.AuthRecipeMethodBasicAuth + ":" + user.ID
+		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+nonce, sessionToken)
+		sessionCookie := url.QueryEscape(sessionToken)
+
+		// session is rolled over with every authorization, so the latest session cookie is used
+		authorize := func(query string) *url.URL {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/authorize?client_id="+clientID+"&state=test-state&redirect_uri=http://localhost:3000/callback&response_type=id_token&nonce=test-nonce&"+query, nil)
+			c.Request.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+			if sessionCookie != "" {
+				c.Request.AddCookie(&http.Cookie{Name: constants.AppCookieName + "_session", Value: sessionCookie})
+			}
+			handlers.AuthorizeHandler()(c)
+			for _, cookie := range w.Result().Cookies() {
+				if cookie.Name == constants.AppCookieName+"_session" {
+					sessionCookie = cookie.Value
+				}
+			}
+			assert.Equal(t, http.StatusFound, w.Code)
+			location, err := url.Parse(w.Header().Get("Location"))
+			assert.NoError(t, err)
+			return location
+		}
+		getFragment := func(location *url.URL) url.Values {
+			values, err := url.ParseQuery(location.Fragment)
+			assert.NoError(t, err)
+			return values
+		}
+		assertLoginRequired := func(location *url.URL) {
+			fragment := getFragment(location)
+			assert.Equal(t, "login_required", fragment.Get("error"))
+			assert.Equal(t, "test-state", fragment.Get("state"))
+		}
+
+		// authentication info of session is carried over to the id token
+		location := authorize("prompt=none&max_age=7200&login_hint=" + url.QueryEscape(email))
+		idToken := getFragment(location).Get("id_token")
+		assert.NotEmpty(t, idToken)
+		claims, err := token.ParseJWTToken(idToken)
+		assert.NoError(t, err)
+		assert.Equal(t, authTime, int64(claims["auth_time"].(float64)))
+		assert.Equal(t, []interface{}{constants.AMRPassword}, claims["amr"])
+		assert.Equal(t, constants.ACRSingleFactor, claims["acr"])
+
+		// ui can't be shown for prompt none
+		assertLoginRequired(authorize("prompt=none&max_age=60"))
+		assertLoginRequired(authorize("prompt=none&login_hint=" + url.QueryEscape("other."+email)))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/authorize?client_id="+clientID+"&state=test-state&response_type=id_token&prompt=none%20login", nil)
+		handlers.AuthorizeHandler()(c)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// prompt login removes the session & shows the login page
+		location = authorize("prompt=login&login_hint=" + url.QueryEscape(email))
+		assert.Equal(t, "/app", location.Path)
+		assert.Equal(t, email, location.Query().Get("login_hint"))
+		assertLoginRequired(authorize("prompt=none"))
+
+		sessionCookie = ""
+		assertLoginRequired(authorize("prompt=none"))
+
+		// second factor is reflected in amr & acr
+		authInfo := token.NewAuthenticationInfo(constants.AuthRecipeMethodBasicAuth, true)
+		assert.Equal(t, constants.ACRMultiFactor, authInfo.ACR())
+		assert.Contains(t, authInfo.AMR, constants.AMRMultiFactor)
+	})
+}
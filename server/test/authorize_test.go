@@ -0,0 +1,156 @@
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+func authorizeTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should return authorization response with response mode`, func(t *testing.T) {
+		clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+		assert.NoError(t, err)
+
+		req, ctx := createContext(s)
+		req.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+		email := "authorize." + s.TestInfo.Email
+		user, err := db.Provider.AddUser(ctx, models.User{
+			Email:         email,
+			SignupMethods: constants.AuthRecipeMethodBasicAuth,
+			Roles:         "user",
+		})
+		assert.NoError(t, err)
+
+		gc, err := utils.GinContextFromContext(ctx)
+		assert.NoError(t, err)
+		authToken, err := token.CreateAuthToken(gc, user, []string{"user"}, []string{"openid", "email"}, constants.AuthRecipeMethodBasicAuth, token.AuthTokenOptions{})
+		assert.NoError(t, err)
+		sessionKey := constants.AuthRecipeMethodBasicAuth + ":" + user.ID
+		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash)
+		sessionCookie := url.QueryEscape(authToken.FingerPrintHash)
+
+		// session is rolled over with every authorization, so the latest session cookie is used
+		authorize := func(query string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(w)
+			r.LoadHTMLGlob("../../templates/*")
+			c.Request = httptest.NewRequest(http.MethodGet, "/authorize?client_id="+clientID+"&state=test-state&redirect_uri=http://localhost:3000/callback&"+query, nil)
+			c.Request.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+			c.Request.AddCookie(&http.Cookie{Name: constants.AppCookieName + "_session", Value: sessionCookie})
+			handlers.AuthorizeHandler()(c)
+			for _, cookie := range w.Result().Cookies() {
+				if cookie.Name == constants.AppCookieName+"_session" {
+					sessionCookie = cookie.Value
+				}
+			}
+			return w
+		}
+		getFragment := func(w *httptest.ResponseRecorder) url.Values {
+			location, err := url.Parse(w.Header().Get("Location"))
+			assert.NoError(t, err)
+			values, err := url.ParseQuery(location.Fragment)
+			assert.NoError(t, err)
+			assert.Empty(t, location.RawQuery)
+			return values
+		}
+
+		w := authorize("response_mode=invalid")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// tokens must not be returned in query
+		w = authorize("response_type=token&response_mode=query")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// tokens are returned in fragment by default
+		w = authorize("response_type=token")
+		assert.Equal(t, http.StatusFound, w.Code)
+		fragment := getFragment(w)
+		assert.NotEmpty(t, fragment.Get("access_token"))
+		assert.Equal(t, "Bearer", fragment.Get("token_type"))
+		assert.Equal(t, "test-state", fragment.Get("state"))
+
+		// id token is bound to the nonce & the tokens returned with it
+		w = authorize("response_type=id_token&response_mode=fragment")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = authorize("response_type=id_token&response_mode=fragment&nonce=authorize-test-nonce")
+		assert.Equal(t, http.StatusFound, w.Code)
+		fragment = getFragment(w)
+		assert.NotEmpty(t, fragment.Get("id_token"))
+		assert.Empty(t, fragment.Get("access_token"))
+		claims, err := token.ParseJWTToken(fragment.Get("id_token"))
+		assert.NoError(t, err)
+		assert.Equal(t, "authorize-test-nonce", claims["nonce"])
+		assert.Nil(t, claims["at_hash"])
+
+		w = authorize("response_type=token%20id_token&nonce=authorize-test-nonce")
+		assert.Equal(t, http.StatusFound, w.Code)
+		fragment = getFragment(w)
+		claims, err = token.ParseJWTToken(fragment.Get("id_token"))
+		assert.NoError(t, err)
+		assert.Equal(t, tokenHash(fragment.Get("access_token")), claims["at_hash"])
+		assert.Nil(t, claims["c_hash"])
+
+		w = authorize("response_type=code&code_challenge=authorize-test-challenge")
+		assert.Equal(t, http.StatusFound, w.Code)
+		location, err := url.Parse(w.Header().Get("Location"))
+		assert.NoError(t, err)
+		assert.NotEmpty(t, location.Query().Get("code"))
+		assert.Equal(t, "test-state", location.Query().Get("state"))
+
+		w = authorize("response_type=code%20id_token&response_mode=form_post&code_challenge=authorize-test-challenge&nonce=authorize-test-nonce")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `action="http://localhost:3000/callback"`)
+		assert.Contains(t, w.Body.String(), `name="code"`)
+		assert.Contains(t, w.Body.String(), `name="id_token"`)
+		assert.NotContains(t, w.Body.String(), `name="access_token"`)
+
+		// code is bound to the only session created for the response
+		codeState, err := memorystore.Provider.GetState("authorize-test-challenge")
+		assert.NoError(t, err)
+		codeStateSplit := strings.Split(codeState, "@")
+		newSession, err := url.QueryUnescape(sessionCookie)
+		assert.NoError(t, err)
+		assert.Equal(t, newSession, codeStateSplit[1])
+		sessionClaims, err := token.ParseSessionToken(newSession)
+		assert.NoError(t, err)
+		sessionToken, err := memorystore.Provider.GetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+sessionClaims.Nonce)
+		assert.NoError(t, err)
+		assert.Equal(t, newSession, sessionToken)
+		idToken := strings.SplitN(strings.SplitN(w.Body.String(), `name="id_token" value="`, 2)[1], `"`, 2)[0]
+		claims, err = token.ParseJWTToken(idToken)
+		assert.NoError(t, err)
+		assert.Equal(t, tokenHash(codeStateSplit[0]), claims["c_hash"])
+		assert.Equal(t, "authorize-test-nonce", claims["nonce"])
+
+		w = authorize("response_type=code%20token%20id_token&response_mode=web_message&code_challenge=authorize-test-challenge&nonce=authorize-test-nonce")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `postMessage`)
+		assert.Contains(t, w.Body.String(), `access_token`)
+
+		memorystore.Provider.RemoveState("authorize-test-challenge")
+		cleanData(email)
+	})
+}
+
+// tokenHash returns the at_hash & c_hash of token signed with SHA-256 based algorithm
+func tokenHash(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(hash[:len(hash)/2])
+}
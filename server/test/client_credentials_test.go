@@ -0,0 +1,102 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+)
+
+func clientCredentialsTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should issue access token with client credentials grant`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+
+		res, err := resolvers.AddClientResolver(ctx, model.AddClientRequest{
+			Name:          "test service",
+			RedirectUris:  []string{"https://service.example.com/callback"},
+			AllowedScopes: []string{"read", "write"},
+			GrantTypes:    []string{constants.GrantTypeClientCredentials},
+		})
+		assert.NoError(t, err)
+		clientID := res.Client.ID
+		clientSecret := refs.StringValue(res.ClientSecret)
+
+		tokenRequest := func(form url.Values, basicAuth bool, secret string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+			c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Request.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+			if basicAuth {
+				c.Request.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(secret))
+			}
+			handlers.TokenHandler()(c)
+			return w
+		}
+
+		// client_secret_basic
+		w := tokenRequest(url.Values{"grant_type": {constants.GrantTypeClientCredentials}, "scope": {"read"}}, true, clientSecret)
+		assert.Equal(t, http.StatusOK, w.Code)
+		tokenRes := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokenRes))
+		assert.NotEmpty(t, tokenRes["access_token"])
+		assert.Nil(t, tokenRes["id_token"])
+		assert.Nil(t, tokenRes["refresh_token"])
+
+		req, ctx = createContext(s)
+		req.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+		validateRes, err := resolvers.ValidateJwtTokenResolver(ctx, model.ValidateJWTTokenInput{
+			TokenType: constants.TokenTypeAccessToken,
+			Token:     tokenRes["access_token"].(string),
+		})
+		assert.NoError(t, err)
+		assert.True(t, validateRes.IsValid)
+
+		// client_secret_post
+		w = tokenRequest(url.Values{"grant_type": {constants.GrantTypeClientCredentials}, "client_id": {clientID}, "client_secret": {clientSecret}}, false, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// invalid secret
+		w = tokenRequest(url.Values{"grant_type": {constants.GrantTypeClientCredentials}}, true, "invalid")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		// scope not allowed for client
+		w = tokenRequest(url.Values{"grant_type": {constants.GrantTypeClientCredentials}, "scope": {"admin"}}, true, clientSecret)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		req, ctx = createContext(s)
+		req.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+		_, err = resolvers.DeleteClientResolver(ctx, model.ClientRequest{
+			ID: clientID,
+		})
+		assert.NoError(t, err)
+
+		// token of deleted client should not be valid
+		_, err = resolvers.ValidateJwtTokenResolver(ctx, model.ValidateJWTTokenInput{
+			TokenType: constants.TokenTypeAccessToken,
+			Token:     tokenRes["access_token"].(string),
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+func clientsTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should manage oauth clients`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		_, err := resolvers.AddClientResolver(ctx, model.AddClientRequest{
+			Name:         "test app",
+			RedirectUris: []string{"https://app.example.com/callback"},
+		})
+		assert.Error(t, err, "unauthorized")
+
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+
+		_, err = resolvers.AddClientResolver(ctx, model.AddClientRequest{
+			Name:         "test app",
+			RedirectUris: []string{"/callback"},
+		})
+		assert.Error(t, err, "redirect uri must be absolute")
+
+		res, err := resolvers.AddClientResolver(ctx, model.AddClientRequest{
+			Name:                  "test app",
+			RedirectUris:          []string{"https://app.example.com/callback"},
+			AccessTokenExpiryTime: refs.NewStringRef("10m"),
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, refs.StringValue(res.ClientSecret))
+		assert.Equal(t, constants.DefaultClientGrantTypes, res.Client.GrantTypes)
+		clientID := res.Client.ID
+
+		client, err := resolvers.ClientResolver(ctx, model.ClientRequest{
+			ID: clientID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "test app", client.Name)
+
+		clients, err := resolvers.ClientsResolver(ctx, nil)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, len(clients.Clients), 1)
+
+		res, err = resolvers.UpdateClientResolver(ctx, model.UpdateClientRequest{
+			ID:                     clientID,
+			RedirectUris:           []string{"https://app.example.com/callback", "com.example.app:/callback"},
+			RegenerateClientSecret: refs.NewBoolRef(true),
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, refs.StringValue(res.ClientSecret))
+		assert.Len(t, res.Client.RedirectUris, 2)
+
+		authorize := func(redirectURI string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/authorize?client_id="+clientID+"&state=test-state&response_type=code&code_challenge=test&redirect_uri="+redirectURI, nil)
+			handlers.AuthorizeHandler()(c)
+			return w
+		}
+		// unregistered redirect uri should be rejected
+		w := authorize("https://evil.example.com/callback")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		// registered redirect uri should continue to login
+		w = authorize("https://app.example.com/callback")
+		assert.Equal(t, http.StatusFound, w.Code)
+
+		// tokens should be issued for the client
+		gc, err := utils.GinContextFromContext(ctx)
+		assert.NoError(t, err)
+		dbClient := models.Client{
+			ID:                    clientID,
+			AccessTokenExpiryTime: "10m",
+		}
+		authToken, err := token.CreateAuthToken(gc, models.User{ID: uuid.New().String()}, []string{"user"}, []string{"openid"}, "", token.AuthTokenOptions{Client: &dbClient})
+		assert.NoError(t, err)
+		claims, err := token.ParseJWTToken(authToken.AccessToken.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, clientID, claims["aud"])
+		assert.True(t, token.IsValidAudience(claims["aud"]))
+
+		deleteRes, err := resolvers.DeleteClientResolver(ctx, model.ClientRequest{
+			ID: clientID,
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, deleteRes.Message)
+		assert.False(t, token.IsValidAudience(clientID))
+	})
+}
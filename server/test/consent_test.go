@@ -0,0 +1,159 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+func consentTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should request consent of user for registered clients`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		req.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+
+		res, err := resolvers.AddClientResolver(ctx, model.AddClientRequest{
+			Name:         "consent app",
+			RedirectUris: []string{"https://app.example.com/callback"},
+		})
+		assert.NoError(t, err)
+		clientID := res.Client.ID
+		defer resolvers.DeleteClientResolver(ctx, model.ClientRequest{
+			ID: clientID,
+		})
+
+		user, err := db.Provider.AddUser(ctx, models.User{
+			Email:         "consent." + s.TestInfo.Email,
+			SignupMethods: constants.AuthRecipeMethodBasicAuth,
+			Roles:         "user",
+		})
+		assert.NoError(t, err)
+		defer db.Provider.DeleteUser(ctx, user)
+
+		gc, err := utils.GinContextFromContext(ctx)
+		assert.NoError(t, err)
+		authToken, err := token.CreateAuthToken(gc, user, []string{"user"}, []string{"openid", "email"}, constants.AuthRecipeMethodBasicAuth, token.AuthTokenOptions{})
+		assert.NoError(t, err)
+		sessionKey := constants.AuthRecipeMethodBasicAuth + ":" + user.ID
+		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash)
+		defer memorystore.Provider.DeleteAllUserSessions(user.ID)
+		defer memorystore.Provider.RemoveState("consent-test-challenge")
+		sessionCookie := url.QueryEscape(authToken.FingerPrintHash)
+		// browser session is rolled over by authorization, so another login is used for the consent page
+		appToken, err := token.CreateAuthToken(gc, user, []string{"user"}, []string{"openid", "email"}, constants.AuthRecipeMethodBasicAuth, token.AuthTokenOptions{})
+		assert.NoError(t, err)
+		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+appToken.FingerPrint, appToken.AccessToken.Token)
+		req.Header.Set("Authorization", "Bearer "+appToken.AccessToken.Token)
+
+		authorizeQuery := "client_id=" + clientID + "&state=test-state&response_type=code&code_challenge=consent-test-challenge&scope=openid%20email"
+		// session is rolled over with every authorization, so the latest session cookie is used
+		authorize := func(query string) *url.URL {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/authorize?"+query, nil)
+			c.Request.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+			c.Request.AddCookie(&http.Cookie{Name: constants.AppCookieName + "_session", Value: sessionCookie})
+			handlers.AuthorizeHandler()(c)
+			for _, cookie := range w.Result().Cookies() {
+				if cookie.Name == constants.AppCookieName+"_session" {
+					sessionCookie = cookie.Value
+				}
+			}
+			assert.Equal(t, http.StatusFound, w.Code)
+			location, err := url.Parse(w.Header().Get("Location"))
+			assert.NoError(t, err)
+			return location
+		}
+		requestConsent := func(query string) string {
+			location := authorize(query)
+			assert.Equal(t, "/app/consent", location.Path)
+			challenge := location.Query().Get(constants.ConsentChallengeParam)
+			assert.NotEmpty(t, challenge)
+			return challenge
+		}
+		respond := func(challenge string, approve bool) string {
+			consentRes, err := resolvers.ConsentResolver(ctx, model.ConsentInput{
+				ConsentChallenge: challenge,
+				Approve:          approve,
+			})
+			assert.NoError(t, err)
+			redirectURI, err := url.Parse(consentRes.RedirectURI)
+			assert.NoError(t, err)
+			assert.Equal(t, "/authorize", redirectURI.Path)
+			return redirectURI.RawQuery
+		}
+
+		// consent can't be requested without user interaction
+		location := authorize(authorizeQuery + "&prompt=none")
+		assert.Equal(t, "consent_required", location.Query().Get("error"))
+		assert.Equal(t, "test-state", location.Query().Get("state"))
+
+		challenge := requestConsent(authorizeQuery)
+		consentRequest, err := resolvers.ConsentRequestResolver(ctx, model.ConsentRequestInput{
+			ConsentChallenge: challenge,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "consent app", consentRequest.ClientName)
+		assert.Equal(t, []string{"openid", "email"}, consentRequest.Scope)
+
+		// denied consent returns access_denied to the client
+		location = authorize(respond(challenge, false))
+		assert.Equal(t, "https://app.example.com/callback", location.Scheme+"://"+location.Host+location.Path)
+		assert.Equal(t, "access_denied", location.Query().Get("error"))
+		// consent request can be used only once
+		_, err = resolvers.ConsentResolver(ctx, model.ConsentInput{
+			ConsentChallenge: challenge,
+			Approve:          true,
+		})
+		assert.Error(t, err)
+
+		location = authorize(respond(requestConsent(authorizeQuery), true))
+		assert.NotEmpty(t, location.Query().Get("code"))
+		assert.Equal(t, "test-state", location.Query().Get("state"))
+
+		// granted scopes don't need the consent again
+		location = authorize(authorizeQuery)
+		assert.NotEmpty(t, location.Query().Get("code"))
+		requestConsent(authorizeQuery + "&prompt=consent")
+		challenge = requestConsent(authorizeQuery + "%20profile")
+		location = authorize(respond(challenge, true))
+		assert.NotEmpty(t, location.Query().Get("code"))
+
+		grants, err := resolvers.GrantsResolver(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, grants, 1)
+		assert.Equal(t, clientID, grants[0].ClientID)
+		assert.Equal(t, "consent app", grants[0].ClientName)
+		assert.ElementsMatch(t, []string{"openid", "email", "profile"}, grants[0].Scope)
+
+		_, err = resolvers.RevokeGrantResolver(ctx, model.RevokeGrantInput{
+			ClientID: clientID,
+		})
+		assert.NoError(t, err)
+		grants, err = resolvers.GrantsResolver(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, grants, 0)
+		requestConsent(authorizeQuery)
+	})
+}
@@ -0,0 +1,135 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+func deviceAuthorizationTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should authorize device with user code`, func(t *testing.T) {
+		clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+		assert.NoError(t, err)
+
+		post := func(handler gin.HandlerFunc, path string, form url.Values) (int, map[string]interface{}) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+			c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Request.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+			handler(c)
+			body := map[string]interface{}{}
+			json.Unmarshal(w.Body.Bytes(), &body)
+			return w.Code, body
+		}
+		authorizeDevice := func() (string, string) {
+			code, body := post(handlers.DeviceAuthorizationHandler(), "/oauth/device_authorization", url.Values{
+				"client_id": {clientID},
+				"scope":     {"openid email"},
+			})
+			assert.Equal(t, http.StatusOK, code)
+			assert.Equal(t, "http://localhost:8080/app/device", body["verification_uri"])
+			assert.NotEmpty(t, body["verification_uri_complete"])
+			return body["device_code"].(string), body["user_code"].(string)
+		}
+		poll := func(deviceCode string) (int, map[string]interface{}) {
+			return post(handlers.TokenHandler(), "/oauth/token", url.Values{
+				"grant_type":  {constants.GrantTypeDeviceCode},
+				"client_id":   {clientID},
+				"device_code": {deviceCode},
+			})
+		}
+
+		req, ctx := createContext(s)
+		req.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+		email := "device." + s.TestInfo.Email
+		user, err := db.Provider.AddUser(ctx, models.User{
+			Email:         email,
+			SignupMethods: constants.AuthRecipeMethodBasicAuth,
+			Roles:         "user",
+		})
+		assert.NoError(t, err)
+
+		gc, err := utils.GinContextFromContext(ctx)
+		assert.NoError(t, err)
+		authToken, err := token.CreateAuthToken(gc, user, []string{"user"}, []string{"openid", "email"}, constants.AuthRecipeMethodBasicAuth, token.AuthTokenOptions{})
+		assert.NoError(t, err)
+		sessionKey := constants.AuthRecipeMethodBasicAuth + ":" + user.ID
+		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token)
+		req.Header.Set("Authorization", "Bearer "+authToken.AccessToken.Token)
+
+		deviceCode, userCode := authorizeDevice()
+		code, body := poll(deviceCode)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "authorization_pending", body["error"])
+
+		// polling faster than the interval should slow down the device
+		code, body = poll(deviceCode)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "slow_down", body["error"])
+		assert.Equal(t, float64(2*constants.DevicePollingInterval), body["interval"])
+
+		_, err = resolvers.VerifyDeviceCodeResolver(ctx, model.VerifyDeviceCodeInput{
+			UserCode: "invalid",
+			Approve:  true,
+		})
+		assert.Error(t, err)
+
+		// user code can be entered in lower case & without separator
+		res, err := resolvers.VerifyDeviceCodeResolver(ctx, model.VerifyDeviceCodeInput{
+			UserCode: strings.ToLower(strings.ReplaceAll(userCode, "-", "")),
+			Approve:  true,
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, res.Message)
+
+		code, body = poll(deviceCode)
+		assert.Equal(t, http.StatusOK, code)
+		assert.NotEmpty(t, body["access_token"])
+		assert.NotEmpty(t, body["id_token"])
+
+		// device code can be used only once
+		code, body = poll(deviceCode)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_grant", body["error"])
+
+		deviceCode, userCode = authorizeDevice()
+		_, err = resolvers.VerifyDeviceCodeResolver(ctx, model.VerifyDeviceCodeInput{
+			UserCode: userCode,
+			Approve:  false,
+		})
+		assert.NoError(t, err)
+		code, body = poll(deviceCode)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "access_denied", body["error"])
+
+		// user must be logged in to verify the user code
+		req.Header.Del("Authorization")
+		_, userCode = authorizeDevice()
+		_, err = resolvers.VerifyDeviceCodeResolver(ctx, model.VerifyDeviceCodeInput{
+			UserCode: userCode,
+			Approve:  true,
+		})
+		assert.Error(t, err)
+
+		memorystore.Provider.DeleteUserSession(sessionKey, authToken.FingerPrint)
+		cleanData(email)
+	})
+}
@@ -0,0 +1,156 @@
+package test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/dpop"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+func dpopTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should bind tokens to dpop key`, func(t *testing.T) {
+		clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+		assert.NoError(t, err)
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		jwk := jose.JSONWebKey{Key: &key.PublicKey}
+		jkt, err := dpop.Thumbprint(&jwk)
+		assert.NoError(t, err)
+		createProof := func(method, uri, accessToken string) string {
+			claims := jwt.MapClaims{
+				"jti": uuid.New().String(),
+				"htm": method,
+				"htu": uri,
+				"iat": time.Now().Unix(),
+			}
+			if accessToken != "" {
+				hash := sha256.Sum256([]byte(accessToken))
+				claims["ath"] = base64.RawURLEncoding.EncodeToString(hash[:])
+			}
+			proof := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+			proof.Header["typ"] = constants.DPoPProofType
+			proof.Header["jwk"] = jwk
+			signedProof, err := proof.SignedString(key)
+			assert.NoError(t, err)
+			return signedProof
+		}
+		refresh := func(refreshToken, proof string) (int, map[string]interface{}) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			form := url.Values{
+				"grant_type":    {constants.GrantTypeRefreshToken},
+				"client_id":     {clientID},
+				"refresh_token": {refreshToken},
+			}
+			c.Request = httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+			c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Request.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+			if proof != "" {
+				c.Request.Header.Set(constants.DPoPHeader, proof)
+			}
+			handlers.TokenHandler()(c)
+			body := map[string]interface{}{}
+			json.Unmarshal(w.Body.Bytes(), &body)
+			return w.Code, body
+		}
+		userInfo := func(authorization, proof string) int {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/userinfo", nil)
+			c.Request.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+			c.Request.Header.Set("Authorization", authorization)
+			if proof != "" {
+				c.Request.Header.Set(constants.DPoPHeader, proof)
+			}
+			handlers.UserInfoHandler()(c)
+			return w.Code
+		}
+
+		req, ctx := createContext(s)
+		req.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+		user, err := db.Provider.AddUser(ctx, models.User{
+			Email:         "dpop." + s.TestInfo.Email,
+			SignupMethods: constants.AuthRecipeMethodBasicAuth,
+			Roles:         "user",
+		})
+		assert.NoError(t, err)
+		defer db.Provider.DeleteUser(ctx, user)
+
+		gc, err := utils.GinContextFromContext(ctx)
+		assert.NoError(t, err)
+		authToken, err := token.CreateAuthToken(gc, user, []string{"user"}, []string{"openid", "email", "offline_access"}, constants.AuthRecipeMethodBasicAuth, token.AuthTokenOptions{})
+		assert.NoError(t, err)
+		sessionKey := constants.AuthRecipeMethodBasicAuth + ":" + user.ID
+		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash)
+		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token)
+		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeRefreshToken+"_"+authToken.FingerPrint, authToken.RefreshToken.Token)
+		defer memorystore.Provider.DeleteAllUserSessions(user.ID)
+
+		// invalid proof is rejected
+		code, body := refresh(authToken.RefreshToken.Token, createProof(http.MethodGet, "http://localhost:8080/oauth/token", ""))
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_dpop_proof", body["error"])
+
+		proof := createProof(http.MethodPost, "http://localhost:8080/oauth/token", "")
+		code, body = refresh(authToken.RefreshToken.Token, proof)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, constants.DPoPHeader, body["token_type"])
+		accessToken, _ := body["access_token"].(string)
+		refreshToken, _ := body["refresh_token"].(string)
+		claims, err := token.ParseJWTToken(accessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, jkt, dpop.GetJKT(claims))
+
+		// proof can't be replayed
+		code, body = refresh(refreshToken, proof)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_dpop_proof", body["error"])
+		// bound refresh token requires the proof
+		code, _ = refresh(refreshToken, "")
+		assert.Equal(t, http.StatusBadRequest, code)
+
+		// bound access token requires the proof of same key
+		assert.Equal(t, http.StatusUnauthorized, userInfo("Bearer "+accessToken, ""))
+		assert.Equal(t, http.StatusUnauthorized, userInfo("DPoP "+accessToken, ""))
+		assert.Equal(t, http.StatusUnauthorized, userInfo("DPoP "+accessToken, createProof(http.MethodGet, "http://localhost:8080/userinfo", "invalid")))
+		assert.Equal(t, http.StatusOK, userInfo("DPoP "+accessToken, createProof(http.MethodGet, "http://localhost:8080/userinfo", accessToken)))
+
+		req.Header.Set("Authorization", "DPoP "+accessToken)
+		req.Header.Set(constants.DPoPHeader, createProof(http.MethodPost, "http://localhost:8080/graphql", accessToken))
+		profile, err := resolvers.ProfileResolver(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, profile.ID)
+		// unbound access token can't be used with dpop scheme
+		req.Header.Set("Authorization", "DPoP "+authToken.AccessToken.Token)
+		req.Header.Set(constants.DPoPHeader, createProof(http.MethodPost, "http://localhost:8080/graphql", authToken.AccessToken.Token))
+		_, err = resolvers.ProfileResolver(ctx)
+		assert.Error(t, err)
+		req.Header.Del(constants.DPoPHeader)
+	})
+}
@@ -62,5 +62,14 @@ func generateJWTkeyTest(t *testing.T, s TestSetup) {
 			assert.NotEmpty(t, res.PrivateKey)
 			assert.NotEmpty(t, res.PublicKey)
 		})
+
+		t.Run(`should generate EdDSA secret`, func(t *testing.T) {
+			res, err := resolvers.GenerateJWTKeysResolver(ctx, model.GenerateJWTKeysInput{
+				Type: "EdDSA",
+			})
+			assert.NoError(t, err)
+			assert.NotEmpty(t, res.PrivateKey)
+			assert.NotEmpty(t, res.PublicKey)
+		})
 	})
 }
@@ -0,0 +1,127 @@
+package test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+)
+
+func idTokenEncryptionTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should encrypt id token for clients with encryption key`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err)
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		getJWK := func(key interface{}) string {
+			jwk, err := json.Marshal(jose.JSONWebKey{
+				Key: key,
+				Use: "enc",
+			})
+			assert.NoError(t, err)
+			return string(jwk)
+		}
+
+		// key must match the key management algorithm
+		_, err = resolvers.AddClientResolver(ctx, model.AddClientRequest{
+			Name:                        "id token encryption app",
+			RedirectUris:                []string{"https://app.example.com/callback"},
+			IDTokenEncryptedResponseAlg: refs.NewStringRef(constants.IDTokenEncryptionAlgRSAOAEP),
+			IDTokenEncryptionKey:        refs.NewStringRef(getJWK(&ecKey.PublicKey)),
+		})
+		assert.Error(t, err)
+		// private keys must not be registered
+		_, err = resolvers.AddClientResolver(ctx, model.AddClientRequest{
+			Name:                        "id token encryption app",
+			RedirectUris:                []string{"https://app.example.com/callback"},
+			IDTokenEncryptedResponseAlg: refs.NewStringRef(constants.IDTokenEncryptionAlgRSAOAEP),
+			IDTokenEncryptionKey:        refs.NewStringRef(getJWK(rsaKey)),
+		})
+		assert.Error(t, err)
+
+		res, err := resolvers.AddClientResolver(ctx, model.AddClientRequest{
+			Name:                        "id token encryption app",
+			RedirectUris:                []string{"https://app.example.com/callback"},
+			IDTokenEncryptedResponseAlg: refs.NewStringRef(constants.IDTokenEncryptionAlgRSAOAEP256),
+			IDTokenEncryptionKey:        refs.NewStringRef(getJWK(&rsaKey.PublicKey)),
+		})
+		assert.NoError(t, err)
+		clientID := res.Client.ID
+		defer resolvers.DeleteClientResolver(ctx, model.ClientRequest{
+			ID: clientID,
+		})
+		// content encryption defaults to A256GCM
+		assert.Equal(t, constants.IDTokenEncryptionEncA256GCM, refs.StringValue(res.Client.IDTokenEncryptedResponseEnc))
+
+		userID := uuid.New().String()
+		assertEncryptedIDToken := func(decryptionKey interface{}) {
+			client, err := db.Provider.GetClientByID(ctx, clientID)
+			assert.NoError(t, err)
+			idToken, _, err := token.CreateIDToken(models.User{ID: userID, Email: "id_token_encryption." + s.TestInfo.Email}, []string{"user"}, "http://localhost:8080", "test-nonce", constants.AuthRecipeMethodBasicAuth, token.NewAuthenticationInfo(constants.AuthRecipeMethodBasicAuth, false), &client, nil)
+			assert.NoError(t, err)
+
+			// encrypted token can't be parsed as a signed token
+			_, err = token.ParseJWTToken(idToken)
+			assert.Error(t, err)
+
+			encrypted, err := jose.ParseEncrypted(idToken)
+			assert.NoError(t, err)
+			assert.Equal(t, "JWT", encrypted.Header.ExtraHeaders[jose.HeaderContentType])
+			signedToken, err := encrypted.Decrypt(decryptionKey)
+			assert.NoError(t, err)
+			claims, err := token.ParseJWTToken(string(signedToken))
+			assert.NoError(t, err)
+			assert.Equal(t, userID, claims["sub"])
+			assert.Equal(t, clientID, claims["aud"])
+		}
+		assertEncryptedIDToken(rsaKey)
+
+		res, err = resolvers.UpdateClientResolver(ctx, model.UpdateClientRequest{
+			ID:                          clientID,
+			IDTokenEncryptedResponseAlg: refs.NewStringRef(constants.IDTokenEncryptionAlgECDHES),
+			IDTokenEncryptionKey:        refs.NewStringRef(getJWK(&ecKey.PublicKey)),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, constants.IDTokenEncryptionAlgECDHES, refs.StringValue(res.Client.IDTokenEncryptedResponseAlg))
+		assertEncryptedIDToken(ecKey)
+
+		// id token is only signed once encryption is disabled
+		_, err = resolvers.UpdateClientResolver(ctx, model.UpdateClientRequest{
+			ID:                          clientID,
+			IDTokenEncryptedResponseAlg: refs.NewStringRef(""),
+		})
+		assert.NoError(t, err)
+		client, err := db.Provider.GetClientByID(ctx, clientID)
+		assert.NoError(t, err)
+		idToken, _, err := token.CreateIDToken(models.User{ID: userID}, []string{"user"}, "http://localhost:8080", "test-nonce", constants.AuthRecipeMethodBasicAuth, token.NewAuthenticationInfo(constants.AuthRecipeMethodBasicAuth, false), &client, nil)
+		assert.NoError(t, err)
+		claims, err := token.ParseJWTToken(idToken)
+		assert.NoError(t, err)
+		assert.Equal(t, userID, claims["sub"])
+	})
+}
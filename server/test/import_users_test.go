@@ -0,0 +1,150 @@
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+)
+
+func importUsersTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should import users with password hashes`, func(t *testing.T) {
+		password := s.TestInfo.Password
+		salt := []byte("import_users_salt")
+		encode := base64.RawStdEncoding.EncodeToString
+		argon2Hash := fmt.Sprintf("$argon2id$v=19$m=1024,t=1,p=1$%s$%s", encode(salt), encode(argon2.IDKey([]byte(password), salt, 1, 1024, 1, 32)))
+		scryptKey, err := scrypt.Key([]byte(password), salt, 1<<10, 8, 1, 32)
+		assert.NoError(t, err)
+		scryptHash := fmt.Sprintf("$scrypt$ln=10,r=8,p=1$%s$%s", encode(salt), encode(scryptKey))
+		pbkdf2Hash := fmt.Sprintf("$pbkdf2-sha256$1000$%s$%s", encode(salt), encode(pbkdf2.Key([]byte(password), salt, 1000, 32, sha256.New)))
+		djangoHash := fmt.Sprintf("pbkdf2_sha256$1000$%s$%s", salt, base64.StdEncoding.EncodeToString(pbkdf2.Key([]byte(password), salt, 1000, 32, sha256.New)))
+		bcryptHash, err := crypto.EncryptPassword(password)
+		assert.NoError(t, err)
+
+		for _, hash := range []string{bcryptHash, argon2Hash, scryptHash, pbkdf2Hash, djangoHash} {
+			assert.True(t, crypto.IsSupportedPasswordHash(hash))
+			shouldRehash, err := crypto.ComparePassword(hash, password)
+			assert.NoError(t, err)
+			assert.Equal(t, hash != bcryptHash, shouldRehash)
+			_, err = crypto.ComparePassword(hash, "invalid"+password)
+			assert.Error(t, err)
+		}
+		assert.False(t, crypto.IsSupportedPasswordHash("plain_password"))
+		// hashes with out of range parameters are rejected
+		for _, params := range []string{"m=4194304,t=1,p=1", "m=1024,t=1000,p=1", "m=1024,t=1,p=255", "m=0,t=1,p=1"} {
+			assert.False(t, crypto.IsSupportedPasswordHash(strings.Replace(argon2Hash, "m=1024,t=1,p=1", params, 1)))
+		}
+
+		req, ctx := createContext(s)
+		params := model.ImportUsersInput{
+			Format: constants.UserRecordsFormatJSON,
+			Data:   "[]",
+		}
+		_, err = resolvers.ImportUsersResolver(ctx, params)
+		assert.Error(t, err)
+
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+
+		jsonEmail := "import_users_json." + s.TestInfo.Email
+		csvEmail := "import_users_csv." + s.TestInfo.Email
+		userID := uuid.New().String()
+		defer cleanData(jsonEmail)
+		defer cleanData(csvEmail)
+		params.Data = fmt.Sprintf(`[
+			{"id": "%s", "email": "%s", "email_verified_at": 1700000000, "password_hash": "%s", "roles": "user"},
+			{"email": "invalid_email"},
+			{"email": "%s"},
+			{"email": "unsupported_hash.%s", "password_hash": "plain_password"}
+		]`, userID, strings.ToUpper(jsonEmail), argon2Hash, jsonEmail, s.TestInfo.Email)
+		res, err := resolvers.ImportUsersResolver(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), res.Imported)
+		assert.Len(t, res.Errors, 3)
+		rows := []int64{}
+		for _, importError := range res.Errors {
+			rows = append(rows, importError.Row)
+		}
+		assert.Equal(t, []int64{2, 3, 4}, rows)
+
+		user, err := db.Provider.GetUserByEmail(ctx, jsonEmail)
+		assert.NoError(t, err)
+		assert.Contains(t, user.ID, userID)
+		assert.Equal(t, "user", user.Roles)
+		assert.Equal(t, constants.AuthRecipeMethodBasicAuth, user.SignupMethods)
+		assert.NotNil(t, user.EmailVerifiedAt)
+
+		// imported password hash is replaced with bcrypt hash on login
+		_, err = resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:    jsonEmail,
+			Password: password,
+		})
+		assert.NoError(t, err)
+		user, err = db.Provider.GetUserByEmail(ctx, jsonEmail)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(*user.Password, "$2"))
+		_, err = resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:    jsonEmail,
+			Password: password,
+		})
+		assert.NoError(t, err)
+
+		params = model.ImportUsersInput{
+			Format: constants.UserRecordsFormatCSV,
+			Data:   fmt.Sprintf("email,password_hash,email_verified_at\n%s,%s,\ninvalid_timestamp.%s,,yesterday\n", csvEmail, pbkdf2Hash, s.TestInfo.Email),
+		}
+		res, err = resolvers.ImportUsersResolver(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), res.Imported)
+		assert.Len(t, res.Errors, 1)
+		assert.Equal(t, int64(2), res.Errors[0].Row)
+		user, err = db.Provider.GetUserByEmail(ctx, csvEmail)
+		assert.NoError(t, err)
+		assert.Nil(t, user.EmailVerifiedAt)
+
+		_, err = resolvers.ImportUsersResolver(ctx, model.ImportUsersInput{
+			Format: constants.UserRecordsFormatCSV,
+			Data:   "email,password\n",
+		})
+		assert.Error(t, err)
+
+		export := func(format, cookie string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/admin/users/export?format="+format, nil)
+			c.Request.Header.Set("Cookie", cookie)
+			handlers.ExportUsersHandler()(c)
+			return w
+		}
+		assert.Equal(t, http.StatusUnauthorized, export(constants.UserRecordsFormatCSV, "").Code)
+		w := export(constants.UserRecordsFormatCSV, req.Header.Get("Cookie"))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, strings.HasPrefix(w.Body.String(), "id,email,"))
+		assert.Contains(t, w.Body.String(), csvEmail+",,"+pbkdf2Hash)
+		w = export(constants.UserRecordsFormatJSON, req.Header.Get("Cookie"))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"email":"`+jsonEmail+`"`)
+	})
+}
@@ -0,0 +1,101 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+func introspectTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should introspect tokens`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		req.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+
+		res, err := resolvers.AddClientResolver(ctx, model.AddClientRequest{
+			Name:         "test resource server",
+			RedirectUris: []string{"https://api.example.com/callback"},
+		})
+		assert.NoError(t, err)
+		clientID := res.Client.ID
+		clientSecret := refs.StringValue(res.ClientSecret)
+
+		introspect := func(tokenString, secret string) (int, map[string]interface{}) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			form := url.Values{"token": {tokenString}}
+			c.Request = httptest.NewRequest(http.MethodPost, "/oauth/introspect", strings.NewReader(form.Encode()))
+			c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Request.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+			c.Request.SetBasicAuth(clientID, secret)
+			handlers.IntrospectHandler()(c)
+			body := map[string]interface{}{}
+			json.Unmarshal(w.Body.Bytes(), &body)
+			return w.Code, body
+		}
+
+		gc, err := utils.GinContextFromContext(ctx)
+		assert.NoError(t, err)
+		user := models.User{ID: uuid.New().String()}
+		authToken, err := token.CreateAuthToken(gc, user, []string{"user"}, []string{"openid", "email"}, "", token.AuthTokenOptions{})
+		assert.NoError(t, err)
+		memorystore.Provider.SetUserSession(user.ID, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token)
+
+		code, body := introspect(authToken.AccessToken.Token, "invalid")
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		code, body = introspect(authToken.AccessToken.Token, clientSecret)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, true, body["active"])
+		assert.Equal(t, user.ID, body["sub"])
+		assert.Equal(t, "openid email", body["scope"])
+		assert.Equal(t, "Bearer", body["token_type"])
+		assert.NotEmpty(t, body["client_id"])
+
+		// revoked session should not be active
+		memorystore.Provider.DeleteUserSession(user.ID, authToken.FingerPrint)
+		code, body = introspect(authToken.AccessToken.Token, clientSecret)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, false, body["active"])
+
+		code, body = introspect("invalid", clientSecret)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, false, body["active"])
+
+		// signed token without nonce e.g. logout token should not be active
+		logoutToken, err := token.CreateLogoutToken(clientID, user.ID, "http://localhost:8080")
+		assert.NoError(t, err)
+		code, body = introspect(logoutToken, clientSecret)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, false, body["active"])
+
+		_, err = resolvers.DeleteClientResolver(ctx, model.ClientRequest{
+			ID: clientID,
+		})
+		assert.NoError(t, err)
+	})
+}
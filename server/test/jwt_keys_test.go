@@ -0,0 +1,279 @@
+package test
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+)
+
+func jwtKeysTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	// persist older keys till test is done and then reset them
+	envKeys := []string{constants.EnvKeyJwtType, constants.EnvKeyJwtSecret, constants.EnvKeyJwtPrivateKey, constants.EnvKeyJwtPublicKey, constants.EnvKeyJwtKeys, constants.EnvKeyJWK}
+	originalEnv := map[string]string{}
+	for _, key := range envKeys {
+		value, err := memorystore.Provider.GetStringStoreEnvVariable(key)
+		assert.NoError(t, err)
+		originalEnv[key] = value
+	}
+	restoreEnv := func(jwtKeys string) {
+		data := map[string]interface{}{}
+		for key, value := range originalEnv {
+			if key == constants.EnvKeyJwtKeys {
+				value = jwtKeys
+			}
+			memorystore.Provider.UpdateEnvVariable(key, value)
+			data[key] = value
+		}
+		// rotation persists the keyset, reset it in db as well
+		env, err := db.Provider.GetEnv(context.Background())
+		assert.NoError(t, err)
+		env.EnvData, err = crypto.EncryptEnvData(data)
+		assert.NoError(t, err)
+		_, err = db.Provider.UpdateEnv(context.Background(), env)
+		assert.NoError(t, err)
+	}
+	defer restoreEnv(originalEnv[constants.EnvKeyJwtKeys])
+	// every test starts with the original active key & without the keys left by other tests
+	resetKeyset := func() {
+		restoreEnv("")
+	}
+
+	adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+	assert.NoError(t, err)
+	h, err := crypto.EncryptPassword(adminSecret)
+	assert.NoError(t, err)
+	adminCookie := fmt.Sprintf("%s=%s", constants.AdminCookieName, h)
+
+	signToken := func(t *testing.T) (string, string) {
+		signedToken, err := token.SignJWTToken(jwt.MapClaims{
+			"exp": time.Now().Add(time.Minute * 30).Unix(),
+			"iat": time.Now().Unix(),
+			"sub": "jwt-keys-test",
+		})
+		assert.NoError(t, err)
+		parsedToken, _, err := new(jwt.Parser).ParseUnverified(signedToken, jwt.MapClaims{})
+		assert.NoError(t, err)
+		kid, _ := parsedToken.Header["kid"].(string)
+		return signedToken, kid
+	}
+	getJWKS := func(t *testing.T) []string {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+		handlers.JWKsHandler()(c)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res struct {
+			Keys []struct {
+				Kid string `json:"kid"`
+			} `json:"keys"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		kids := []string{}
+		for _, key := range res.Keys {
+			kids = append(kids, key.Kid)
+		}
+		return kids
+	}
+	// signTokenWithoutKid signs the token with key without kid header, as the tokens signed before key rotation
+	signTokenWithoutKid := func(t *testing.T, key token.JWTKey) string {
+		var signingKey interface{}
+		var err error
+		switch {
+		case crypto.IsHMACA(key.Type):
+			signingKey = []byte(key.Secret)
+		case crypto.IsRSA(key.Type):
+			signingKey, err = crypto.ParseRsaPrivateKeyFromPemStr(key.PrivateKey)
+		case crypto.IsECDSA(key.Type):
+			signingKey, err = crypto.ParseEcdsaPrivateKeyFromPemStr(key.PrivateKey)
+		default:
+			signingKey, err = crypto.ParseEdDSAPrivateKeyFromPemStr(key.PrivateKey)
+		}
+		assert.NoError(t, err)
+		signedToken, err := jwt.NewWithClaims(jwt.GetSigningMethod(key.Type), jwt.MapClaims{
+			"exp": time.Now().Add(time.Minute * 30).Unix(),
+			"iat": time.Now().Unix(),
+			"sub": "jwt-keys-test",
+		}).SignedString(signingKey)
+		assert.NoError(t, err)
+		return signedToken
+	}
+	// updateStoredKeys changes the keys stored in JWT_KEYS, so that the time of activation
+	// & retirement can be passed without waiting for it
+	updateStoredKeys := func(t *testing.T, update func(keys []token.JWTKey)) {
+		storedKeys := []token.JWTKey{}
+		keysString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtKeys)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal([]byte(keysString), &storedKeys))
+		assert.Len(t, storedKeys, 1)
+		update(storedKeys)
+		keysJSON, err := json.Marshal(storedKeys)
+		assert.NoError(t, err)
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJwtKeys, string(keysJSON))
+	}
+
+	t.Run(`should rotate jwt keys`, func(t *testing.T) {
+		resetKeyset()
+		req, ctx := createContext(s)
+		_, err := resolvers.JWTKeysResolver(ctx)
+		assert.Error(t, err)
+		_, err = resolvers.RotateJWTKeyResolver(ctx, model.RotateJWTKeyInput{})
+		assert.Error(t, err)
+		req.Header.Set("Cookie", adminCookie)
+
+		keys, err := resolvers.JWTKeysResolver(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, keys.Keys, 1)
+		activeKeyID := keys.Keys[0].Kid
+		assert.Equal(t, constants.JWTKeyStatusActive, keys.Keys[0].Status)
+
+		oldToken, kid := signToken(t)
+		assert.Equal(t, activeKeyID, kid)
+		assert.Equal(t, []string{activeKeyID}, getJWKS(t))
+
+		invalidType := "invalid"
+		_, err = resolvers.RotateJWTKeyResolver(ctx, model.RotateJWTKeyInput{
+			Type: &invalidType,
+		})
+		assert.Error(t, err)
+
+		// next key is published before it is activated
+		activatesAt := time.Now().Add(time.Hour).Unix()
+		nextKey, err := resolvers.RotateJWTKeyResolver(ctx, model.RotateJWTKeyInput{
+			ActivatesAt: &activatesAt,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, constants.JWTKeyStatusNext, nextKey.Status)
+		assert.NotEqual(t, activeKeyID, nextKey.Kid)
+		assert.ElementsMatch(t, []string{activeKeyID, nextKey.Kid}, getJWKS(t))
+		_, kid = signToken(t)
+		assert.Equal(t, activeKeyID, kid)
+
+		_, err = resolvers.RotateJWTKeyResolver(ctx, model.RotateJWTKeyInput{})
+		assert.Error(t, err, "key rotation is already scheduled")
+
+		// next key signs the tokens once activation time has passed
+		updateStoredKeys(t, func(keys []token.JWTKey) {
+			keys[0].ActivatesAt = time.Now().Unix() - 1
+		})
+		newToken, kid := signToken(t)
+		assert.Equal(t, nextKey.Kid, kid)
+		_, err = token.ParseJWTToken(newToken)
+		assert.NoError(t, err)
+		// tokens signed with previous key are still valid
+		_, err = token.ParseJWTToken(oldToken)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{activeKeyID, nextKey.Kid}, getJWKS(t))
+
+		keys, err = resolvers.JWTKeysResolver(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, keys.Keys, 2)
+		assert.Equal(t, nextKey.Kid, keys.Keys[0].Kid)
+		assert.Equal(t, constants.JWTKeyStatusActive, keys.Keys[0].Status)
+		assert.Equal(t, activeKeyID, keys.Keys[1].Kid)
+		assert.Equal(t, constants.JWTKeyStatusPrevious, keys.Keys[1].Status)
+	})
+
+	t.Run(`should retire jwt keys`, func(t *testing.T) {
+		resetKeyset()
+		req, ctx := createContext(s)
+		req.Header.Set("Cookie", adminCookie)
+
+		oldToken, activeKeyID := signToken(t)
+		// tokens signed before kid header was set are verified with the previous keys after rotation
+		keys, err := token.GetJWTKeys()
+		assert.NoError(t, err)
+		tokenWithoutKid := signTokenWithoutKid(t, keys[0])
+		// key is activated immediately if activation time is not in future
+		nextKey, err := resolvers.RotateJWTKeyResolver(ctx, model.RotateJWTKeyInput{})
+		assert.NoError(t, err)
+		assert.Equal(t, constants.JWTKeyStatusActive, nextKey.Status)
+		newToken, kid := signToken(t)
+		assert.Equal(t, nextKey.Kid, kid)
+		_, err = token.ParseJWTToken(tokenWithoutKid)
+		assert.NoError(t, err)
+
+		// active key can't be retired
+		_, err = resolvers.RetireJWTKeyResolver(ctx, model.RetireJWTKeyInput{
+			Kid: nextKey.Kid,
+		})
+		assert.Error(t, err)
+
+		// previous key is retired after the longest token lifetime
+		retiredKey, err := resolvers.RetireJWTKeyResolver(ctx, model.RetireJWTKeyInput{
+			Kid: activeKeyID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, constants.JWTKeyStatusPrevious, retiredKey.Status)
+		assert.NotNil(t, retiredKey.RetiresAt)
+		assert.Greater(t, *retiredKey.RetiresAt, time.Now().Unix())
+
+		// once retired, tokens signed with the key are rejected
+		updateStoredKeys(t, func(keys []token.JWTKey) {
+			keys[0].RetiresAt = time.Now().Unix() - 1
+		})
+		_, err = token.ParseJWTToken(oldToken)
+		assert.Error(t, err)
+		_, err = token.ParseJWTToken(tokenWithoutKid)
+		assert.Error(t, err)
+		_, err = token.ParseJWTToken(newToken)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{nextKey.Kid}, getJWKS(t))
+	})
+
+	t.Run(`should hash tokens with the algorithm of the signing key`, func(t *testing.T) {
+		resetKeyset()
+		req, ctx := createContext(s)
+		req.Header.Set("Cookie", adminCookie)
+
+		// EdDSA key signs with SHA-512, so at_hash is the left half of SHA-512 hash
+		_, err := resolvers.RotateJWTKeyResolver(ctx, model.RotateJWTKeyInput{
+			Type: refs.NewStringRef("EdDSA"),
+		})
+		assert.NoError(t, err)
+		idToken, _, err := token.CreateIDToken(models.User{ID: "jwt-keys-test"}, []string{"user"}, "http://localhost:8080", "test-nonce", "", token.AuthenticationInfo{}, nil, map[string]string{
+			"at_hash": "test-access-token",
+		})
+		assert.NoError(t, err)
+		parsedToken, _, err := new(jwt.Parser).ParseUnverified(idToken, jwt.MapClaims{})
+		assert.NoError(t, err)
+		assert.Equal(t, "EdDSA", parsedToken.Method.Alg())
+		hash := sha512.Sum512([]byte("test-access-token"))
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(hash[:32]), parsedToken.Claims.(jwt.MapClaims)["at_hash"])
+	})
+
+	t.Run(`should not publish hmac keys in jwks`, func(t *testing.T) {
+		resetKeyset()
+		req, ctx := createContext(s)
+		req.Header.Set("Cookie", adminCookie)
+
+		hmacKey, err := resolvers.RotateJWTKeyResolver(ctx, model.RotateJWTKeyInput{
+			Type: refs.NewStringRef("HS256"),
+		})
+		assert.NoError(t, err)
+		_, kid := signToken(t)
+		assert.Equal(t, hmacKey.Kid, kid)
+		assert.NotContains(t, getJWKS(t), hmacKey.Kid)
+	})
+}
@@ -197,6 +197,41 @@ func TestJwt(t *testing.T) {
 		})
 	})
 
+	t.Run("EdDSA algorithms", func(t *testing.T) {
+		t.Run("EdDSA", func(t *testing.T) {
+			_, privateKey, publickKey, _, err := crypto.NewEdDSAKey("EdDSA", clientID)
+			assert.NoError(t, err)
+			memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJwtType, "EdDSA")
+			memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJwtPrivateKey, privateKey)
+			memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJwtPublicKey, publickKey)
+			jwtToken, err := token.SignJWTToken(claims)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, jwtToken)
+			c, err := token.ParseJWTToken(jwtToken)
+			assert.NoError(t, err)
+			assert.Equal(t, c["email"].(string), claims["email"])
+			valid, err := token.ValidateJWTClaims(c, hostname, nonce, subject)
+			assert.NoError(t, err)
+			assert.True(t, valid)
+			jwks, err := token.GetJWKS()
+			assert.NoError(t, err)
+			assert.Len(t, jwks, 1)
+			assert.Equal(t, "OKP", jwks[0]["kty"])
+			assert.Equal(t, "Ed25519", jwks[0]["crv"])
+			assert.Equal(t, "EdDSA", jwks[0]["alg"])
+		})
+		t.Run("EdDSA with invalid key", func(t *testing.T) {
+			_, privateKey, publickKey, _, err := crypto.NewECDSAKey("ES256", clientID)
+			assert.NoError(t, err)
+			memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJwtType, "EdDSA")
+			memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJwtPrivateKey, privateKey)
+			memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJwtPublicKey, publickKey)
+			jwtToken, err := token.SignJWTToken(claims)
+			assert.Error(t, err)
+			assert.Empty(t, jwtToken)
+		})
+	})
+
 	memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJwtType, jwtType)
 	memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJwtPublicKey, publicKey)
 	memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJwtPrivateKey, privateKey)
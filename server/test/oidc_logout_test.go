@@ -0,0 +1,148 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+func oidcLogoutTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should logout with id token hint and notify clients`, func(t *testing.T) {
+		logoutTokens := make(chan string, 1)
+		backchannelServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logoutTokens <- r.FormValue("logout_token")
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer backchannelServer.Close()
+
+		req, ctx := createContext(s)
+		req.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+
+		_, err = resolvers.AddClientResolver(ctx, model.AddClientRequest{
+			Name:                 "invalid logout client",
+			RedirectUris:         []string{"https://app.example.com/callback"},
+			BackchannelLogoutURI: refs.NewStringRef("com.example.app:/logout"),
+		})
+		assert.Error(t, err)
+
+		res, err := resolvers.AddClientResolver(ctx, model.AddClientRequest{
+			Name:                   "logout client",
+			RedirectUris:           []string{"https://app.example.com/callback"},
+			PostLogoutRedirectUris: []string{"https://app.example.com/logged-out"},
+			BackchannelLogoutURI:   refs.NewStringRef(backchannelServer.URL + "/backchannel"),
+			FrontchannelLogoutURI:  refs.NewStringRef("https://app.example.com/frontchannel"),
+		})
+		assert.NoError(t, err)
+		clientID := res.Client.ID
+		client, err := db.Provider.GetClientByID(ctx, clientID)
+		assert.NoError(t, err)
+
+		email := "oidc_logout." + s.TestInfo.Email
+		user, err := db.Provider.AddUser(ctx, models.User{
+			Email:         email,
+			SignupMethods: constants.AuthRecipeMethodBasicAuth,
+			Roles:         "user",
+		})
+		assert.NoError(t, err)
+
+		// user is logged in with two sessions, both should be ended
+		gc, err := utils.GinContextFromContext(ctx)
+		assert.NoError(t, err)
+		sessionKey := constants.AuthRecipeMethodBasicAuth + ":" + user.ID
+		authTokens := []*token.Token{}
+		for i := 0; i < 2; i++ {
+			authToken, err := token.CreateAuthToken(gc, user, []string{"user"}, []string{"openid"}, constants.AuthRecipeMethodBasicAuth, token.AuthTokenOptions{Client: &client})
+			assert.NoError(t, err)
+			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash)
+			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token)
+			authTokens = append(authTokens, authToken)
+		}
+
+		sessionCookie := ""
+		logoutRequest := func(query string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(w)
+			r.LoadHTMLGlob("../../templates/*")
+			c.Request = httptest.NewRequest(http.MethodGet, "/logout?"+query, nil)
+			c.Request.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+			if sessionCookie != "" {
+				c.Request.Header.Set("Cookie", sessionCookie)
+			}
+			handlers.LogoutHandler()(c)
+			return w
+		}
+
+		// user must be identified with session or id token hint
+		w := logoutRequest("post_logout_redirect_uri=https://app.example.com/logged-out")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = logoutRequest("id_token_hint=invalid")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = logoutRequest("id_token_hint=" + authTokens[0].IDToken.Token + "&post_logout_redirect_uri=https://evil.example.com")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// id token hint without the browser session of user does not end the sessions
+		w = logoutRequest("id_token_hint=" + authTokens[0].IDToken.Token + "&post_logout_redirect_uri=https://app.example.com/logged-out")
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "https://app.example.com/logged-out", w.Header().Get("Location"))
+		for _, authToken := range authTokens {
+			sessionToken, _ := memorystore.Provider.GetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint)
+			assert.NotEmpty(t, sessionToken)
+		}
+
+		sessionCookie = fmt.Sprintf("%s=%s", constants.AppCookieName+"_session", authTokens[0].FingerPrintHash)
+		w = logoutRequest("id_token_hint=" + authTokens[0].IDToken.Token + "&post_logout_redirect_uri=https://app.example.com/logged-out&state=logout-state")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `src="https://app.example.com/frontchannel?iss=http%3A%2F%2Flocalhost%3A8080"`)
+		assert.Contains(t, w.Body.String(), `logged-out?state=logout-state`)
+
+		for _, authToken := range authTokens {
+			sessionToken, _ := memorystore.Provider.GetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint)
+			assert.Empty(t, sessionToken)
+			accessToken, _ := memorystore.Provider.GetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint)
+			assert.Empty(t, accessToken)
+		}
+
+		select {
+		case logoutToken := <-logoutTokens:
+			claims, err := token.ParseJWTToken(logoutToken)
+			assert.NoError(t, err)
+			assert.Equal(t, clientID, claims["aud"])
+			assert.Equal(t, user.ID, claims["sub"])
+			assert.Nil(t, claims["nonce"])
+			assert.Contains(t, claims["events"], token.BackchannelLogoutEvent)
+		case <-time.After(5 * time.Second):
+			t.Error("logout token is not sent to back-channel logout uri")
+		}
+
+		_, err = resolvers.DeleteClientResolver(ctx, model.ClientRequest{
+			ID: clientID,
+		})
+		assert.NoError(t, err)
+		cleanData(email)
+	})
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/oauth"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/stretchr/testify/assert"
+)
+
+func oidcProvidersTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should configure generic oidc providers`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+
+		// issuer that only supports discovery
+		var issuer *httptest.Server
+		issuer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"issuer":                 issuer.URL,
+				"authorization_endpoint": issuer.URL + "/authorize",
+				"token_endpoint":         issuer.URL + "/token",
+				"jwks_uri":               issuer.URL + "/jwks",
+				"userinfo_endpoint":      issuer.URL + "/userinfo",
+			})
+		}))
+		defer issuer.Close()
+
+		invalidConfigs := []string{
+			`invalid`,
+			`[{"name": "google", "issuer_url": "` + issuer.URL + `", "client_id": "test", "client_secret": "test"}]`,
+			`[{"name": "keycloak", "issuer_url": "` + issuer.URL + `"}]`,
+		}
+		for _, config := range invalidConfigs {
+			_, err = resolvers.UpdateEnvResolver(ctx, model.UpdateEnvInput{
+				OidcProviders: refs.NewStringRef(config),
+			})
+			assert.Error(t, err)
+		}
+
+		_, err = resolvers.UpdateEnvResolver(ctx, model.UpdateEnvInput{
+			OidcProviders: refs.NewStringRef(`[{"name": "keycloak", "display_name": "Keycloak", "issuer_url": "` + issuer.URL + `", "client_id": "test", "client_secret": "test", "claim_mapping": {"email": "upn"}}]`),
+		})
+		assert.NoError(t, err)
+
+		oidcProvider := oauth.GetGenericOIDCProvider("keycloak")
+		assert.NotNil(t, oidcProvider)
+		assert.Equal(t, "upn", oidcProvider.GetClaimName("email"))
+		assert.Equal(t, "given_name", oidcProvider.GetClaimName("given_name"))
+
+		meta, err := resolvers.MetaResolver(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, meta.OidcProviders, 1)
+		assert.Equal(t, "keycloak", meta.OidcProviders[0].Name)
+		assert.Equal(t, "Keycloak", meta.OidcProviders[0].DisplayName)
+
+		_, err = resolvers.UpdateEnvResolver(ctx, model.UpdateEnvInput{
+			OidcProviders: refs.NewStringRef(""),
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, oauth.GetGenericOIDCProvider("keycloak"))
+	})
+}
@@ -0,0 +1,215 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+)
+
+func organizationsTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should login to organization with roles of membership`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		_, err := resolvers.AddOrganizationResolver(ctx, model.AddOrganizationRequest{
+			Name:  "acme",
+			Roles: []string{"owner", "viewer"},
+		})
+		assert.Error(t, err, "unauthorized")
+
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		adminCookie := fmt.Sprintf("%s=%s", constants.AdminCookieName, h)
+		req.Header.Set("Cookie", adminCookie)
+
+		_, err = resolvers.AddOrganizationResolver(ctx, model.AddOrganizationRequest{
+			Name:         "acme",
+			Roles:        []string{"owner", "viewer"},
+			DefaultRoles: []string{"admin"},
+		})
+		assert.Error(t, err, "default roles must be the roles of organization")
+
+		organization, err := resolvers.AddOrganizationResolver(ctx, model.AddOrganizationRequest{
+			Name:         "acme",
+			DisplayName:  refs.NewStringRef("Acme Inc"),
+			Roles:        []string{"owner", "viewer"},
+			DefaultRoles: []string{"viewer"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"viewer"}, organization.DefaultRoles)
+		organizationID := organization.ID
+		defer resolvers.DeleteOrganizationResolver(ctx, model.OrganizationRequest{ID: organizationID})
+
+		_, err = resolvers.AddOrganizationResolver(ctx, model.AddOrganizationRequest{
+			Name:  "acme",
+			Roles: []string{"viewer"},
+		})
+		assert.Error(t, err, "organization name must be unique")
+
+		organization, err = resolvers.UpdateOrganizationResolver(ctx, model.UpdateOrganizationRequest{
+			ID:    organizationID,
+			Roles: []string{"owner", "viewer", "billing"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, organization.Roles, 3)
+
+		organizations, err := resolvers.OrganizationsResolver(ctx, nil)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, len(organizations.Organizations), 1)
+
+		email := "organization." + s.TestInfo.Email
+		invitedEmail := "organization.invited." + s.TestInfo.Email
+		defer cleanData(email)
+		defer cleanData(invitedEmail)
+		signup := func(email string) *model.AuthResponse {
+			_, err := resolvers.SignupResolver(ctx, model.SignUpInput{
+				Email:           email,
+				Password:        s.TestInfo.Password,
+				ConfirmPassword: s.TestInfo.Password,
+			})
+			assert.NoError(t, err)
+			verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+			assert.NoError(t, err)
+			res, err := resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+				Token: verificationRequest.Token,
+			})
+			assert.NoError(t, err)
+			return res
+		}
+		user := signup(email).User
+		signup(invitedEmail)
+		req.Header.Set("Cookie", adminCookie)
+
+		_, err = resolvers.AddOrganizationMemberResolver(ctx, model.AddOrganizationMemberRequest{
+			OrganizationID: organizationID,
+			UserID:         user.ID,
+			Roles:          []string{"admin"},
+		})
+		assert.Error(t, err, "roles must be the roles of organization")
+		member, err := resolvers.AddOrganizationMemberResolver(ctx, model.AddOrganizationMemberRequest{
+			OrganizationID: organizationID,
+			UserID:         user.ID,
+			Roles:          []string{"owner", "viewer"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, email, member.User.Email)
+		_, err = resolvers.AddOrganizationMemberResolver(ctx, model.AddOrganizationMemberRequest{
+			OrganizationID: organizationID,
+			UserID:         user.ID,
+		})
+		assert.Error(t, err, "user is already a member of organization")
+
+		_, err = resolvers.InviteOrganizationMembersResolver(ctx, model.InviteOrganizationMembersRequest{
+			OrganizationID: organizationID,
+			Emails:         []string{email, invitedEmail},
+		})
+		assert.NoError(t, err)
+		invitations, err := resolvers.OrganizationInvitationsResolver(ctx, model.OrganizationRequest{ID: organizationID})
+		assert.NoError(t, err)
+		assert.Len(t, invitations, 1)
+		assert.Equal(t, invitedEmail, invitations[0].Email)
+
+		members, err := resolvers.OrganizationMembersResolver(ctx, model.ListOrganizationMembersRequest{
+			OrganizationID: organizationID,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, members.Members, 1)
+
+		req.Header.Set("Cookie", "")
+		_, err = resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:          email,
+			Password:       s.TestInfo.Password,
+			OrganizationID: refs.NewStringRef(organizationID),
+			Roles:          []string{"billing"},
+		})
+		assert.Error(t, err, "invalid roles")
+		loginRes, err := resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:          email,
+			Password:       s.TestInfo.Password,
+			OrganizationID: refs.NewStringRef(organizationID),
+			Roles:          []string{"viewer"},
+		})
+		assert.NoError(t, err)
+		claims, err := token.ParseJWTToken(refs.StringValue(loginRes.AccessToken))
+		assert.NoError(t, err)
+		assert.Equal(t, organizationID, claims["org_id"])
+		assert.Equal(t, []interface{}{"viewer"}, claims["roles"])
+
+		// invited user becomes the member with default roles of organization on login
+		invitedRes, err := resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:          invitedEmail,
+			Password:       s.TestInfo.Password,
+			OrganizationID: refs.NewStringRef(organizationID),
+		})
+		assert.NoError(t, err)
+		claims, err = token.ParseJWTToken(refs.StringValue(invitedRes.AccessToken))
+		assert.NoError(t, err)
+		assert.Equal(t, organizationID, claims["org_id"])
+		assert.Equal(t, []interface{}{"viewer"}, claims["roles"])
+
+		// session is switched between organization and user roles
+		loginRes, err = resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:    email,
+			Password: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		claims, err = token.ParseJWTToken(refs.StringValue(loginRes.AccessToken))
+		assert.NoError(t, err)
+		assert.Nil(t, claims["org_id"])
+		sessionKey := constants.AuthRecipeMethodBasicAuth + ":" + user.ID
+		sessionToken, err := memorystore.Provider.GetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+claims["nonce"].(string))
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AppCookieName+"_session", sessionToken))
+		sessionRes, err := resolvers.SessionResolver(ctx, &model.SessionQueryInput{
+			OrganizationID: refs.NewStringRef(organizationID),
+		})
+		assert.NoError(t, err)
+		claims, err = token.ParseJWTToken(refs.StringValue(sessionRes.AccessToken))
+		assert.NoError(t, err)
+		assert.Equal(t, organizationID, claims["org_id"])
+		assert.Equal(t, []interface{}{"owner", "viewer"}, claims["roles"])
+
+		req.Header.Set("Authorization", "Bearer "+refs.StringValue(sessionRes.AccessToken))
+		userOrganizations, err := resolvers.UserOrganizationsResolver(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, userOrganizations, 1)
+		assert.Equal(t, "acme", userOrganizations[0].Organization.Name)
+		req.Header.Del("Authorization")
+
+		// removed member can not login to organization
+		req.Header.Set("Cookie", adminCookie)
+		_, err = resolvers.RemoveOrganizationMemberResolver(ctx, model.OrganizationMemberRequest{
+			OrganizationID: organizationID,
+			UserID:         user.ID,
+		})
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", "")
+		_, err = resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:          email,
+			Password:       s.TestInfo.Password,
+			OrganizationID: refs.NewStringRef(organizationID),
+		})
+		assert.Error(t, err, "user is not a member of organization")
+
+		// members & invitations are deleted with organization
+		req.Header.Set("Cookie", adminCookie)
+		_, err = resolvers.DeleteOrganizationResolver(ctx, model.OrganizationRequest{ID: organizationID})
+		assert.NoError(t, err)
+		_, err = resolvers.OrganizationResolver(ctx, model.OrganizationRequest{ID: organizationID})
+		assert.Error(t, err, "organization not found")
+		memberships, err := db.Provider.ListOrganizationMembersByUserID(ctx, invitedRes.User.ID)
+		assert.NoError(t, err)
+		assert.Len(t, memberships, 0)
+	})
+}
@@ -0,0 +1,161 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/logout"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+func refreshTokenFamilyTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should revoke refresh token family on reuse`, func(t *testing.T) {
+		clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+		assert.NoError(t, err)
+
+		refresh := func(refreshToken string) (int, map[string]interface{}) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			form := url.Values{
+				"grant_type":    {constants.GrantTypeRefreshToken},
+				"client_id":     {clientID},
+				"refresh_token": {refreshToken},
+			}
+			c.Request = httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+			c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Request.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+			handlers.TokenHandler()(c)
+			body := map[string]interface{}{}
+			json.Unmarshal(w.Body.Bytes(), &body)
+			return w.Code, body
+		}
+
+		req, ctx := createContext(s)
+		req.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+		email := "refresh_token_family." + s.TestInfo.Email
+		user, err := db.Provider.AddUser(ctx, models.User{
+			Email:         email,
+			SignupMethods: constants.AuthRecipeMethodBasicAuth,
+			Roles:         "user",
+		})
+		assert.NoError(t, err)
+		defer db.Provider.DeleteUser(ctx, user)
+
+		gc, err := utils.GinContextFromContext(ctx)
+		assert.NoError(t, err)
+		sessionKey := constants.AuthRecipeMethodBasicAuth + ":" + user.ID
+		login := func() string {
+			authToken, err := token.CreateAuthToken(gc, user, []string{"user"}, []string{"openid", "offline_access"}, constants.AuthRecipeMethodBasicAuth, token.AuthTokenOptions{})
+			assert.NoError(t, err)
+			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash)
+			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token)
+			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeRefreshToken+"_"+authToken.FingerPrint, authToken.RefreshToken.Token)
+			return authToken.RefreshToken.Token
+		}
+
+		firstRefreshToken := login()
+		claims, err := token.ParseJWTToken(firstRefreshToken)
+		assert.NoError(t, err)
+		familyID, _ := claims["family_id"].(string)
+		assert.NotEmpty(t, familyID)
+
+		// rotated refresh tokens continue the family
+		code, body := refresh(firstRefreshToken)
+		assert.Equal(t, http.StatusOK, code)
+		secondRefreshToken, _ := body["refresh_token"].(string)
+		assert.NotEmpty(t, secondRefreshToken)
+		claims, err = token.ParseJWTToken(secondRefreshToken)
+		assert.NoError(t, err)
+		assert.Equal(t, familyID, claims["family_id"])
+		assert.Equal(t, constants.AuthRecipeMethodBasicAuth, claims["login_method"])
+		// rotated refresh token is removed before the new tokens are returned
+		firstClaims, err := token.ParseJWTToken(firstRefreshToken)
+		assert.NoError(t, err)
+		session, _ := memorystore.Provider.GetUserSession(sessionKey, constants.TokenTypeRefreshToken+"_"+firstClaims["nonce"].(string))
+		assert.Empty(t, session)
+
+		code, body = refresh(secondRefreshToken)
+		assert.Equal(t, http.StatusOK, code)
+		thirdRefreshToken, _ := body["refresh_token"].(string)
+		assert.NotEmpty(t, thirdRefreshToken)
+		family, err := token.GetRefreshTokenFamily(familyID)
+		assert.NoError(t, err)
+		assert.Len(t, family.Nonces, 3)
+
+		// another login is not affected by the revoked family
+		otherRefreshToken := login()
+
+		// presenting rotated refresh token revokes the whole family
+		code, body = refresh(firstRefreshToken)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_grant", body["error"])
+		_, err = token.GetRefreshTokenFamily(familyID)
+		assert.Error(t, err)
+		code, _ = refresh(thirdRefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, code)
+		for _, nonce := range family.Nonces {
+			session, _ := memorystore.Provider.GetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+nonce)
+			assert.Empty(t, session)
+		}
+
+		code, _ = refresh(otherRefreshToken)
+		assert.Equal(t, http.StatusOK, code)
+
+		// refresh token can be used only once by concurrent requests
+		concurrentRefreshToken := login()
+		codes := make(chan int, 2)
+		var wg sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				code, _ := refresh(concurrentRefreshToken)
+				codes <- code
+			}()
+		}
+		wg.Wait()
+		close(codes)
+		successCount := 0
+		for code := range codes {
+			if code == http.StatusOK {
+				successCount++
+			}
+		}
+		assert.Equal(t, 1, successCount)
+
+		// refresh tokens do not outlive the max lifetime of family
+		maxLifetime, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyRefreshTokenMaxLifetime)
+		assert.NoError(t, err)
+		defer memorystore.Provider.UpdateEnvVariable(constants.EnvKeyRefreshTokenMaxLifetime, maxLifetime)
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyRefreshTokenMaxLifetime, "1h")
+		claims, err = token.ParseJWTToken(login())
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, claims["exp"].(int64), time.Now().Add(time.Hour).Unix())
+		family, err = token.GetRefreshTokenFamily(claims["family_id"].(string))
+		assert.NoError(t, err)
+		assert.Equal(t, claims["exp"].(int64), family.ExpiresAt)
+
+		// refresh token families are removed with the sessions on logout
+		logout.EndSession(ctx, sessionKey, user.ID, "http://localhost:8080")
+		_, err = token.GetRefreshTokenFamily(family.ID)
+		assert.Error(t, err)
+
+		memorystore.Provider.DeleteAllUserSessions(user.ID)
+	})
+}
@@ -90,6 +90,11 @@ func TestResolvers(t *testing.T) {
 			adminLogoutTests(t, s)
 			adminSessionTests(t, s)
 			updateEnvTests(t, s)
+			oidcProvidersTest(t, s)
+			samlTest(t, s)
+			clientsTest(t, s)
+			clientCredentialsTest(t, s)
+			introspectTest(t, s)
 			envTests(t, s)
 			revokeAccessTest(t, s)
 			enableAccessTest(t, s)
@@ -101,6 +106,9 @@ func TestResolvers(t *testing.T) {
 
 			// user resolvers tests
 			loginTests(t, s)
+			verifyOTPTest(t, s)
+			webauthnTest(t, s)
+			sendOTPTest(t, s)
 			signupTests(t, s)
 			forgotPasswordTest(t, s)
 			resendVerifyEmailTests(t, s)
@@ -114,6 +122,20 @@ func TestResolvers(t *testing.T) {
 			metaTests(t, s)
 			inviteUserTest(t, s)
 			validateJwtTokenTest(t, s)
+			deviceAuthorizationTest(t, s)
+			authorizeTest(t, s)
+			oidcLogoutTest(t, s)
+			jwtKeysTest(t, s)
+			idTokenEncryptionTest(t, s)
+			refreshTokenFamilyTest(t, s)
+			dpopTest(t, s)
+			consentTest(t, s)
+			authorizePromptTest(t, s)
+			userSessionsTest(t, s)
+			sessionLimitTest(t, s)
+			importUsersTest(t, s)
+			scimTest(t, s)
+			organizationsTest(t, s)
 
 			webhookLogsTest(t, s)   // get logs after above resolver tests are done
 			deleteWebhookTest(t, s) // delete webhooks (admin resolver)
@@ -0,0 +1,156 @@
+package test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// samlTestResponse returns the saml response signed by identity provider with conditions restricted to service provider
+func samlTestResponse(key *rsa.PrivateKey, requestID, email string) string {
+	now := time.Now().UTC()
+	conditions := `<saml:Conditions NotBefore="` + now.Add(-time.Minute).Format(time.RFC3339) + `" NotOnOrAfter="` + now.Add(5*time.Minute).Format(time.RFC3339) + `"><saml:AudienceRestriction><saml:Audience>http://localhost:8080/saml/metadata</saml:Audience></saml:AudienceRestriction></saml:Conditions>`
+	return samlTestResponseWithConditions(key, requestID, email, conditions)
+}
+
+// samlTestResponseWithConditions returns the saml response signed by identity provider,
+// assertion & signed info are written in canonical form so that digest can be computed from them directly
+func samlTestResponseWithConditions(key *rsa.PrivateKey, requestID, email, conditions string) string {
+	acsURL := "http://localhost:8080/saml/acs"
+	now := time.Now().UTC()
+	notOnOrAfter := now.Add(5 * time.Minute).Format(time.RFC3339)
+	assertionStart := `<saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="assertion-` + requestID + `" IssueInstant="` + now.Format(time.RFC3339) + `" Version="2.0"><saml:Issuer>https://idp.example.com</saml:Issuer>`
+	assertionEnd := `<saml:Subject><saml:NameID>` + email + `</saml:NameID><saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer"><saml:SubjectConfirmationData InResponseTo="` + requestID + `" NotOnOrAfter="` + notOnOrAfter + `" Recipient="` + acsURL + `"></saml:SubjectConfirmationData></saml:SubjectConfirmation></saml:Subject>` +
+		conditions +
+		`<saml:AttributeStatement><saml:Attribute Name="firstName"><saml:AttributeValue>Saml</saml:AttributeValue></saml:Attribute><saml:Attribute Name="groups"><saml:AttributeValue>user</saml:AttributeValue><saml:AttributeValue>unknown</saml:AttributeValue></saml:Attribute></saml:AttributeStatement></saml:Assertion>`
+
+	digest := sha256.Sum256([]byte(assertionStart + assertionEnd))
+	signedInfo := `<ds:SignedInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#"><ds:CanonicalizationMethod Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"></ds:CanonicalizationMethod><ds:SignatureMethod Algorithm="http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"></ds:SignatureMethod>` +
+		`<ds:Reference URI="#assertion-` + requestID + `"><ds:Transforms><ds:Transform Algorithm="http://www.w3.org/2000/09/xmldsig#enveloped-signature"></ds:Transform><ds:Transform Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"></ds:Transform></ds:Transforms>` +
+		`<ds:DigestMethod Algorithm="http://www.w3.org/2001/04/xmlenc#sha256"></ds:DigestMethod><ds:DigestValue>` + base64.StdEncoding.EncodeToString(digest[:]) + `</ds:DigestValue></ds:Reference></ds:SignedInfo>`
+	signedInfoHash := sha256.Sum256([]byte(signedInfo))
+	signature, _ := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, signedInfoHash[:])
+
+	response := `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" Destination="` + acsURL + `" ID="response-` + requestID + `" InResponseTo="` + requestID + `" Version="2.0">` +
+		`<samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>` +
+		assertionStart + `<ds:Signature xmlns:ds="http://www.w3.org/2000/09/xmldsig#">` + signedInfo + `<ds:SignatureValue>` + base64.StdEncoding.EncodeToString(signature) + `</ds:SignatureValue></ds:Signature>` + assertionEnd +
+		`</samlp:Response>`
+
+	return base64.StdEncoding.EncodeToString([]byte(response))
+}
+
+func samlTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should login with saml identity provider`, func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "idp.example.com"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		assert.NoError(t, err)
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySamlIdpSsoURL, "https://idp.example.com/sso")
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySamlIdpEntityID, "https://idp.example.com")
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySamlIdpCertificate, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})))
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySamlAttributeMapping, `{"given_name": "firstName", "roles": "groups"}`)
+
+		// requests are served by router, so the status of response is written as it is by the server
+		router := gin.New()
+		router.GET("/oauth_login/:oauth_provider", handlers.OAuthLoginHandler())
+		router.POST("/saml/acs", handlers.SamlAcsHandler())
+
+		login := func() string {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/oauth_login/saml?redirect_uri=http://localhost:3000/app&state=saml-state", nil)
+			req.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+			location, err := url.Parse(w.Header().Get("Location"))
+			assert.NoError(t, err)
+			assert.Equal(t, "idp.example.com", location.Host)
+			assert.NotEmpty(t, location.Query().Get("SAMLRequest"))
+			return location.Query().Get("RelayState")
+		}
+
+		acs := func(relayState, samlResponse string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			form := url.Values{
+				"RelayState":   {relayState},
+				"SAMLResponse": {samlResponse},
+			}
+			req := httptest.NewRequest(http.MethodPost, "/saml/acs", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		email := "saml." + s.TestInfo.Email
+		requestID := login()
+		samlResponse := samlTestResponse(key, requestID, email)
+		w := acs(requestID, samlResponse)
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Contains(t, w.Header().Get("Location"), "access_token=")
+		assert.Contains(t, w.Header().Get("Location"), "state=saml-state")
+
+		// response can be used only once
+		w = acs(requestID, samlResponse)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// response for other request should fail
+		requestID = login()
+		w = acs(requestID, samlTestResponse(key, "id-other", email))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// tampered assertion should fail
+		requestID = login()
+		samlResponseXML, err := base64.StdEncoding.DecodeString(samlTestResponse(key, requestID, email))
+		assert.NoError(t, err)
+		tamperedResponse := strings.Replace(string(samlResponseXML), email, "tampered."+email, 1)
+		w = acs(requestID, base64.StdEncoding.EncodeToString([]byte(tamperedResponse)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// response signed by other key should fail
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err)
+		requestID = login()
+		w = acs(requestID, samlTestResponse(otherKey, requestID, email))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// assertion without conditions or audience restriction should fail
+		requestID = login()
+		w = acs(requestID, samlTestResponseWithConditions(key, requestID, email, ""))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		requestID = login()
+		w = acs(requestID, samlTestResponseWithConditions(key, requestID, email, `<saml:Conditions></saml:Conditions>`))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySamlIdpSsoURL, "")
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySamlIdpEntityID, "")
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySamlIdpCertificate, "")
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySamlAttributeMapping, "")
+		cleanData(email)
+	})
+}
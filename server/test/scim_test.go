@@ -0,0 +1,137 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/middlewares"
+	"github.com/authorizerdev/authorizer/server/scim"
+)
+
+func scimTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should provision users & groups with scim`, func(t *testing.T) {
+		scimToken := "scim_test_token_0123456789abcdefghij"
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyScimToken, scimToken)
+		defer memorystore.Provider.UpdateEnvVariable(constants.EnvKeyScimToken, "")
+
+		router := gin.New()
+		group := router.Group("/scim/v2", middlewares.ScimAuthMiddleware())
+		group.GET("/ServiceProviderConfig", handlers.ScimServiceProviderConfigHandler())
+		group.GET("/Users", handlers.ScimListUsersHandler())
+		group.POST("/Users", handlers.ScimCreateUserHandler())
+		group.GET("/Users/:id", handlers.ScimGetUserHandler())
+		group.PATCH("/Users/:id", handlers.ScimPatchUserHandler())
+		group.DELETE("/Users/:id", handlers.ScimDeleteUserHandler())
+		group.GET("/Groups/:id", handlers.ScimGetGroupHandler())
+		group.PATCH("/Groups/:id", handlers.ScimPatchGroupHandler())
+
+		request := func(method, path, body, token string) (*httptest.ResponseRecorder, map[string]interface{}) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(method, path, strings.NewReader(body))
+			req.Header.Set("Content-Type", scim.ContentType)
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			router.ServeHTTP(w, req)
+			res := map[string]interface{}{}
+			json.Unmarshal(w.Body.Bytes(), &res)
+			return w, res
+		}
+
+		w, _ := request(http.MethodGet, "/scim/v2/ServiceProviderConfig", "", "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		w, _ = request(http.MethodGet, "/scim/v2/ServiceProviderConfig", "", "invalid")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		w, res := request(http.MethodGet, "/scim/v2/ServiceProviderConfig", "", scimToken)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, true, res["patch"].(map[string]interface{})["supported"])
+
+		email := "scim." + s.TestInfo.Email
+		defer cleanData(email)
+		userBody := `{
+			"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+			"userName": "` + strings.ToUpper(email) + `",
+			"name": {"givenName": "Scim", "familyName": "User"},
+			"emails": [{"value": "` + email + `", "type": "work", "primary": true}],
+			"active": true
+		}`
+		w, res = request(http.MethodPost, "/scim/v2/Users", userBody, scimToken)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, email, res["userName"])
+		userID, _ := res["id"].(string)
+		assert.NotEmpty(t, userID)
+		w, res = request(http.MethodPost, "/scim/v2/Users", userBody, scimToken)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, scim.ErrorTypeUniqueness, res["scimType"])
+
+		w, res = request(http.MethodGet, "/scim/v2/Users?filter="+url.QueryEscape(`userName eq "`+email+`"`), "", scimToken)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, float64(1), res["totalResults"])
+		w, res = request(http.MethodGet, "/scim/v2/Users?filter="+url.QueryEscape(`name.givenName sw "scim" and (active eq true or emails[type eq "home"])`), "", scimToken)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.GreaterOrEqual(t, res["totalResults"], float64(1))
+		w, res = request(http.MethodGet, "/scim/v2/Users?filter="+url.QueryEscape(`userName eq`), "", scimToken)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, scim.ErrorTypeInvalidFilter, res["scimType"])
+
+		// azure ad sends boolean values as strings
+		patchBody := `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [
+				{"op": "Replace", "path": "active", "value": "False"},
+				{"op": "replace", "value": {"name.familyName": "Provisioned", "nickName": "scim"}}
+			]
+		}`
+		w, res = request(http.MethodPatch, "/scim/v2/Users/"+userID, patchBody, scimToken)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, false, res["active"])
+		user, err := db.Provider.GetUserByID(context.Background(), userID)
+		assert.NoError(t, err)
+		assert.NotNil(t, user.RevokedTimestamp)
+		assert.Equal(t, "Provisioned", *user.FamilyName)
+		assert.Equal(t, "Scim", *user.GivenName)
+
+		adminRole := "admin"
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyProtectedRoles, adminRole)
+		w, _ = request(http.MethodPatch, "/scim/v2/Groups/"+adminRole, `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [{"op": "add", "path": "members", "value": [{"value": "`+userID+`"}]}]
+		}`, scimToken)
+		assert.Equal(t, http.StatusOK, w.Code)
+		user, err = db.Provider.GetUserByID(context.Background(), userID)
+		assert.NoError(t, err)
+		assert.Contains(t, strings.Split(user.Roles, ","), adminRole)
+		w, _ = request(http.MethodGet, "/scim/v2/Groups/"+adminRole, "", scimToken)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), userID)
+
+		w, _ = request(http.MethodPatch, "/scim/v2/Groups/"+adminRole, `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [{"op": "remove", "path": "members[value eq \"`+userID+`\"]"}]
+		}`, scimToken)
+		assert.Equal(t, http.StatusOK, w.Code)
+		user, err = db.Provider.GetUserByID(context.Background(), userID)
+		assert.NoError(t, err)
+		assert.NotContains(t, strings.Split(user.Roles, ","), adminRole)
+		w, _ = request(http.MethodGet, "/scim/v2/Groups/invalid_role", "", scimToken)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w, _ = request(http.MethodDelete, "/scim/v2/Users/"+userID, "", scimToken)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		w, _ = request(http.MethodGet, "/scim/v2/Users/"+userID, "", scimToken)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
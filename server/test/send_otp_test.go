@@ -0,0 +1,160 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/sms"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSmsSender captures the sms sent to phone numbers
+type testSmsSender struct {
+	messages map[string]string
+}
+
+func (s *testSmsSender) SendSMS(to, message string) error {
+	s.messages[to] = message
+	return nil
+}
+
+// otp returns the otp from the last sms sent to phone number
+func (s *testSmsSender) otp(phoneNumber string) string {
+	return strings.Split(s.messages[phoneNumber], " ")[0]
+}
+
+func sendOTPTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should login with otp sent to verified phone number`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		email := "send_otp." + s.TestInfo.Email
+		phoneNumber := fmt.Sprintf("+1%010d", time.Now().UnixNano()%10000000000)
+
+		sender := &testSmsSender{messages: map[string]string{}}
+		sms.RegisterSender("test", sender)
+
+		_, err := resolvers.SendOtpResolver(ctx, model.SendOTPInput{
+			PhoneNumber: phoneNumber,
+		})
+		assert.Error(t, err, "sms service is not enabled")
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySmsProvider, "test")
+		_, err = resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           email,
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		verifyRes, err := resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+
+		req.Header.Set("Authorization", "Bearer "+*verifyRes.AccessToken)
+		_, err = resolvers.UpdateProfileResolver(ctx, model.UpdateProfileInput{
+			PhoneNumber: refs.NewStringRef("invalid"),
+		})
+		assert.Error(t, err)
+		_, err = resolvers.UpdateProfileResolver(ctx, model.UpdateProfileInput{
+			PhoneNumber: refs.NewStringRef(phoneNumber),
+		})
+		assert.NoError(t, err)
+		verifyPhoneOTP := sender.otp(phoneNumber)
+		assert.NotEmpty(t, verifyPhoneOTP)
+
+		// login otp should not be sent to unverified phone number
+		_, err = resolvers.SendOtpResolver(ctx, model.SendOTPInput{
+			PhoneNumber: phoneNumber,
+		})
+		assert.Error(t, err)
+
+		// phone number can only be verified by logged in user
+		req.Header.Set("Authorization", "")
+		_, err = resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
+			PhoneNumber: refs.NewStringRef(phoneNumber),
+			Otp:         verifyPhoneOTP,
+		})
+		assert.Error(t, err)
+
+		req.Header.Set("Authorization", "Bearer "+*verifyRes.AccessToken)
+		verifyPhoneRes, err := resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
+			PhoneNumber: refs.NewStringRef(phoneNumber),
+			Otp:         verifyPhoneOTP,
+		})
+		assert.NoError(t, err)
+		assert.True(t, refs.BoolValue(verifyPhoneRes.User.PhoneNumberVerified))
+		req.Header.Set("Authorization", "")
+
+		// login with otp has the roles of user instead of default roles
+		user, err := db.Provider.GetUserByEmail(ctx, email)
+		assert.NoError(t, err)
+		user.Roles = "user,editor"
+		_, err = db.Provider.UpdateUser(ctx, user)
+		assert.NoError(t, err)
+
+		_, err = resolvers.SendOtpResolver(ctx, model.SendOTPInput{
+			PhoneNumber: phoneNumber,
+		})
+		assert.NoError(t, err)
+		loginOTP := sender.otp(phoneNumber)
+		assert.NotEmpty(t, loginOTP)
+
+		invalidOTP := "000000"
+		if loginOTP == invalidOTP {
+			invalidOTP = "111111"
+		}
+		_, err = resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
+			PhoneNumber: refs.NewStringRef(phoneNumber),
+			Otp:         invalidOTP,
+		})
+		assert.Error(t, err, "invalid otp")
+
+		loginRes, err := resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
+			PhoneNumber: refs.NewStringRef(phoneNumber),
+			Otp:         loginOTP,
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, loginRes.AccessToken)
+		assert.Equal(t, email, loginRes.User.Email)
+		claims, err := token.ParseJWTToken(refs.StringValue(loginRes.AccessToken))
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{"user", "editor"}, claims["roles"])
+
+		// otp can be used only once
+		_, err = resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
+			PhoneNumber: refs.NewStringRef(phoneNumber),
+			Otp:         loginOTP,
+		})
+		assert.Error(t, err)
+
+		// mfa policy is applied to the login with otp sent via sms
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyMFARequiredRoles, "user")
+		_, err = resolvers.SendOtpResolver(ctx, model.SendOTPInput{
+			PhoneNumber: phoneNumber,
+		})
+		assert.NoError(t, err)
+		loginRes, err = resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
+			PhoneNumber: refs.NewStringRef(phoneNumber),
+			Otp:         sender.otp(phoneNumber),
+		})
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyMFARequiredRoles, "")
+		assert.NoError(t, err)
+		assert.True(t, refs.BoolValue(loginRes.ShouldShowEmailOtpScreen))
+		assert.Nil(t, loginRes.AccessToken)
+		assert.Nil(t, loginRes.TotpEnrollment)
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySmsProvider, "")
+		cleanData(email)
+	})
+}
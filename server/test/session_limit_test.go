@@ -0,0 +1,84 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+func sessionLimitTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should limit active sessions and their lifetime`, func(t *testing.T) {
+		_, ctx := createContext(s)
+		user, err := db.Provider.AddUser(ctx, models.User{
+			Email:         "session_limit." + s.TestInfo.Email,
+			SignupMethods: constants.AuthRecipeMethodBasicAuth,
+			Roles:         "user",
+		})
+		assert.NoError(t, err)
+		defer db.Provider.DeleteUser(ctx, user)
+		defer memorystore.Provider.DeleteAllUserSessions(user.ID)
+
+		for _, key := range []string{constants.EnvKeyMaxActiveSessions, constants.EnvKeySessionIdleTimeout, constants.EnvKeySessionMaxLifetime} {
+			value, err := memorystore.Provider.GetStringStoreEnvVariable(key)
+			assert.NoError(t, err)
+			defer memorystore.Provider.UpdateEnvVariable(key, value)
+		}
+
+		gc, err := utils.GinContextFromContext(ctx)
+		assert.NoError(t, err)
+		sessionKey := constants.AuthRecipeMethodBasicAuth + ":" + user.ID
+		login := func(authInfo token.AuthenticationInfo) *token.Token {
+			authToken, err := token.CreateAuthToken(gc, user, []string{"user"}, []string{"openid"}, constants.AuthRecipeMethodBasicAuth, token.AuthTokenOptions{AuthInfo: &authInfo})
+			assert.NoError(t, err)
+			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash)
+			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token)
+			return authToken
+		}
+		isActive := func(authToken *token.Token) bool {
+			_, err := token.ValidateBrowserSession(gc, authToken.FingerPrintHash)
+			return err == nil
+		}
+
+		// oldest login is ended when the limit is reached
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyMaxActiveSessions, "2")
+		firstAuthInfo := token.NewAuthenticationInfo(constants.AuthRecipeMethodBasicAuth, false)
+		firstAuthInfo.AuthTime = time.Now().Add(-time.Minute).Unix()
+		first := login(firstAuthInfo)
+		second := login(token.NewAuthenticationInfo(constants.AuthRecipeMethodBasicAuth, false))
+		// rolled over session is not a new login
+		rolledOver := login(firstAuthInfo)
+		assert.True(t, isActive(first))
+		assert.True(t, isActive(second))
+		assert.True(t, isActive(rolledOver))
+		third := login(token.NewAuthenticationInfo(constants.AuthRecipeMethodBasicAuth, false))
+		assert.False(t, isActive(first))
+		assert.False(t, isActive(rolledOver))
+		assert.True(t, isActive(second))
+		assert.True(t, isActive(third))
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyMaxActiveSessions, "")
+
+		// session is ended after the max lifetime from login
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySessionMaxLifetime, "1h")
+		assert.True(t, isActive(third))
+		oldAuthInfo := token.NewAuthenticationInfo(constants.AuthRecipeMethodBasicAuth, false)
+		oldAuthInfo.AuthTime = time.Now().Add(-2 * time.Hour).Unix()
+		assert.False(t, isActive(login(oldAuthInfo)))
+
+		// session expires after the idle timeout from its last use
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySessionIdleTimeout, "10m")
+		authToken := login(token.NewAuthenticationInfo(constants.AuthRecipeMethodBasicAuth, false))
+		assert.True(t, isActive(authToken))
+		sessionData, err := token.ParseSessionToken(authToken.FingerPrintHash)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, sessionData.ExpiresAt, time.Now().Add(10*time.Minute).Unix())
+	})
+}
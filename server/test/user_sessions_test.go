@@ -0,0 +1,129 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+func userSessionsTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should list and revoke user sessions`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		user, err := db.Provider.AddUser(ctx, models.User{
+			Email:         "user_sessions." + s.TestInfo.Email,
+			SignupMethods: constants.AuthRecipeMethodBasicAuth,
+			Roles:         "user",
+		})
+		assert.NoError(t, err)
+		defer db.Provider.DeleteUser(ctx, user)
+		defer memorystore.Provider.DeleteAllUserSessions(user.ID)
+
+		gc, err := utils.GinContextFromContext(ctx)
+		assert.NoError(t, err)
+		login := func(loginMethod, userAgent string) *token.Token {
+			authToken, err := token.CreateAuthToken(gc, user, []string{"user"}, []string{"openid", "email"}, loginMethod, token.AuthTokenOptions{})
+			assert.NoError(t, err)
+			sessionKey := loginMethod + ":" + user.ID
+			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash)
+			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token)
+			err = db.Provider.AddSession(ctx, models.Session{
+				ID:        authToken.SessionID,
+				UserID:    user.ID,
+				UserAgent: userAgent,
+				IP:        "127.0.0.1",
+			})
+			assert.NoError(t, err)
+			return authToken
+		}
+
+		current := login(constants.AuthRecipeMethodBasicAuth, "current-device")
+		other := login(constants.AuthRecipeMethodMagicLinkLogin, "other-device")
+		req.Header.Set("Authorization", "Bearer "+current.AccessToken.Token)
+
+		sessions, err := resolvers.SessionsResolver(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 2)
+		for _, session := range sessions {
+			switch session.Nonce {
+			case current.FingerPrint:
+				assert.True(t, session.IsCurrent)
+				assert.Equal(t, constants.AuthRecipeMethodBasicAuth, session.LoginMethod)
+				assert.Equal(t, "current-device", refs.StringValue(session.UserAgent))
+			case other.FingerPrint:
+				assert.False(t, session.IsCurrent)
+				assert.Equal(t, constants.AuthRecipeMethodMagicLinkLogin, session.LoginMethod)
+				assert.Equal(t, "other-device", refs.StringValue(session.UserAgent))
+				assert.Equal(t, "127.0.0.1", refs.StringValue(session.IP))
+			default:
+				t.Errorf("unexpected session %s", session.Nonce)
+			}
+			assert.NotNil(t, session.CreatedAt)
+			assert.NotNil(t, session.LastUsedAt)
+		}
+
+		// revoked session can't be used anymore
+		_, err = resolvers.RevokeSessionResolver(ctx, model.RevokeSessionInput{
+			Nonce: other.FingerPrint,
+		})
+		assert.NoError(t, err)
+		_, err = token.ValidateBrowserSession(gc, other.FingerPrintHash)
+		assert.Error(t, err)
+		_, err = resolvers.RevokeSessionResolver(ctx, model.RevokeSessionInput{
+			Nonce: other.FingerPrint,
+		})
+		assert.Error(t, err)
+		sessions, err = resolvers.SessionsResolver(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 1)
+
+		// all the sessions except current one are revoked
+		login(constants.AuthRecipeMethodBasicAuth, "another-device")
+		login(constants.AuthRecipeMethodGoogle, "another-device")
+		_, err = resolvers.RevokeOtherSessionsResolver(ctx)
+		assert.NoError(t, err)
+		sessions, err = resolvers.SessionsResolver(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 1)
+		assert.Equal(t, current.FingerPrint, sessions[0].Nonce)
+		dbSessions, err := db.Provider.ListSessionsByUserID(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.Len(t, dbSessions, 1)
+
+		// admin apis
+		_, err = resolvers.UserSessionsResolver(ctx, model.UserSessionsInput{
+			UserID: user.ID,
+		})
+		assert.Error(t, err)
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+		sessions, err = resolvers.UserSessionsResolver(ctx, model.UserSessionsInput{
+			UserID: user.ID,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 1)
+		assert.False(t, sessions[0].IsCurrent)
+		_, err = resolvers.RevokeUserSessionResolver(ctx, model.RevokeUserSessionInput{
+			UserID: user.ID,
+			Nonce:  current.FingerPrint,
+		})
+		assert.NoError(t, err)
+		_, err = token.ValidateAccessToken(gc, current.AccessToken.Token)
+		assert.Error(t, err)
+	})
+}
@@ -2,13 +2,19 @@ package test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,7 +38,7 @@ func usersTest(t *testing.T, s TestSetup) {
 			},
 		}
 
-		usersRes, err := resolvers.UsersResolver(ctx, pagination)
+		usersRes, err := resolvers.UsersResolver(ctx, pagination, nil, nil)
 		assert.NotNil(t, err, "unauthorized")
 
 		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
@@ -41,11 +47,144 @@ func usersTest(t *testing.T, s TestSetup) {
 		assert.Nil(t, err)
 		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
 
-		usersRes, err = resolvers.UsersResolver(ctx, pagination)
+		usersRes, err = resolvers.UsersResolver(ctx, pagination, nil, nil)
 		assert.Nil(t, err)
 		rLen := len(usersRes.Users)
 		assert.GreaterOrEqual(t, rLen, 1)
 
 		cleanData(email)
 	})
+
+	t.Run(`should filter and sort users`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.Nil(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.Nil(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+
+		now := time.Now().Unix()
+		emailFragment := "users_filter_" + uuid.New().String()[:8]
+		addUser := func(prefix, roles, signupMethods string, verified, revoked bool) models.User {
+			user := models.User{
+				Email:         prefix + "." + emailFragment + "." + s.TestInfo.Email,
+				Roles:         roles,
+				SignupMethods: signupMethods,
+			}
+			if verified {
+				user.EmailVerifiedAt = &now
+			}
+			if revoked {
+				user.RevokedTimestamp = &now
+			}
+			user, err := db.Provider.AddUser(ctx, user)
+			assert.Nil(t, err)
+			return user
+		}
+		first := addUser("a", "user", constants.AuthRecipeMethodBasicAuth, true, false)
+		second := addUser("b", "user,admin", constants.AuthRecipeMethodBasicAuth+","+constants.AuthRecipeMethodGoogle, false, false)
+		third := addUser("c", "admins", constants.AuthRecipeMethodGithub, true, true)
+		defer db.Provider.DeleteUser(ctx, first)
+		defer db.Provider.DeleteUser(ctx, second)
+		defer db.Provider.DeleteUser(ctx, third)
+
+		listUsers := func(filter model.UsersFilterInput, sortBy, sortOrder string) []string {
+			// email fragment limits the results to the users of this test
+			filter.Email = refs.NewStringRef(strings.ToUpper(emailFragment))
+			res, err := resolvers.UsersResolver(ctx, nil, &filter, &model.UsersSortInput{
+				SortBy:    refs.NewStringRef(sortBy),
+				SortOrder: refs.NewStringRef(sortOrder),
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, int64(len(res.Users)), res.Pagination.Total)
+			emails := []string{}
+			for _, user := range res.Users {
+				emails = append(emails, user.Email)
+			}
+			return emails
+		}
+
+		assert.Equal(t, []string{first.Email, second.Email, third.Email}, listUsers(model.UsersFilterInput{}, "email", "asc"))
+		assert.Equal(t, []string{third.Email, second.Email, first.Email}, listUsers(model.UsersFilterInput{}, "email", "desc"))
+		// role must match one of the comma separated roles
+		assert.Equal(t, []string{second.Email}, listUsers(model.UsersFilterInput{Role: refs.NewStringRef("admin")}, "email", "asc"))
+		assert.Equal(t, []string{first.Email, second.Email}, listUsers(model.UsersFilterInput{Role: refs.NewStringRef("user")}, "email", "asc"))
+		assert.Equal(t, []string{second.Email}, listUsers(model.UsersFilterInput{SignupMethod: refs.NewStringRef(constants.AuthRecipeMethodGoogle)}, "email", "asc"))
+		assert.Equal(t, []string{first.Email, third.Email}, listUsers(model.UsersFilterInput{IsEmailVerified: refs.NewBoolRef(true)}, "email", "asc"))
+		assert.Equal(t, []string{second.Email}, listUsers(model.UsersFilterInput{IsEmailVerified: refs.NewBoolRef(false)}, "email", "asc"))
+		assert.Equal(t, []string{third.Email}, listUsers(model.UsersFilterInput{IsRevoked: refs.NewBoolRef(true)}, "email", "asc"))
+		assert.Equal(t, []string{first.Email, second.Email}, listUsers(model.UsersFilterInput{IsRevoked: refs.NewBoolRef(false)}, "email", "asc"))
+		assert.Len(t, listUsers(model.UsersFilterInput{CreatedAfter: refs.NewInt64Ref(now - 60), CreatedBefore: refs.NewInt64Ref(now + 60)}, "", ""), 3)
+		assert.Len(t, listUsers(model.UsersFilterInput{CreatedAfter: refs.NewInt64Ref(now + 60)}, "", ""), 0)
+
+		_, err = resolvers.UsersResolver(ctx, nil, nil, &model.UsersSortInput{
+			SortBy: refs.NewStringRef("password"),
+		})
+		assert.NotNil(t, err)
+		_, err = resolvers.UsersResolver(ctx, nil, nil, &model.UsersSortInput{
+			SortOrder: refs.NewStringRef("random"),
+		})
+		assert.NotNil(t, err)
+	})
+	t.Run(`should paginate users with cursor`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.Nil(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.Nil(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+
+		emailFragment := "users_cursor_" + uuid.New().String()[:8]
+		for _, prefix := range []string{"a", "b", "c"} {
+			user, err := db.Provider.AddUser(ctx, models.User{
+				Email:         prefix + "." + emailFragment + "." + s.TestInfo.Email,
+				Roles:         "user",
+				SignupMethods: constants.AuthRecipeMethodBasicAuth,
+			})
+			assert.Nil(t, err)
+			defer db.Provider.DeleteUser(ctx, user)
+		}
+
+		first := int64(2)
+		listUsers := func(pagination *model.PaginationInput) *model.Users {
+			res, err := resolvers.UsersResolver(ctx, &model.PaginatedInput{
+				Pagination: pagination,
+			}, &model.UsersFilterInput{
+				Email: refs.NewStringRef(emailFragment),
+			}, nil)
+			assert.Nil(t, err)
+			// total is counted only for the pages listed by offset
+			if pagination.After == nil {
+				assert.Equal(t, int64(3), res.Pagination.Total)
+			} else {
+				assert.Equal(t, int64(0), res.Pagination.Total)
+			}
+			return res
+		}
+
+		// users created in same second are ordered by id
+		res := listUsers(&model.PaginationInput{First: &first})
+		assert.Len(t, res.Users, 2)
+		assert.True(t, res.Pagination.HasNextPage)
+		assert.NotEmpty(t, refs.StringValue(res.Pagination.EndCursor))
+		emails := []string{res.Users[0].Email, res.Users[1].Email}
+
+		res = listUsers(&model.PaginationInput{First: &first, After: res.Pagination.EndCursor})
+		assert.Len(t, res.Users, 1)
+		assert.False(t, res.Pagination.HasNextPage)
+		assert.NotContains(t, emails, res.Users[0].Email)
+
+		// page based pagination keeps working
+		page := int64(2)
+		res = listUsers(&model.PaginationInput{Limit: &first, Page: &page})
+		assert.Len(t, res.Users, 1)
+		assert.False(t, res.Pagination.HasNextPage)
+
+		_, err = resolvers.UsersResolver(ctx, &model.PaginatedInput{
+			Pagination: &model.PaginationInput{
+				After: refs.NewStringRef("invalid"),
+			},
+		}, nil, nil)
+		assert.NotNil(t, err)
+	})
 }
@@ -0,0 +1,160 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/stretchr/testify/assert"
+)
+
+func verifyOTPTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should verify otp for mfa login`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		email := "verify_otp." + s.TestInfo.Email
+		_, err := resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           email,
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		_, err = resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyMFARequiredRoles, "user")
+		loginRes, err := resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:    email,
+			Password: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		// totp secret should not be returned before the otp sent to email is verified
+		assert.True(t, refs.BoolValue(loginRes.ShouldShowEmailOtpScreen))
+		assert.False(t, refs.BoolValue(loginRes.ShouldShowTotpScreen))
+		assert.Nil(t, loginRes.AccessToken)
+		assert.Nil(t, loginRes.TotpEnrollment)
+
+		// otp without mfa session should fail
+		_, err = resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
+			Email: refs.NewStringRef(email),
+			Otp:   "000000",
+		})
+		assert.Error(t, err)
+
+		mfaSession := ""
+		for _, c := range (&http.Response{Header: s.GinContext.Writer.Header()}).Cookies() {
+			if c.Name == constants.MfaCookieName+"_session" {
+				mfaSession = c.Value
+			}
+		}
+		assert.NotEmpty(t, mfaSession)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.MfaCookieName+"_session", mfaSession))
+
+		_, err = resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
+			Email: refs.NewStringRef(email),
+			Otp:   "invalid",
+		})
+		assert.Error(t, err, "invalid otp")
+
+		// email otp is only sent via email, so it is replaced with the known otp
+		emailOtpRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeMfaEnrollment)
+		assert.NoError(t, err)
+		emailOtp := "123456"
+		emailOtpHash, err := crypto.EncryptPassword(emailOtp)
+		assert.NoError(t, err)
+		emailOtpRequest.Token = emailOtpHash
+		assert.NoError(t, db.Provider.DeleteVerificationRequest(ctx, emailOtpRequest))
+		emailOtpRequest.ID = ""
+		_, err = db.Provider.AddVerificationRequest(ctx, emailOtpRequest)
+		assert.NoError(t, err)
+		verifyRes, err := resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
+			Email: refs.NewStringRef(email),
+			Otp:   emailOtp,
+		})
+		assert.NoError(t, err)
+		assert.True(t, refs.BoolValue(verifyRes.ShouldShowTotpScreen))
+		assert.Nil(t, verifyRes.AccessToken)
+		assert.NotNil(t, verifyRes.TotpEnrollment)
+		assert.NotEmpty(t, verifyRes.TotpEnrollment.OtpauthURI)
+
+		// email otp can be used only once
+		_, err = resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
+			Email: refs.NewStringRef(email),
+			Otp:   emailOtp,
+		})
+		assert.Error(t, err, "invalid otp")
+
+		secret := verifyRes.TotpEnrollment.Secret
+		otp, err := crypto.GenerateTOTPCode(secret, time.Now())
+		assert.NoError(t, err)
+		verifyRes, err = resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
+			Email: refs.NewStringRef(email),
+			Otp:   otp,
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, verifyRes.AccessToken)
+		assert.True(t, refs.BoolValue(verifyRes.User.IsMultiFactorAuthEnabled))
+
+		// mfa session can be used only once
+		_, err = resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
+			Email: refs.NewStringRef(email),
+			Otp:   otp,
+		})
+		assert.Error(t, err)
+
+		// enrolled users should always be asked for otp
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyMFARequiredRoles, "")
+		loginRes, err = resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:    email,
+			Password: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		assert.True(t, refs.BoolValue(loginRes.ShouldShowTotpScreen))
+		assert.Nil(t, loginRes.TotpEnrollment)
+
+		for _, c := range (&http.Response{Header: s.GinContext.Writer.Header()}).Cookies() {
+			if c.Name == constants.MfaCookieName+"_session" {
+				mfaSession = c.Value
+			}
+		}
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.MfaCookieName+"_session", mfaSession))
+
+		// used otp can not be replayed with new mfa session
+		_, err = resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
+			Email: refs.NewStringRef(email),
+			Otp:   otp,
+		})
+		assert.Error(t, err, "invalid otp")
+
+		// mfa session is invalidated after too many invalid attempts
+		for i := 1; i < constants.OtpMaxAttempts; i++ {
+			_, err = resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
+				Email: refs.NewStringRef(email),
+				Otp:   "000000",
+			})
+			assert.Error(t, err)
+		}
+		otp, err = crypto.GenerateTOTPCode(secret, time.Now().Add(30*time.Second))
+		assert.NoError(t, err)
+		_, err = resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
+			Email: refs.NewStringRef(email),
+			Otp:   otp,
+		})
+		assert.Error(t, err, "invalid mfa session")
+
+		cleanData(email)
+	})
+}
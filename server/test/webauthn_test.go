@@ -0,0 +1,193 @@
+package test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/webauthn"
+	"github.com/stretchr/testify/assert"
+)
+
+// webauthnTestAuthenticator simulates ed25519 platform authenticator
+type webauthnTestAuthenticator struct {
+	credentialID []byte
+	publicKey    ed25519.PublicKey
+	privateKey   ed25519.PrivateKey
+	signCount    uint32
+}
+
+func (a *webauthnTestAuthenticator) authenticatorData(rpID string, attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append([]byte{}, rpIDHash[:]...)
+	// user present & user verified flags
+	flags := byte(0x05)
+	if attested {
+		flags |= 0x40
+	}
+	data = append(data, flags)
+	signCount := make([]byte, 4)
+	binary.BigEndian.PutUint32(signCount, a.signCount)
+	data = append(data, signCount...)
+	if attested {
+		data = append(data, make([]byte, 16)...)
+		credentialIDLength := make([]byte, 2)
+		binary.BigEndian.PutUint16(credentialIDLength, uint16(len(a.credentialID)))
+		data = append(data, credentialIDLength...)
+		data = append(data, a.credentialID...)
+		// COSE key {1: 1 (OKP), 3: -8 (EdDSA), -1: 6 (Ed25519), -2: x}
+		data = append(data, 0xa4, 0x01, 0x01, 0x03, 0x27, 0x20, 0x06, 0x21, 0x58, 0x20)
+		data = append(data, a.publicKey...)
+	}
+	return data
+}
+
+func (a *webauthnTestAuthenticator) attestationObject(rpID string) []byte {
+	authData := a.authenticatorData(rpID, true)
+	// {"fmt": "none", "attStmt": {}, "authData": authData}
+	data := []byte{0xa3, 0x63}
+	data = append(data, "fmt"...)
+	data = append(data, 0x64)
+	data = append(data, "none"...)
+	data = append(data, 0x67)
+	data = append(data, "attStmt"...)
+	data = append(data, 0xa0, 0x68)
+	data = append(data, "authData"...)
+	data = append(data, 0x59, byte(len(authData)>>8), byte(len(authData)))
+	return append(data, authData...)
+}
+
+func webauthnTestClientData(ceremonyType, challenge, origin string) []byte {
+	clientData, _ := json.Marshal(map[string]string{
+		"type":      ceremonyType,
+		"challenge": challenge,
+		"origin":    origin,
+	})
+	return clientData
+}
+
+func webauthnTest(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should register & login with webauthn credential`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		req.Header.Set("X-Authorizer-URL", "http://localhost:8080")
+		rpID := "localhost"
+		origin := "http://localhost:3000"
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyAllowedOrigins, "http://*.localhost:3000,"+origin)
+		defer memorystore.Provider.UpdateEnvVariable(constants.EnvKeyAllowedOrigins, "*")
+		email := "webauthn." + s.TestInfo.Email
+		_, err := resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           email,
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		verifyRes, err := resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+
+		// registration requires logged in user
+		_, err = resolvers.WebauthnRegistrationOptionsResolver(ctx)
+		assert.Error(t, err)
+
+		req.Header.Set("Authorization", "Bearer "+*verifyRes.AccessToken)
+		registrationOptions, err := resolvers.WebauthnRegistrationOptionsResolver(ctx)
+		assert.NoError(t, err)
+		challenge := registrationOptions.Options["challenge"].(string)
+		assert.NotEmpty(t, challenge)
+
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		credentialID := make([]byte, 16)
+		rand.Read(credentialID)
+		authenticator := &webauthnTestAuthenticator{
+			credentialID: credentialID,
+			publicKey:    publicKey,
+			privateKey:   privateKey,
+		}
+
+		// challenge not issued by server should fail
+		_, err = resolvers.WebauthnRegisterResolver(ctx, model.WebauthnRegisterInput{
+			ID:                webauthn.EncodeBase64(credentialID),
+			ClientDataJSON:    webauthn.EncodeBase64(webauthnTestClientData(webauthn.CeremonyTypeCreate, "invalid", origin)),
+			AttestationObject: webauthn.EncodeBase64(authenticator.attestationObject(rpID)),
+		})
+		assert.Error(t, err)
+
+		// sub domain of relying party is not trusted unless it is an allowed origin
+		_, err = resolvers.WebauthnRegisterResolver(ctx, model.WebauthnRegisterInput{
+			ID:                webauthn.EncodeBase64(credentialID),
+			ClientDataJSON:    webauthn.EncodeBase64(webauthnTestClientData(webauthn.CeremonyTypeCreate, challenge, "http://evil.localhost:3000")),
+			AttestationObject: webauthn.EncodeBase64(authenticator.attestationObject(rpID)),
+		})
+		assert.Error(t, err)
+
+		registerRes, err := resolvers.WebauthnRegisterResolver(ctx, model.WebauthnRegisterInput{
+			ID:                webauthn.EncodeBase64(credentialID),
+			ClientDataJSON:    webauthn.EncodeBase64(webauthnTestClientData(webauthn.CeremonyTypeCreate, challenge, origin)),
+			AttestationObject: webauthn.EncodeBase64(authenticator.attestationObject(rpID)),
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, registerRes.Message)
+		req.Header.Set("Authorization", "")
+
+		loginOptions, err := resolvers.WebauthnLoginOptionsResolver(ctx, &model.WebauthnLoginOptionsInput{
+			Email: refs.NewStringRef(email),
+		})
+		assert.NoError(t, err)
+		challenge = loginOptions.Options["challenge"].(string)
+
+		login := func(challenge string) (*model.AuthResponse, error) {
+			authenticator.signCount++
+			clientData := webauthnTestClientData(webauthn.CeremonyTypeGet, challenge, origin)
+			authData := authenticator.authenticatorData(rpID, false)
+			clientDataHash := sha256.Sum256(clientData)
+			signature := ed25519.Sign(authenticator.privateKey, append(append([]byte{}, authData...), clientDataHash[:]...))
+			return resolvers.WebauthnLoginResolver(ctx, model.WebauthnLoginInput{
+				ID:                webauthn.EncodeBase64(credentialID),
+				ClientDataJSON:    webauthn.EncodeBase64(clientData),
+				AuthenticatorData: webauthn.EncodeBase64(authData),
+				Signature:         webauthn.EncodeBase64(signature),
+			})
+		}
+
+		loginRes, err := login(challenge)
+		assert.NoError(t, err)
+		assert.NotNil(t, loginRes.AccessToken)
+		assert.Equal(t, email, loginRes.User.Email)
+
+		// challenge can be used only once
+		_, err = login(challenge)
+		assert.Error(t, err)
+
+		// discoverable credential login without email
+		loginOptions, err = resolvers.WebauthnLoginOptionsResolver(ctx, nil)
+		assert.NoError(t, err)
+		loginRes, err = login(loginOptions.Options["challenge"].(string))
+		assert.NoError(t, err)
+		assert.NotNil(t, loginRes.AccessToken)
+
+		// sign count that does not increase should fail
+		authenticator.signCount = 0
+		loginOptions, err = resolvers.WebauthnLoginOptionsResolver(ctx, nil)
+		assert.NoError(t, err)
+		_, err = login(loginOptions.Options["challenge"].(string))
+		assert.Error(t, err)
+
+		req.Header.Set("X-Authorizer-URL", "")
+		cleanData(email)
+	})
+}
@@ -1,6 +1,7 @@
 package token
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/crypto"
 	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/dpop"
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/utils"
@@ -29,6 +31,8 @@ type JWTToken struct {
 
 // Token object to hold the finger print and refresh token information
 type Token struct {
+	// SessionID is the id of login session that is saved in db with the device information
+	SessionID       string    `json:"session_id"`
 	FingerPrint     string    `json:"fingerprint"`
 	FingerPrintHash string    `json:"fingerprint_hash"`
 	RefreshToken    *JWTToken `json:"refresh_token"`
@@ -45,18 +49,25 @@ type SessionData struct {
 	IssuedAt    int64    `json:"iat"`
 	ExpiresAt   int64    `json:"exp"`
 	LoginMethod string   `json:"login_method"`
+	AuthenticationInfo
 }
 
-// CreateSessionToken creates a new session token
-func CreateSessionToken(user models.User, nonce string, roles, scope []string, loginMethod string) (*SessionData, string, error) {
+// CreateSessionToken creates a new session token,
+// authInfo is of the login that started the session
+func CreateSessionToken(user models.User, nonce string, roles, scope []string, loginMethod string, authInfo AuthenticationInfo) (*SessionData, string, error) {
 	fingerPrintMap := &SessionData{
-		Nonce:       nonce,
-		Roles:       roles,
-		Subject:     user.ID,
-		Scope:       scope,
-		LoginMethod: loginMethod,
-		IssuedAt:    time.Now().Unix(),
-		ExpiresAt:   time.Now().AddDate(1, 0, 0).Unix(),
+		Nonce:              nonce,
+		Roles:              roles,
+		Subject:            user.ID,
+		Scope:              scope,
+		LoginMethod:        loginMethod,
+		IssuedAt:           time.Now().Unix(),
+		ExpiresAt:          time.Now().AddDate(1, 0, 0).Unix(),
+		AuthenticationInfo: authInfo,
+	}
+	// session does not outlive the idle timeout & max lifetime of session
+	if expiresAt := getSessionExpiresAt(authInfo, fingerPrintMap.IssuedAt); expiresAt != 0 && expiresAt < fingerPrintMap.ExpiresAt {
+		fingerPrintMap.ExpiresAt = expiresAt
 	}
 	fingerPrintBytes, _ := json.Marshal(fingerPrintMap)
 	fingerPrintHash, err := crypto.EncryptAES(string(fingerPrintBytes))
@@ -67,25 +78,86 @@ func CreateSessionToken(user models.User, nonce string, roles, scope []string, l
 	return fingerPrintMap, fingerPrintHash, nil
 }
 
-// CreateAuthToken creates a new auth token when userlogs in
-func CreateAuthToken(gc *gin.Context, user models.User, roles, scope []string, loginMethod string) (*Token, error) {
+// AuthorizationParams are the params of authorization request
+// that are bound to the id token returned by the authorize endpoint
+type AuthorizationParams struct {
+	// Nonce sent by the client is set in the id token instead of the session nonce
+	Nonce string
+	// Code returned with the id token is bound with c_hash claim
+	Code string
+	// IsAccessTokenReturned binds the access token returned with the id token with at_hash claim
+	IsAccessTokenReturned bool
+}
+
+// AuthTokenOptions are the optional params to create the auth token,
+// zero value creates the tokens of a new login for the instance client configured with CLIENT_ID
+type AuthTokenOptions struct {
+	// AuthInfo is the authentication info of login e.g. multi factor login or the existing session,
+	// single factor authentication with the login method is used if it is nil
+	AuthInfo *AuthenticationInfo
+	// Client is the registered oauth client, client_id is used as audience & token lifetimes of client are used
+	Client *models.Client
+	// RefreshTokenFamilyID is the family of rotated refresh token that the new refresh token is added to,
+	// new refresh token family is created if it is empty i.e. for a new login
+	RefreshTokenFamilyID string
+	// DPoPJKT is the thumbprint of DPoP key that the access & refresh tokens are bound to
+	DPoPJKT string
+	// Authorization binds the id token to the authorization request if it is set
+	Authorization *AuthorizationParams
+}
+
+// CreateAuthToken creates the session, access, id & refresh tokens when user logs in
+func CreateAuthToken(gc *gin.Context, user models.User, roles, scope []string, loginMethod string, options AuthTokenOptions) (*Token, error) {
+	authInfo := NewAuthenticationInfo(loginMethod, false)
+	if options.AuthInfo != nil {
+		authInfo = *options.AuthInfo
+	}
+	client := options.Client
+	dpopJKT := options.DPoPJKT
+	authorization := options.Authorization
+
 	hostname := parsers.GetHost(gc)
 	nonce := uuid.New().String()
-	_, fingerPrintHash, err := CreateSessionToken(user, nonce, roles, scope, loginMethod)
+	// new login ends the oldest sessions of user beyond MAX_ACTIVE_SESSIONS
+	evictSessions(gc, user.ID, authInfo.SessionID)
+	// roles are limited to the roles of membership when user is logged in to organization,
+	// so the removed members & roles are not carried over when tokens are refreshed
+	if authInfo.OrganizationID != "" {
+		allowedRoles, err := GetAllowedRoles(gc, user, authInfo.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		roles = filterRoles(roles, allowedRoles)
+	}
+	_, fingerPrintHash, err := CreateSessionToken(user, nonce, roles, scope, loginMethod, authInfo)
 	if err != nil {
 		return nil, err
 	}
-	accessToken, accessTokenExpiresAt, err := CreateAccessToken(user, roles, scope, hostname, nonce, loginMethod)
+	accessToken, accessTokenExpiresAt, err := CreateAccessToken(user, roles, scope, hostname, nonce, loginMethod, authInfo, client, dpopJKT)
 	if err != nil {
 		return nil, err
 	}
 
-	idToken, idTokenExpiresAt, err := CreateIDToken(user, roles, hostname, nonce, loginMethod)
+	idTokenNonce := nonce
+	idTokenHashClaims := map[string]string{}
+	if authorization != nil {
+		if authorization.Nonce != "" {
+			idTokenNonce = authorization.Nonce
+		}
+		if authorization.IsAccessTokenReturned {
+			idTokenHashClaims["at_hash"] = accessToken
+		}
+		if authorization.Code != "" {
+			idTokenHashClaims["c_hash"] = authorization.Code
+		}
+	}
+	idToken, idTokenExpiresAt, err := CreateIDToken(user, roles, hostname, idTokenNonce, loginMethod, authInfo, client, idTokenHashClaims)
 	if err != nil {
 		return nil, err
 	}
 
 	res := &Token{
+		SessionID:       authInfo.SessionID,
 		FingerPrint:     nonce,
 		FingerPrintHash: fingerPrintHash,
 		AccessToken:     &JWTToken{Token: accessToken, ExpiresAt: accessTokenExpiresAt},
@@ -93,10 +165,26 @@ func CreateAuthToken(gc *gin.Context, user models.User, roles, scope []string, l
 	}
 
 	if utils.StringSliceContains(scope, "offline_access") {
-		refreshToken, refreshTokenExpiresAt, err := CreateRefreshToken(user, roles, scope, hostname, nonce, loginMethod)
+		var family *RefreshTokenFamily
+		if options.RefreshTokenFamilyID == "" {
+			family = newRefreshTokenFamily(user.ID, loginMethod)
+		} else {
+			family, err = GetRefreshTokenFamily(options.RefreshTokenFamilyID)
+			if err != nil {
+				return nil, err
+			}
+			if family.IsExpired() {
+				return nil, fmt.Errorf("refresh token family is expired")
+			}
+		}
+		refreshToken, refreshTokenExpiresAt, err := CreateRefreshToken(user, roles, scope, hostname, nonce, loginMethod, authInfo, client, family, dpopJKT)
 		if err != nil {
 			return nil, err
 		}
+		family.Nonces = append(family.Nonces, nonce)
+		if err := saveRefreshTokenFamily(family, refreshTokenExpiresAt); err != nil {
+			return nil, err
+		}
 
 		res.RefreshToken = &JWTToken{Token: refreshToken, ExpiresAt: refreshTokenExpiresAt}
 	}
@@ -104,12 +192,20 @@ func CreateAuthToken(gc *gin.Context, user models.User, roles, scope []string, l
 	return res, nil
 }
 
-// CreateRefreshToken util to create JWT token
-func CreateRefreshToken(user models.User, roles, scopes []string, hostname, nonce, loginMethod string) (string, int64, error) {
-	// expires in 1 year
-	expiryBound := time.Hour * 8760
+// CreateRefreshToken util to create JWT token,
+// refresh token does not outlive the max lifetime of its refresh token family
+// & carries the authentication info to the refreshed tokens
+func CreateRefreshToken(user models.User, roles, scopes []string, hostname, nonce, loginMethod string, authInfo AuthenticationInfo, client *models.Client, family *RefreshTokenFamily, dpopJKT string) (string, int64, error) {
+	expiryBound := getRefreshTokenExpiry(client)
 	expiresAt := time.Now().Add(expiryBound).Unix()
-	clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+	if family.ExpiresAt != 0 && family.ExpiresAt < expiresAt {
+		expiresAt = family.ExpiresAt
+	}
+	// refresh token is ended with the session it is issued in
+	if sessionExpiresAt := getSessionExpiresAt(authInfo, time.Now().Unix()); sessionExpiresAt != 0 && sessionExpiresAt < expiresAt {
+		expiresAt = sessionExpiresAt
+	}
+	clientID, err := GetAudience(client)
 	if err != nil {
 		return "", 0, err
 	}
@@ -124,6 +220,16 @@ func CreateRefreshToken(user models.User, roles, scopes []string, hostname, nonc
 		"scope":        scopes,
 		"nonce":        nonce,
 		"login_method": loginMethod,
+		"family_id":    family.ID,
+		"sid":          authInfo.SessionID,
+		"auth_time":    authInfo.AuthTime,
+		"amr":          authInfo.AMR,
+	}
+	if authInfo.OrganizationID != "" {
+		customClaims["org_id"] = authInfo.OrganizationID
+	}
+	if dpopJKT != "" {
+		customClaims["cnf"] = map[string]string{"jkt": dpopJKT}
 	}
 
 	token, err := SignJWTToken(customClaims)
@@ -135,20 +241,17 @@ func CreateRefreshToken(user models.User, roles, scopes []string, hostname, nonc
 }
 
 // CreateAccessToken util to create JWT token, based on
-// user information, roles config and CUSTOM_ACCESS_TOKEN_SCRIPT
-func CreateAccessToken(user models.User, roles, scopes []string, hostName, nonce, loginMethod string) (string, int64, error) {
-	expireTime, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAccessTokenExpiryTime)
+// user information, roles config and CUSTOM_ACCESS_TOKEN_SCRIPT.
+// org_id claim is added if user is logged in to organization
+func CreateAccessToken(user models.User, roles, scopes []string, hostName, nonce, loginMethod string, authInfo AuthenticationInfo, client *models.Client, dpopJKT string) (string, int64, error) {
+	expiryBound, err := getAccessTokenExpiry(client)
 	if err != nil {
 		return "", 0, err
 	}
-	expiryBound, err := utils.ParseDurationInSeconds(expireTime)
-	if err != nil {
-		expiryBound = time.Minute * 30
-	}
 
 	expiresAt := time.Now().Add(expiryBound).Unix()
 
-	clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+	clientID, err := GetAudience(client)
 	if err != nil {
 		return "", 0, err
 	}
@@ -164,6 +267,13 @@ func CreateAccessToken(user models.User, roles, scopes []string, hostName, nonce
 		"roles":        roles,
 		"login_method": loginMethod,
 	}
+	if authInfo.OrganizationID != "" {
+		customClaims["org_id"] = authInfo.OrganizationID
+	}
+	// access token is sender constrained to the DPoP key
+	if dpopJKT != "" {
+		customClaims["cnf"] = map[string]string{"jkt": dpopJKT}
+	}
 
 	token, err := SignJWTToken(customClaims)
 	if err != nil {
@@ -186,16 +296,48 @@ func GetAccessToken(gc *gin.Context) (string, error) {
 		return "", fmt.Errorf(`unauthorized`)
 	}
 
-	if strings.ToLower(authSplit[0]) != "bearer" {
+	// DPoP bound access token is sent with DPoP authorization scheme
+	scheme := strings.ToLower(authSplit[0])
+	if scheme != "bearer" && scheme != strings.ToLower(constants.DPoPHeader) {
 		return "", fmt.Errorf(`not a bearer token`)
 	}
 
-	token := strings.TrimPrefix(auth, "Bearer ")
-	return token, nil
+	return authSplit[1], nil
 }
 
-// Function to validate access token for authorizer apis (profile, update_profile)
+// Function to validate access token for authorizer apis (profile, update_profile),
+// DPoP proof is required for the access token bound to DPoP key
 func ValidateAccessToken(gc *gin.Context, accessToken string) (map[string]interface{}, error) {
+	res, err := ValidateAccessTokenWithoutProof(gc, accessToken)
+	if err != nil {
+		return res, err
+	}
+
+	isDPoPScheme := strings.HasPrefix(strings.ToLower(gc.Request.Header.Get("Authorization")), strings.ToLower(constants.DPoPHeader)+" ")
+	jkt := dpop.GetJKT(res)
+	if jkt == "" {
+		if isDPoPScheme {
+			return res, fmt.Errorf(`unauthorized: access token is not bound to dpop key`)
+		}
+		return res, nil
+	}
+	if !isDPoPScheme {
+		return res, fmt.Errorf(`unauthorized: dpop proof is required`)
+	}
+	proofJKT, err := dpop.ValidateProof(gc, accessToken)
+	if err != nil {
+		return res, fmt.Errorf(`unauthorized: %s`, err.Error())
+	}
+	if proofJKT != jkt {
+		return res, fmt.Errorf(`unauthorized: dpop proof is not signed with the bound key`)
+	}
+
+	return res, nil
+}
+
+// ValidateAccessTokenWithoutProof validates the access token issued in user session,
+// DPoP proof of bound access token is not validated e.g. for token introspection
+func ValidateAccessTokenWithoutProof(gc *gin.Context, accessToken string) (map[string]interface{}, error) {
 	res := make(map[string]interface{})
 
 	if accessToken == "" {
@@ -207,16 +349,22 @@ func ValidateAccessToken(gc *gin.Context, accessToken string) (map[string]interf
 		return res, err
 	}
 
-	userID := res["sub"].(string)
-	nonce := res["nonce"].(string)
-	loginMethod := res["login_method"]
+	// tokens issued without session e.g. logout tokens do not have nonce
+	userID, ok := res["sub"].(string)
+	if !ok || userID == "" {
+		return res, fmt.Errorf(`unauthorized`)
+	}
+	nonce, ok := res["nonce"].(string)
+	if !ok || nonce == "" {
+		return res, fmt.Errorf(`unauthorized`)
+	}
 	sessionKey := userID
-	if loginMethod != nil && loginMethod != "" {
-		sessionKey = loginMethod.(string) + ":" + userID
+	if loginMethod, ok := res["login_method"].(string); ok && loginMethod != "" {
+		sessionKey = loginMethod + ":" + userID
 	}
 
 	token, err := memorystore.Provider.GetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+nonce)
-	if nonce == "" || err != nil {
+	if err != nil {
 		return res, fmt.Errorf(`unauthorized`)
 	}
 
@@ -249,16 +397,22 @@ func ValidateRefreshToken(gc *gin.Context, refreshToken string) (map[string]inte
 		return res, err
 	}
 
-	userID := res["sub"].(string)
-	nonce := res["nonce"].(string)
-	loginMethod := res["login_method"]
+	// tokens issued without session e.g. logout tokens do not have nonce
+	userID, ok := res["sub"].(string)
+	if !ok || userID == "" {
+		return res, fmt.Errorf(`unauthorized`)
+	}
+	nonce, ok := res["nonce"].(string)
+	if !ok || nonce == "" {
+		return res, fmt.Errorf(`unauthorized`)
+	}
 	sessionKey := userID
-	if loginMethod != nil && loginMethod != "" {
-		sessionKey = loginMethod.(string) + ":" + userID
+	if loginMethod, ok := res["login_method"].(string); ok && loginMethod != "" {
+		sessionKey = loginMethod + ":" + userID
 	}
 
 	token, err := memorystore.Provider.GetUserSession(sessionKey, constants.TokenTypeRefreshToken+"_"+nonce)
-	if nonce == "" || err != nil {
+	if err != nil {
 		return res, fmt.Errorf(`unauthorized`)
 	}
 
@@ -278,11 +432,8 @@ func ValidateRefreshToken(gc *gin.Context, refreshToken string) (map[string]inte
 	return res, nil
 }
 
-func ValidateBrowserSession(gc *gin.Context, encryptedSession string) (*SessionData, error) {
-	if encryptedSession == "" {
-		return nil, fmt.Errorf(`unauthorized`)
-	}
-
+// ParseSessionToken decrypts the session token & returns the session data
+func ParseSessionToken(encryptedSession string) (*SessionData, error) {
 	decryptedFingerPrint, err := crypto.DecryptAES(encryptedSession)
 	if err != nil {
 		return nil, err
@@ -294,6 +445,24 @@ func ValidateBrowserSession(gc *gin.Context, encryptedSession string) (*SessionD
 		return nil, err
 	}
 
+	// sessions created before authentication time was tracked
+	if res.AuthTime == 0 {
+		res.AuthTime = res.IssuedAt
+	}
+
+	return &res, nil
+}
+
+func ValidateBrowserSession(gc *gin.Context, encryptedSession string) (*SessionData, error) {
+	if encryptedSession == "" {
+		return nil, fmt.Errorf(`unauthorized`)
+	}
+
+	res, err := ParseSessionToken(encryptedSession)
+	if err != nil {
+		return nil, err
+	}
+
 	sessionStoreKey := res.Subject
 	if res.LoginMethod != "" {
 		sessionStoreKey = res.LoginMethod + ":" + res.Subject
@@ -309,24 +478,23 @@ func ValidateBrowserSession(gc *gin.Context, encryptedSession string) (*SessionD
 		return nil, fmt.Errorf(`unauthorized: invalid nonce`)
 	}
 
-	if res.ExpiresAt < time.Now().Unix() {
+	if res.IsExpired() {
+		// idle & expired sessions are ended
+		go memorystore.Provider.DeleteUserSession(sessionStoreKey, res.Nonce)
 		return nil, fmt.Errorf(`unauthorized: token expired`)
 	}
 
-	return &res, nil
+	return res, nil
 }
 
 // CreateIDToken util to create JWT token, based on
-// user information, roles config and CUSTOM_ACCESS_TOKEN_SCRIPT
-func CreateIDToken(user models.User, roles []string, hostname, nonce, loginMethod string) (string, int64, error) {
-	expireTime, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAccessTokenExpiryTime)
+// user information, roles config and CUSTOM_ACCESS_TOKEN_SCRIPT.
+// hashClaims are the claims like at_hash & c_hash with the value that is hashed
+func CreateIDToken(user models.User, roles []string, hostname, nonce, loginMethod string, authInfo AuthenticationInfo, client *models.Client, hashClaims map[string]string) (string, int64, error) {
+	expiryBound, err := getAccessTokenExpiry(client)
 	if err != nil {
 		return "", 0, err
 	}
-	expiryBound, err := utils.ParseDurationInSeconds(expireTime)
-	if err != nil {
-		expiryBound = time.Minute * 30
-	}
 
 	expiresAt := time.Now().Add(expiryBound).Unix()
 
@@ -340,7 +508,16 @@ func CreateIDToken(user models.User, roles []string, hostname, nonce, loginMetho
 		claimKey = "roles"
 	}
 
-	clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+	// allowed roles are the roles of membership when user is logged in to organization
+	allowedRoles := strings.Split(user.Roles, ",")
+	if authInfo.OrganizationID != "" {
+		allowedRoles, err = GetAllowedRoles(context.Background(), user, authInfo.OrganizationID)
+		if err != nil {
+			return "", 0, err
+		}
+	}
+
+	clientID, err := GetAudience(client)
 	if err != nil {
 		return "", 0, err
 	}
@@ -352,10 +529,27 @@ func CreateIDToken(user models.User, roles []string, hostname, nonce, loginMetho
 		"exp":           expiresAt,
 		"iat":           time.Now().Unix(),
 		"token_type":    constants.TokenTypeIdentityToken,
-		"allowed_roles": strings.Split(user.Roles, ","),
+		"allowed_roles": allowedRoles,
 		"login_method":  loginMethod,
+		"auth_time":     authInfo.AuthTime,
+		"amr":           authInfo.AMR,
+		"acr":           authInfo.ACR(),
 		claimKey:        roles,
 	}
+	if authInfo.SessionID != "" {
+		customClaims["sid"] = authInfo.SessionID
+	}
+	if authInfo.OrganizationID != "" {
+		customClaims["org_id"] = authInfo.OrganizationID
+	}
+	// hash claims depend on the key, so id token is signed with the same key
+	signingKey, err := getSigningJWTKey()
+	if err != nil {
+		return "", 0, err
+	}
+	for claim, value := range hashClaims {
+		customClaims[claim] = getTokenHash(value, signingKey.Type)
+	}
 
 	for k, v := range userMap {
 		if k != "roles" {
@@ -396,11 +590,19 @@ func CreateIDToken(user models.User, roles []string, hostname, nonce, loginMetho
 		}
 	}
 
-	token, err := SignJWTToken(customClaims)
+	token, err := signJWTTokenWithKey(customClaims, signingKey)
 	if err != nil {
 		return "", 0, err
 	}
 
+	// encrypt the signed id token for clients that registered an encryption key
+	if client != nil && client.IsIDTokenEncrypted() {
+		token, err = crypto.EncryptJWE(token, client.IDTokenEncryptionKey, client.IDTokenEncryptedResponseAlg, client.IDTokenEncryptedResponseEnc)
+		if err != nil {
+			return "", 0, err
+		}
+	}
+
 	return token, expiresAt, nil
 }
 
@@ -0,0 +1,88 @@
+package token
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// AuthenticationInfo is the time & methods of user authentication.
+// It is saved in the session & carried over when the session is rolled over
+// or the tokens are refreshed, so that auth_time, amr & acr claims are of the actual login.
+// SessionID identifies the login across the rolled over sessions & is the id of session saved in db.
+// OrganizationID is the organization user is logged in to, roles of the tokens are scoped to it
+type AuthenticationInfo struct {
+	SessionID      string   `json:"sid"`
+	AuthTime       int64    `json:"auth_time"`
+	AMR            []string `json:"amr"`
+	OrganizationID string   `json:"org_id,omitempty"`
+}
+
+// NewAuthenticationInfo returns the authentication info of the login done now with login method,
+// isMultiFactor is true if the second factor was verified for the login
+func NewAuthenticationInfo(loginMethod string, isMultiFactor bool) AuthenticationInfo {
+	var amr []string
+	switch loginMethod {
+	case constants.AuthRecipeMethodBasicAuth:
+		amr = []string{constants.AMRPassword}
+	case constants.AuthRecipeMethodMagicLinkLogin:
+		amr = []string{constants.AMROTP}
+	case constants.AuthRecipeMethodMobileOTP:
+		amr = []string{constants.AMROTP, constants.AMRSMS}
+	case constants.AuthRecipeMethodWebauthn:
+		amr = []string{constants.AMRHardwareKey}
+	case "":
+		amr = []string{}
+	default:
+		amr = []string{constants.AMRFederated}
+	}
+	if isMultiFactor {
+		if !utils.StringSliceContains(amr, constants.AMROTP) {
+			amr = append(amr, constants.AMROTP)
+		}
+		amr = append(amr, constants.AMRMultiFactor)
+	}
+
+	return AuthenticationInfo{
+		SessionID: uuid.New().String(),
+		AuthTime:  time.Now().Unix(),
+		AMR:       amr,
+	}
+}
+
+// GetAuthenticationInfo returns the authentication info from the claims of refresh token,
+// issued at time is used as authentication time for the tokens issued before it was tracked
+func GetAuthenticationInfo(claims map[string]interface{}) AuthenticationInfo {
+	info := AuthenticationInfo{
+		AMR: []string{},
+	}
+	info.SessionID, _ = claims["sid"].(string)
+	info.OrganizationID, _ = claims["org_id"].(string)
+	if authTime, ok := claims["auth_time"].(float64); ok {
+		info.AuthTime = int64(authTime)
+	} else if iat, ok := claims["iat"].(float64); ok {
+		info.AuthTime = int64(iat)
+	}
+	for _, amr := range utils.ConvertInterfaceToSlice(claims["amr"]) {
+		if value, ok := amr.(string); ok {
+			info.AMR = append(info.AMR, value)
+		}
+	}
+	return info
+}
+
+// ACR returns the authentication context class reference of the authentication
+func (a AuthenticationInfo) ACR() string {
+	if utils.StringSliceContains(a.AMR, constants.AMRMultiFactor) {
+		return constants.ACRMultiFactor
+	}
+	return constants.ACRSingleFactor
+}
+
+// IsOlderThan returns true if the authentication was done more than maxAge seconds ago
+func (a AuthenticationInfo) IsOlderThan(maxAge int64) bool {
+	return a.AuthTime+maxAge < time.Now().Unix()
+}
@@ -0,0 +1,147 @@
+package token
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// GetAudience returns the client_id used as aud claim of tokens,
+// nil client is the instance client configured with CLIENT_ID
+func GetAudience(client *models.Client) (string, error) {
+	if client != nil {
+		return client.GetClientID(), nil
+	}
+	return memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+}
+
+// registeredAudienceCacheTTL is the time for which a registered oauth client is trusted as audience
+// without reading it from db again, so that tokens are not validated against db every time
+const registeredAudienceCacheTTL = time.Minute
+
+// registeredAudiences caches the expiry of oauth client ids that are found in db
+var registeredAudiences = struct {
+	sync.Mutex
+	expiresAt map[string]time.Time
+}{expiresAt: map[string]time.Time{}}
+
+// IsValidAudience returns true if aud claim is the instance client or a registered oauth client
+func IsValidAudience(aud interface{}) bool {
+	audience, ok := aud.(string)
+	if !ok || audience == "" {
+		return false
+	}
+
+	clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+	if err == nil && audience == clientID {
+		return true
+	}
+
+	registeredAudiences.Lock()
+	expiresAt, ok := registeredAudiences.expiresAt[audience]
+	registeredAudiences.Unlock()
+	if ok && time.Now().Before(expiresAt) {
+		return true
+	}
+
+	_, err = db.Provider.GetClientByID(context.Background(), audience)
+	registeredAudiences.Lock()
+	defer registeredAudiences.Unlock()
+	if err != nil {
+		delete(registeredAudiences.expiresAt, audience)
+		return false
+	}
+	registeredAudiences.expiresAt[audience] = time.Now().Add(registeredAudienceCacheTTL)
+	return true
+}
+
+// InvalidateAudience removes the cached oauth client from the valid audiences,
+// it is called when the client is deleted so that its tokens are rejected immediately
+func InvalidateAudience(clientID string) {
+	registeredAudiences.Lock()
+	defer registeredAudiences.Unlock()
+	delete(registeredAudiences.expiresAt, clientID)
+}
+
+// getAccessTokenExpiry returns the lifetime of access & id token,
+// ACCESS_TOKEN_EXPIRY_TIME is used if client does not override it
+func getAccessTokenExpiry(client *models.Client) (time.Duration, error) {
+	expireTime := ""
+	if client != nil && client.AccessTokenExpiryTime != "" {
+		expireTime = client.AccessTokenExpiryTime
+	} else {
+		var err error
+		expireTime, err = memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAccessTokenExpiryTime)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	expiryBound, err := utils.ParseDurationInSeconds(expireTime)
+	if err != nil {
+		expiryBound = time.Minute * 30
+	}
+	return expiryBound, nil
+}
+
+// getRefreshTokenExpiry returns the lifetime of refresh token,
+// REFRESH_TOKEN_EXPIRY_TIME is used if client does not override it & by default it expires in 1 year
+func getRefreshTokenExpiry(client *models.Client) time.Duration {
+	expireTime := ""
+	if client != nil && client.RefreshTokenExpiryTime != "" {
+		expireTime = client.RefreshTokenExpiryTime
+	} else {
+		expireTime, _ = memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyRefreshTokenExpiryTime)
+	}
+
+	expiryBound, err := utils.ParseDurationInSeconds(expireTime)
+	if err != nil {
+		expiryBound = time.Hour * 8760
+	}
+	return expiryBound
+}
+
+// CreateClientAccessToken creates the access token of client_credentials grant,
+// client itself is the subject of token & it is not persisted in session store
+func CreateClientAccessToken(client models.Client, scopes []string, hostname string) (string, int64, error) {
+	expiryBound, err := getAccessTokenExpiry(&client)
+	if err != nil {
+		return "", 0, err
+	}
+	expiresAt := time.Now().Add(expiryBound).Unix()
+
+	clientID := client.GetClientID()
+	customClaims := jwt.MapClaims{
+		"iss":        hostname,
+		"aud":        clientID,
+		"sub":        clientID,
+		"client_id":  clientID,
+		"nonce":      uuid.New().String(),
+		"exp":        expiresAt,
+		"iat":        time.Now().Unix(),
+		"token_type": constants.TokenTypeAccessToken,
+		"scope":      scopes,
+	}
+
+	token, err := SignJWTToken(customClaims)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// IsClientAccessToken returns true if the token is issued with client_credentials grant
+func IsClientAccessToken(claims jwt.MapClaims) bool {
+	clientID, ok := claims["client_id"].(string)
+	return ok && clientID != "" && claims["sub"] == clientID && claims["token_type"] == constants.TokenTypeAccessToken
+}
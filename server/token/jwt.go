@@ -1,22 +1,35 @@
 package token
 
 import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
 	"errors"
+	"hash"
+	"strings"
 
 	"github.com/golang-jwt/jwt"
-
-	"github.com/authorizerdev/authorizer/server/constants"
-	"github.com/authorizerdev/authorizer/server/crypto"
-	"github.com/authorizerdev/authorizer/server/memorystore"
 )
 
-// SignJWTToken common util to sing jwt token
+// SignJWTToken common util to sing jwt token with the active key,
+// kid header is set so that the token can be verified after key rotation
 func SignJWTToken(claims jwt.MapClaims) (string, error) {
-	jwtType, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtType)
+	key, err := getSigningJWTKey()
 	if err != nil {
 		return "", err
 	}
-	signingMethod := jwt.GetSigningMethod(jwtType)
+	return signJWTTokenWithKey(claims, key)
+}
+
+// getSigningJWTKey returns the active key after activating the key due for activation
+func getSigningJWTKey() (*JWTKey, error) {
+	activateDueJWTKey()
+	return getActiveJWTKey()
+}
+
+// signJWTTokenWithKey signs the jwt token with key, used when the claims depend on the key
+func signJWTTokenWithKey(claims jwt.MapClaims, key *JWTKey) (string, error) {
+	signingMethod := jwt.GetSigningMethod(key.Type)
 	if signingMethod == nil {
 		return "", errors.New("unsupported signing method")
 	}
@@ -25,85 +38,60 @@ func SignJWTToken(claims jwt.MapClaims) (string, error) {
 		return "", errors.New("unsupported signing method")
 	}
 	t.Claims = claims
+	t.Header["kid"] = key.KeyID
 
-	switch signingMethod {
-	case jwt.SigningMethodHS256, jwt.SigningMethodHS384, jwt.SigningMethodHS512:
-		jwtSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtSecret)
-		if err != nil {
-			return "", err
-		}
-		return t.SignedString([]byte(jwtSecret))
-	case jwt.SigningMethodRS256, jwt.SigningMethodRS384, jwt.SigningMethodRS512:
-		jwtPrivateKey, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtPrivateKey)
-		if err != nil {
-			return "", err
-		}
-		key, err := crypto.ParseRsaPrivateKeyFromPemStr(jwtPrivateKey)
-		if err != nil {
-			return "", err
-		}
-		return t.SignedString(key)
-	case jwt.SigningMethodES256, jwt.SigningMethodES384, jwt.SigningMethodES512:
-		jwtPrivateKey, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtPrivateKey)
-		if err != nil {
-			return "", err
-		}
-		key, err := crypto.ParseEcdsaPrivateKeyFromPemStr(jwtPrivateKey)
-		if err != nil {
-			return "", err
-		}
+	signingKey, err := key.signingKey()
+	if err != nil {
+		return "", err
+	}
+	return t.SignedString(signingKey)
+}
 
-		return t.SignedString(key)
+// getTokenHash returns the hash of token for at_hash & c_hash claims i.e. base64url encoded
+// left half of the hash, hash algorithm is the one of alg of the key signing the id token.
+// SHA-512 is used for EdDSA as Ed25519 signs with SHA-512
+func getTokenHash(value, alg string) string {
+	var h hash.Hash
+	switch {
+	case alg == "EdDSA" || strings.HasSuffix(alg, "512"):
+		h = sha512.New()
+	case strings.HasSuffix(alg, "384"):
+		h = sha512.New384()
 	default:
-		return "", errors.New("unsupported signing method")
+		h = sha256.New()
 	}
+	h.Write([]byte(value))
+	sum := h.Sum(nil)
+	return base64.RawURLEncoding.EncodeToString(sum[:len(sum)/2])
 }
 
-// ParseJWTToken common util to parse jwt token
+// ParseJWTToken common util to parse jwt token,
+// key is selected by the kid header & tokens without kid are verified with the active & previous keys
 func ParseJWTToken(token string) (jwt.MapClaims, error) {
-	jwtType, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtType)
-	if err != nil {
-		return nil, err
-	}
-	signingMethod := jwt.GetSigningMethod(jwtType)
-
 	var claims jwt.MapClaims
 
-	switch signingMethod {
-	case jwt.SigningMethodHS256, jwt.SigningMethodHS384, jwt.SigningMethodHS512:
-		_, err = jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
-			jwtSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtSecret)
-			if err != nil {
-				return nil, err
-			}
-			return []byte(jwtSecret), nil
-		})
-	case jwt.SigningMethodRS256, jwt.SigningMethodRS384, jwt.SigningMethodRS512:
-		_, err = jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
-			jwtPublicKey, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtPublicKey)
-			if err != nil {
-				return nil, err
-			}
-			key, err := crypto.ParseRsaPublicKeyFromPemStr(jwtPublicKey)
-			if err != nil {
-				return nil, err
-			}
-			return key, nil
-		})
-	case jwt.SigningMethodES256, jwt.SigningMethodES384, jwt.SigningMethodES512:
+	unverifiedToken, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		return claims, err
+	}
+	keyID, _ := unverifiedToken.Header["kid"].(string)
+	keys, err := getVerificationJWTKeys(keyID)
+	if err != nil {
+		return claims, err
+	}
+	for _, key := range keys {
+		claims = jwt.MapClaims{}
 		_, err = jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
-			jwtPublicKey, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtPublicKey)
-			if err != nil {
-				return nil, err
-			}
-			key, err := crypto.ParseEcdsaPublicKeyFromPemStr(jwtPublicKey)
-			if err != nil {
-				return nil, err
+			// signing method of token must be the one of key
+			if token.Method.Alg() != key.Type {
+				return nil, errors.New("unexpected signing method")
 			}
-			return key, nil
+			return key.verificationKey()
 		})
-	default:
-		err = errors.New("unsupported signing method")
+		// claims validation errors e.g. expired token are returned for the key that signed the token
+		if !isKeyMismatch(err) {
+			break
+		}
 	}
 	if err != nil {
 		return claims, err
@@ -122,11 +110,7 @@ func ParseJWTToken(token string) (jwt.MapClaims, error) {
 
 // ValidateJWTClaims common util to validate claims
 func ValidateJWTClaims(claims jwt.MapClaims, hostname, nonce, subject string) (bool, error) {
-	clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
-	if err != nil {
-		return false, err
-	}
-	if claims["aud"] != clientID {
+	if !IsValidAudience(claims["aud"]) {
 		return false, errors.New("invalid audience")
 	}
 
@@ -147,11 +131,7 @@ func ValidateJWTClaims(claims jwt.MapClaims, hostname, nonce, subject string) (b
 
 // ValidateJWTTokenWithoutNonce common util to validate claims without nonce
 func ValidateJWTTokenWithoutNonce(claims jwt.MapClaims, hostname, subject string) (bool, error) {
-	clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
-	if err != nil {
-		return false, err
-	}
-	if claims["aud"] != clientID {
+	if !IsValidAudience(claims["aud"]) {
 		return false, errors.New("invalid audience")
 	}
 
@@ -164,3 +144,9 @@ func ValidateJWTTokenWithoutNonce(claims jwt.MapClaims, hostname, subject string
 	}
 	return true, nil
 }
+
+// isKeyMismatch returns true if the token is not signed with the key used to verify it
+func isKeyMismatch(err error) bool {
+	validationErr, ok := err.(*jwt.ValidationError)
+	return ok && validationErr.Errors&(jwt.ValidationErrorUnverifiable|jwt.ValidationErrorSignatureInvalid) != 0
+}
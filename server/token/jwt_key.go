@@ -0,0 +1,575 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// JWTKey is a key of the signing keyset.
+// Active key is stored in JWT_TYPE, JWT_SECRET, JWT_PRIVATE_KEY & JWT_PUBLIC_KEY env variables
+// so that existing configuration keeps working, next & previous keys are stored in JWT_KEYS env variable
+type JWTKey struct {
+	KeyID      string `json:"kid"`
+	Type       string `json:"type"`
+	Secret     string `json:"secret,omitempty"`
+	PrivateKey string `json:"private_key,omitempty"`
+	PublicKey  string `json:"public_key,omitempty"`
+	CreatedAt  int64  `json:"created_at,omitempty"`
+	// ActivatesAt is the time at which next key replaces the active key
+	ActivatesAt int64 `json:"activates_at,omitempty"`
+	// DeactivatedAt is the time at which key was replaced by the next key
+	DeactivatedAt int64 `json:"deactivated_at,omitempty"`
+	// RetiresAt is the time after which tokens signed with the key are not verified
+	RetiresAt int64 `json:"retires_at,omitempty"`
+
+	// parsed keys are set for the cached keys, so that PEM keys are not parsed for every token
+	parsedSigningKey      interface{}
+	parsedVerificationKey interface{}
+}
+
+// Status returns the status of key at given time
+func (k *JWTKey) Status(now int64) string {
+	if k.RetiresAt != 0 && k.RetiresAt <= now {
+		return constants.JWTKeyStatusRetired
+	}
+	if k.DeactivatedAt != 0 {
+		return constants.JWTKeyStatusPrevious
+	}
+	if k.ActivatesAt > now {
+		return constants.JWTKeyStatusNext
+	}
+	return constants.JWTKeyStatusActive
+}
+
+// AsAPIJWTKey returns the public information of key for graphql api
+func (k *JWTKey) AsAPIJWTKey(now int64) *model.JWTKey {
+	res := &model.JWTKey{
+		Kid:    k.KeyID,
+		Type:   k.Type,
+		Status: k.Status(now),
+	}
+	if k.CreatedAt != 0 {
+		res.CreatedAt = &k.CreatedAt
+	}
+	if k.ActivatesAt != 0 {
+		res.ActivatesAt = &k.ActivatesAt
+	}
+	if k.DeactivatedAt != 0 {
+		res.DeactivatedAt = &k.DeactivatedAt
+	}
+	if k.RetiresAt != 0 {
+		res.RetiresAt = &k.RetiresAt
+	}
+	if k.PublicKey != "" {
+		res.PublicKey = &k.PublicKey
+	}
+	return res
+}
+
+// signingKey returns the key used to sign the tokens
+func (k *JWTKey) signingKey() (interface{}, error) {
+	if k.parsedSigningKey != nil {
+		return k.parsedSigningKey, nil
+	}
+	switch {
+	case crypto.IsHMACA(k.Type):
+		return []byte(k.Secret), nil
+	case crypto.IsRSA(k.Type):
+		return crypto.ParseRsaPrivateKeyFromPemStr(k.PrivateKey)
+	case crypto.IsECDSA(k.Type):
+		return crypto.ParseEcdsaPrivateKeyFromPemStr(k.PrivateKey)
+	case crypto.IsEdDSA(k.Type):
+		return crypto.ParseEdDSAPrivateKeyFromPemStr(k.PrivateKey)
+	default:
+		return nil, errors.New("unsupported signing method")
+	}
+}
+
+// verificationKey returns the key used to verify the signature of tokens
+func (k *JWTKey) verificationKey() (interface{}, error) {
+	if k.parsedVerificationKey != nil {
+		return k.parsedVerificationKey, nil
+	}
+	switch {
+	case crypto.IsHMACA(k.Type):
+		return []byte(k.Secret), nil
+	case crypto.IsRSA(k.Type):
+		return crypto.ParseRsaPublicKeyFromPemStr(k.PublicKey)
+	case crypto.IsECDSA(k.Type):
+		return crypto.ParseEcdsaPublicKeyFromPemStr(k.PublicKey)
+	case crypto.IsEdDSA(k.Type):
+		return crypto.ParseEdDSAPublicKeyFromPemStr(k.PublicKey)
+	default:
+		return nil, errors.New("unsupported signing method")
+	}
+}
+
+// jwk returns the JWK of key that is published in JWKS
+func (k *JWTKey) jwk() (map[string]interface{}, error) {
+	publicKey, err := k.verificationKey()
+	if err != nil {
+		return nil, err
+	}
+	jwkString, err := crypto.GetPubJWK(k.Type, k.KeyID, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]interface{}
+	err = json.Unmarshal([]byte(jwkString), &data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// jwtKeysMutex makes sure that the keyset is not updated concurrently
+var jwtKeysMutex sync.Mutex
+
+// jwtKeysetCache caches the keys parsed from env variables with the values they were parsed from,
+// keys are parsed again only when the env variables are changed e.g. by rotation or by other instance
+var jwtKeysetCache struct {
+	sync.Mutex
+	activeKeyEnv  string
+	activeKey     JWTKey
+	storedKeysEnv string
+	storedKeys    []JWTKey
+}
+
+// getActiveJWTKey returns the key stored in JWT_* env variables
+func getActiveJWTKey() (*JWTKey, error) {
+	jwtType, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtType)
+	if err != nil {
+		return nil, err
+	}
+	key := &JWTKey{
+		Type: jwtType,
+	}
+	if crypto.IsHMACA(jwtType) {
+		key.Secret, err = memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtSecret)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		key.PrivateKey, err = memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		key.PublicKey, err = memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtPublicKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	keyEnv := strings.Join([]string{key.Type, key.Secret, key.PrivateKey, key.PublicKey}, "\x00")
+	jwtKeysetCache.Lock()
+	defer jwtKeysetCache.Unlock()
+	if jwtKeysetCache.activeKeyEnv == keyEnv {
+		activeKey := jwtKeysetCache.activeKey
+		return &activeKey, nil
+	}
+
+	publicKey, err := key.verificationKey()
+	if err != nil {
+		return nil, err
+	}
+	key.KeyID, err = crypto.GetKeyID(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	key.parsedVerificationKey = publicKey
+	// invalid private key is not cached, so that the error is returned while signing
+	if signingKey, err := key.signingKey(); err == nil {
+		key.parsedSigningKey = signingKey
+	}
+
+	jwtKeysetCache.activeKeyEnv = keyEnv
+	jwtKeysetCache.activeKey = *key
+	return key, nil
+}
+
+// getStoredJWTKeys returns the next & previous keys stored in JWT_KEYS env variable
+func getStoredJWTKeys() ([]JWTKey, error) {
+	keys := []JWTKey{}
+	keysString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtKeys)
+	if err != nil {
+		return nil, err
+	}
+	if keysString == "" {
+		return keys, nil
+	}
+
+	jwtKeysetCache.Lock()
+	defer jwtKeysetCache.Unlock()
+	if jwtKeysetCache.storedKeysEnv == keysString {
+		return append(keys, jwtKeysetCache.storedKeys...), nil
+	}
+
+	err = json.Unmarshal([]byte(keysString), &keys)
+	if err != nil {
+		return nil, err
+	}
+	for i := range keys {
+		if verificationKey, err := keys[i].verificationKey(); err == nil {
+			keys[i].parsedVerificationKey = verificationKey
+		}
+		if keys[i].PrivateKey != "" || keys[i].Secret != "" {
+			if signingKey, err := keys[i].signingKey(); err == nil {
+				keys[i].parsedSigningKey = signingKey
+			}
+		}
+	}
+
+	jwtKeysetCache.storedKeysEnv = keysString
+	jwtKeysetCache.storedKeys = append([]JWTKey{}, keys...)
+	return keys, nil
+}
+
+// saveJWTKeys updates the keyset in env store & persists it in database.
+// activeKey is nil if the active key is not changed
+func saveJWTKeys(ctx context.Context, keys []JWTKey, activeKey *JWTKey) error {
+	// retired keys are no longer needed
+	now := time.Now().Unix()
+	storedKeys := []JWTKey{}
+	for _, key := range keys {
+		if key.Status(now) != constants.JWTKeyStatusRetired {
+			storedKeys = append(storedKeys, key)
+		}
+	}
+	keysJSON, err := json.Marshal(storedKeys)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		constants.EnvKeyJwtKeys: string(keysJSON),
+	}
+	if activeKey != nil {
+		data[constants.EnvKeyJwtType] = activeKey.Type
+		data[constants.EnvKeyJwtSecret] = activeKey.Secret
+		data[constants.EnvKeyJwtPrivateKey] = activeKey.PrivateKey
+		data[constants.EnvKeyJwtPublicKey] = activeKey.PublicKey
+	}
+	for key, value := range data {
+		err = memorystore.Provider.UpdateEnvVariable(key, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	if activeKey != nil {
+		jwk, err := crypto.GenerateJWKBasedOnEnv()
+		if err != nil {
+			return err
+		}
+		err = memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJWK, jwk)
+		if err != nil {
+			return err
+		}
+	}
+
+	env, err := db.Provider.GetEnv(ctx)
+	if err != nil {
+		return err
+	}
+	encryptedConfig, err := crypto.EncryptEnvData(data)
+	if err != nil {
+		return err
+	}
+	env.EnvData = encryptedConfig
+	_, err = db.Provider.UpdateEnv(ctx, env)
+	return err
+}
+
+// findDueJWTKey returns the index of next key whose activation time has passed
+func findDueJWTKey(keys []JWTKey, now int64) int {
+	for i, key := range keys {
+		if key.DeactivatedAt == 0 && key.ActivatesAt <= now {
+			return i
+		}
+	}
+	return -1
+}
+
+// activateJWTKey replaces the active key with keys[index],
+// active key is kept as previous key so that the tokens signed with it can be verified.
+// caller must hold jwtKeysMutex
+func activateJWTKey(ctx context.Context, keys []JWTKey, index int, now int64) error {
+	activeKey, err := getActiveJWTKey()
+	if err != nil {
+		return err
+	}
+	nextKey := keys[index]
+	nextKey.ActivatesAt = now
+
+	updatedKeys := []JWTKey{}
+	for i, key := range keys {
+		if i != index {
+			updatedKeys = append(updatedKeys, key)
+		}
+	}
+	activeKey.DeactivatedAt = now
+	updatedKeys = append(updatedKeys, *activeKey)
+
+	return saveJWTKeys(ctx, updatedKeys, &nextKey)
+}
+
+// activateDueJWTKey activates the next key once its activation time has passed.
+// It is called before signing & verifying the tokens, so no scheduler is required
+func activateDueJWTKey() {
+	keys, err := getStoredJWTKeys()
+	if err != nil {
+		log.Debug("Failed to get jwt keys: ", err)
+		return
+	}
+	now := time.Now().Unix()
+	if findDueJWTKey(keys, now) == -1 {
+		return
+	}
+
+	jwtKeysMutex.Lock()
+	defer jwtKeysMutex.Unlock()
+	// keyset can be updated while waiting for the lock
+	keys, err = getStoredJWTKeys()
+	if err != nil {
+		log.Debug("Failed to get jwt keys: ", err)
+		return
+	}
+	index := findDueJWTKey(keys, now)
+	if index == -1 {
+		return
+	}
+	err = activateJWTKey(context.Background(), keys, index, now)
+	if err != nil {
+		log.Debug("Failed to activate next jwt key: ", err)
+	}
+}
+
+// GetJWTKeys returns all the keys of keyset, active key is the first key
+func GetJWTKeys() ([]JWTKey, error) {
+	activateDueJWTKey()
+	activeKey, err := getActiveJWTKey()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := getStoredJWTKeys()
+	if err != nil {
+		return nil, err
+	}
+	return append([]JWTKey{*activeKey}, keys...), nil
+}
+
+// getVerificationJWTKeys returns the non retired key with given key id.
+// Tokens without kid header may be signed before the rotation,
+// hence they are verified with the active & previous keys
+func getVerificationJWTKeys(keyID string) ([]JWTKey, error) {
+	keys, err := GetJWTKeys()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	if keyID == "" {
+		verificationKeys := []JWTKey{keys[0]}
+		for _, key := range keys[1:] {
+			if key.Status(now) == constants.JWTKeyStatusPrevious {
+				verificationKeys = append(verificationKeys, key)
+			}
+		}
+		return verificationKeys, nil
+	}
+	for _, key := range keys {
+		if key.KeyID == keyID && key.Status(now) != constants.JWTKeyStatusRetired {
+			return []JWTKey{key}, nil
+		}
+	}
+	return nil, errors.New("unknown signing key")
+}
+
+// GetJWKS returns the JWK of all the non retired asymmetric keys,
+// HMAC keys are not published as the JWK of HMAC key is its secret
+func GetJWKS() ([]map[string]interface{}, error) {
+	keys, err := GetJWTKeys()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	jwks := []map[string]interface{}{}
+	for _, key := range keys {
+		if key.Status(now) == constants.JWTKeyStatusRetired || crypto.IsHMACA(key.Type) {
+			continue
+		}
+		jwk, err := key.jwk()
+		if err != nil {
+			return nil, err
+		}
+		jwks = append(jwks, jwk)
+	}
+	return jwks, nil
+}
+
+// RotateJWTKey generates the next key of given type that replaces the active key at activatesAt.
+// Next key is published in JWKS before activation so that relying parties can cache it,
+// if activatesAt is not in the future the key is activated immediately
+func RotateJWTKey(ctx context.Context, jwtType string, activatesAt int64) (*JWTKey, error) {
+	key := JWTKey{
+		Type: jwtType,
+	}
+	var err error
+	switch {
+	case crypto.IsHMACA(jwtType):
+		key.Secret, _, err = crypto.NewHMACKey(jwtType, "")
+	case crypto.IsRSA(jwtType):
+		_, key.PrivateKey, key.PublicKey, _, err = crypto.NewRSAKey(jwtType, "")
+	case crypto.IsECDSA(jwtType):
+		_, key.PrivateKey, key.PublicKey, _, err = crypto.NewECDSAKey(jwtType, "")
+	case crypto.IsEdDSA(jwtType):
+		_, key.PrivateKey, key.PublicKey, _, err = crypto.NewEdDSAKey(jwtType, "")
+	default:
+		return nil, errors.New("unsupported signing method")
+	}
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := key.verificationKey()
+	if err != nil {
+		return nil, err
+	}
+	key.KeyID, err = crypto.GetKeyID(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtKeysMutex.Lock()
+	defer jwtKeysMutex.Unlock()
+	keys, err := getStoredJWTKeys()
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		if k.DeactivatedAt == 0 {
+			return nil, errors.New("key rotation is already scheduled")
+		}
+	}
+
+	now := time.Now().Unix()
+	key.CreatedAt = now
+	key.ActivatesAt = activatesAt
+	if key.ActivatesAt < now {
+		key.ActivatesAt = now
+	}
+	keys = append(keys, key)
+	if key.ActivatesAt == now {
+		err = activateJWTKey(ctx, keys, len(keys)-1, now)
+	} else {
+		err = saveJWTKeys(ctx, keys, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RetireJWTKey schedules the retirement of previous key once all the tokens signed with it are expired.
+// Key is retired after the longest token lifetime from the time it was replaced
+func RetireJWTKey(ctx context.Context, keyID string) (*JWTKey, error) {
+	lifetime, err := getLongestTokenLifetime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtKeysMutex.Lock()
+	defer jwtKeysMutex.Unlock()
+	keys, err := getStoredJWTKeys()
+	if err != nil {
+		return nil, err
+	}
+	index := -1
+	for i, key := range keys {
+		if key.KeyID == keyID {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		if activeKey, err := getActiveJWTKey(); err == nil && activeKey.KeyID == keyID {
+			return nil, errors.New("active key can not be retired")
+		}
+		return nil, errors.New("key not found")
+	}
+	if keys[index].DeactivatedAt == 0 {
+		return nil, errors.New("only previous keys can be retired")
+	}
+
+	now := time.Now().Unix()
+	retiresAt := keys[index].DeactivatedAt + int64(lifetime.Seconds())
+	if retiresAt < now {
+		retiresAt = now
+	}
+	// retirement can't be postponed
+	if keys[index].RetiresAt == 0 || keys[index].RetiresAt > retiresAt {
+		keys[index].RetiresAt = retiresAt
+	}
+	key := keys[index]
+	err = saveJWTKeys(ctx, keys, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// getLongestTokenLifetime returns the longest lifetime of access, id & refresh tokens
+// of instance & all the registered clients
+func getLongestTokenLifetime(ctx context.Context) (time.Duration, error) {
+	lifetime, err := getAccessTokenExpiry(nil)
+	if err != nil {
+		return 0, err
+	}
+	if refreshTokenExpiry := getRefreshTokenExpiry(nil); refreshTokenExpiry > lifetime {
+		lifetime = refreshTokenExpiry
+	}
+
+	pagination := model.Pagination{
+		Limit: 100,
+		Page:  1,
+	}
+	for {
+		res, err := db.Provider.ListClients(ctx, pagination)
+		if err != nil {
+			return 0, err
+		}
+		for _, c := range res.Clients {
+			client := &models.Client{}
+			if c.AccessTokenExpiryTime != nil {
+				client.AccessTokenExpiryTime = *c.AccessTokenExpiryTime
+			}
+			if c.RefreshTokenExpiryTime != nil {
+				client.RefreshTokenExpiryTime = *c.RefreshTokenExpiryTime
+			}
+			if accessTokenExpiry, err := getAccessTokenExpiry(client); err == nil && accessTokenExpiry > lifetime {
+				lifetime = accessTokenExpiry
+			}
+			if refreshTokenExpiry := getRefreshTokenExpiry(client); refreshTokenExpiry > lifetime {
+				lifetime = refreshTokenExpiry
+			}
+		}
+		if int64(len(res.Clients)) < pagination.Limit {
+			break
+		}
+		pagination.Page++
+		pagination.Offset = (pagination.Page - 1) * pagination.Limit
+	}
+
+	return lifetime, nil
+}
@@ -0,0 +1,54 @@
+package token
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+)
+
+// BackchannelLogoutEvent is the event of logout token as per OpenID Connect Back-Channel Logout
+const BackchannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+// CreateLogoutToken creates the logout token sent to back-channel logout uri of client,
+// it must not contain nonce so that it can not be used as id token
+func CreateLogoutToken(clientID, userID, hostname string) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss": hostname,
+		"aud": clientID,
+		"sub": userID,
+		"iat": now.Unix(),
+		"exp": now.Add(2 * time.Minute).Unix(),
+		"jti": uuid.New().String(),
+		"events": map[string]interface{}{
+			BackchannelLogoutEvent: map[string]interface{}{},
+		},
+	}
+
+	return SignJWTToken(claims)
+}
+
+// ParseIDTokenHint parses the id token sent as id_token_hint for logout,
+// hint signed by authorizer is accepted even if it is expired
+func ParseIDTokenHint(idTokenHint string) (jwt.MapClaims, error) {
+	claims, err := ParseJWTToken(idTokenHint)
+	if err != nil {
+		validationErr, ok := err.(*jwt.ValidationError)
+		if !ok || validationErr.Errors != jwt.ValidationErrorExpired {
+			return nil, err
+		}
+	}
+
+	if claims["token_type"] != constants.TokenTypeIdentityToken {
+		return nil, errors.New("invalid token type")
+	}
+	if _, ok := claims["sub"].(string); !ok {
+		return nil, errors.New("invalid subject")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,35 @@
+package token
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// GetAllowedRoles returns the roles of user in the organization,
+// error is returned if user is not a member of organization
+func GetAllowedRoles(ctx context.Context, user models.User, organizationID string) ([]string, error) {
+	member, err := db.Provider.GetOrganizationMember(ctx, organizationID, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("user is not a member of organization")
+	}
+	return member.GetRoles(), nil
+}
+
+// filterRoles returns the roles that are allowed,
+// allowed roles are returned if none of the roles are allowed e.g. roles of member are changed
+func filterRoles(roles, allowedRoles []string) []string {
+	res := []string{}
+	for _, role := range roles {
+		if utils.StringSliceContains(allowedRoles, role) {
+			res = append(res, role)
+		}
+	}
+	if len(res) == 0 {
+		return allowedRoles
+	}
+	return res
+}
@@ -0,0 +1,158 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// RefreshTokenFamily is the chain of refresh tokens issued for a login.
+// Rotating a refresh token adds the nonce of new token to the family,
+// so that a rotated refresh token presented again can be detected & the whole family revoked
+type RefreshTokenFamily struct {
+	ID          string `json:"id"`
+	UserID      string `json:"user_id"`
+	LoginMethod string `json:"login_method"`
+	// nonces of all the tokens issued in the family, last one is of the current refresh token
+	Nonces    []string `json:"nonces"`
+	CreatedAt int64    `json:"created_at"`
+	// 0 if REFRESH_TOKEN_MAX_LIFETIME is not set
+	ExpiresAt int64 `json:"expires_at"`
+}
+
+// SessionKey returns the key of session store where tokens of the family are saved
+func (f *RefreshTokenFamily) SessionKey() string {
+	if f.LoginMethod != "" {
+		return f.LoginMethod + ":" + f.UserID
+	}
+	return f.UserID
+}
+
+// CurrentNonce returns the nonce of latest refresh token issued in the family
+func (f *RefreshTokenFamily) CurrentNonce() string {
+	if len(f.Nonces) == 0 {
+		return ""
+	}
+	return f.Nonces[len(f.Nonces)-1]
+}
+
+// IsRotated returns true if refresh token with nonce was issued in the family & already rotated
+func (f *RefreshTokenFamily) IsRotated(nonce string) bool {
+	if nonce == "" || nonce == f.CurrentNonce() {
+		return false
+	}
+	return utils.StringSliceContains(f.Nonces, nonce)
+}
+
+// IsExpired returns true if max lifetime of the family is over
+func (f *RefreshTokenFamily) IsExpired() bool {
+	return f.ExpiresAt != 0 && f.ExpiresAt < time.Now().Unix()
+}
+
+// newRefreshTokenFamily creates the refresh token family for a new login,
+// max lifetime of family is set using REFRESH_TOKEN_MAX_LIFETIME
+func newRefreshTokenFamily(userID, loginMethod string) *RefreshTokenFamily {
+	now := time.Now()
+	family := &RefreshTokenFamily{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		LoginMethod: loginMethod,
+		Nonces:      []string{},
+		CreatedAt:   now.Unix(),
+	}
+
+	maxLifetime, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyRefreshTokenMaxLifetime)
+	if err == nil && maxLifetime != "" {
+		if lifetime, err := utils.ParseDurationInSeconds(maxLifetime); err == nil {
+			family.ExpiresAt = now.Add(lifetime).Unix()
+		} else {
+			log.Debug("Invalid refresh token max lifetime: ", err)
+		}
+	}
+
+	return family
+}
+
+// GetRefreshTokenFamily returns the refresh token family from the state store
+func GetRefreshTokenFamily(familyID string) (*RefreshTokenFamily, error) {
+	if familyID == "" {
+		return nil, errors.New("refresh token family not found")
+	}
+	data, err := memorystore.Provider.GetState(constants.RefreshTokenFamilyStatePrefix + familyID)
+	if err != nil || data == "" {
+		return nil, errors.New("refresh token family not found")
+	}
+
+	var family RefreshTokenFamily
+	if err := json.Unmarshal([]byte(data), &family); err != nil {
+		return nil, err
+	}
+	return &family, nil
+}
+
+// saveRefreshTokenFamily saves the refresh token family in the state store,
+// family is removed once the latest refresh token issued in it is expired
+func saveRefreshTokenFamily(family *RefreshTokenFamily, refreshTokenExpiresAt int64) error {
+	data, err := json.Marshal(family)
+	if err != nil {
+		return err
+	}
+	expiresIn := time.Until(time.Unix(refreshTokenExpiresAt, 0))
+	if expiresIn < time.Second {
+		expiresIn = time.Second
+	}
+	return memorystore.Provider.SetStateWithExpiry(constants.RefreshTokenFamilyStatePrefix+family.ID, string(data), expiresIn)
+}
+
+// DeleteRefreshTokenFamily removes the refresh token family from the state store
+func DeleteRefreshTokenFamily(familyID string) {
+	memorystore.Provider.RemoveState(constants.RefreshTokenFamilyStatePrefix + familyID)
+}
+
+// RevokeRefreshTokenFamily deletes the session, access & refresh tokens
+// of all the refresh tokens issued in the family & removes the family
+func RevokeRefreshTokenFamily(family *RefreshTokenFamily) {
+	sessionKey := family.SessionKey()
+	for _, nonce := range family.Nonces {
+		memorystore.Provider.DeleteUserSession(sessionKey, nonce)
+	}
+	DeleteRefreshTokenFamily(family.ID)
+}
+
+// RevokeReusedRefreshToken revokes the family of refresh token if it was already rotated
+// & notifies about the reuse with user.refresh_token_reused webhook event.
+// It returns true if the reuse is detected
+func RevokeReusedRefreshToken(ctx context.Context, refreshToken string) bool {
+	// only the tokens signed by us can revoke the family
+	claims, err := ParseJWTToken(refreshToken)
+	if err != nil || claims["token_type"] != constants.TokenTypeRefreshToken {
+		return false
+	}
+	familyID, _ := claims["family_id"].(string)
+	nonce, _ := claims["nonce"].(string)
+	family, err := GetRefreshTokenFamily(familyID)
+	if err != nil || !family.IsRotated(nonce) {
+		return false
+	}
+
+	log.Debug("Refresh token reuse detected, revoking refresh token family: ", family.ID)
+	RevokeRefreshTokenFamily(family)
+
+	user, err := db.Provider.GetUserByID(ctx, family.UserID)
+	if err != nil {
+		log.Debug("Failed to get user: ", err)
+		return true
+	}
+	go utils.RegisterEvent(context.Background(), constants.UserRefreshTokenReusedWebhookEvent, family.LoginMethod, user)
+
+	return true
+}
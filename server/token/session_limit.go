@@ -0,0 +1,160 @@
+package token
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// getSessionDuration returns the duration of SESSION_IDLE_TIMEOUT or SESSION_MAX_LIFETIME,
+// 0 if it is not configured
+func getSessionDuration(envKey string) time.Duration {
+	value, err := memorystore.Provider.GetStringStoreEnvVariable(envKey)
+	if err != nil || value == "" {
+		return 0
+	}
+	duration, err := utils.ParseDurationInSeconds(value)
+	if err != nil {
+		log.Debugf("Invalid %s: %s", envKey, err.Error())
+		return 0
+	}
+	return duration
+}
+
+// getSessionExpiresAt returns the expiry of session updated at issuedAt, i.e.
+// session is valid for SESSION_IDLE_TIMEOUT after its last use & SESSION_MAX_LIFETIME after the login.
+// 0 is returned if neither is configured
+func getSessionExpiresAt(authInfo AuthenticationInfo, issuedAt int64) int64 {
+	var expiresAt int64
+	if idleTimeout := getSessionDuration(constants.EnvKeySessionIdleTimeout); idleTimeout > 0 {
+		expiresAt = issuedAt + int64(idleTimeout.Seconds())
+	}
+	if maxLifetime := getSessionDuration(constants.EnvKeySessionMaxLifetime); maxLifetime > 0 {
+		authTime := authInfo.AuthTime
+		if authTime == 0 {
+			authTime = issuedAt
+		}
+		if lifetimeExpiresAt := authTime + int64(maxLifetime.Seconds()); expiresAt == 0 || lifetimeExpiresAt < expiresAt {
+			expiresAt = lifetimeExpiresAt
+		}
+	}
+	return expiresAt
+}
+
+// IsExpired returns true if session is expired or it is beyond the idle timeout or max lifetime of session.
+// Limits are checked with current configuration, so that they are applied to the existing sessions as well
+func (s *SessionData) IsExpired() bool {
+	now := time.Now().Unix()
+	if s.ExpiresAt < now {
+		return true
+	}
+	expiresAt := getSessionExpiresAt(s.AuthenticationInfo, s.IssuedAt)
+	return expiresAt != 0 && expiresAt < now
+}
+
+// getMaxActiveSessions returns the MAX_ACTIVE_SESSIONS, 0 if sessions are not limited
+func getMaxActiveSessions() int {
+	value, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyMaxActiveSessions)
+	if err != nil || value == "" {
+		return 0
+	}
+	maxSessions, err := strconv.Atoi(value)
+	if err != nil || maxSessions < 0 {
+		log.Debug("Invalid max active sessions: ", value)
+		return 0
+	}
+	return maxSessions
+}
+
+// activeLogin is the login of user with its sessions, rolled over sessions share the session id
+type activeLogin struct {
+	sessionID  string
+	sessionKey string
+	nonces     []string
+	authTime   int64
+}
+
+// evictSessions ends the oldest logins of user when the login with sessionID would exceed MAX_ACTIVE_SESSIONS.
+// Session rolled over from an active login is not a new login & does not evict other sessions.
+// Expired sessions are removed & not counted
+func evictSessions(ctx context.Context, userID, sessionID string) {
+	maxSessions := getMaxActiveSessions()
+	if maxSessions == 0 || sessionID == "" {
+		return
+	}
+
+	log := log.WithFields(log.Fields{
+		"user_id": userID,
+	})
+	sessions, err := memorystore.Provider.ListUserSessions(userID)
+	if err != nil {
+		log.Debug("Failed to list user sessions: ", err)
+		return
+	}
+
+	logins := map[string]*activeLogin{}
+	for sessionKey, tokens := range sessions {
+		for key, value := range tokens {
+			if !strings.HasPrefix(key, constants.TokenTypeSessionToken+"_") {
+				continue
+			}
+			sessionData, err := ParseSessionToken(value)
+			if err != nil {
+				continue
+			}
+			if sessionData.IsExpired() {
+				memorystore.Provider.DeleteUserSession(sessionKey, sessionData.Nonce)
+				continue
+			}
+			if sessionData.SessionID == sessionID {
+				return
+			}
+			// sessions created before session id was tracked are counted separately
+			loginID := sessionData.SessionID
+			if loginID == "" {
+				loginID = sessionData.Nonce
+			}
+			if _, ok := logins[loginID]; !ok {
+				logins[loginID] = &activeLogin{
+					sessionID:  sessionData.SessionID,
+					sessionKey: sessionKey,
+					authTime:   sessionData.AuthTime,
+				}
+			}
+			logins[loginID].nonces = append(logins[loginID].nonces, sessionData.Nonce)
+		}
+	}
+
+	if len(logins) < maxSessions {
+		return
+	}
+
+	oldestLogins := []*activeLogin{}
+	for _, login := range logins {
+		oldestLogins = append(oldestLogins, login)
+	}
+	sort.Slice(oldestLogins, func(i, j int) bool {
+		return oldestLogins[i].authTime < oldestLogins[j].authTime
+	})
+	for _, login := range oldestLogins[:len(oldestLogins)-maxSessions+1] {
+		log.Debug("Max active sessions reached, ending the oldest session")
+		for _, nonce := range login.nonces {
+			memorystore.Provider.DeleteUserSession(login.sessionKey, nonce)
+		}
+		if login.sessionID != "" {
+			if err := db.Provider.DeleteSession(ctx, models.Session{ID: login.sessionID}); err != nil {
+				log.Debug("Failed to delete session: ", err)
+			}
+		}
+	}
+}
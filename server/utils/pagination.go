@@ -6,10 +6,12 @@ import (
 )
 
 // GetPagination helps getting pagination data from paginated input
-// also returns default limit and offset if pagination data is not present
+// also returns default limit and offset if pagination data is not present.
+// If after cursor is present, items after it are listed & page is ignored
 func GetPagination(paginatedInput *model.PaginatedInput) model.Pagination {
 	limit := int64(constants.DefaultLimit)
 	page := int64(1)
+	var after *string
 
 	if paginatedInput != nil && paginatedInput.Pagination != nil {
 		if paginatedInput.Pagination.Limit != nil {
@@ -19,11 +21,26 @@ func GetPagination(paginatedInput *model.PaginatedInput) model.Pagination {
 		if paginatedInput.Pagination.Page != nil {
 			page = *paginatedInput.Pagination.Page
 		}
+
+		if paginatedInput.Pagination.First != nil {
+			limit = *paginatedInput.Pagination.First
+		}
+
+		if paginatedInput.Pagination.After != nil && *paginatedInput.Pagination.After != "" {
+			after = paginatedInput.Pagination.After
+			page = 1
+		}
+	}
+
+	// one extra item is fetched by the db providers to know if there is next page
+	if limit < 1 {
+		limit = int64(constants.DefaultLimit)
 	}
 
 	return model.Pagination{
 		Limit:  limit,
 		Offset: (page - 1) * limit,
 		Page:   page,
+		After:  after,
 	}
 }
@@ -1,21 +1,12 @@
 package utils
 
 import (
-	"errors"
 	"time"
+
+	"github.com/authorizerdev/authorizer/server/memorystore/providers"
 )
 
 // ParseDurationInSeconds parses input s, removes ms/us/ns and returns result duration
 func ParseDurationInSeconds(s string) (time.Duration, error) {
-	d, err := time.ParseDuration(s)
-	if err != nil {
-		return 0, err
-	}
-
-	d = d.Truncate(time.Second)
-	if d <= 0 {
-		return 0, errors.New(`duration must be greater than 0s`)
-	}
-
-	return d, nil
+	return providers.ParseDurationInSeconds(s)
 }
@@ -42,7 +42,7 @@ func RegisterEvent(ctx context.Context, eventName string, authRecipe string, use
 		"user":       userMap,
 	}
 
-	if eventName == constants.UserLoginWebhookEvent || eventName == constants.UserSignUpWebhookEvent {
+	if eventName == constants.UserLoginWebhookEvent || eventName == constants.UserSignUpWebhookEvent || eventName == constants.UserRefreshTokenReusedWebhookEvent {
 		reqBody["auth_recipe"] = authRecipe
 	}
 
@@ -0,0 +1,43 @@
+package validators
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+)
+
+// IsValidGrantType to validate the grant type of oauth client
+func IsValidGrantType(grantType string) bool {
+	return grantType == constants.GrantTypeAuthorizationCode || grantType == constants.GrantTypeRefreshToken || grantType == constants.GrantTypeImplicit || grantType == constants.GrantTypeClientCredentials || grantType == constants.GrantTypeDeviceCode
+}
+
+// IsValidRedirectURI to validate the redirect uri registered for oauth client,
+// it must be absolute url without fragment, custom schemes are allowed for native apps.
+// Comma is not allowed as uris are stored comma separated
+func IsValidRedirectURI(redirectURI string) bool {
+	if strings.Contains(redirectURI, ",") {
+		return false
+	}
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" || u.Fragment != "" {
+		return false
+	}
+	if (u.Scheme == "http" || u.Scheme == "https") && u.Host == "" {
+		return false
+	}
+	return true
+}
+
+// IsValidLogoutURI to validate the back-channel & front-channel logout uri of oauth client,
+// it must be absolute http(s) url without fragment
+func IsValidLogoutURI(logoutURI string) bool {
+	u, err := url.Parse(logoutURI)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && u.Fragment == ""
+}
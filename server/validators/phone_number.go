@@ -0,0 +1,11 @@
+package validators
+
+import "regexp"
+
+// phoneNumberRegex matches phone numbers in E.164 format
+var phoneNumberRegex = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// IsValidPhoneNumber validates phone number, it should be in E.164 format
+func IsValidPhoneNumber(phoneNumber string) bool {
+	return phoneNumberRegex.MatchString(phoneNumber)
+}
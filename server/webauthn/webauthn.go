@@ -0,0 +1,390 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+)
+
+const (
+	// CeremonyTypeCreate is the client data type for registration ceremony
+	CeremonyTypeCreate = "webauthn.create"
+	// CeremonyTypeGet is the client data type for authentication ceremony
+	CeremonyTypeGet = "webauthn.get"
+	// Timeout is the time in milliseconds the client should wait for the ceremony to complete
+	Timeout = 300000
+
+	// COSE algorithm identifiers supported for credential public keys
+	algES256 = -7
+	algEdDSA = -8
+	algRS256 = -257
+
+	// authenticator data flags
+	flagUserPresent            = 0x01
+	flagUserVerified           = 0x04
+	flagAttestedCredentialData = 0x40
+)
+
+// ClientData is the client data collected by the browser during the ceremony
+type ClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// AuthenticatorData is the parsed authenticator data
+// CredentialID & CredentialPublicKey are only available for registration ceremony
+type AuthenticatorData struct {
+	RPIDHash            []byte
+	Flags               byte
+	SignCount           uint32
+	AAGUID              []byte
+	CredentialID        []byte
+	CredentialPublicKey []byte
+}
+
+// UserPresent returns true if user presence was tested by authenticator
+func (a *AuthenticatorData) UserPresent() bool {
+	return a.Flags&flagUserPresent != 0
+}
+
+// UserVerified returns true if user was verified by authenticator (pin, biometrics, etc)
+func (a *AuthenticatorData) UserVerified() bool {
+	return a.Flags&flagUserVerified != 0
+}
+
+// Credential is the credential created by successful registration ceremony
+type Credential struct {
+	// ID is base64 url encoded credential id
+	ID string
+	// PublicKey is the COSE encoded credential public key
+	PublicKey []byte
+	// AAGUID is the hex encoded authenticator model identifier
+	AAGUID            string
+	SignCount         uint32
+	AttestationFormat string
+}
+
+// NewChallenge generates random challenge for the ceremony
+func NewChallenge() (string, error) {
+	challenge := make([]byte, 32)
+	_, err := rand.Read(challenge)
+	if err != nil {
+		return "", err
+	}
+	return EncodeBase64(challenge), nil
+}
+
+// EncodeBase64 encodes data as base64 url without padding, as used by webauthn
+func EncodeBase64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeBase64 decodes base64 url or std encoded data with or without padding
+func DecodeBase64(data string) ([]byte, error) {
+	data = strings.TrimRight(data, "=")
+	data = strings.NewReplacer("+", "-", "/", "_").Replace(data)
+	return base64.RawURLEncoding.DecodeString(data)
+}
+
+// CreationOptions returns the public key credential creation options for navigator.credentials.create
+func CreationOptions(challenge, rpID, rpName, userID, userName string, excludeCredentialIDs []string) map[string]interface{} {
+	excludeCredentials := []map[string]interface{}{}
+	for _, id := range excludeCredentialIDs {
+		excludeCredentials = append(excludeCredentials, map[string]interface{}{
+			"type": "public-key",
+			"id":   id,
+		})
+	}
+
+	return map[string]interface{}{
+		"challenge": challenge,
+		"rp": map[string]interface{}{
+			"id":   rpID,
+			"name": rpName,
+		},
+		"user": map[string]interface{}{
+			"id":          EncodeBase64([]byte(userID)),
+			"name":        userName,
+			"displayName": userName,
+		},
+		"pubKeyCredParams": []map[string]interface{}{
+			{"type": "public-key", "alg": algES256},
+			{"type": "public-key", "alg": algEdDSA},
+			{"type": "public-key", "alg": algRS256},
+		},
+		"timeout":            Timeout,
+		"attestation":        "none",
+		"excludeCredentials": excludeCredentials,
+		"authenticatorSelection": map[string]interface{}{
+			"residentKey":      "preferred",
+			"userVerification": "preferred",
+		},
+	}
+}
+
+// RequestOptions returns the public key credential request options for navigator.credentials.get
+// if allowCredentialIDs is empty, discoverable credentials (passkeys) can be used
+func RequestOptions(challenge, rpID string, allowCredentialIDs []string) map[string]interface{} {
+	allowCredentials := []map[string]interface{}{}
+	for _, id := range allowCredentialIDs {
+		allowCredentials = append(allowCredentials, map[string]interface{}{
+			"type": "public-key",
+			"id":   id,
+		})
+	}
+
+	return map[string]interface{}{
+		"challenge":        challenge,
+		"rpId":             rpID,
+		"timeout":          Timeout,
+		"userVerification": "preferred",
+		"allowCredentials": allowCredentials,
+	}
+}
+
+// ParseClientData parses the client data json and validates the ceremony type & origin,
+// origin should be one of the allowedOrigins & on the rpID domain.
+// challenge should be validated by the caller against the stored challenge
+func ParseClientData(clientDataJSON []byte, ceremonyType, rpID string, allowedOrigins []string) (*ClientData, error) {
+	var clientData ClientData
+	err := json.Unmarshal(clientDataJSON, &clientData)
+	if err != nil {
+		return nil, err
+	}
+
+	if clientData.Type != ceremonyType {
+		return nil, fmt.Errorf("invalid client data type %s", clientData.Type)
+	}
+
+	if clientData.Challenge == "" {
+		return nil, errors.New("missing challenge")
+	}
+
+	origin, err := url.Parse(clientData.Origin)
+	if err != nil {
+		return nil, fmt.Errorf("invalid origin %s", clientData.Origin)
+	}
+	host := origin.Hostname()
+	if host != rpID && !strings.HasSuffix(host, "."+rpID) {
+		return nil, fmt.Errorf("origin %s is not allowed for %s", clientData.Origin, rpID)
+	}
+	if !isAllowedOrigin(origin, allowedOrigins) {
+		return nil, fmt.Errorf("origin %s is not allowed", clientData.Origin)
+	}
+	// webauthn is only available in secure context
+	if origin.Scheme != "https" && host != "localhost" {
+		return nil, fmt.Errorf("origin %s is not secure", clientData.Origin)
+	}
+
+	return &clientData, nil
+}
+
+// isAllowedOrigin returns true if scheme, host & port of origin are same as one of the allowed origins
+func isAllowedOrigin(origin *url.URL, allowedOrigins []string) bool {
+	for _, allowedOrigin := range allowedOrigins {
+		allowed, err := url.Parse(strings.TrimSpace(allowedOrigin))
+		if err != nil || allowed.Host == "" {
+			continue
+		}
+		if strings.EqualFold(allowed.Scheme, origin.Scheme) && strings.EqualFold(allowed.Host, origin.Host) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseAuthenticatorData parses the authenticator data and validates the rp id hash & user presence
+func ParseAuthenticatorData(data []byte, rpID string) (*AuthenticatorData, error) {
+	// rp id hash (32) + flags (1) + sign count (4)
+	if len(data) < 37 {
+		return nil, errors.New("authenticator data is too short")
+	}
+
+	authData := &AuthenticatorData{
+		RPIDHash:  data[:32],
+		Flags:     data[32],
+		SignCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	if !bytes.Equal(authData.RPIDHash, rpIDHash[:]) {
+		return nil, errors.New("invalid rp id hash")
+	}
+
+	if !authData.UserPresent() {
+		return nil, errors.New("user is not present")
+	}
+
+	if authData.Flags&flagAttestedCredentialData != 0 {
+		rest := data[37:]
+		// aaguid (16) + credential id length (2)
+		if len(rest) < 18 {
+			return nil, errors.New("attested credential data is too short")
+		}
+		authData.AAGUID = rest[:16]
+		credentialIDLength := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if len(rest) < credentialIDLength {
+			return nil, errors.New("invalid credential id length")
+		}
+		authData.CredentialID = rest[:credentialIDLength]
+		rest = rest[credentialIDLength:]
+
+		_, extensions, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, err
+		}
+		authData.CredentialPublicKey = rest[:len(rest)-len(extensions)]
+	}
+
+	return authData, nil
+}
+
+// VerifyRegistration verifies the attestation object of registration ceremony
+// and returns the credential that should be saved for the user.
+// Attestation statement is not verified as attestation conveyance is set to none,
+// authenticators are trusted on first use.
+func VerifyRegistration(attestationObject []byte, rpID string) (*Credential, error) {
+	decoded, _, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, err
+	}
+	attestation, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("invalid attestation object")
+	}
+	format, _ := attestation["fmt"].(string)
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, errors.New("missing authenticator data")
+	}
+
+	authData, err := ParseAuthenticatorData(rawAuthData, rpID)
+	if err != nil {
+		return nil, err
+	}
+	if len(authData.CredentialID) == 0 || len(authData.CredentialPublicKey) == 0 {
+		return nil, errors.New("missing attested credential data")
+	}
+
+	// make sure that the public key is supported before saving it
+	_, _, err = parsePublicKey(authData.CredentialPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		ID:                EncodeBase64(authData.CredentialID),
+		PublicKey:         authData.CredentialPublicKey,
+		AAGUID:            hex.EncodeToString(authData.AAGUID),
+		SignCount:         authData.SignCount,
+		AttestationFormat: format,
+	}, nil
+}
+
+// VerifyAssertion verifies the assertion signature of authentication ceremony
+// using the COSE encoded public key saved during registration
+func VerifyAssertion(publicKey, clientDataJSON, authenticatorData, signature []byte, rpID string) (*AuthenticatorData, error) {
+	authData, err := ParseAuthenticatorData(authenticatorData, rpID)
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	message := append(append([]byte{}, authenticatorData...), clientDataHash[:]...)
+
+	key, alg, err := parsePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	switch alg {
+	case algES256:
+		digest := sha256.Sum256(message)
+		if !ecdsa.VerifyASN1(key.(*ecdsa.PublicKey), digest[:], signature) {
+			return nil, errors.New("invalid signature")
+		}
+	case algRS256:
+		digest := sha256.Sum256(message)
+		err = rsa.VerifyPKCS1v15(key.(*rsa.PublicKey), crypto.SHA256, digest[:], signature)
+		if err != nil {
+			return nil, errors.New("invalid signature")
+		}
+	case algEdDSA:
+		if !ed25519.Verify(key.(ed25519.PublicKey), message, signature) {
+			return nil, errors.New("invalid signature")
+		}
+	}
+
+	return authData, nil
+}
+
+// parsePublicKey parses the COSE encoded public key (RFC 8152)
+// and returns the public key along with the algorithm
+func parsePublicKey(coseKey []byte) (interface{}, int64, error) {
+	decoded, _, err := decodeCBOR(coseKey)
+	if err != nil {
+		return nil, 0, err
+	}
+	key, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, 0, errors.New("invalid public key")
+	}
+
+	kty, _ := key[int64(1)].(int64)
+	alg, _ := key[int64(3)].(int64)
+	switch {
+	case kty == 2 && alg == algES256:
+		crv, _ := key[int64(-1)].(int64)
+		x, _ := key[int64(-2)].([]byte)
+		y, _ := key[int64(-3)].([]byte)
+		// P-256
+		if crv != 1 || len(x) != 32 || len(y) != 32 {
+			return nil, 0, errors.New("invalid ec2 public key")
+		}
+		publicKey := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		if !publicKey.Curve.IsOnCurve(publicKey.X, publicKey.Y) {
+			return nil, 0, errors.New("invalid ec2 public key")
+		}
+		return publicKey, alg, nil
+	case kty == 3 && alg == algRS256:
+		n, _ := key[int64(-1)].([]byte)
+		e, _ := key[int64(-2)].([]byte)
+		if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return nil, 0, errors.New("invalid rsa public key")
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, alg, nil
+	case kty == 1 && alg == algEdDSA:
+		crv, _ := key[int64(-1)].(int64)
+		x, _ := key[int64(-2)].([]byte)
+		// Ed25519
+		if crv != 6 || len(x) != ed25519.PublicKeySize {
+			return nil, 0, errors.New("invalid okp public key")
+		}
+		return ed25519.PublicKey(x), alg, nil
+	}
+
+	return nil, 0, fmt.Errorf("unsupported public key type %d with algorithm %d", kty, alg)
+}